@@ -2,13 +2,16 @@ package sqle
 
 import (
 	"fmt"
+	"io"
+	"strings"
 	"time"
 
-	"github.com/go-kit/kit/metrics/discard"
+	kitmetrics "github.com/go-kit/kit/metrics"
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/sirupsen/logrus"
 
 	"github.com/dolthub/go-mysql-server/auth"
+	"github.com/dolthub/go-mysql-server/metrics"
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/analyzer"
 	"github.com/dolthub/go-mysql-server/sql/expression/function"
@@ -39,26 +42,33 @@ type ColumnWithRawDefault struct {
 
 var (
 	// QueryCounter describes a metric that accumulates number of queries monotonically.
-	QueryCounter = discard.NewCounter()
+	// It is labelled with the statement type.
+	QueryCounter kitmetrics.Counter = metrics.NewCounter("gms_queries_total", "Number of queries executed successfully.", "statement")
 
 	// QueryErrorCounter describes a metric that accumulates number of failed queries monotonically.
-	QueryErrorCounter = discard.NewCounter()
+	// It is labelled with the statement type.
+	QueryErrorCounter kitmetrics.Counter = metrics.NewCounter("gms_query_errors_total", "Number of queries that failed.", "statement")
 
-	// QueryHistogram describes a queries latency.
-	QueryHistogram = discard.NewHistogram()
+	// QueryHistogram describes a queries latency, labelled with the statement type.
+	QueryHistogram kitmetrics.Histogram = metrics.NewHistogram("gms_query_duration_seconds", "Latency of the queries, in seconds.", "statement")
+
+	// RowsReturnedCounter describes a metric that accumulates the number of rows returned by queries monotonically.
+	RowsReturnedCounter kitmetrics.Counter = metrics.NewCounter("gms_rows_returned_total", "Number of rows returned by queries.")
 )
 
-func observeQuery(ctx *sql.Context, query string) func(err error) {
+// observeQuery starts observing the given query, and returns the function to call with the statement type of the query
+// and its error, if any, once it's done.
+func observeQuery(ctx *sql.Context, query string) func(statement string, err error) {
 	logrus.WithField("query", query).Debug("executing query")
 	span, _ := ctx.Span("query", opentracing.Tag{Key: "query", Value: query})
 
 	t := time.Now()
-	return func(err error) {
+	return func(statement string, err error) {
 		if err != nil {
-			QueryErrorCounter.With("query", query, "error", err.Error()).Add(1)
+			QueryErrorCounter.With("statement", statement).Add(1)
 		} else {
-			QueryCounter.With("query", query).Add(1)
-			QueryHistogram.With("query", query, "duration", "seconds").Observe(time.Since(t).Seconds())
+			QueryCounter.With("statement", statement).Add(1)
+			QueryHistogram.With("statement", statement).Observe(time.Since(t).Seconds())
 		}
 
 		span.Finish()
@@ -120,16 +130,28 @@ func (e *Engine) Query(
 		parsed, analyzed sql.Node
 		iter             sql.RowIter
 		err              error
+		statement        = "unknown"
 	)
 
 	finish := observeQuery(ctx, query)
-	defer finish(err)
+	defer func() {
+		finish(statement, err)
+	}()
+
+	e.Catalog.IncrementStatus(ctx.ID(), sql.StatusQueries, 1)
 
 	parsed, err = parse.Parse(ctx, query)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	statement = "other"
+	name := commandStatus(parsed)
+	if name != "" {
+		e.Catalog.IncrementStatus(ctx.ID(), name, 1)
+		statement = strings.TrimPrefix(name, "Com_")
+	}
+
 	var perm = auth.ReadPerm
 	var typ = sql.QueryProcess
 	switch parsed.(type) {
@@ -159,17 +181,163 @@ func (e *Engine) Query(
 		return nil, nil, err
 	}
 
+	if name == "Com_select" {
+		if timeout := maxExecutionTime(ctx, query); timeout > 0 {
+			e.Catalog.SetTimeout(ctx.Pid(), timeout)
+		}
+	}
+
 	analyzed, err = e.Analyzer.Analyze(ctx, parsed, nil)
 	if err != nil {
+		if e.Catalog.Killed(ctx.Pid()) {
+			err = sql.ErrQueryTimeout.New()
+		}
 		return nil, nil, err
 	}
 
 	iter, err = analyzed.RowIter(ctx, nil)
 	if err != nil {
+		if e.Catalog.Killed(ctx.Pid()) {
+			err = sql.ErrQueryTimeout.New()
+		}
 		return nil, nil, err
 	}
 
-	return analyzed.Schema(), iter, nil
+	return analyzed.Schema(), &timeoutRowIter{RowIter: iter, pid: ctx.Pid(), procs: e.Catalog.ProcessList}, nil
+}
+
+// commandStatus returns the name of the Com_xxx status variable counting the
+// statements of the same kind as the given parsed node, or an empty string if
+// there is none.
+func commandStatus(n sql.Node) string {
+	switch n := n.(type) {
+	case *plan.InsertInto:
+		if n.IsReplace {
+			return "Com_replace"
+		}
+		return "Com_insert"
+	case *plan.Update:
+		return "Com_update"
+	case *plan.DeleteFrom:
+		return "Com_delete"
+	case *plan.Set:
+		return "Com_set_option"
+	case *plan.Use:
+		return "Com_change_db"
+	case *plan.CreateTable:
+		return "Com_create_table"
+	case *plan.DropTable:
+		return "Com_drop_table"
+	case *plan.RenameTable:
+		return "Com_rename_table"
+	case *plan.AddColumn, *plan.DropColumn, *plan.RenameColumn, *plan.ModifyColumn,
+		*plan.AlterAutoIncrement, *plan.CreateForeignKey, *plan.DropForeignKey:
+		return "Com_alter_table"
+	case *plan.CreateIndex:
+		return "Com_create_index"
+	case *plan.DropIndex:
+		return "Com_drop_index"
+	case *plan.CreateView:
+		return "Com_create_view"
+	case *plan.DropView:
+		return "Com_drop_view"
+	case *plan.CreateTrigger:
+		return "Com_create_trigger"
+	case *plan.DropTrigger:
+		return "Com_drop_trigger"
+	case *plan.LockTables:
+		return "Com_lock_tables"
+	case *plan.UnlockTables:
+		return "Com_unlock_tables"
+	case *plan.Commit:
+		return "Com_commit"
+	case *plan.Rollback:
+		return "Com_rollback"
+	case *plan.ShowDatabases:
+		return "Com_show_databases"
+	case *plan.ShowTables:
+		return "Com_show_tables"
+	case *plan.ShowTableStatus:
+		return "Com_show_table_status"
+	case *plan.ShowColumns:
+		return "Com_show_fields"
+	case *plan.ShowIndexes:
+		return "Com_show_keys"
+	case *plan.ShowVariables:
+		return "Com_show_variables"
+	case *plan.ShowStatus:
+		return "Com_show_status"
+	case *plan.ShowProcessList:
+		return "Com_show_processlist"
+	case *plan.ShowCreateTable:
+		return "Com_show_create_table"
+	case *plan.ShowCreateDatabase:
+		return "Com_show_create_db"
+	case *plan.ShowTriggers:
+		return "Com_show_triggers"
+	case *plan.ShowCreateTrigger:
+		return "Com_show_create_trigger"
+	case *plan.Describe, *plan.DescribeQuery:
+		return "Com_explain"
+	case *plan.Project, *plan.Filter, *plan.GroupBy, *plan.Having, *plan.Sort,
+		*plan.Limit, *plan.Offset, *plan.Distinct, *plan.Union, *plan.UnresolvedTable,
+		*plan.CrossJoin, *plan.InnerJoin, *plan.LeftJoin, *plan.RightJoin, *plan.NaturalJoin,
+		*plan.SubqueryAlias, *plan.TableAlias:
+		return "Com_select"
+	default:
+		return ""
+	}
+}
+
+// maxExecutionTime returns the maximum execution time of the given SELECT
+// query, taken from its MAX_EXECUTION_TIME hint or else from the
+// max_execution_time session variable. Zero means there is no limit.
+func maxExecutionTime(ctx *sql.Context, query string) time.Duration {
+	if timeout, ok := parse.MaxExecutionTimeHint(query); ok {
+		return timeout
+	}
+
+	_, val := ctx.Get("max_execution_time")
+	if val == nil {
+		return 0
+	}
+
+	ms, err := sql.Int64.Convert(val)
+	if err != nil {
+		return 0
+	}
+
+	return time.Duration(ms.(int64)) * time.Millisecond
+}
+
+// timeoutRowIter reports ErrQueryTimeout instead of the cancellation error
+// of a query that was killed for exceeding its maximum execution time. It also
+// counts the rows returned by the query, and adds them to RowsReturnedCounter
+// once it's closed.
+type timeoutRowIter struct {
+	sql.RowIter
+	pid   uint64
+	procs *sql.ProcessList
+	rows  int64
+}
+
+func (i *timeoutRowIter) Next() (sql.Row, error) {
+	row, err := i.RowIter.Next()
+	if err != nil && err != io.EOF && i.procs.Killed(i.pid) {
+		return nil, sql.ErrQueryTimeout.New()
+	}
+
+	if err == nil {
+		i.rows++
+	}
+
+	return row, err
+}
+
+func (i *timeoutRowIter) Close() error {
+	RowsReturnedCounter.Add(float64(i.rows))
+	i.rows = 0
+	return i.RowIter.Close()
 }
 
 // ParseDefaults takes in a schema, along with each column's default value in a string form, and returns the schema
@@ -17,6 +17,9 @@ package enginetest_test
 import (
 	"context"
 	"fmt"
+	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/opentracing/opentracing-go"
@@ -28,6 +31,7 @@ import (
 	sqle "github.com/dolthub/go-mysql-server"
 	"github.com/dolthub/go-mysql-server/enginetest"
 	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/metrics"
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/analyzer"
 	"github.com/dolthub/go-mysql-server/sql/expression"
@@ -151,6 +155,43 @@ func TestLocks(t *testing.T) {
 	require.Equal(1, t2.unlocks)
 }
 
+func TestQueryMetrics(t *testing.T) {
+	require := require.New(t)
+
+	harness := enginetest.NewDefaultMemoryHarness()
+	e := enginetest.NewEngine(t, harness)
+
+	queries := `gms_queries_total{statement="select"}`
+	queriesBefore, rowsBefore := metricValue(t, queries), metricValue(t, "gms_rows_returned_total")
+	rowsReadBefore := metricValue(t, "gms_rows_read_total")
+
+	for _, q := range []string{"SELECT i FROM mytable WHERE i = 1", "select i from mytable where i = 2"} {
+		_, iter, err := e.Query(enginetest.NewContext(harness), q)
+		require.NoError(err)
+		_, err = sql.RowIterToRows(iter)
+		require.NoError(err)
+	}
+
+	require.Equal(queriesBefore+2, metricValue(t, queries))
+	require.Equal(rowsBefore+2, metricValue(t, "gms_rows_returned_total"))
+	require.Greater(metricValue(t, "gms_rows_read_total"), rowsReadBefore)
+	require.NotZero(metricValue(t, `gms_query_duration_seconds_count{statement="select"}`))
+}
+
+// metricValue returns the value of the given series, as exposed by the metrics handler, or zero if it isn't.
+func metricValue(t *testing.T, series string) float64 {
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	for _, line := range strings.Split(rec.Body.String(), "\n") {
+		if strings.HasPrefix(line, series+" ") {
+			v, err := strconv.ParseFloat(strings.TrimPrefix(line, series+" "), 64)
+			require.NoError(t, err)
+			return v
+		}
+	}
+	return 0
+}
+
 type mockSpan struct {
 	opentracing.Span
 	finished bool
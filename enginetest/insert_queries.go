@@ -67,7 +67,7 @@ var InsertQueries = []WriteQueryTest{
 			uint8(math.MaxUint8), uint16(math.MaxUint16), uint32(math.MaxUint32), uint64(math.MaxUint64),
 			float32(math.MaxFloat32), float64(math.MaxFloat64),
 			sql.Timestamp.MustConvert("2037-04-05 12:51:36"), sql.Date.MustConvert("2231-11-07"),
-			"random text", sql.True, ([]byte)(`{"key":"value"}`), "blobdata",
+			"random text", sql.True, sql.MustJSON(`{"key":"value"}`), "blobdata",
 		}},
 	},
 	{
@@ -85,7 +85,7 @@ var InsertQueries = []WriteQueryTest{
 			uint8(math.MaxUint8), uint16(math.MaxUint16), uint32(math.MaxUint32), uint64(math.MaxUint64),
 			float32(math.MaxFloat32), float64(math.MaxFloat64),
 			sql.Timestamp.MustConvert("2037-04-05 12:51:36"), sql.Date.MustConvert("2231-11-07"),
-			"random text", sql.True, ([]byte)(`{"key":"value"}`), "blobdata",
+			"random text", sql.True, sql.MustJSON(`{"key":"value"}`), "blobdata",
 		}},
 	},
 	{
@@ -93,8 +93,8 @@ var InsertQueries = []WriteQueryTest{
 			999, -128, -32768, -2147483648, -9223372036854775808,
 			0, 0, 0, 0,
 			1.401298464324817070923729583289916131280e-45, 4.940656458412465441765687928682213723651e-324,
-			'0000-00-00 00:00:00', '0000-00-00',
-			'', false, '', ''
+			'1970-01-01 00:00:01', '1000-01-01',
+			'', false, '""', ''
 			);`,
 		[]sql.Row{{sql.NewOkResult(1)}},
 		"SELECT * FROM typestable WHERE id = 999;",
@@ -102,8 +102,8 @@ var InsertQueries = []WriteQueryTest{
 			int64(999), int8(-math.MaxInt8 - 1), int16(-math.MaxInt16 - 1), int32(-math.MaxInt32 - 1), int64(-math.MaxInt64 - 1),
 			uint8(0), uint16(0), uint32(0), uint64(0),
 			float32(math.SmallestNonzeroFloat32), float64(math.SmallestNonzeroFloat64),
-			sql.Timestamp.Zero(), sql.Date.Zero(),
-			"", sql.False, ([]byte)(`""`), "",
+			sql.Timestamp.MustConvert("1970-01-01 00:00:01"), sql.Date.MustConvert("1000-01-01"),
+			"", sql.False, sql.MustJSON(`""`), "",
 		}},
 	},
 	{
@@ -111,8 +111,8 @@ var InsertQueries = []WriteQueryTest{
 			id = 999, i8 = -128, i16 = -32768, i32 = -2147483648, i64 = -9223372036854775808,
 			u8 = 0, u16 = 0, u32 = 0, u64 = 0,
 			f32 = 1.401298464324817070923729583289916131280e-45, f64 = 4.940656458412465441765687928682213723651e-324,
-			ti = '0000-00-00 00:00:00', da = '0000-00-00',
-			te = '', bo = false, js = '', bl = ''
+			ti = '1970-01-01 00:00:01', da = '1000-01-01',
+			te = '', bo = false, js = '""', bl = ''
 			;`,
 		[]sql.Row{{sql.NewOkResult(1)}},
 		"SELECT * FROM typestable WHERE id = 999;",
@@ -120,8 +120,8 @@ var InsertQueries = []WriteQueryTest{
 			int64(999), int8(-math.MaxInt8 - 1), int16(-math.MaxInt16 - 1), int32(-math.MaxInt32 - 1), int64(-math.MaxInt64 - 1),
 			uint8(0), uint16(0), uint32(0), uint64(0),
 			float32(math.SmallestNonzeroFloat32), float64(math.SmallestNonzeroFloat64),
-			sql.Timestamp.Zero(), sql.Date.Zero(),
-			"", sql.False, ([]byte)(`""`), "",
+			sql.Timestamp.MustConvert("1970-01-01 00:00:01"), sql.Date.MustConvert("1000-01-01"),
+			"", sql.False, sql.MustJSON(`""`), "",
 		}},
 	},
 	{
@@ -751,9 +751,22 @@ var InsertErrorTests = []GenericErrorQueryTest{
 		"bad column in on duplicate key update clause",
 		"INSERT INTO mytable values (10, 'b') ON DUPLICATE KEY UPDATE notExist = 1",
 	},
+	{
+		"zero date in strict mode",
+		"INSERT INTO typestable (id, da) VALUES (999, '0000-00-00');",
+	},
 }
 
 var InsertErrorScripts = []ScriptTest{
+	{
+		Name: "primary key that only differs in case",
+		SetUpScript: []string{
+			"create table ci (pk varchar(20) primary key)",
+			"insert into ci values ('abc')",
+		},
+		Query:       "insert into ci values ('ABC')",
+		ExpectedErr: sql.ErrUniqueKeyViolation,
+	},
 	{
 		Name:        "create table with non-pk auto_increment column",
 		Query:       "create table bad (pk int primary key, c0 int auto_increment);",
@@ -354,7 +354,7 @@ func unmergableIndexDriver(dbs []sql.Database) sql.IndexDriver {
 		},
 		"floattable": {
 			newUnmergableIndex(dbs, "floattable",
-				expression.NewGetFieldWithTable(2, sql.Text, "floattable", "f64", false)),
+				expression.NewGetFieldWithTable(2, sql.Float64, "floattable", "f64", false)),
 		},
 		"niltable": {
 			newUnmergableIndex(dbs, "niltable",
@@ -399,7 +399,7 @@ func mergableIndexDriver(dbs []sql.Database) sql.IndexDriver {
 		},
 		"floattable": {
 			newMergableIndex(dbs, "floattable",
-				expression.NewGetFieldWithTable(2, sql.Text, "floattable", "f64", false)),
+				expression.NewGetFieldWithTable(2, sql.Float64, "floattable", "f64", false)),
 		},
 		"niltable": {
 			newMergableIndex(dbs, "niltable",
@@ -22,6 +22,7 @@ import (
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
 )
 
 type QueryTest struct {
@@ -260,6 +261,18 @@ var QueryTests = []QueryTest{
 		"SELECT i FROM mytable WHERE i <> 2;",
 		[]sql.Row{{int64(1)}, {int64(3)}},
 	},
+	{
+		"SELECT i FROM mytable WHERE s = 'FIRST ROW';",
+		[]sql.Row{{int64(1)}},
+	},
+	{
+		"SELECT i FROM mytable WHERE s LIKE 'SECOND%';",
+		[]sql.Row{{int64(2)}},
+	},
+	{
+		"SELECT i FROM mytable WHERE UPPER(s) IN (SELECT s FROM mytable) ORDER BY i;",
+		[]sql.Row{{int64(1)}, {int64(2)}, {int64(3)}},
+	},
 	{
 		"SELECT NULL IN (SELECT i FROM emptytable)",
 		[]sql.Row{{false}},
@@ -591,6 +604,50 @@ var QueryTests = []QueryTest{
 		`SELECT SUBSTRING_INDEX(mytable.s, "d", 1) AS s FROM mytable INNER JOIN othertable ON (SUBSTRING_INDEX(mytable.s, "d", 1) = SUBSTRING_INDEX(othertable.s2, "d", 1)) GROUP BY 1 HAVING s = 'secon'`,
 		[]sql.Row{{"secon"}},
 	},
+	{
+		`SELECT LOCATE('bar', 'foobarbar'), LOCATE('bar', 'foobarbar', 5), LOCATE('xbar', 'foobar'), POSITION('bar' IN 'foobarbar'), INSTR('foobarbar', 'bar')`,
+		[]sql.Row{{int64(4), int64(7), int64(0), int64(4), int64(4)}},
+	},
+	{
+		`SELECT FIELD('Bb', 'Aa', 'Bb', 'Cc'), FIELD('ej', 'Hej', 'ej', 'Heja', 'hej', 'foo'), ELT(2, 'Aa', 'Bb'), ELT(3, 'Aa', 'Bb')`,
+		[]sql.Row{{int64(2), int64(2), "Bb", nil}},
+	},
+	{
+		`SELECT FIND_IN_SET('b', 'a,b,c,d'), FIND_IN_SET('B', 'a,b,c,d'), FIND_IN_SET('e', 'a,b,c,d'), FIND_IN_SET(NULL, 'a')`,
+		[]sql.Row{{int64(2), int64(2), int64(0), nil}},
+	},
+	{
+		`SELECT MAKE_SET(1, 'a', 'b', 'c'), MAKE_SET(1 | 4, 'hello', 'nice', 'world'), MAKE_SET(1 | 4, 'hello', 'nice', NULL, 'world'), EXPORT_SET(5, 'Y', 'N', ',', 4), EXPORT_SET(6, '1', '0', ',', 10)`,
+		[]sql.Row{{"a", "hello,world", "hello", "Y,N,Y,N", "0,1,1,0,0,0,0,0,0,0"}},
+	},
+	{
+		`SELECT INSERT('Quadratic', 3, 4, 'What'), INSERT('Quadratic', -1, 4, 'What'), INSERT('Quadratic', 3, 100, 'What'), RIGHT('foobarbar', 4), LEFT('foobarbar', 5)`,
+		[]sql.Row{{"QuWhattic", "Quadratic", "QuWhat", "rbar", "fooba"}},
+	},
+	{
+		`SELECT QUOTE('Don''t!'), QUOTE(NULL), SPACE(3), STRCMP('text', 'text2'), STRCMP('text2', 'text'), STRCMP('TEXT', 'text')`,
+		[]sql.Row{{`'Don\'t!'`, "NULL", "   ", int64(-1), int64(1), int64(0)}},
+	},
+	{
+		`SELECT FORMAT(12332.123456, 4), FORMAT(12332.1, 4), FORMAT(12332.2, 0), FORMAT(12332.2, 2, 'de_DE')`,
+		[]sql.Row{{"12,332.1235", "12,332.1000", "12,332", "12.332,20"}},
+	},
+	{
+		`SELECT CONV('a', 16, 2), CONV('6E', 18, 8), CONV(-17, 10, -18), CONV(10 + '10' + '10', 10, 10)`,
+		[]sql.Row{{"1010", "172", "-H", "30"}},
+	},
+	{
+		`SELECT CHAR(77, 121, 83, 81, 76), CHAR(0xC3, 0xA9 USING utf8mb4), ORD('2'), ORD('é'), OCTET_LENGTH('añb'), CHAR_LENGTH('añb')`,
+		[]sql.Row{{"MySQL", "é", int64(50), int64(0xC3A9), int32(4), int32(3)}},
+	},
+	{
+		`SELECT WEIGHT_STRING('ab' AS BINARY(4)), WEIGHT_STRING(CAST('ab' AS BINARY)), LENGTH(WEIGHT_STRING('ab' AS CHAR(4))) > 0`,
+		[]sql.Row{{"ab\x00\x00", "ab", true}},
+	},
+	{
+		`SELECT 'a_c' LIKE 'a|_c' ESCAPE '|', 'abc' LIKE 'a|_c' ESCAPE '|', 'a%' LIKE 'a\\%', '10%' LIKE '10!%' ESCAPE '!'`,
+		[]sql.Row{{true, false, true, true}},
+	},
 	{
 		"SELECT YEAR('2007-12-11') FROM mytable",
 		[]sql.Row{{int32(2007)}, {int32(2007)}, {int32(2007)}},
@@ -651,6 +708,106 @@ var QueryTests = []QueryTest{
 		"SELECT YEARWEEK('1987-01-01', 20), YEARWEEK('1987-01-01', 1), YEARWEEK('1987-01-01', 2), YEARWEEK('1987-01-01', 3), YEARWEEK('1987-01-01', 4), YEARWEEK('1987-01-01', 5), YEARWEEK('1987-01-01', 6), YEARWEEK('1987-01-01', 7)",
 		[]sql.Row{{int32(198653), int32(198701), int32(198652), int32(198701), int32(198653), int32(198652), int32(198653), int32(198652)}},
 	},
+	{
+		"SELECT STR_TO_DATE('May 1, 2013', '%M %d,%Y'), STR_TO_DATE('a09:30:17', 'a%h:%i:%s'), STR_TO_DATE('04/31/2004', '%m/%d/%Y')",
+		[]sql.Row{{time.Date(2013, time.May, 1, 0, 0, 0, 0, time.UTC), "09:30:17", nil}},
+	},
+	{
+		"SELECT STR_TO_DATE(DATE_FORMAT('2013-05-01 10:11:12', GET_FORMAT(DATETIME, 'ISO')), GET_FORMAT(DATETIME, 'ISO'))",
+		[]sql.Row{{time.Date(2013, time.May, 1, 10, 11, 12, 0, time.UTC)}},
+	},
+	{
+		"SELECT DATEDIFF('2007-12-31 23:59:59', '2007-12-30'), DATEDIFF('0000-00-00', '2007-12-30')",
+		[]sql.Row{{int64(1), nil}},
+	},
+	{
+		"SELECT TIMESTAMPDIFF(MONTH, '2003-02-01', '2003-05-01'), TIMESTAMPDIFF(MINUTE, '2003-02-01', '2003-05-01 12:05:55')",
+		[]sql.Row{{int64(3), int64(128885)}},
+	},
+	{
+		"SELECT EXTRACT(YEAR FROM '2019-07-02'), EXTRACT(YEAR_MONTH FROM '2019-07-02 01:02:03'), EXTRACT(DAY_MINUTE FROM '2019-07-02 01:02:03'), EXTRACT(MICROSECOND FROM '2003-01-02 10:30:00.000123')",
+		[]sql.Row{{int64(2019), int64(201907), int64(20102), int64(123)}},
+	},
+	{
+		"SELECT EXTRACT(hour FROM '-10:30:00'), EXTRACT(WEEK FROM '2019-01-01'), EXTRACT(MONTH FROM '0000-00-00'), EXTRACT(DAY FROM '2019-02-30')",
+		[]sql.Row{{int64(-10), int64(0), int64(0), nil}},
+	},
+	{
+		"SELECT TIMESTAMPADD(MINUTE, 1, '2003-01-02'), TIMESTAMPADD(MONTH, 1, '2003-01-31')",
+		[]sql.Row{{time.Date(2003, time.January, 2, 0, 1, 0, 0, time.UTC), time.Date(2003, time.February, 28, 0, 0, 0, 0, time.UTC)}},
+	},
+	{
+		"SELECT LAST_DAY('2004-02-05'), LAST_DAY('2003-03-32'), MAKEDATE(2011, 32), MAKEDATE(2011, 0)",
+		[]sql.Row{{time.Date(2004, time.February, 29, 0, 0, 0, 0, time.UTC), nil, time.Date(2011, time.February, 1, 0, 0, 0, 0, time.UTC), nil}},
+	},
+	{
+		"SELECT MAKETIME(12, 15, 30), SEC_TO_TIME(2378), FROM_UNIXTIME(1447430881)",
+		[]sql.Row{{"12:15:30", "00:39:38", time.Date(2015, time.November, 13, 16, 8, 1, 0, time.UTC)}},
+	},
+	{
+		"SELECT PERIOD_ADD(200801, 2), PERIOD_DIFF(200802, 200703), QUARTER('2008-04-01')",
+		[]sql.Row{{int64(200803), int64(11), int32(2)}},
+	},
+	{
+		"SELECT TO_DAYS('2007-10-07'), FROM_DAYS(733321), TO_DAYS('0000-00-00')",
+		[]sql.Row{{int64(733321), time.Date(2007, time.October, 7, 0, 0, 0, 0, time.UTC), nil}},
+	},
+	{
+		"SELECT MD5('testing'), SHA1('abc'), SHA2('abc', 224), SHA2('abc', 1)",
+		[]sql.Row{{"ae2b1fca515949e5d54fb22b8ed95575", "a9993e364706816aba3e25717850c26c9cd0d89d", "23097d223405d8228642a477bda255b32aadbce4bda0b3f7e36c9da7", nil}},
+	},
+	{
+		"SELECT MD5(s) FROM mytable ORDER BY i",
+		[]sql.Row{{"5e39cc6589230838240730e173397a60"}, {"1f249fcf7d219aa0201e6aa72e5b3066"}, {"ec8b1882db0ad5813149cef53e771d9d"}},
+	},
+	{
+		"SELECT TO_BASE64(AES_ENCRYPT('text', 'password')), AES_DECRYPT(AES_ENCRYPT('text', 'password'), 'password'), AES_DECRYPT(AES_ENCRYPT('text', 'password'), 'secret')",
+		[]sql.Row{{"9r0PqNy3+M1KL6q8VGaARA==", "text", nil}},
+	},
+	{
+		"SELECT BIN_TO_UUID(UUID_TO_BIN('6ccd780c-baba-1026-9564-5b8c656024db', 1)), BIN_TO_UUID(UUID_TO_BIN('6ccd780c-baba-1026-9564-5b8c656024db', 1), 1)",
+		[]sql.Row{{"1026baba-6ccd-780c-9564-5b8c656024db", "6ccd780c-baba-1026-9564-5b8c656024db"}},
+	},
+	{
+		"SELECT COUNT(DISTINCT UUID()), MIN(LENGTH(UUID())), MIN(LENGTH(RANDOM_BYTES(8))) FROM mytable",
+		[]sql.Row{{int64(3), int32(36), int32(8)}},
+	},
+	{
+		"SELECT INET_ATON('10.0.5.9'), INET_NTOA(167773449), INET_ATON('10.0.5.256')",
+		[]sql.Row{{uint64(167773449), "10.0.5.9", nil}},
+	},
+	{
+		"SELECT TO_BASE64(INET6_ATON('fdfe::5a55:caff:fefa:9089')), INET6_NTOA(INET6_ATON('::ffff:10.0.5.9')), INET6_NTOA(INET6_ATON('10.0.5.9'))",
+		[]sql.Row{{"/f4AAAAAAABaVcr//vqQiQ==", "::ffff:10.0.5.9", "10.0.5.9"}},
+	},
+	{
+		"SELECT i FROM mytable WHERE REGEXP_LIKE(s, '^(first|THIRD)') ORDER BY i",
+		[]sql.Row{{int64(1)}, {int64(3)}},
+	},
+	{
+		"SELECT i FROM mytable WHERE REGEXP_LIKE(s, '^(first|THIRD)', 'c') ORDER BY i",
+		[]sql.Row{{int64(1)}},
+	},
+	{
+		"SELECT REGEXP_REPLACE(s, '(\\\\w+) row', 'row $1'), REGEXP_SUBSTR(s, '[a-z]+', 1, 2), REGEXP_INSTR(s, 'row') FROM mytable ORDER BY i",
+		[]sql.Row{
+			{"row first", "row", int64(7)},
+			{"row second", "row", int64(8)},
+			{"row third", "row", int64(7)},
+		},
+	},
+	{
+		"SELECT REGEXP_INSTR('dog cat dog', 'dog', 1, 2, 1), REGEXP_REPLACE('abc def ghi', '[a-z]+', 'X', 1, 3), REGEXP_SUBSTR('abc', 'x')",
+		[]sql.Row{{int64(12), "abc def X", nil}},
+	},
+	{
+		"SELECT ST_ASTEXT(ST_GEOMFROMTEXT('polygon((0 0, 1 0, 1 1, 0 0))', 4326)), ST_SRID(ST_GEOMFROMTEXT('POINT(1 1)', 4326)), TO_BASE64(ST_GEOMFROMTEXT('POINT(1 2)'))",
+		[]sql.Row{{"POLYGON((0 0,1 0,1 1,0 0))", uint32(4326), "AAAAAAEBAAAAAAAAAAAA8D8AAAAAAAAAQA=="}},
+	},
+	{
+		"SELECT ROUND(ST_DISTANCE_SPHERE(POINT(2.3522, 48.8566), POINT(-0.1276, 51.5072))), ST_DISTANCE(POINT(0, 0), ST_GEOMFROMTEXT('LINESTRING(3 -1, 3 1)'))",
+		[]sql.Row{{343529.0, 3.0}},
+	},
 	{
 		"SELECT i FROM mytable WHERE i BETWEEN 1 AND 2",
 		[]sql.Row{{int64(1)}, {int64(2)}},
@@ -1366,7 +1523,7 @@ var QueryTests = []QueryTest{
 	{
 		`SELECT AVG(23.222000)`,
 		[]sql.Row{
-			{float64(23.222)},
+			{"23.2220000000"},
 		},
 	},
 	{
@@ -1389,11 +1546,14 @@ var QueryTests = []QueryTest{
 			{"time_zone", "SYSTEM"},
 			{"system_time_zone", time.Now().UTC().Location().String()},
 			{"max_allowed_packet", math.MaxInt32},
-			{"sql_mode", ""},
+			{"max_execution_time", int64(0)},
+			{"long_query_time", float64(10)},
+			{"sql_mode", "ONLY_FULL_GROUP_BY,STRICT_TRANS_TABLES,NO_ZERO_IN_DATE,NO_ZERO_DATE,ERROR_FOR_DIVISION_BY_ZERO,NO_ENGINE_SUBSTITUTION"},
 			{"gtid_mode", int32(0)},
 			{"collation_database", "utf8mb4_0900_ai_ci"},
 			{"ndbinfo_version", ""},
 			{"sql_select_limit", math.MaxInt32},
+			{"group_concat_max_len", int64(1024)},
 			{"transaction_isolation", "READ UNCOMMITTED"},
 			{"version", ""},
 			{"version_comment", ""},
@@ -1418,13 +1578,13 @@ var QueryTests = []QueryTest{
 	{
 		`SHOW GLOBAL VARIABLES LIKE '%mode`,
 		[]sql.Row{
-			{"sql_mode", ""},
+			{"sql_mode", "ONLY_FULL_GROUP_BY,STRICT_TRANS_TABLES,NO_ZERO_IN_DATE,NO_ZERO_DATE,ERROR_FOR_DIVISION_BY_ZERO,NO_ENGINE_SUBSTITUTION"},
 			{"gtid_mode", int32(0)},
 		},
 	},
 	{
-		`SELECT JSON_EXTRACT("foo", "$")`,
-		[]sql.Row{{"foo"}},
+		`SELECT JSON_EXTRACT('"foo"', "$")`,
+		[]sql.Row{{sql.MustJSON(`"foo"`)}},
 	},
 	{
 		`SELECT JSON_UNQUOTE('"foo"')`,
@@ -1913,17 +2073,158 @@ var QueryTests = []QueryTest{
 		[]sql.Row{{int64(1)}, {int64(2)}, {int64(3)}},
 	},
 	{
-		`SELECT JSON_EXTRACT('[1, 2, 3]', '$.[0]')`,
-		[]sql.Row{{float64(1)}},
+		`SELECT JSON_EXTRACT('[1, 2, 3]', '$[0]')`,
+		[]sql.Row{{sql.MustJSON(`1`)}},
 	},
 	{
 		`SELECT ARRAY_LENGTH(JSON_EXTRACT('[1, 2, 3]', '$'))`,
 		[]sql.Row{{int32(3)}},
 	},
 	{
-		`SELECT ARRAY_LENGTH(JSON_EXTRACT('[{"i":0}, {"i":1, "y":"yyy"}, {"i":2, "x":"xxx"}]', '$.i'))`,
+		`SELECT ARRAY_LENGTH(JSON_EXTRACT('[{"i":0}, {"i":1, "y":"yyy"}, {"i":2, "x":"xxx"}]', '$[*].i'))`,
 		[]sql.Row{{int32(3)}},
 	},
+	{
+		`SELECT JSON_EXTRACT('{"a": [1, {"b": 2}]}', '$.a[last].b', '$.c')`,
+		[]sql.Row{{sql.MustJSON(`[2]`)}},
+	},
+	{
+		`SELECT JSON_OBJECT('id', i, 'name', s) FROM mytable WHERE i = 1`,
+		[]sql.Row{{sql.MustJSON(`{"id": 1, "name": "first row"}`)}},
+	},
+	{
+		`SELECT JSON_ARRAY(1, 'a', NULL, TRUE)`,
+		[]sql.Row{{sql.MustJSON(`[1, "a", null, true]`)}},
+	},
+	{
+		`SELECT JSON_SET('{"a": 1}', '$.a', 2, '$.b', JSON_ARRAY(3)), JSON_INSERT('{"a": 1}', '$.a', 2), JSON_REPLACE('{"a": 1}', '$.b', 2)`,
+		[]sql.Row{{sql.MustJSON(`{"a": 2, "b": [3]}`), sql.MustJSON(`{"a": 1}`), sql.MustJSON(`{"a": 1}`)}},
+	},
+	{
+		`SELECT JSON_REMOVE('[1, 2, 3]', '$[1]'), JSON_ARRAY_APPEND('[1]', '$', 2), JSON_ARRAY_INSERT('[1]', '$[0]', 2)`,
+		[]sql.Row{{sql.MustJSON(`[1, 3]`), sql.MustJSON(`[1, 2]`), sql.MustJSON(`[2, 1]`)}},
+	},
+	{
+		`SELECT JSON_CONTAINS('{"a": [1, 2]}', '2', '$.a'), JSON_CONTAINS_PATH('{"a": 1}', 'all', '$.a', '$.b')`,
+		[]sql.Row{{true, false}},
+	},
+	{
+		`SELECT JSON_KEYS('{"b": 1, "a": 2}'), JSON_LENGTH('[1, 2, 3]'), JSON_DEPTH('[[1]]')`,
+		[]sql.Row{{sql.MustJSON(`["a", "b"]`), int64(3), int64(3)}},
+	},
+	{
+		`SELECT JSON_TYPE('[1]'), JSON_VALID('{'), JSON_QUOTE('a"b')`,
+		[]sql.Row{{"ARRAY", false, `"a\"b"`}},
+	},
+	{
+		`SELECT JSON_SEARCH('["abc", {"x": "abc"}]', 'all', 'ab%')`,
+		[]sql.Row{{sql.MustJSON(`["$[0]", "$[1].x"]`)}},
+	},
+	{
+		`SELECT JSON_MERGE_PRESERVE('{"a": 1}', '{"a": 2}'), JSON_MERGE_PATCH('{"a": 1, "b": 2}', '{"a": 3, "b": null}')`,
+		[]sql.Row{{sql.MustJSON(`{"a": [1, 2]}`), sql.MustJSON(`{"a": 3}`)}},
+	},
+	{
+		`SELECT JSON_OBJECT('b', 1, 'a', 2) = JSON_OBJECT('a', 2, 'b', 1), JSON_EXTRACT('[1]', '$[0]') = 1, JSON_EXTRACT('"a"', '$') = 'a'`,
+		[]sql.Row{{true, true, true}},
+	},
+	{
+		`SELECT * FROM JSON_TABLE('[{"a": 1, "b": [11, 111]}, {"a": 2, "b": [22, 222]}, {"a": 3}]', '$[*]' COLUMNS (id FOR ORDINALITY, a INT PATH '$.a', NESTED PATH '$.b[*]' COLUMNS (b INT PATH '$'))) AS jt`,
+		[]sql.Row{
+			{uint32(1), int32(1), int32(11)},
+			{uint32(1), int32(1), int32(111)},
+			{uint32(2), int32(2), int32(22)},
+			{uint32(2), int32(2), int32(222)},
+			{uint32(3), int32(3), nil},
+		},
+	},
+	{
+		`SELECT i, jt.x FROM mytable, JSON_TABLE(CONCAT('[', i, ',', i*10, ']'), '$[*]' COLUMNS (x INT PATH '$')) jt ORDER BY 1, 2`,
+		[]sql.Row{
+			{int64(1), int32(1)},
+			{int64(1), int32(10)},
+			{int64(2), int32(2)},
+			{int64(2), int32(20)},
+			{int64(3), int32(3)},
+			{int64(3), int32(30)},
+		},
+	},
+	{
+		`SELECT (SELECT MAX(x) FROM JSON_TABLE(CONCAT('[', i, ']'), '$[*]' COLUMNS (x INT PATH '$')) jt) FROM mytable ORDER BY i`,
+		[]sql.Row{{int32(1)}, {int32(2)}, {int32(3)}},
+	},
+	{
+		`SELECT JSON_LENGTH(JSON_ARRAYAGG(i)), JSON_CONTAINS(JSON_ARRAYAGG(i), '[3, 1, 2]'), JSON_OBJECTAGG(s, i) FROM mytable`,
+		[]sql.Row{{int64(3), true, sql.MustJSON(`{"first row": 1, "second row": 2, "third row": 3}`)}},
+	},
+	{
+		`SELECT i % 2, JSON_LENGTH(JSON_ARRAYAGG(s)), JSON_OBJECTAGG(i, JSON_OBJECT('s', s)) FROM mytable GROUP BY 1 ORDER BY 1`,
+		[]sql.Row{
+			{int64(0), int64(1), sql.MustJSON(`{"2": {"s": "second row"}}`)},
+			{int64(1), int64(2), sql.MustJSON(`{"1": {"s": "first row"}, "3": {"s": "third row"}}`)},
+		},
+	},
+	{
+		`SELECT JSON_ARRAYAGG(s) FROM mytable WHERE i = 2`,
+		[]sql.Row{{sql.MustJSON(`["second row"]`)}},
+	},
+	{
+		`SELECT GROUP_CONCAT(s ORDER BY i DESC SEPARATOR '; '), GROUP_CONCAT(i) FROM mytable WHERE i > 1`,
+		[]sql.Row{{"third row; second row", "2,3"}},
+	},
+	{
+		`SELECT GROUP_CONCAT(DISTINCT i % 2 ORDER BY i % 2 DESC) FROM mytable`,
+		[]sql.Row{{"1,0"}},
+	},
+	{
+		`SELECT i % 2, GROUP_CONCAT(i, '-', s ORDER BY i SEPARATOR '') FROM mytable GROUP BY 1 ORDER BY 1`,
+		[]sql.Row{
+			{int64(0), "2-second row"},
+			{int64(1), "1-first row3-third row"},
+		},
+	},
+	{
+		`SELECT GROUP_CONCAT(NULL), GROUP_CONCAT(s) FROM mytable WHERE i > 10`,
+		[]sql.Row{{nil, nil}},
+	},
+	{
+		`SELECT VAR_POP(i), VARIANCE(i), VAR_SAMP(i), STD(i), STDDEV(i), STDDEV_POP(i), STDDEV_SAMP(i) FROM mytable`,
+		[]sql.Row{{float64(2) / 3, float64(2) / 3, float64(1), math.Sqrt(float64(2) / 3), math.Sqrt(float64(2) / 3), math.Sqrt(float64(2) / 3), float64(1)}},
+	},
+	{
+		`SELECT i % 2, VAR_SAMP(i), STDDEV_POP(i) FROM mytable GROUP BY 1 ORDER BY 1`,
+		[]sql.Row{
+			{int64(0), nil, float64(0)},
+			{int64(1), float64(2), float64(1)},
+		},
+	},
+	{
+		`SELECT BIT_AND(i), BIT_OR(i), BIT_XOR(i) FROM mytable`,
+		[]sql.Row{{uint64(0), uint64(3), uint64(0)}},
+	},
+	{
+		`SELECT BIT_AND(i), BIT_OR(i), BIT_XOR(i), STDDEV(i) FROM mytable WHERE i > 10`,
+		[]sql.Row{{uint64(math.MaxUint64), uint64(0), uint64(0), nil}},
+	},
+	{
+		`SELECT i % 2 AS m, ANY_VALUE(i % 2), COUNT(*) FROM mytable GROUP BY m ORDER BY m`,
+		[]sql.Row{
+			{int64(0), int64(0), int64(1)},
+			{int64(1), int64(1), int64(2)},
+		},
+	},
+	{
+		`SELECT SUM(DISTINCT i % 2), AVG(DISTINCT i % 2), COUNT(DISTINCT i % 2), MAX(DISTINCT s) FROM mytable`,
+		[]sql.Row{{float64(1), float64(0.5), int64(2), "third row"}},
+	},
+	{
+		`SELECT i % 2 AS m, SUM(DISTINCT i) FROM mytable GROUP BY m HAVING SUM(DISTINCT i) > 2 ORDER BY m`,
+		[]sql.Row{{int64(1), float64(4)}},
+	},
+	{
+		`SELECT JSON_ARRAYAGG(i), JSON_OBJECTAGG(s, i) FROM mytable WHERE i > 10`,
+		[]sql.Row{{nil, nil}},
+	},
 	{
 		`SELECT GREATEST(1, 2, 3, 4)`,
 		[]sql.Row{{int64(4)}},
@@ -2404,7 +2705,7 @@ var QueryTests = []QueryTest{
 		},
 	},
 	{
-		`SELECT pk, (SELECT max(pk) FROM one_pk WHERE pk < opk.pk) AS x FROM one_pk opk GROUP BY x ORDER BY x`,
+		`SELECT ANY_VALUE(pk), (SELECT max(pk) FROM one_pk WHERE pk < opk.pk) AS x FROM one_pk opk GROUP BY x ORDER BY x`,
 		[]sql.Row{
 			{0, nil},
 			{1, 0},
@@ -2531,7 +2832,7 @@ var QueryTests = []QueryTest{
 		},
 	},
 	{
-		`SELECT pk, (SELECT max(pk) FROM one_pk WHERE pk < opk.pk) AS x 
+		`SELECT ANY_VALUE(pk), (SELECT max(pk) FROM one_pk WHERE pk < opk.pk) AS x 
 						FROM one_pk opk WHERE (SELECT max(pk) FROM one_pk WHERE pk < opk.pk) > 0 
 						GROUP BY x ORDER BY x`,
 		[]sql.Row{
@@ -2540,7 +2841,7 @@ var QueryTests = []QueryTest{
 		},
 	},
 	{
-		`SELECT pk, (SELECT max(pk) FROM one_pk WHERE pk < opk.pk) AS x 
+		`SELECT ANY_VALUE(pk), (SELECT max(pk) FROM one_pk WHERE pk < opk.pk) AS x 
 						FROM one_pk opk WHERE (SELECT max(pk) FROM one_pk WHERE pk < opk.pk) > 0 
 						GROUP BY (SELECT max(pk) FROM one_pk WHERE pk < opk.pk) ORDER BY x`,
 		[]sql.Row{
@@ -2699,10 +3000,10 @@ var QueryTests = []QueryTest{
 			{int64(7)},
 			{int64(3)},
 			{int64(2)},
+			{int64(4)},
 			{int64(8)},
 			{int64(6)},
 			{int64(5)},
-			{int64(4)},
 		},
 	},
 	{
@@ -3149,7 +3450,35 @@ var QueryTests = []QueryTest{
 	},
 	{
 		"SELECT 2.0 + CAST(5 AS DECIMAL)",
-		[]sql.Row{{float64(7)}},
+		[]sql.Row{{"7.0"}},
+	},
+	{
+		"SELECT CAST(1.005 AS DECIMAL(5,2)), CAST('-12.5' AS SIGNED), CAST('-3' AS UNSIGNED), CAST(-2.5e0 AS SIGNED), CONVERT('7x', SIGNED)",
+		[]sql.Row{{"1.01", int64(-12), uint64(18446744073709551613), int64(-2), int64(7)}},
+	},
+	{
+		"SELECT CAST('1.5e2' AS DOUBLE), CAST(2 AS REAL), CAST(0.1 AS FLOAT), CAST(0.1 AS FLOAT(30)), CONVERT('2.25', DOUBLE)",
+		[]sql.Row{{float64(150), float64(2), float32(0.1), 0.1, 2.25}},
+	},
+	{
+		"SELECT CAST('abcdef' AS CHAR(3)), CAST('ñandú' AS CHAR(10) CHARACTER SET ascii), CAST('ab' AS BINARY(3)), CAST(12 AS NCHAR)",
+		[]sql.Row{{"abc", "?and?", "ab\x00", "12"}},
+	},
+	{
+		"SELECT CAST('2001-02-03 04:05:06.789' AS DATETIME), CAST('2001-02-03 04:05:06.789' AS DATETIME(2)), CAST('2001-02-03 04:05:06' AS DATE)",
+		[]sql.Row{{
+			time.Date(2001, 2, 3, 4, 5, 7, 0, time.UTC),
+			time.Date(2001, 2, 3, 4, 5, 6, 790000000, time.UTC),
+			time.Date(2001, 2, 3, 0, 0, 0, 0, time.UTC),
+		}},
+	},
+	{
+		"SELECT CAST('-01:02:03.456' AS TIME), CAST('01:02:03.456' AS TIME(2)), CAST('03:04:05.678' AS TIME(1)), CAST(1999 AS YEAR), CONVERT('05', YEAR), CAST(1800 AS YEAR)",
+		[]sql.Row{{"-01:02:03", "01:02:03.46", "03:04:05.7", int16(1999), int16(2005), nil}},
+	},
+	{
+		"SELECT CAST(CAST('{\"a\": 1}' AS JSON) AS CHAR), CAST(CAST(1.5 AS DOUBLE) AS SIGNED), CAST(CAST('2.5' AS FLOAT) AS DECIMAL(3,2))",
+		[]sql.Row{{`{"a": 1}`, int64(2), "2.50"}},
 	},
 	{
 		"SELECT (CASE WHEN i THEN i ELSE 0 END) as cases_i from mytable",
@@ -3179,6 +3508,24 @@ var QueryTests = []QueryTest{
 	{"SELECT 0.0 div 0.0 FROM dual",
 		[]sql.Row{{sql.Null}},
 	},
+	{"SELECT 7 DIV 2, -7 DIV 2, 7 DIV -2, 5.5 DIV 2, '7' DIV 2",
+		[]sql.Row{{int64(3), int64(-3), int64(-3), int64(2), int64(3)}},
+	},
+	{"SELECT -7 % 3, 7 % -3, -7 MOD -3, 5.5 % 2, MOD(-5.5, 2)",
+		[]sql.Row{{int64(-1), int64(1), int64(-1), "1.5", "-1.5"}},
+	},
+	{"SELECT 1 - 200, 9223372036854775806 + 1, -9223372036854775807 - 1, 18446744073709551614 + 1, CAST(1 AS UNSIGNED) + -1",
+		[]sql.Row{{int64(-199), int64(math.MaxInt64), int64(math.MinInt64), uint64(math.MaxUint64), uint64(0)}},
+	},
+	{"SELECT CAST(18446744073709551615 AS UNSIGNED) DIV 2, CAST(10 AS UNSIGNED) % -3, -10 % CAST(3 AS UNSIGNED)",
+		[]sql.Row{{uint64(math.MaxInt64), uint64(1), int64(-1)}},
+	},
+	{"SELECT -1 & 1, -1 | 0, 1 ^ -1, 1 << 64, -1 >> 1, 5 & 3, ~0, ~5, ~NULL",
+		[]sql.Row{{uint64(1), uint64(math.MaxUint64), uint64(math.MaxUint64 - 1), uint64(0), uint64(math.MaxInt64), uint64(1), uint64(math.MaxUint64), uint64(math.MaxUint64 - 5), nil}},
+	},
+	{"SELECT CAST(18446744073709551615 AS UNSIGNED) & 18446744073709551614, 18446744073709551615 ^ 1, ~CAST(1 AS UNSIGNED)",
+		[]sql.Row{{uint64(math.MaxUint64 - 1), uint64(math.MaxUint64 - 1), uint64(math.MaxUint64 - 1)}},
+	},
 	{"SELECT POW(2,3) FROM dual",
 		[]sql.Row{{float64(8)}},
 	},
@@ -3669,6 +4016,66 @@ type QueryErrorTest struct {
 }
 
 var errorQueries = []QueryErrorTest{
+	{
+		Query:       `SELECT 9223372036854775807 + 1`,
+		ExpectedErr: sql.ErrDataOutOfRange,
+	},
+	{
+		Query:       `SELECT -9223372036854775807 - 2`,
+		ExpectedErr: sql.ErrDataOutOfRange,
+	},
+	{
+		Query:       `SELECT 4294967296 * 4294967296`,
+		ExpectedErr: sql.ErrDataOutOfRange,
+	},
+	{
+		Query:       `SELECT 18446744073709551615 + 1`,
+		ExpectedErr: sql.ErrDataOutOfRange,
+	},
+	{
+		Query:       `SELECT CAST(1 AS UNSIGNED) - 2`,
+		ExpectedErr: sql.ErrDataOutOfRange,
+	},
+	{
+		Query:       `SELECT -9223372036854775808 DIV -1`,
+		ExpectedErr: sql.ErrDataOutOfRange,
+	},
+	{
+		Query:       `SELECT -(-9223372036854775808)`,
+		ExpectedErr: sql.ErrDataOutOfRange,
+	},
+	{
+		Query:       `SELECT CAST(1 AS FLOAT(54))`,
+		ExpectedErr: expression.ErrConvertPrecisionTooBig,
+	},
+	{
+		Query:       `SELECT CAST('10:00:00' AS TIME(7))`,
+		ExpectedErr: expression.ErrConvertPrecisionTooBig,
+	},
+	{
+		Query:       `SELECT CAST(1 AS DECIMAL(66))`,
+		ExpectedErr: sql.ErrInvalidDecimalPrecisionScale,
+	},
+	{
+		Query:       `SELECT JSON_EXTRACT('foo', '$')`,
+		ExpectedErr: sql.ErrInvalidJSONText,
+	},
+	{
+		Query:       `SELECT JSON_EXTRACT('[1, 2]', '$.[0]')`,
+		ExpectedErr: sql.ErrInvalidJSONPath,
+	},
+	{
+		Query:       `SELECT JSON_SET('[1, 2]', '$[*]', 3)`,
+		ExpectedErr: sql.ErrJSONPathWildcard,
+	},
+	{
+		Query:       `SELECT * FROM JSON_TABLE('[{}]', '$[*]' COLUMNS (x INT PATH '$.x' ERROR ON EMPTY)) AS jt`,
+		ExpectedErr: plan.ErrJSONTableMissingValue,
+	},
+	{
+		Query:       `SELECT JSON_OBJECTAGG(NULL, i) FROM mytable`,
+		ExpectedErr: sql.ErrJSONNullKey,
+	},
 	{
 		Query:       "select foo.i from mytable as a",
 		ExpectedErr: sql.ErrTableNotFound,
@@ -86,7 +86,7 @@ var ReplaceQueries = []WriteQueryTest{
 			uint8(math.MaxUint8), uint16(math.MaxUint16), uint32(math.MaxUint32), uint64(math.MaxUint64),
 			float32(math.MaxFloat32), float64(math.MaxFloat64),
 			sql.Timestamp.MustConvert("2037-04-05 12:51:36"), sql.Date.MustConvert("2231-11-07"),
-			"random text", sql.True, ([]byte)(`{"key":"value"}`), "blobdata",
+			"random text", sql.True, sql.MustJSON(`{"key":"value"}`), "blobdata",
 		}},
 	},
 	{
@@ -104,7 +104,7 @@ var ReplaceQueries = []WriteQueryTest{
 			uint8(math.MaxUint8), uint16(math.MaxUint16), uint32(math.MaxUint32), uint64(math.MaxUint64),
 			float32(math.MaxFloat32), float64(math.MaxFloat64),
 			sql.Timestamp.MustConvert("2037-04-05 12:51:36"), sql.Date.MustConvert("2231-11-07"),
-			"random text", sql.True, ([]byte)(`{"key":"value"}`), "blobdata",
+			"random text", sql.True, sql.MustJSON(`{"key":"value"}`), "blobdata",
 		}},
 	},
 	{
@@ -112,8 +112,8 @@ var ReplaceQueries = []WriteQueryTest{
 			999, -128, -32768, -2147483648, -9223372036854775808,
 			0, 0, 0, 0,
 			1.401298464324817070923729583289916131280e-45, 4.940656458412465441765687928682213723651e-324,
-			'0000-00-00 00:00:00', '0000-00-00',
-			'', false, '', ''
+			'1970-01-01 00:00:01', '1000-01-01',
+			'', false, '""', ''
 			);`,
 		[]sql.Row{{sql.NewOkResult(1)}},
 		"SELECT * FROM typestable WHERE id = 999;",
@@ -121,8 +121,8 @@ var ReplaceQueries = []WriteQueryTest{
 			int64(999), int8(-math.MaxInt8 - 1), int16(-math.MaxInt16 - 1), int32(-math.MaxInt32 - 1), int64(-math.MaxInt64 - 1),
 			uint8(0), uint16(0), uint32(0), uint64(0),
 			float32(math.SmallestNonzeroFloat32), float64(math.SmallestNonzeroFloat64),
-			sql.Timestamp.Zero(), sql.Date.Zero(),
-			"", sql.False, ([]byte)(`""`), "",
+			sql.Timestamp.MustConvert("1970-01-01 00:00:01"), sql.Date.MustConvert("1000-01-01"),
+			"", sql.False, sql.MustJSON(`""`), "",
 		}},
 	},
 	{
@@ -130,8 +130,8 @@ var ReplaceQueries = []WriteQueryTest{
 			id = 999, i8 = -128, i16 = -32768, i32 = -2147483648, i64 = -9223372036854775808,
 			u8 = 0, u16 = 0, u32 = 0, u64 = 0,
 			f32 = 1.401298464324817070923729583289916131280e-45, f64 = 4.940656458412465441765687928682213723651e-324,
-			ti = '0000-00-00 00:00:00', da = '0000-00-00',
-			te = '', bo = false, js = '', bl = ''
+			ti = '1970-01-01 00:00:01', da = '1000-01-01',
+			te = '', bo = false, js = '""', bl = ''
 			;`,
 		[]sql.Row{{sql.NewOkResult(1)}},
 		"SELECT * FROM typestable WHERE id = 999;",
@@ -139,8 +139,8 @@ var ReplaceQueries = []WriteQueryTest{
 			int64(999), int8(-math.MaxInt8 - 1), int16(-math.MaxInt16 - 1), int32(-math.MaxInt32 - 1), int64(-math.MaxInt64 - 1),
 			uint8(0), uint16(0), uint32(0), uint64(0),
 			float32(math.SmallestNonzeroFloat32), float64(math.SmallestNonzeroFloat64),
-			sql.Timestamp.Zero(), sql.Date.Zero(),
-			"", sql.False, ([]byte)(`""`), "",
+			sql.Timestamp.MustConvert("1970-01-01 00:00:01"), sql.Date.MustConvert("1000-01-01"),
+			"", sql.False, sql.MustJSON(`""`), "",
 		}},
 	},
 	{
@@ -15,9 +15,12 @@
 package enginetest
 
 import (
+	"time"
+
 	"gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
 )
 
 type ScriptTest struct {
@@ -44,6 +47,838 @@ type ScriptTestAssertion struct {
 // Unlike other engine tests, ScriptTests must be self-contained. No other tables are created outside the definition of
 // the tests.
 var ScriptTests = []ScriptTest{
+	{
+		Name: "json columns",
+		SetUpScript: []string{
+			"create table t (pk int primary key, js json)",
+			`insert into t values (1, '{"a": 1, "b": [1, 2]}'), (2, '{"b": [3], "a": "x"}'), (3, '[1, 2]'), (4, null)`,
+			`update t set js = json_set(js, '$.c', pk) where pk = 2`,
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "select pk, js -> '$.a', js ->> '$.a' from t order by pk",
+				Expected: []sql.Row{
+					{1, sql.MustJSON(`1`), "1"},
+					{2, sql.MustJSON(`"x"`), "x"},
+					{3, nil, nil},
+					{4, nil, nil},
+				},
+			},
+			{
+				Query:    "select pk from t where js -> '$.b[last]' = 3",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "select js from t where pk = 2",
+				Expected: []sql.Row{{sql.MustJSON(`{"a": "x", "b": [3], "c": 2}`)}},
+			},
+			{
+				Query:    `select pk from t where js = cast('{"b": [1, 2], "a": 1}' as json)`,
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    `select pk from t where js = '{"b": [1, 2], "a": 1}'`,
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select pk from t where pk <> 2 order by js",
+				Expected: []sql.Row{{4}, {1}, {3}},
+			},
+			{
+				Query:       `insert into t values (5, '{"a": }')`,
+				ExpectedErr: sql.ErrInvalidJSONText,
+			},
+		},
+	},
+	{
+		Name: "spatial columns",
+		SetUpScript: []string{
+			"create table stores (id int primary key, name varchar(20), loc point)",
+			"create table zones (id int primary key, name varchar(20), area polygon, shape geometry)",
+			"insert into stores values (1, 'center', point(2, 2)), (2, 'edge', st_geomfromtext('POINT(4 1)')), (3, 'outside', point(7, 6)), (4, 'unknown', null)",
+			"insert into zones values (1, 'square', st_geomfromtext('POLYGON((0 0,4 0,4 4,0 4,0 0))'), st_geomfromtext('LINESTRING(0 0,1 1)'))",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "select id, st_astext(loc), st_x(loc), st_y(loc), st_srid(loc) from stores order by id",
+				Expected: []sql.Row{
+					{1, "POINT(2 2)", 2.0, 2.0, uint32(0)},
+					{2, "POINT(4 1)", 4.0, 1.0, uint32(0)},
+					{3, "POINT(7 6)", 7.0, 6.0, uint32(0)},
+					{4, nil, nil, nil, nil},
+				},
+			},
+			{
+				Query: "select s.name, st_contains(z.area, s.loc), st_within(s.loc, z.area), st_intersects(z.area, s.loc), st_distance(z.area, s.loc) from stores s join zones z order by s.id",
+				Expected: []sql.Row{
+					{"center", true, true, true, 0.0},
+					{"edge", false, false, true, 0.0},
+					{"outside", false, false, false, 3.6055512754639896},
+					{"unknown", nil, nil, nil, nil},
+				},
+			},
+			{
+				Query:    "select name from stores where st_distance(loc, point(0, 0)) < 3",
+				Expected: []sql.Row{{"center"}},
+			},
+			{
+				Query:    "select st_astext(shape), st_asgeojson(shape) from zones",
+				Expected: []sql.Row{{"LINESTRING(0 0,1 1)", sql.MustJSON(`{"type": "LineString", "coordinates": [[0.0, 0.0], [1.0, 1.0]]}`)}},
+			},
+			{
+				Query:       "insert into stores values (5, 'line', st_geomfromtext('LINESTRING(0 0,1 1)'))",
+				ExpectedErr: sql.ErrInvalidGeometry,
+			},
+			{
+				Query:       "insert into stores values (5, 'text', 'POINT(1 1)')",
+				ExpectedErr: sql.ErrInvalidGeometry,
+			},
+		},
+	},
+	{
+		Name: "fulltext indexes",
+		SetUpScript: []string{
+			"create table articles (id int primary key, title varchar(200), body text)",
+			"insert into articles values (1, 'MySQL Tutorial', 'DBMS stands for DataBase ...'), (2, 'How To Use MySQL Well', 'After you went through a ...'), (3, 'Optimizing MySQL', 'In this tutorial, we show ...'), (4, '1001 MySQL Tricks', '1. Never run mysqld as root. 2. ...'), (5, 'MySQL vs. YourSQL', 'In the following database comparison ...'), (6, 'MySQL Security', 'When configured properly, MySQL ...')",
+			"create fulltext index ft on articles (title, body)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select id, match (title, body) against ('database') from articles order by id",
+				Expected: []sql.Row{{1, 0.22764469170526494}, {2, 0.0}, {3, 0.0}, {4, 0.0}, {5, 0.22764469170526494}, {6, 0.0}},
+			},
+			{
+				Query:    "select id from articles where match (title, body) against ('tutorial database') order by match (title, body) against ('tutorial database') desc, id",
+				Expected: []sql.Row{{1}, {3}, {5}},
+			},
+			{
+				Query: "explain select id from articles where match (title, body) against ('tutorial') order by match (title, body) against ('tutorial') desc",
+				Expected: []sql.Row{
+					{"Project(articles.id)"},
+					{" └─ Indexed table access on index [articles.title,articles.body]"},
+					{"     └─ Filter(MATCH (articles.title, articles.body) AGAINST (\"tutorial\"))"},
+					{"         └─ Table(articles)"},
+				},
+			},
+			{
+				Query:    "select a.id from articles a where match (a.body, a.title) against ('+mysql -yoursql -tutorial' in boolean mode) order by id",
+				Expected: []sql.Row{{2}, {4}, {6}},
+			},
+			{
+				Query:    "select id from articles where match (title, body) against ('\"database comparison\" mysq*' in boolean mode) order by id",
+				Expected: []sql.Row{{1}, {2}, {3}, {4}, {5}, {6}},
+			},
+			{
+				Query:       "select id from articles where match (title) against ('database')",
+				ExpectedErr: sql.ErrNoFullTextIndex,
+			},
+			{
+				Query:       "create fulltext index bad on articles (id)",
+				ExpectedErr: sql.ErrBadFullTextColumn,
+			},
+			{
+				Query:    "show create table articles",
+				Expected: []sql.Row{{"articles", "CREATE TABLE `articles` (\n  `id` int NOT NULL,\n  `title` varchar(200),\n  `body` text,\n  PRIMARY KEY (`id`),\n  FULLTEXT KEY `ft` (`title`,`body`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"}},
+			},
+		},
+	},
+	{
+		Name: "fulltext indexes in create table",
+		SetUpScript: []string{
+			"CREATE TABLE `notes` (\n  `id` int NOT NULL,\n  `body` text,\n  PRIMARY KEY (`id`),\n  FULLTEXT KEY `ft` (`body`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4",
+			"insert into notes values (1, 'fulltext search in mysql'), (2, 'something else')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select id from notes where match (body) against ('search')",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "show create table notes",
+				Expected: []sql.Row{{"notes", "CREATE TABLE `notes` (\n  `id` int NOT NULL,\n  `body` text,\n  PRIMARY KEY (`id`),\n  FULLTEXT KEY `ft` (`body`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"}},
+			},
+			{
+				Query:       "create table bad (id int primary key, fulltext index (id))",
+				ExpectedErr: sql.ErrBadFullTextColumn,
+			},
+		},
+	},
+	{
+		Name: "generated columns",
+		SetUpScript: []string{
+			"create table t (id int primary key, a int, b int as (a + 1) stored, c varchar(20) generated always as (concat('x', b)) virtual not null, j json, k varchar(10) as (json_unquote(json_extract(j, '$.k'))) stored)",
+			`insert into t (id, a, j) values (1, 10, '{"k": "one"}'), (2, 20, '{"k": "two"}')`,
+			`insert into t values (3, 30, default, default, '{"k": "three"}', default)`,
+			"update t set a = a * 2 where id = 1",
+			"update t set b = default, a = 7 where id = 2",
+			"insert into t (id, a) values (3, 0) on duplicate key update a = 300",
+			"replace into t (id, a) values (4, 40)",
+			"create index kidx on t (k)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "select id, a, b, c, k from t order by id",
+				Expected: []sql.Row{
+					{1, 20, 21, "x21", "one"},
+					{2, 7, 8, "x8", "two"},
+					{3, 300, 301, "x301", "three"},
+					{4, 40, 41, "x41", nil},
+				},
+			},
+			{
+				Query:    "select id, b from t where k = 'two'",
+				Expected: []sql.Row{{2, 8}},
+			},
+			{
+				Query: "explain select id from t where k = 'two'",
+				Expected: []sql.Row{
+					{"Project(t.id)"},
+					{" └─ Indexed table access on index [t.k]"},
+					{"     └─ Filter(t.k = \"two\")"},
+					{"         └─ Table(t)"},
+				},
+			},
+			{
+				Query:       "insert into t (id, a, b) values (5, 1, 2)",
+				ExpectedErr: sql.ErrGeneratedColumnValue,
+			},
+			{
+				Query:       "insert into t (id, b) select 5, 6",
+				ExpectedErr: sql.ErrGeneratedColumnValue,
+			},
+			{
+				Query:       "update t set c = 'x'",
+				ExpectedErr: sql.ErrGeneratedColumnValue,
+			},
+			{
+				Query:       "insert into t (id, a) values (1, 0) on duplicate key update b = 0",
+				ExpectedErr: sql.ErrGeneratedColumnValue,
+			},
+			{
+				Query: "show create table t",
+				Expected: []sql.Row{{"t", "CREATE TABLE `t` (\n" +
+					"  `id` int NOT NULL,\n" +
+					"  `a` int,\n" +
+					"  `b` int GENERATED ALWAYS AS (a + 1) STORED,\n" +
+					"  `c` varchar(20) GENERATED ALWAYS AS (concat(\"x\", b)) VIRTUAL NOT NULL,\n" +
+					"  `j` json,\n" +
+					"  `k` varchar(10) GENERATED ALWAYS AS (JSON_UNQUOTE(JSON_EXTRACT(j, \"$.k\"))) STORED,\n" +
+					"  PRIMARY KEY (`id`),\n" +
+					"  KEY `kidx` (`k`)\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"}},
+			},
+			{
+				Query: "select column_name, extra, generation_expression from information_schema.columns where table_name = 't' order by ordinal_position",
+				Expected: []sql.Row{
+					{"id", "", ""},
+					{"a", "", ""},
+					{"b", "STORED GENERATED", "a + 1"},
+					{"c", "VIRTUAL GENERATED", `concat("x", b)`},
+					{"j", "", ""},
+					{"k", "STORED GENERATED", `JSON_UNQUOTE(JSON_EXTRACT(j, "$.k"))`},
+				},
+			},
+			{
+				Query:       "create table bad (a int as (b + 1), b int as (1))",
+				ExpectedErr: sql.ErrInvalidDefaultValueOrder,
+			},
+			{
+				Query:       "alter table t drop column a",
+				ExpectedErr: sql.ErrDropColumnReferencedInDefault,
+			},
+			{
+				Query:    "alter table t add column d int as (a * 10) stored after a",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "alter table t rename column a to aa",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "update t set aa = 1 where id = 1",
+				Expected: []sql.Row{{newUpdateResult(1, 1)}},
+			},
+			{
+				Query:    "select id, aa, d, b, c from t order by id",
+				Expected: []sql.Row{{1, 1, 10, 2, "x2"}, {2, 7, 70, 8, "x8"}, {3, 300, 3000, 301, "x301"}, {4, 40, 400, 41, "x41"}},
+			},
+		},
+	},
+	{
+		Name: "sql_mode",
+		SetUpScript: []string{
+			"create table t (pk int primary key, ti tinyint, tu tinyint unsigned, d decimal(4,2), s varchar(3), dt datetime)",
+			"set sql_mode = ''",
+			"insert into t values (1, 300, -1, 123.456, 'abcdef', 'not a date'), (2, '12abc', 'x', '-9999', 'ab', '0000-00-00')",
+			"update t set ti = ti / 0 where pk = 1",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "select * from t order by pk",
+				Expected: []sql.Row{
+					{1, nil, uint8(0), "99.99", "abc", time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC)},
+					{2, int8(12), uint8(0), "-99.99", "ab", time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC)},
+				},
+			},
+			{
+				Query:    "insert into t (pk, ti, s) values (3, 128, 'abcd'), (4, 'x', 'xyz')",
+				Expected: []sql.Row{{sql.NewOkResult(2)}},
+			},
+			{
+				Query: "show warnings limit 3",
+				Expected: []sql.Row{
+					{"Warning", 1366, "Incorrect integer value: 'x' for column 'ti' at row 2"},
+					{"Warning", 1265, "Data truncated for column 's' at row 1"},
+					{"Warning", 1264, "Out of range value for column 'ti' at row 1"},
+				},
+			},
+			{
+				Query:    "set sql_mode = 'traditional'",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:       "insert into t (pk, ti) values (5, 300)",
+				ExpectedErr: sql.ErrOutOfRange,
+			},
+			{
+				Query:       "insert into t (pk, s) values (5, 'abcd')",
+				ExpectedErr: sql.ErrLengthBeyondLimit,
+			},
+			{
+				Query:       "insert into t (pk, dt) values (5, '0000-00-00')",
+				ExpectedErr: sql.ErrIncorrectValue,
+			},
+			{
+				Query:       "insert into t (pk, ti) values (5, 1 / 0)",
+				ExpectedErr: sql.ErrDivisionByZero,
+			},
+			{
+				Query:       "update t set ti = 1 % 0",
+				ExpectedErr: sql.ErrDivisionByZero,
+			},
+			{
+				Query:    "select 1 / 0",
+				Expected: []sql.Row{{sql.Null}},
+			},
+			{
+				Query:    "show warnings limit 1",
+				Expected: []sql.Row{{"Warning", 1365, "Division by 0"}},
+			},
+			{
+				Query:    "set sql_mode = 'ANSI_QUOTES,NO_BACKSLASH_ESCAPES'",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    `select concat("t"."s", 'x\') from "t" where pk = 2`,
+				Expected: []sql.Row{{`abx\`}},
+			},
+			{
+				Query:    "set sql_mode = 'ONLY_FULL_GROUP_BY,STRICT_TRANS_TABLES,NO_ZERO_IN_DATE,NO_ZERO_DATE,ERROR_FOR_DIVISION_BY_ZERO,NO_ENGINE_SUBSTITUTION'",
+				Expected: []sql.Row{{}},
+			},
+		},
+	},
+	{
+		Name: "only_full_group_by",
+		SetUpScript: []string{
+			"create table t (pk int primary key, a int, b int)",
+			"create table u (pk int primary key, c int)",
+			"insert into t values (1, 1, 10), (2, 1, 20), (3, 2, 30)",
+			"insert into u values (1, 100), (2, 200), (3, 300)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select a, count(*), a + 1 from t group by a order by a",
+				Expected: []sql.Row{{1, 2, 2}, {2, 1, 3}},
+			},
+			{
+				Query:    "select pk, a, b from t group by pk order by pk",
+				Expected: []sql.Row{{1, 1, 10}, {2, 1, 20}, {3, 2, 30}},
+			},
+			{
+				Query:    "select a, b from t where b = 30 group by a",
+				Expected: []sql.Row{{2, 30}},
+			},
+			{
+				Query:    "select t.pk, b, c from t join u on t.pk = u.pk group by u.pk order by t.pk",
+				Expected: []sql.Row{{1, 10, 100}, {2, 20, 200}, {3, 30, 300}},
+			},
+			{
+				Query:    "select a as x, sum(b) from t group by x having x > 1",
+				Expected: []sql.Row{{2, float64(30)}},
+			},
+			{
+				Query:       "select a, b from t group by a",
+				ExpectedErr: sql.ErrWrongFieldWithGroup,
+			},
+			{
+				Query:       "select a, count(*) from t group by a having b > 10",
+				ExpectedErr: sql.ErrWrongFieldWithGroup,
+			},
+			{
+				Query:       "select a, count(*) from t group by a order by b",
+				ExpectedErr: sql.ErrWrongFieldWithGroup,
+			},
+			{
+				Query:       "select a, count(*) from t",
+				ExpectedErr: sql.ErrMixOfGroupFuncAndFields,
+			},
+			{
+				Query: `SELECT LOGFILE_GROUP_NAME, FILE_NAME, TOTAL_EXTENTS, INITIAL_SIZE, ENGINE, EXTRA
+					FROM INFORMATION_SCHEMA.FILES
+					WHERE FILE_TYPE = 'UNDO LOG' AND FILE_NAME IS NOT NULL AND LOGFILE_GROUP_NAME IS NOT NULL
+					GROUP BY LOGFILE_GROUP_NAME, FILE_NAME, ENGINE, TOTAL_EXTENTS, INITIAL_SIZE
+					ORDER BY LOGFILE_GROUP_NAME`,
+				Expected: nil,
+			},
+			{
+				Query:       "SELECT table_schema, table_name FROM information_schema.tables GROUP BY table_schema",
+				ExpectedErr: sql.ErrWrongFieldWithGroup,
+			},
+			{
+				Query:    "SELECT table_name, table_type FROM information_schema.tables WHERE table_schema = 'mydb' AND table_name = 't' GROUP BY table_schema, table_name",
+				Expected: []sql.Row{{"t", "BASE TABLE"}},
+			},
+			{
+				Query:    "set sql_mode = ''",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "select a, count(*) from t where a = 2",
+				Expected: []sql.Row{{2, 1}},
+			},
+			{
+				Query:    "set sql_mode = 'ONLY_FULL_GROUP_BY,STRICT_TRANS_TABLES,NO_ZERO_IN_DATE,NO_ZERO_DATE,ERROR_FOR_DIVISION_BY_ZERO,NO_ENGINE_SUBSTITUTION'",
+				Expected: []sql.Row{{}},
+			},
+		},
+	},
+	{
+		Name: "character sets",
+		SetUpScript: []string{
+			"create table t (pk int primary key, l varchar(4) character set latin1, u text character set utf16, b varbinary(8))",
+			"insert into t values (1, 'aé€', 'aé😀', 'aé')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select length(l), char_length(l), length(u), char_length(u), length(b), char_length(b) from t",
+				Expected: []sql.Row{{int32(3), int32(3), int32(8), int32(3), int32(3), int32(3)}},
+			},
+			{
+				Query:    "select charset(l), collation(l), charset(u), charset(b), charset(pk) from t",
+				Expected: []sql.Row{{"latin1", "latin1_swedish_ci", "utf16", "binary", "binary"}},
+			},
+			{
+				Query:    "select convert(l using ascii), convert(u using latin1), convert(l using 'binary') from t",
+				Expected: []sql.Row{{"a??", "aé?", "a\xE9\x80"}},
+			},
+			{
+				Query:    "select charset(convert(l using utf16)), length(convert(l using utf16)) from t",
+				Expected: []sql.Row{{"utf16", int32(6)}},
+			},
+			{
+				Query:    "select convert(convert('aé' using latin1) using 'binary'), convert(b using latin1) from t",
+				Expected: []sql.Row{{"a\xE9", "aÃ©"}},
+			},
+			{
+				Query:    "select convert(b using ascii) from t",
+				Expected: []sql.Row{{nil}},
+			},
+			{
+				Query:       "insert into t (pk, l) values (2, 'a中')",
+				ExpectedErr: sql.ErrIncorrectStringValue,
+			},
+			{
+				Query:    "insert into t (pk, l) values (2, 'aéb€')",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:       "insert into t (pk, l) values (3, 'aéb€c')",
+				ExpectedErr: sql.ErrLengthBeyondLimit,
+			},
+			{
+				Query:    "set sql_mode = ''",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "insert into t (pk, l) values (3, 'a中')",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "select l from t where pk = 3",
+				Expected: []sql.Row{{"a?"}},
+			},
+			{
+				Query:       "create table u (s varchar(10) collate utf8mb4_de_pb_0900_ai_ci)",
+				ExpectedErr: sql.ErrCollationUnsupported,
+			},
+			{
+				Query:       "select convert(l using tis620) from t",
+				ExpectedErr: sql.ErrCollationUnsupported,
+			},
+			{
+				Query:    "set sql_mode = 'ONLY_FULL_GROUP_BY,STRICT_TRANS_TABLES,NO_ZERO_IN_DATE,NO_ZERO_DATE,ERROR_FOR_DIVISION_BY_ZERO,NO_ENGINE_SUBSTITUTION'",
+				Expected: []sql.Row{{}},
+			},
+		},
+	},
+	{
+		Name: "string functions use the collation of their arguments",
+		SetUpScript: []string{
+			"create table strs (pk int primary key, ci varchar(20), cs varchar(20) collate utf8mb4_bin, l varchar(20) character set latin1, b varbinary(20))",
+			"insert into strs values (1, 'Añb,C', 'Añb,C', 'Añb,C', 'Añb,C')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select locate('b', ci), locate('B', ci), locate('B', cs), locate('b', b), position('ñ' in l) from strs",
+				Expected: []sql.Row{{int64(3), int64(3), int64(0), int64(4), int64(2)}},
+			},
+			{
+				Query:    "select find_in_set('c', ci), find_in_set('c', cs), field('c', 'a', ci, 'C'), strcmp(ci, 'AÑB,C'), strcmp(cs, 'AÑB,C') from strs",
+				Expected: []sql.Row{{int64(2), int64(0), int64(3), int64(0), int64(1)}},
+			},
+			{
+				Query:    "select right(ci, 4), right(b, 4), insert(l, 2, 1, 'n'), ord(l), ord(b) from strs",
+				Expected: []sql.Row{{"ñb,C", "\xb1b,C", "Anb,C", int64(0x41), int64(0x41)}},
+			},
+			{
+				Query:    "select ci from strs where ci like 'a_b|,%' escape '|'",
+				Expected: []sql.Row{{"Añb,C"}},
+			},
+			{
+				Query:    "select cs from strs where cs like 'a_b|,%' escape '|'",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:       "select ci from strs where ci like 'a%' escape '||'",
+				ExpectedErr: expression.ErrIncorrectEscape,
+			},
+		},
+	},
+	{
+		Name: "casts",
+		SetUpScript: []string{
+			"create table casts (pk int primary key, s varchar(20), d datetime, f double)",
+			"insert into casts values (1, '12.36abc', '2020-03-04 05:06:07', -2.5)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select cast(s as signed), cast(s as decimal(4,1)), cast(s as double), cast(s as char(3)), cast(s as binary(10)) from casts",
+				Expected: []sql.Row{{int64(12), "12.4", 12.36, "12.", "12.36abc\x00\x00"}},
+			},
+			{
+				Query: "show warnings",
+				Expected: []sql.Row{
+					{"Warning", 1292, "Truncated incorrect CHAR(3) value: '12.36abc'"},
+					{"Warning", 1292, "Truncated incorrect DOUBLE value: '12.36abc'"},
+					{"Warning", 1292, "Truncated incorrect DECIMAL value: '12.36abc'"},
+					{"Warning", 1292, "Truncated incorrect INTEGER value: '12.36abc'"},
+				},
+			},
+			{
+				Query:    "select cast(d as signed), cast(d as date), cast(d as time), cast(d as year), cast(f as signed), cast(f as unsigned) from casts",
+				Expected: []sql.Row{{int64(20200304050607), time.Date(2020, 3, 4, 0, 0, 0, 0, time.UTC), "05:06:07", int16(2020), int64(-2), uint64(18446744073709551614)}},
+			},
+			{
+				Query:    "select cast(f as float) / 2, cast(f as decimal(3,1)) * 2, concat(cast(pk as char(5)), 'x') from casts",
+				Expected: []sql.Row{{-1.25, "-5.0", "1x"}},
+			},
+		},
+	},
+	{
+		Name: "json_table over json columns",
+		SetUpScript: []string{
+			"create table t (pk int primary key, js json)",
+			`insert into t values (1, '[{"n": "a", "v": 1}, {"n": "b"}]'), (2, '[]'), (3, '[{"n": "c", "v": "x"}]')`,
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: `select t.pk, jt.n, jt.v from t join json_table(t.js, '$[*]' columns (n varchar(10) path '$.n', v int path '$.v' default '0' on empty null on error)) as jt order by 1, 2`,
+				Expected: []sql.Row{
+					{1, "a", 1},
+					{1, "b", 0},
+					{3, "c", nil},
+				},
+			},
+			{
+				Query: `select pk, (select count(*) from json_table(t.js, '$[*]' columns (has_v int exists path '$.v')) jt where has_v = 1) from t order by pk`,
+				Expected: []sql.Row{
+					{1, 1},
+					{2, 0},
+					{3, 1},
+				},
+			},
+			{
+				Query: `select t.pk, jt.n from t left join json_table(t.js, '$[*]' columns (n varchar(10) path '$.n')) as jt on true order by 1, 2`,
+				Expected: []sql.Row{
+					{1, "a"},
+					{1, "b"},
+					{2, nil},
+					{3, "c"},
+				},
+			},
+			{
+				Query: `select t.pk, jt.n from t left join json_table(t.js, '$[*]' columns (n varchar(10) path '$.n', v int path '$.v')) as jt on jt.v = t.pk order by 1, 2`,
+				Expected: []sql.Row{
+					{1, "a"},
+					{2, nil},
+					{3, nil},
+				},
+			},
+		},
+	},
+	{
+		Name: "table named json_table",
+		SetUpScript: []string{
+			"create table json_table (a int)",
+			"insert into json_table (a) values (1), (2)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select a from json_table where a in (select a from json_table where a > 1)",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "update json_table set a = a + 10 where a = 1",
+				Expected: []sql.Row{{newUpdateResult(1, 1)}},
+			},
+			{
+				Query:    "select j.a, jt.x from json_table j, json_table('[5]', '$[*]' columns (x int path '$')) jt order by 1",
+				Expected: []sql.Row{{2, 5}, {11, 5}},
+			},
+		},
+	},
+	{
+		Name: "group_concat_max_len truncates GROUP_CONCAT",
+		SetUpScript: []string{
+			"create table t (pk int primary key, s varchar(20))",
+			"insert into t values (1, 'aaaa'), (2, 'bbbb'), (3, 'cccc')",
+			"set group_concat_max_len = 7",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select group_concat(s order by pk) from t",
+				Expected: []sql.Row{{"aaaa,bb"}},
+			},
+			{
+				Query:    "show warnings",
+				Expected: []sql.Row{{"Warning", 1260, "Row 2 was cut by GROUP_CONCAT()"}},
+			},
+			{
+				Query:    "select group_concat(s order by pk) from t where pk = 1",
+				Expected: []sql.Row{{"aaaa"}},
+			},
+		},
+	},
+	{
+		Name: "exact decimal arithmetic and aggregation",
+		SetUpScript: []string{
+			"create table t (pk int primary key, d decimal(10,2))",
+			"insert into t values (1, 0.1), (2, 0.2), (3, 0.3), (4, null)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select sum(d), avg(d) from t where d is not null",
+				Expected: []sql.Row{{"0.60", "0.200000"}},
+			},
+			{
+				Query:    "select d + cast(0.2 as decimal(3,1)) = 0.3 from t where pk = 1",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "select d * d, d / 3, -d from t where pk = 2",
+				Expected: []sql.Row{{"0.0400", "0.066667", "-0.20"}},
+			},
+			{
+				Query:    "select pk from t where d + d = 0.6",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "select round(sum(d) / 7, 3), cast(sum(d) as decimal(5,1)) from t",
+				Expected: []sql.Row{{"0.086", "0.6"}},
+			},
+			{
+				Query:    "select cast('12.345' as decimal), cast('12.345' as decimal(5,2))",
+				Expected: []sql.Row{{"12", "12.35"}},
+			},
+		},
+	},
+	{
+		Name: "numeric literals without an exponent are exact decimals",
+		SetUpScript: []string{
+			"create table products (pk int primary key, price decimal(10,2), weight double)",
+			"insert into products values (1, 10.00, 0.1), (2, 0.10, 2.5), (3, 19.99, null)",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select pk, price * 1.08 from products order by pk",
+				Expected: []sql.Row{{1, "10.8000"}, {2, "0.1080"}, {3, "21.5892"}},
+			},
+			{
+				Query:    "select price * 1.1, price + 0.1, price - 0.05 from products where pk = 2",
+				Expected: []sql.Row{{"0.110", "0.20", "0.05"}},
+			},
+			{
+				Query:    "select pk from products where price + 0.2 = 0.3",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "select 0.1 + 0.2 = 0.3, 0.1 + 0.2, 1.5 * 2, 2.50",
+				Expected: []sql.Row{{true, "0.3", "3.0", "2.50"}},
+			},
+			{
+				Query:    "select weight * 2.0, 1.5e1 from products where pk = 2",
+				Expected: []sql.Row{{5.0, 15.0}},
+			},
+			{
+				Query:    "update products set price = price * 1.08 where price > 15.5",
+				Expected: []sql.Row{{newUpdateResult(1, 1)}},
+			},
+			{
+				Query:    "select price from products where pk = 3",
+				Expected: []sql.Row{{"21.59"}},
+			},
+		},
+	},
+	{
+		Name: "timestamps are stored in UTC and read in the session time zone",
+		SetUpScript: []string{
+			"create table t (pk int primary key, ts timestamp, dt datetime)",
+			"set time_zone = '+00:00'",
+			"insert into t values (1, '2021-01-15 12:00:00', '2021-01-15 12:00:00'), (3, '2021-01-15 12:00:00', '2021-01-15 12:00:00')",
+			"set time_zone = 'Europe/Madrid'",
+			"insert into t values (2, '2021-01-15 12:00:00', '2021-01-15 12:00:00')",
+			"update t set ts = '2021-01-15 18:00:00' where pk = 1",
+			"delete from t where pk = 3",
+			"set time_zone = '+02:00'",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query: "select pk, ts, dt from t order by pk",
+				Expected: []sql.Row{
+					{1, time.Date(2021, time.January, 15, 19, 0, 0, 0, time.UTC), time.Date(2021, time.January, 15, 12, 0, 0, 0, time.UTC)},
+					{2, time.Date(2021, time.January, 15, 13, 0, 0, 0, time.UTC), time.Date(2021, time.January, 15, 12, 0, 0, 0, time.UTC)},
+				},
+			},
+			{
+				Query:    "select pk from t where ts = '2021-01-15 13:00:00'",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "select unix_timestamp(ts), unix_timestamp(dt) from t where pk = 2",
+				Expected: []sql.Row{{float64(1610708400), float64(1610704800)}},
+			},
+			{
+				Query:    "select convert_tz(ts, '+02:00', 'UTC') from t where pk = 2",
+				Expected: []sql.Row{{time.Date(2021, time.January, 15, 11, 0, 0, 0, time.UTC)}},
+			},
+			{
+				Query:       "set time_zone = 'Nowhere/Special'",
+				ExpectedErr: sql.ErrUnknownTimeZone,
+			},
+		},
+	},
+	{
+		Name: "indexed timestamps are looked up in the session time zone",
+		SetUpScript: []string{
+			"create table t (pk int primary key, t timestamp, index (t))",
+			"create table u (pk int primary key, t timestamp)",
+			"set time_zone = '+00:00'",
+			"insert into t values (1, '2020-01-01 12:00:00'), (2, '2020-01-01 10:00:00')",
+			"set time_zone = '+02:00'",
+			"insert into u values (1, '2020-01-01 14:00:00')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select pk, t from t where t = '2020-01-01 14:00:00'",
+				Expected: []sql.Row{{1, time.Date(2020, time.January, 1, 14, 0, 0, 0, time.UTC)}},
+			},
+			{
+				Query:    "select pk from t where t > '2020-01-01 13:00:00'",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "select pk from t where t <= '2020-01-01 12:00:00'",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "select pk from t where t between '2020-01-01 11:00:00' and '2020-01-01 13:00:00'",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "select pk from t where t in ('2020-01-01 12:00:00', '2020-01-01 14:00:00') order by pk",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "select pk from t where t <> '2020-01-01 14:00:00'",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "select u.pk, t.pk from u join t on u.t = t.t",
+				Expected: []sql.Row{{1, 1}},
+			},
+			{
+				Query:    "update t set pk = 3 where t = '2020-01-01 14:00:00'",
+				Expected: []sql.Row{{newUpdateResult(1, 1)}},
+			},
+			{
+				Query:    "delete from t where t = '2020-01-01 12:00:00'",
+				Expected: []sql.Row{{sql.NewOkResult(1)}},
+			},
+			{
+				Query:    "select pk from t",
+				Expected: []sql.Row{{3}},
+			},
+		},
+	},
+	{
+		Name: "collation aware distinct and group by",
+		SetUpScript: []string{
+			"create table t (pk int primary key, s varchar(20), b varchar(20) collate utf8mb4_bin)",
+			"insert into t values (1, 'a', 'a'), (2, 'A', 'A'), (3, 'á', 'á'), (4, 'b', 'b'), (5, 'B', 'b')",
+		},
+		Query: "select count(distinct s), count(distinct b), count(*) from (select distinct s, b from t) sub",
+		Expected: []sql.Row{
+			{2, 4, 4},
+		},
+	},
+	{
+		Name: "collation aware group by",
+		SetUpScript: []string{
+			"create table t (pk int primary key, s varchar(20))",
+			"insert into t values (1, 'a'), (2, 'A'), (3, 'á'), (4, 'b'), (5, 'B')",
+		},
+		Query: "select count(*) from t group by s order by 1",
+		Expected: []sql.Row{
+			{2},
+			{3},
+		},
+	},
+	{
+		Name: "collation aware index lookups",
+		SetUpScript: []string{
+			"create table t (pk int primary key, s varchar(20), b varchar(20) collate utf8mb4_bin, index s_idx (s), index b_idx (b))",
+			"insert into t values (1, 'alice', 'alice'), (2, 'Bob', 'Bob'), (3, 'álvaro', 'álvaro')",
+		},
+		Assertions: []ScriptTestAssertion{
+			{
+				Query:    "select pk from t where s = 'ALICE'",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "select pk from t where b = 'ALICE'",
+				Expected: nil,
+			},
+			{
+				Query:    "select pk from t where s >= 'b' order by pk",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "select pk from t where b > 'a' order by pk",
+				Expected: []sql.Row{{1}, {3}},
+			},
+		},
+	},
 	{
 		Name: "delete with in clause",
 		SetUpScript: []string{
@@ -139,7 +139,7 @@ var UpdateTests = []WriteQueryTest{
 			nil}},
 	},
 	{
-		"UPDATE typestable SET da = '0000-00-00', ti = '0000-00-00 00:00:00';",
+		"UPDATE typestable SET da = '1000-01-01', ti = '1970-01-01 00:00:01';",
 		[]sql.Row{{newUpdateResult(1, 1)}},
 		"SELECT * FROM typestable;",
 		[]sql.Row{{
@@ -154,8 +154,8 @@ var UpdateTests = []WriteQueryTest{
 			uint64(9),
 			float32(10),
 			float64(11),
-			sql.Timestamp.Zero(),
-			sql.Date.Zero(),
+			sql.Timestamp.MustConvert("1970-01-01 00:00:01"),
+			sql.Date.MustConvert("1000-01-01"),
 			"fourteen",
 			false,
 			nil,
@@ -219,4 +219,8 @@ var UpdateErrorTests = []GenericErrorQueryTest{
 		"set null on non-nullable",
 		"UPDATE mytable SET s = NULL;",
 	},
+	{
+		"zero date in strict mode",
+		"UPDATE typestable SET da = '0000-00-00';",
+	},
 }
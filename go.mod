@@ -15,9 +15,9 @@ require (
 	github.com/konsorten/go-windows-terminal-sequences v1.0.2 // indirect
 	github.com/lestrrat-go/strftime v1.0.1
 	github.com/mitchellh/hashstructure v1.0.0
-	github.com/oliveagle/jsonpath v0.0.0-20180606110733-2e52cf6e6852
 	github.com/opentracing/opentracing-go v1.1.0
 	github.com/pmezard/go-difflib v1.0.0
+	github.com/prometheus/client_golang v1.1.0
 	github.com/sanity-io/litter v1.2.0
 	github.com/shopspring/decimal v0.0.0-20191130220710-360f2bc03045
 	github.com/sirupsen/logrus v1.4.2
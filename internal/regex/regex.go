@@ -22,6 +22,12 @@ var (
 type Matcher interface {
 	// Match returns true if the text matches the regular expression.
 	Match(text string) bool
+	// FindAllSubmatchIndex returns the start and end indexes of at most n
+	// successive non-overlapping matches of the regular expression in the
+	// text, followed by the ones of the groups of each match, like the
+	// function of the same name of the regexp package. If n is negative, all
+	// the matches are returned.
+	FindAllSubmatchIndex(text string, n int) [][]int
 }
 
 // Disposer interface is used to release resources.
@@ -30,8 +36,19 @@ type Disposer interface {
 	Dispose()
 }
 
+// Flags are the options of a regular expression.
+type Flags struct {
+	// CaseInsensitive makes letters match both upper and lower case.
+	CaseInsensitive bool
+	// MultiLine makes ^ and $ match at the start and end of each line
+	// instead of only at the start and end of the text.
+	MultiLine bool
+	// DotAll makes . match line terminators.
+	DotAll bool
+}
+
 // Constructor creates a new Matcher.
-type Constructor func(re string) (Matcher, Disposer, error)
+type Constructor func(re string, flags Flags) (Matcher, Disposer, error)
 
 var (
 	// CompileHistogram describes a regexp compile time.
@@ -74,12 +91,18 @@ func Engines() []string {
 
 // New creates a new Matcher with the specified regex engine.
 func New(name, re string) (Matcher, Disposer, error) {
+	return NewWithFlags(name, re, Flags{})
+}
+
+// NewWithFlags creates a new Matcher with the specified regex engine and
+// flags.
+func NewWithFlags(name, re string, flags Flags) (Matcher, Disposer, error) {
 	n, ok := registry[name]
 	if !ok {
 		return nil, nil, ErrRegexNotFound.New(name)
 	}
 
-	return n(re)
+	return n(re, flags)
 }
 
 // Default returns the default regex engine.
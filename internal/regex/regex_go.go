@@ -18,12 +18,36 @@ func (r *Go) Match(s string) bool {
 	return r.reg.MatchString(s)
 }
 
+// FindAllSubmatchIndex implements Matcher interface.
+func (r *Go) FindAllSubmatchIndex(s string, n int) [][]int {
+	t := time.Now()
+	defer func() {
+		MatchHistogram.With("string", s, "duration", "seconds").Observe(time.Since(t).Seconds())
+	}()
+
+	return r.reg.FindAllStringSubmatchIndex(s, n)
+}
+
 // Dispose implements Disposer interface.
 func (*Go) Dispose() {}
 
 // NewGo creates a new Matcher using go regex engine.
-func NewGo(re string) (Matcher, Disposer, error) {
+func NewGo(re string, flags Flags) (Matcher, Disposer, error) {
 	t := time.Now()
+	var options string
+	if flags.CaseInsensitive {
+		options += "i"
+	}
+	if flags.MultiLine {
+		options += "m"
+	}
+	if flags.DotAll {
+		options += "s"
+	}
+	if options != "" {
+		re = "(?" + options + ")" + re
+	}
+
 	reg, err := regexp.Compile(re)
 	if err != nil {
 		return nil, nil, err
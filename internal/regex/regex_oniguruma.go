@@ -21,6 +21,16 @@ func (r *Oniguruma) Match(s string) bool {
 	return r.reg.MatchString(s)
 }
 
+// FindAllSubmatchIndex implements Matcher interface.
+func (r *Oniguruma) FindAllSubmatchIndex(s string, n int) [][]int {
+	t := time.Now()
+	defer func() {
+		MatchHistogram.With("string", s, "duration", "seconds").Observe(time.Since(t).Seconds())
+	}()
+
+	return r.reg.FindAllStringSubmatchIndex(s, n)
+}
+
 // Dispose implements Disposer interface.
 // The function releases resources for oniguruma's precompiled regex
 func (r *Oniguruma) Dispose() {
@@ -28,9 +38,23 @@ func (r *Oniguruma) Dispose() {
 }
 
 // NewOniguruma creates a new Matcher using oniguruma engine.
-func NewOniguruma(re string) (Matcher, Disposer, error) {
+func NewOniguruma(re string, flags Flags) (Matcher, Disposer, error) {
 	t := time.Now()
-	reg, err := rubex.Compile(re)
+	// With the default Ruby syntax, ^ and $ match at the start and end of
+	// each line unless the single line option is given, and the multiline
+	// option makes . match line terminators.
+	option := rubex.ONIG_OPTION_DEFAULT
+	if flags.CaseInsensitive {
+		option |= rubex.ONIG_OPTION_IGNORECASE
+	}
+	if !flags.MultiLine {
+		option |= rubex.ONIG_OPTION_SINGLELINE
+	}
+	if flags.DotAll {
+		option |= rubex.ONIG_OPTION_MULTILINE
+	}
+
+	reg, err := rubex.NewRegexp(re, option)
 	if err != nil {
 		return nil, nil, err
 	}
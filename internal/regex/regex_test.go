@@ -6,7 +6,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func dummy(s string) (Matcher, Disposer, error) { return nil, nil, nil }
+func dummy(s string, flags Flags) (Matcher, Disposer, error) { return nil, nil, nil }
 
 func getDefault() string {
 	for _, n := range Engines() {
@@ -106,3 +106,52 @@ func TestMatcherMultiPatterns(t *testing.T) {
 		})
 	}
 }
+
+func TestMatcherFlags(t *testing.T) {
+	for _, name := range Engines() {
+		if name == "nil" {
+			continue
+		}
+
+		t.Run(name, func(t *testing.T) {
+			testCases := []struct {
+				re       string
+				flags    Flags
+				text     string
+				expected bool
+			}{
+				{"abc", Flags{}, "ABC", false},
+				{"abc", Flags{CaseInsensitive: true}, "ABC", true},
+				{"^b$", Flags{}, "a\nb\nc", false},
+				{"^b$", Flags{MultiLine: true}, "a\nb\nc", true},
+				{"a.b", Flags{}, "a\nb", false},
+				{"a.b", Flags{DotAll: true}, "a\nb", true},
+			}
+
+			for _, tt := range testCases {
+				m, d, err := NewWithFlags(name, tt.re, tt.flags)
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, m.Match(tt.text), "%s %+v %q", tt.re, tt.flags, tt.text)
+				d.Dispose()
+			}
+		})
+	}
+}
+
+func TestMatcherFindAllSubmatchIndex(t *testing.T) {
+	for _, name := range Engines() {
+		if name == "nil" {
+			continue
+		}
+
+		t.Run(name, func(t *testing.T) {
+			m, d, err := New(name, "a(b)?")
+			require.NoError(t, err)
+			defer d.Dispose()
+
+			require.Equal(t, [][]int{{0, 2, 1, 2}, {3, 4, -1, -1}}, m.FindAllSubmatchIndex("abcaab", 2))
+			require.Equal(t, [][]int{{0, 2, 1, 2}, {3, 4, -1, -1}}, m.FindAllSubmatchIndex("abca", -1))
+			require.Nil(t, m.FindAllSubmatchIndex("xyz", -1))
+		})
+	}
+}
@@ -268,6 +268,9 @@ func GetConnInode(c *net.TCPConn) (n uint64, err error) {
 	if err != nil {
 		return
 	}
+	// File returns a copy of the socket, which would keep it open after the
+	// connection is closed.
+	defer f.Close()
 
 	socketStr := fmt.Sprintf("/proc/%d/fd/%d", os.Getpid(), f.Fd())
 	socketLnk, err := os.Readlink(socketStr)
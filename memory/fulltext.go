@@ -0,0 +1,434 @@
+package memory
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// fullTextMinTokenSize is the length of the shortest word that is indexed, as innodb_ft_min_token_size.
+const fullTextMinTokenSize = 3
+
+// fullTextStopwords are the words that are never indexed, as the default InnoDB stopword list.
+var fullTextStopwords = map[string]bool{
+	"a": true, "about": true, "an": true, "are": true, "as": true, "at": true, "be": true, "by": true,
+	"com": true, "de": true, "en": true, "for": true, "from": true, "how": true, "i": true, "in": true,
+	"is": true, "it": true, "la": true, "of": true, "on": true, "or": true, "that": true, "the": true,
+	"this": true, "to": true, "was": true, "what": true, "when": true, "where": true, "who": true,
+	"will": true, "with": true, "und": true, "www": true,
+}
+
+// FullTextIndex is a FULLTEXT index on one or more text columns of a table. Like the rest of the indexes in this
+// package it doesn't store anything: every search tokenizes the rows of the table into an inverted index that lives as
+// long as the search does.
+type FullTextIndex struct {
+	Tbl        *Table
+	TableName  string
+	Exprs      []sql.Expression
+	Name       string
+	CommentStr string
+}
+
+var _ sql.FullTextIndex = (*FullTextIndex)(nil)
+
+func (i *FullTextIndex) Database() string { return "" }
+func (i *FullTextIndex) Table() string    { return i.TableName }
+func (i *FullTextIndex) ID() string       { return i.Name }
+func (i *FullTextIndex) IsUnique() bool   { return false }
+func (i *FullTextIndex) Comment() string  { return i.CommentStr }
+func (i *FullTextIndex) IndexType() string {
+	return "FULLTEXT"
+}
+
+func (i *FullTextIndex) Expressions() []string {
+	var exprs []string
+	for _, e := range i.Exprs {
+		exprs = append(exprs, e.String())
+	}
+	return exprs
+}
+
+func (i *FullTextIndex) Get(key ...interface{}) (sql.IndexLookup, error) {
+	return nil, fmt.Errorf("FULLTEXT index %s can only be used with MATCH", i.Name)
+}
+
+func (i *FullTextIndex) Has(sql.Partition, ...interface{}) (bool, error) {
+	panic("not implemented")
+}
+
+// Search implements sql.FullTextIndex.
+func (i *FullTextIndex) Search(ctx *sql.Context, query string, mode sql.FullTextSearchMode) (sql.FullTextLookup, error) {
+	var terms []fullTextTerm
+	if mode == sql.FullTextSearchMode_Boolean {
+		terms = parseBooleanQuery(query)
+	} else {
+		for _, t := range tokenize(query) {
+			terms = append(terms, fullTextTerm{words: []string{t.word}, offsets: []int{0}})
+		}
+	}
+
+	return &FullTextLookup{
+		ctx:   ctx,
+		idx:   i,
+		query: query,
+		mode:  mode,
+		terms: terms,
+	}, nil
+}
+
+// FullTextLookup is the result of a search on a FullTextIndex. The rows of the table are only indexed the first time
+// they are needed.
+type FullTextLookup struct {
+	ctx   *sql.Context
+	idx   *FullTextIndex
+	query string
+	mode  sql.FullTextSearchMode
+	terms []fullTextTerm
+
+	once sync.Once
+	err  error
+	// docs are the positions of the documents in each partition
+	docs map[string][]int
+	// freqs are the frequencies of each term by document
+	freqs []map[int]int
+	idf   []float64
+}
+
+var _ sql.FullTextLookup = (*FullTextLookup)(nil)
+var _ sql.DriverIndexLookup = (*FullTextLookup)(nil)
+
+func (l *FullTextLookup) String() string {
+	return fmt.Sprintf("MATCH (%s) AGAINST ('%s' %s)", strings.Join(l.idx.Expressions(), ", "), l.query, l.mode)
+}
+
+func (l *FullTextLookup) Indexes() []string {
+	return []string{l.idx.ID()}
+}
+
+// Values implements sql.DriverIndexLookup. Rows are returned in descending order of relevance.
+func (l *FullTextLookup) Values(p sql.Partition) (sql.IndexValueIter, error) {
+	if err := l.init(); err != nil {
+		return nil, err
+	}
+
+	type scoredRow struct {
+		pos   int
+		score float64
+	}
+
+	var rows []scoredRow
+	for pos, doc := range l.docs[string(p.Key())] {
+		tf := make([]int, len(l.terms))
+		for i := range l.terms {
+			tf[i] = l.freqs[i][doc]
+		}
+
+		if score := l.score(tf); score > 0 {
+			rows = append(rows, scoredRow{pos, score})
+		}
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].score > rows[j].score
+	})
+
+	values := make([][]byte, len(rows))
+	for i, r := range rows {
+		encoded, err := encodeIndexValue(&indexValue{Pos: r.pos})
+		if err != nil {
+			return nil, err
+		}
+		values[i] = encoded
+	}
+
+	return &fullTextValueIter{values: values}, nil
+}
+
+// Relevance implements sql.FullTextLookup.
+func (l *FullTextLookup) Relevance(values ...interface{}) (float64, error) {
+	if err := l.init(); err != nil {
+		return 0, err
+	}
+
+	doc := newInvertedIndex()
+	if err := doc.add(0, values...); err != nil {
+		return 0, err
+	}
+
+	tf := make([]int, len(l.terms))
+	for i, t := range l.terms {
+		tf[i] = doc.frequencies(t)[0]
+	}
+
+	return l.score(tf), nil
+}
+
+// score returns the relevance of a document given the frequency of each term in it, or 0 if it doesn't match the
+// search. As in InnoDB, the relevance is the sum of TF * IDF * IDF of every term.
+func (l *FullTextLookup) score(tf []int) float64 {
+	var rank float64
+	for i, t := range l.terms {
+		switch t.op {
+		case '+':
+			if tf[i] == 0 {
+				return 0
+			}
+		case '-':
+			if tf[i] > 0 {
+				return 0
+			}
+			continue
+		}
+
+		rank += float64(tf[i]) * l.idf[i] * l.idf[i]
+	}
+
+	return rank
+}
+
+func (l *FullTextLookup) init() error {
+	l.once.Do(func() {
+		index := newInvertedIndex()
+		l.docs = make(map[string][]int)
+
+		var n int
+		for key, rows := range l.idx.Tbl.partitions {
+			docs := make([]int, len(rows))
+			for i, row := range rows {
+				values := make([]interface{}, len(l.idx.Exprs))
+				for j, e := range l.idx.Exprs {
+					values[j], l.err = e.Eval(l.ctx, row)
+					if l.err != nil {
+						return
+					}
+				}
+
+				if l.err = index.add(n, values...); l.err != nil {
+					return
+				}
+				docs[i] = n
+				n++
+			}
+			l.docs[key] = docs
+		}
+
+		l.freqs = make([]map[int]int, len(l.terms))
+		l.idf = make([]float64, len(l.terms))
+		for i, t := range l.terms {
+			l.freqs[i] = index.frequencies(t)
+			switch df := len(l.freqs[i]); {
+			case df == 0:
+			case df == n:
+				// A word in every row would otherwise have no relevance at all.
+				l.idf[i] = math.Log10(1.0001)
+			default:
+				l.idf[i] = math.Log10(float64(n) / float64(df))
+			}
+		}
+	})
+
+	return l.err
+}
+
+type fullTextValueIter struct {
+	values [][]byte
+	i      int
+}
+
+func (i *fullTextValueIter) Next() ([]byte, error) {
+	if i.i >= len(i.values) {
+		return nil, io.EOF
+	}
+
+	i.i++
+	return i.values[i.i-1], nil
+}
+
+func (i *fullTextValueIter) Close() error {
+	return nil
+}
+
+// fullTextTerm is a word or phrase to search for. Offsets are the positions of each word relative to the first one.
+type fullTextTerm struct {
+	words   []string
+	offsets []int
+	// prefix is whether the (single) word matches every word starting with it
+	prefix bool
+	// op is '+' if the term is required, '-' if it's excluded, or 0 otherwise
+	op rune
+}
+
+// parseBooleanQuery returns the terms of a search in boolean mode. The operators that change the weight of a term (~,
+// < and >) or group terms with parentheses aren't supported, and are ignored.
+func parseBooleanQuery(query string) []fullTextTerm {
+	var terms []fullTextTerm
+	runes := []rune(strings.ToLower(query))
+
+	var op rune
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == '+' || r == '-':
+			op = r
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+
+			if tokens := tokenize(string(runes[i+1 : end])); len(tokens) > 0 {
+				t := fullTextTerm{op: op}
+				for _, token := range tokens {
+					t.words = append(t.words, token.word)
+					t.offsets = append(t.offsets, token.pos-tokens[0].pos)
+				}
+				terms = append(terms, t)
+			}
+
+			op = 0
+			i = end + 1
+		case isWordRune(r):
+			end := i
+			for end < len(runes) && isWordRune(runes[end]) {
+				end++
+			}
+
+			word := string(runes[i:end])
+			prefix := end < len(runes) && runes[end] == '*'
+			if prefix {
+				end++
+			}
+
+			if prefix || isIndexedWord(word) {
+				terms = append(terms, fullTextTerm{words: []string{word}, offsets: []int{0}, prefix: prefix, op: op})
+			}
+
+			op = 0
+			i = end
+		default:
+			if unicode.IsSpace(r) || r == '(' || r == ')' {
+				op = 0
+			}
+			i++
+		}
+	}
+
+	return terms
+}
+
+type fullTextToken struct {
+	word string
+	// pos is the position of the word in the text, counting the words that aren't indexed
+	pos int
+}
+
+// tokenize splits the text given into lowercase words, and returns the ones that are indexed.
+func tokenize(text string) []fullTextToken {
+	var tokens []fullTextToken
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !isWordRune(r)
+	})
+
+	for i, w := range words {
+		if isIndexedWord(w) {
+			tokens = append(tokens, fullTextToken{w, i})
+		}
+	}
+
+	return tokens
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func isIndexedWord(w string) bool {
+	return utf8.RuneCountInString(w) >= fullTextMinTokenSize && !fullTextStopwords[w]
+}
+
+// invertedIndex holds the positions of every word in each of the documents indexed.
+type invertedIndex struct {
+	postings map[string]map[int][]int
+}
+
+func newInvertedIndex() *invertedIndex {
+	return &invertedIndex{postings: make(map[string]map[int][]int)}
+}
+
+// add indexes the values of a document. Words in different values are never adjacent, so phrases can't span them.
+func (ii *invertedIndex) add(doc int, values ...interface{}) error {
+	var offset int
+	for _, v := range values {
+		if v == nil {
+			continue
+		}
+
+		v, err := sql.LongText.Convert(v)
+		if err != nil {
+			return err
+		}
+
+		tokens := tokenize(v.(string))
+		for _, t := range tokens {
+			postings, ok := ii.postings[t.word]
+			if !ok {
+				postings = make(map[int][]int)
+				ii.postings[t.word] = postings
+			}
+			postings[doc] = append(postings[doc], offset+t.pos)
+		}
+
+		if len(tokens) > 0 {
+			offset += tokens[len(tokens)-1].pos + 2
+		}
+	}
+
+	return nil
+}
+
+// frequencies returns the number of times the term given appears in each document that contains it.
+func (ii *invertedIndex) frequencies(t fullTextTerm) map[int]int {
+	freqs := make(map[int]int)
+	if t.prefix {
+		for word, postings := range ii.postings {
+			if strings.HasPrefix(word, t.words[0]) {
+				for doc, positions := range postings {
+					freqs[doc] += len(positions)
+				}
+			}
+		}
+		return freqs
+	}
+
+	for doc, positions := range ii.postings[t.words[0]] {
+		for _, pos := range positions {
+			if ii.hasPhrase(doc, t, pos) {
+				freqs[doc]++
+			}
+		}
+	}
+
+	return freqs
+}
+
+// hasPhrase returns whether the rest of the words of the term follow the first one, found at the position given.
+func (ii *invertedIndex) hasPhrase(doc int, t fullTextTerm, start int) bool {
+	for i := 1; i < len(t.words); i++ {
+		positions := ii.postings[t.words[i]][doc]
+		want := start + t.offsets[i]
+		j := sort.SearchInts(positions, want)
+		if j == len(positions) || positions[j] != want {
+			return false
+		}
+	}
+
+	return true
+}
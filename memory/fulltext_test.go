@@ -0,0 +1,142 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+var articlesSchema = sql.Schema{
+	{Name: "id", Type: sql.Int64, Source: "articles"},
+	{Name: "title", Type: sql.Text, Source: "articles", Nullable: true},
+	{Name: "body", Type: sql.Text, Source: "articles", Nullable: true},
+}
+
+var articles = []sql.Row{
+	sql.NewRow(int64(1), "MySQL Tutorial", "DBMS stands for DataBase ..."),
+	sql.NewRow(int64(2), "How To Use MySQL Well", "After you went through a ..."),
+	sql.NewRow(int64(3), "Optimizing MySQL", "In this tutorial, we show ..."),
+	sql.NewRow(int64(4), "1001 MySQL Tricks", "1. Never run mysqld as root. 2. ..."),
+	sql.NewRow(int64(5), "MySQL vs. YourSQL", "In the following database comparison ..."),
+	sql.NewRow(int64(6), "MySQL Security", "When configured properly, MySQL ..."),
+	sql.NewRow(int64(7), nil, nil),
+}
+
+func newArticlesIndex(t *testing.T, numPartitions int) (*Table, sql.FullTextIndex) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	table := NewPartitionedTable("articles", articlesSchema, numPartitions)
+	for _, row := range articles {
+		require.NoError(table.Insert(ctx, row))
+	}
+
+	columns := []sql.IndexColumn{{Name: "title"}, {Name: "body"}}
+	require.NoError(table.CreateIndex(ctx, "ft", sql.IndexUsing_Default, sql.IndexConstraint_Fulltext, columns, ""))
+
+	indexes, err := table.GetIndexes(ctx)
+	require.NoError(err)
+	require.Len(indexes, 1)
+	require.Equal("FULLTEXT", indexes[0].IndexType())
+
+	return table, indexes[0].(sql.FullTextIndex)
+}
+
+func TestFullTextSearch(t *testing.T) {
+	testCases := []struct {
+		query    string
+		mode     sql.FullTextSearchMode
+		expected []int64
+	}{
+		{"database", sql.FullTextSearchMode_NaturalLanguage, []int64{1, 5}},
+		{"tutorial DATABASE", sql.FullTextSearchMode_NaturalLanguage, []int64{1, 3, 5}},
+		{"the of a", sql.FullTextSearchMode_NaturalLanguage, nil},
+		{"mysql", sql.FullTextSearchMode_NaturalLanguage, []int64{6, 1, 2, 3, 4, 5}},
+		{"+mysql -yoursql", sql.FullTextSearchMode_Boolean, []int64{6, 1, 2, 3, 4}},
+		{"+mysql +tutorial", sql.FullTextSearchMode_Boolean, []int64{1, 3}},
+		{"tutor*", sql.FullTextSearchMode_Boolean, []int64{1, 3}},
+		{`"database comparison"`, sql.FullTextSearchMode_Boolean, []int64{5}},
+		{`"comparison database"`, sql.FullTextSearchMode_Boolean, nil},
+		{`"stands database"`, sql.FullTextSearchMode_Boolean, nil},
+		{"-mysql", sql.FullTextSearchMode_Boolean, nil},
+		{"~security (root)", sql.FullTextSearchMode_Boolean, []int64{4, 6}},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.query, func(t *testing.T) {
+			require := require.New(t)
+			ctx := sql.NewEmptyContext()
+			table, idx := newArticlesIndex(t, 1)
+
+			lookup, err := idx.Search(ctx, tt.query, tt.mode)
+			require.NoError(err)
+
+			var ids []int64
+			for _, row := range testFlatRows(t, table.WithIndexLookup(lookup)) {
+				ids = append(ids, row[0].(int64))
+			}
+
+			if len(tt.expected) > 1 && tt.expected[0] != 1 {
+				require.Equal(tt.expected, ids)
+			} else {
+				require.ElementsMatch(tt.expected, ids)
+			}
+
+			for _, row := range articles {
+				relevance, err := lookup.Relevance(row[1], row[2])
+				require.NoError(err)
+				require.Equal(contains(tt.expected, row[0].(int64)), relevance > 0, "relevance of %d", row[0])
+			}
+		})
+	}
+}
+
+func TestFullTextSearchPartitioned(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+	table, idx := newArticlesIndex(t, 3)
+
+	lookup, err := idx.Search(ctx, "database tutorial", sql.FullTextSearchMode_NaturalLanguage)
+	require.NoError(err)
+
+	var ids []int64
+	for _, row := range testFlatRows(t, table.WithIndexLookup(lookup)) {
+		ids = append(ids, row[0].(int64))
+	}
+	require.ElementsMatch([]int64{1, 3, 5}, ids)
+
+	// Relevance is the same wherever the row is stored: IDF * IDF for each occurrence of a term.
+	r1, err := lookup.Relevance("MySQL Tutorial", "DBMS stands for DataBase ...")
+	require.NoError(err)
+	r3, err := lookup.Relevance("Optimizing MySQL", "In this tutorial, we show ...")
+	require.NoError(err)
+	require.InDelta(0.29601003688313354, r3, 1e-12)
+	require.InDelta(2*r3, r1, 1e-12)
+}
+
+func TestFullTextIndexBadColumn(t *testing.T) {
+	require := require.New(t)
+	table := NewTable("articles", articlesSchema)
+	err := table.CreateIndex(sql.NewEmptyContext(), "ft", sql.IndexUsing_Default, sql.IndexConstraint_Fulltext, []sql.IndexColumn{{Name: "id"}}, "")
+	require.True(sql.ErrBadFullTextColumn.Is(err))
+}
+
+func TestTokenize(t *testing.T) {
+	require := require.New(t)
+	require.Equal(
+		[]fullTextToken{{"quick", 1}, {"brown", 2}, {"fox_1", 3}, {"über", 4}, {"lazy", 8}, {"dog", 9}},
+		tokenize("The quick brown fox_1 über-it a la LAZY dog."),
+	)
+	require.Nil(tokenize("it is a"))
+}
+
+func contains(ids []int64, id int64) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
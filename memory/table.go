@@ -5,6 +5,7 @@ import (
 	"encoding/gob"
 	"fmt"
 	"io"
+	"reflect"
 	"sort"
 	"strconv"
 	"strings"
@@ -473,19 +474,14 @@ func (t *tableEditor) Delete(ctx *sql.Context, row sql.Row) error {
 			// have the row to be replaced, so we need to consider primary key information.
 			pkColIdxes := t.pkColumnIndexes()
 			if len(pkColIdxes) > 0 {
-				if columnsMatch(pkColIdxes, partitionRow, row) {
+				if columnsMatch(t.table.schema, pkColIdxes, partitionRow, row) {
 					t.table.partitions[partitionIndex] = append(partition[:partitionRowIndex], partition[partitionRowIndex+1:]...)
 					break
 				}
 			}
 
 			// If we had no primary key match (or have no primary key), check each row for a total match
-			for rIndex, val := range row {
-				if val != partitionRow[rIndex] {
-					matches = false
-					break
-				}
-			}
+			matches = rowsAreEqual(row, partitionRow)
 
 			if matches {
 				t.table.partitions[partitionIndex] = append(partition[:partitionRowIndex], partition[partitionRowIndex+1:]...)
@@ -521,13 +517,7 @@ func (t *tableEditor) Update(ctx *sql.Context, oldRow sql.Row, newRow sql.Row) e
 	matches := false
 	for partitionIndex, partition := range t.table.partitions {
 		for partitionRowIndex, partitionRow := range partition {
-			matches = true
-			for rIndex, val := range oldRow {
-				if val != partitionRow[rIndex] {
-					matches = false
-					break
-				}
-			}
+			matches = rowsAreEqual(oldRow, partitionRow)
 			if matches {
 				t.table.partitions[partitionIndex][partitionRowIndex] = newRow
 				break
@@ -553,7 +543,7 @@ func (t *tableEditor) checkUniquenessConstraints(row sql.Row) error {
 	if len(pkColIdxes) > 0 {
 		for _, partition := range t.table.partitions {
 			for _, partitionRow := range partition {
-				if columnsMatch(pkColIdxes, partitionRow, row) {
+				if columnsMatch(t.table.schema, pkColIdxes, partitionRow, row) {
 					return sql.ErrUniqueKeyViolation.New(pkColIdxes)
 				}
 			}
@@ -576,13 +566,32 @@ func (t *tableEditor) pkColumnIndexes() []int {
 
 func (t *tableEditor) pkColsDiffer(row, row2 sql.Row) bool {
 	pkColIdxes := t.pkColumnIndexes()
-	return !columnsMatch(pkColIdxes, row, row2)
+	return !columnsMatch(t.table.schema, pkColIdxes, row, row2)
 }
 
-// Returns whether the values for the columns given match in the two rows provided
-func columnsMatch(colIndexes []int, row sql.Row, row2 sql.Row) bool {
+// rowsAreEqual returns whether the rows have the same values. Values such as
+// JSON documents may not be comparable with ==, so they are compared deeply.
+func rowsAreEqual(row sql.Row, row2 sql.Row) bool {
+	for i, val := range row {
+		if !reflect.DeepEqual(val, row2[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns whether the values for the columns given match in the two rows provided. Values are compared with the
+// column types, so strings that are equal in the collation of their column match.
+func columnsMatch(schema sql.Schema, colIndexes []int, row sql.Row, row2 sql.Row) bool {
 	for _, i := range colIndexes {
-		if row[i] != row2[i] {
+		if row[i] == nil || row2[i] == nil {
+			if row[i] != row2[i] {
+				return false
+			}
+			continue
+		}
+		cmp, err := schema[i].Type.Compare(row[i], row2[i])
+		if err != nil || cmp != 0 {
 			return false
 		}
 	}
@@ -597,6 +606,9 @@ func (t *Table) GetAutoIncrementValue(*sql.Context) (interface{}, error) {
 
 func (t *Table) AddColumn(ctx *sql.Context, column *sql.Column, order *sql.ColumnOrder) error {
 	newColIdx := t.addColumnToSchema(ctx, column, order)
+	if column.Generated != nil {
+		return t.insertValueInRows(ctx, newColIdx, column.Generated)
+	}
 	return t.insertValueInRows(ctx, newColIdx, column.Default)
 }
 
@@ -626,13 +638,16 @@ func (t *Table) addColumnToSchema(ctx *sql.Context, newCol *sql.Column, order *s
 		if i == newColIdx {
 			continue
 		}
-		newDefault, _ := expression.TransformUp(newSchCol.Default, func(expr sql.Expression) (sql.Expression, error) {
+		reindex := func(expr sql.Expression) (sql.Expression, error) {
 			if expr, ok := expr.(*expression.GetField); ok {
 				return expr.WithIndex(newSch.IndexOf(expr.Name(), t.name)), nil
 			}
 			return expr, nil
-		})
+		}
+		newDefault, _ := expression.TransformUp(newSchCol.Default, reindex)
 		newSchCol.Default = newDefault.(*sql.ColumnDefaultValue)
+		newGenerated, _ := expression.TransformUp(newSchCol.Generated, reindex)
+		newSchCol.Generated = newGenerated.(*sql.ColumnDefaultValue)
 	}
 
 	t.schema = newSch
@@ -1051,6 +1066,22 @@ func (t *Table) createIndex(name string, columns []sql.IndexColumn, constraint s
 		exprs[i] = expression.NewGetFieldWithTable(idx, field.Type, t.name, field.Name, field.Nullable)
 	}
 
+	if constraint == sql.IndexConstraint_Fulltext {
+		for i, column := range columns {
+			if !sql.IsTextOnly(exprs[i].Type()) {
+				return nil, sql.ErrBadFullTextColumn.New(column.Name)
+			}
+		}
+
+		return &FullTextIndex{
+			Tbl:        t,
+			TableName:  t.name,
+			Exprs:      exprs,
+			Name:       name,
+			CommentStr: comment,
+		}, nil
+	}
+
 	return &UnmergeableIndex{
 		MergeableIndex{
 			DB:         "",
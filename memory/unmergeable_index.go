@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
@@ -136,6 +137,8 @@ func getType(val interface{}) (interface{}, sql.Type) {
 		return float64(val), sql.Float64
 	case string:
 		return val, sql.LongText
+	case time.Time:
+		return val, sql.Datetime
 	case nil:
 		return nil, sql.Null
 	default:
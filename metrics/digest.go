@@ -0,0 +1,132 @@
+package metrics
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+)
+
+// Digest normalizes the given query and returns a hash of the normalized
+// text along with the text itself. Literals are replaced by "?", lists of
+// literals are collapsed into "...", comments are removed, keywords are
+// upper-cased, identifiers are quoted and tokens are separated by a single
+// space, so queries that only differ in their values or their formatting
+// share the same digest. The digest can be used to label metrics without the
+// unbounded cardinality of the raw query text.
+func Digest(query string) (digest, text string) {
+	text = DigestText(query)
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:]), text
+}
+
+// DigestText returns the normalized text of the given query, as used by
+// Digest.
+func DigestText(query string) string {
+	var tokens []string
+	tkn := sqlparser.NewStringTokenizer(query)
+	for {
+		typ, val := tkn.Scan()
+		if typ == 0 {
+			break
+		}
+
+		var token string
+		switch typ {
+		case sqlparser.COMMENT:
+			continue
+		case sqlparser.LEX_ERROR:
+			// Most likely an unterminated literal, which must not be kept.
+			token = "?"
+		case sqlparser.STRING, sqlparser.INTEGRAL, sqlparser.FLOAT, sqlparser.HEX,
+			sqlparser.HEXNUM, sqlparser.BIT_LITERAL, sqlparser.VALUE_ARG, sqlparser.LIST_ARG:
+			// Collapse lists of values, such as those in IN or VALUES, into a
+			// single item.
+			if n := len(tokens); n >= 2 && tokens[n-1] == "," && (tokens[n-2] == "?" || tokens[n-2] == "...") {
+				tokens = append(tokens[:n-2], "...")
+				continue
+			}
+			token = "?"
+		case sqlparser.ID:
+			token = "`" + strings.Replace(string(val), "`", "``", -1) + "`"
+		case sqlparser.LE:
+			token = "<="
+		case sqlparser.GE:
+			token = ">="
+		case sqlparser.NE:
+			token = "!="
+		case sqlparser.NULL_SAFE_EQUAL:
+			token = "<=>"
+		case sqlparser.SHIFT_LEFT:
+			token = "<<"
+		case sqlparser.SHIFT_RIGHT:
+			token = ">>"
+		case sqlparser.JSON_EXTRACT_OP:
+			token = "->"
+		case sqlparser.JSON_UNQUOTE_EXTRACT_OP:
+			token = "->>"
+		default:
+			switch {
+			case len(val) > 0:
+				token = strings.ToUpper(string(val))
+			case typ == sqlparser.AND:
+				token = "AND"
+			case typ == sqlparser.OR:
+				token = "OR"
+			case typ < 256:
+				token = string(rune(typ))
+			default:
+				token = sqlparser.KeywordString(typ)
+			}
+		}
+
+		tokens = append(tokens, token)
+		if token == ")" {
+			tokens = collapseGroup(tokens)
+		}
+	}
+
+	return strings.Join(tokens, " ")
+}
+
+// collapseGroup removes the parenthesized group at the end of the given
+// tokens if it repeats the group before it, such as the rows of a
+// multi-row VALUES.
+func collapseGroup(tokens []string) []string {
+	start := groupStart(tokens, len(tokens)-1)
+	if start < 2 || tokens[start-1] != "," || tokens[start-2] != ")" {
+		return tokens
+	}
+
+	prevStart := groupStart(tokens, start-2)
+	if prevStart < 0 || start-1-prevStart != len(tokens)-start {
+		return tokens
+	}
+
+	for i := range tokens[start:] {
+		if tokens[start+i] != tokens[prevStart+i] {
+			return tokens
+		}
+	}
+
+	return tokens[:start-1]
+}
+
+// groupStart returns the position of the parenthesis opening the one at the
+// given position, or -1 if there is none.
+func groupStart(tokens []string, end int) int {
+	depth := 0
+	for i := end; i >= 0; i-- {
+		switch tokens[i] {
+		case ")":
+			depth++
+		case "(":
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
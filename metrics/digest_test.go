@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigest(t *testing.T) {
+	testCases := []struct {
+		query string
+		text  string
+	}{
+		{
+			"select * from t where a = 1 and b in (1, 2, 3)",
+			"SELECT * FROM `t` WHERE `a` = ? AND `b` IN ( ... )",
+		},
+		{
+			"SELECT  *\n FROM `t` /* comment */ WHERE a='foo' AND b IN (4) -- other",
+			"SELECT * FROM `t` WHERE `a` = ? AND `b` IN ( ? )",
+		},
+		{
+			"insert into t(a, b) values (1, 'x'), (2, 'y'), (3, 'z')",
+			"INSERT INTO `t` ( `a` , `b` ) VALUES ( ... )",
+		},
+		{
+			"select a from t where a <= 1.5 or b <> x'1f' && c >= b'1'",
+			"SELECT `a` FROM `t` WHERE `a` <= ? OR `b` != ? AND `c` >= ?",
+		},
+		{
+			"select 'unterminated",
+			"SELECT ?",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.query, func(t *testing.T) {
+			require := require.New(t)
+			digest, text := Digest(tt.query)
+			require.Equal(tt.text, text)
+			require.Len(digest, 64)
+		})
+	}
+
+	d1, _ := Digest("SELECT * FROM t WHERE a = 1")
+	d2, _ := Digest("select * from t where a = 2")
+	d3, _ := Digest("select * from t where b = 2")
+	require.Equal(t, d1, d2)
+	require.NotEqual(t, d1, d3)
+}
@@ -0,0 +1,93 @@
+// Package metrics provides counters, gauges and histograms backed by the
+// Prometheus client, and an HTTP handler exposing them in the Prometheus
+// text format.
+//
+// The metrics implement the go-kit metrics interfaces, so they can be used
+// wherever a go-kit metric is expected. Their label names are given when they
+// are created, and their label values are given to With as alternating name
+// and value pairs, as in go-kit.
+package metrics
+
+import (
+	"net/http"
+
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry is the registry in which the metrics of the engine and the server
+// are registered.
+var Registry = prometheus.NewRegistry()
+
+// Handler returns an HTTP handler serving the metrics of the registry in the
+// Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// NewCounter creates a counter with the given label names and registers it.
+func NewCounter(name, help string, labelNames ...string) *kitprometheus.Counter {
+	cv := prometheus.NewCounterVec(prometheus.CounterOpts{Name: name, Help: help}, labelNames)
+	Registry.MustRegister(cv)
+	return kitprometheus.NewCounter(cv)
+}
+
+// NewGauge creates a gauge with the given label names and registers it.
+func NewGauge(name, help string, labelNames ...string) *kitprometheus.Gauge {
+	gv := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: name, Help: help}, labelNames)
+	Registry.MustRegister(gv)
+	return kitprometheus.NewGauge(gv)
+}
+
+// NewHistogram creates a histogram with the given label names and the
+// default buckets of the Prometheus client, meant to measure durations in
+// seconds, and registers it.
+func NewHistogram(name, help string, labelNames ...string) *kitprometheus.Histogram {
+	hv := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: name, Help: help}, labelNames)
+	Registry.MustRegister(hv)
+	return kitprometheus.NewHistogram(hv)
+}
+
+// MemoryReporter reports the memory usage of an engine, as the memory
+// manager of its catalog does.
+type MemoryReporter interface {
+	UsedMemory() uint64
+	NumCaches() int
+}
+
+// NewMemoryCollector returns a collector of the memory in use and of the
+// number of caches held by the given memory manager. It isn't registered, so
+// that it's collected only while the engine it belongs to is in use.
+func NewMemoryCollector(m MemoryReporter) prometheus.Collector {
+	return memoryCollector{
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "gms_memory_used_bytes",
+			Help: "Memory in use, in bytes.",
+		}, func() float64 {
+			return float64(m.UsedMemory())
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "gms_memory_caches",
+			Help: "Number of caches held by the memory manager.",
+		}, func() float64 {
+			return float64(m.NumCaches())
+		}),
+	}
+}
+
+type memoryCollector []prometheus.Collector
+
+// Describe implements the prometheus.Collector interface.
+func (c memoryCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, collector := range c {
+		collector.Describe(ch)
+	}
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c memoryCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, collector := range c {
+		collector.Collect(ch)
+	}
+}
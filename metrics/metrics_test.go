@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type memoryReporter struct{}
+
+func (memoryReporter) UsedMemory() uint64 { return 1024 }
+func (memoryReporter) NumCaches() int     { return 2 }
+
+func TestHandler(t *testing.T) {
+	require := require.New(t)
+
+	counter := NewCounter("test_total", "A counter.", "a")
+	gauge := NewGauge("test_gauge", "A gauge.")
+	histogram := NewHistogram("test_seconds", "A histogram.", "op")
+
+	counter.With("a", `x"y`).Add(2)
+	counter.With("a", `x"y`).Add(1)
+	counter.With("a", "z").Add(1)
+	gauge.Set(3)
+	gauge.Add(-1.5)
+	histogram.With("op", "select").Observe(0.2)
+	histogram.With("op", "select").Observe(3)
+
+	collector := NewMemoryCollector(memoryReporter{})
+	Registry.MustRegister(collector)
+	defer Registry.Unregister(collector)
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	require.Equal(200, rec.Code)
+	require.Contains(rec.Header().Get("Content-Type"), "text/plain")
+
+	body := rec.Body.String()
+	for _, line := range []string{
+		"# TYPE test_total counter",
+		`test_total{a="x\"y"} 3`,
+		`test_total{a="z"} 1`,
+		"test_gauge 1.5",
+		`test_seconds_bucket{op="select",le="0.25"} 1`,
+		`test_seconds_bucket{op="select",le="+Inf"} 2`,
+		`test_seconds_sum{op="select"} 3.2`,
+		`test_seconds_count{op="select"} 2`,
+		"gms_memory_used_bytes 1024",
+		"gms_memory_caches 2",
+	} {
+		require.Contains(body, line+"\n")
+	}
+}
@@ -151,6 +151,30 @@ func (s *SessionManager) NewContextWithQuery(conn *mysql.Conn, query string) (*s
 	return context, nil
 }
 
+// ResetSession replaces the session of the given connection with a new one
+// created by the SessionBuilder, discarding its variables, warnings and any
+// other session state. The index and view registries of the connection are
+// replaced by the ones of the new session too, which drops the indexes and
+// views the connection created for itself. Only the current database is kept.
+func (s *SessionManager) ResetSession(ctx context.Context, conn *mysql.Conn) error {
+	sess, ir, vr, err := s.builder(ctx, conn, s.addr)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if old, ok := s.sessions[conn.ConnectionID]; ok {
+		sess.SetCurrentDatabase(old.GetCurrentDatabase())
+	}
+	s.sessions[conn.ConnectionID] = sess
+	s.idxRegs[conn.ConnectionID] = ir
+	s.viewRegs[conn.ConnectionID] = vr
+
+	return nil
+}
+
 // CloseConn closes the connection in the session manager and all its
 // associated contexts, which are cancelled.
 func (s *SessionManager) CloseConn(conn *mysql.Conn) {
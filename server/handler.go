@@ -14,15 +14,25 @@ import (
 	"github.com/dolthub/vitess/go/sqltypes"
 	"github.com/dolthub/vitess/go/vt/proto/query"
 	"github.com/dolthub/vitess/go/vt/sqlparser"
+	kitmetrics "github.com/go-kit/kit/metrics"
 	"github.com/sirupsen/logrus"
 	"gopkg.in/src-d/go-errors.v1"
 
 	sqle "github.com/dolthub/go-mysql-server"
 	"github.com/dolthub/go-mysql-server/auth"
 	"github.com/dolthub/go-mysql-server/internal/sockstate"
+	"github.com/dolthub/go-mysql-server/metrics"
 	"github.com/dolthub/go-mysql-server/sql"
 )
 
+var (
+	// ConnectionCounter describes a metric that accumulates the number of connections monotonically.
+	ConnectionCounter kitmetrics.Counter = metrics.NewCounter("gms_connections_total", "Number of connections established.")
+
+	// ConnectionsGauge describes the number of connections currently open.
+	ConnectionsGauge kitmetrics.Gauge = metrics.NewGauge("gms_connections", "Number of connections currently open.")
+)
+
 var regKillCmd = regexp.MustCompile(`^kill (?:(query|connection) )?(\d+)$`)
 
 var errConnectionNotFound = errors.NewKind("connection not found: %c")
@@ -33,21 +43,53 @@ var ErrRowTimeout = errors.NewKind("row read wait bigger than connection timeout
 // ErrConnectionWasClosed will be returned if we try to use a previously closed connection
 var ErrConnectionWasClosed = errors.NewKind("connection was closed")
 
+// ErrStatementSkipped is returned for the statements of a multi-statement query that follow a failed one, which are
+// not executed
+var ErrStatementSkipped = errors.NewKind("statement not executed because a previous statement in the query failed: %s")
+
+// ERQueryTimeout is the MySQL error code returned when a statement is
+// interrupted for exceeding its maximum execution time.
+const ERQueryTimeout = 3024
+
+// ssSyntaxErrorOrAccessViolation is the SQL state of the errors of queries
+// that are invalid.
+const ssSyntaxErrorOrAccessViolation = "42000"
+
 // TODO parametrize
 const rowsBatch = 100
 const tcpCheckerSleepTime = 1
 
+// defaultLongQueryTime is the duration after which a query is considered slow
+// when the session doesn't set long_query_time.
+const defaultLongQueryTime = 10 * time.Second
+
 type conntainer struct {
 	MysqlConn *mysql.Conn
 	NetConn   net.Conn
 }
 
+// failedStatement is the last statement of a connection that failed. Statements received with the same read count of
+// the connection come from the same multi-statement query.
+type failedStatement struct {
+	reads uint64
+	err   error
+}
+
+// reads returns the number of reads made on the network connection, and whether they can be known.
+func (c conntainer) reads() (uint64, bool) {
+	if tc, ok := c.NetConn.(*trackedConn); ok {
+		return tc.Reads(), true
+	}
+	return 0, false
+}
+
 // Handler is a connection handler for a SQLe engine.
 type Handler struct {
 	mu          sync.Mutex
 	e           *sqle.Engine
 	sm          *SessionManager
 	c           map[uint32]conntainer
+	failed      map[uint32]failedStatement
 	readTimeout time.Duration
 	lc          []*net.Conn
 }
@@ -58,6 +100,7 @@ func NewHandler(e *sqle.Engine, sm *SessionManager, rt time.Duration) *Handler {
 		e:           e,
 		sm:          sm,
 		c:           make(map[uint32]conntainer),
+		failed:      make(map[uint32]failedStatement),
 		readTimeout: rt,
 	}
 }
@@ -84,6 +127,10 @@ func (h *Handler) NewConnection(c *mysql.Conn) {
 				"connection checker won't run")
 		}
 		h.c[c.ConnectionID] = conntainer{c, netConn}
+		h.e.Catalog.IncrementStatus(0, sql.StatusConnections, 1)
+		h.e.Catalog.IncrementStatus(0, sql.StatusThreadsConnected, 1)
+		ConnectionCounter.Add(1)
+		ConnectionsGauge.Add(1)
 	}
 
 	h.mu.Unlock()
@@ -103,8 +150,28 @@ func (h *Handler) ComStmtExecute(c *mysql.Conn, prepare *mysql.PrepareData, call
 	panic("prepared statements are not implemented")
 }
 
+// ComResetConnection resets the session state of the connection, as requested by connection pools before handing it
+// to a new borrower. The named and table locks of the session are released, the session status counters are cleared and
+// the session is replaced by a new one, which only keeps the current database.
 func (h *Handler) ComResetConnection(c *mysql.Conn) {
-	// TODO: handle reset logic
+	logrus.Debugf("ComResetConnection: client %v", c.ConnectionID)
+
+	ctx, err := h.sm.NewContextWithQuery(c, "")
+	if err != nil {
+		logrus.Errorf("unable to reset connection %v: %s", c.ConnectionID, err)
+		return
+	}
+
+	h.releaseLocks(ctx, c)
+	h.e.Catalog.CloseSessionStatus(c.ConnectionID)
+
+	h.mu.Lock()
+	delete(h.failed, c.ConnectionID)
+	h.mu.Unlock()
+
+	if err := h.sm.ResetSession(ctx, c); err != nil {
+		logrus.Errorf("unable to reset connection %v: %s", c.ConnectionID, err)
+	}
 }
 
 // ConnectionClosed reports that a connection has been closed.
@@ -113,18 +180,37 @@ func (h *Handler) ConnectionClosed(c *mysql.Conn) {
 	h.sm.CloseConn(c)
 
 	h.mu.Lock()
+	if _, ok := h.c[c.ConnectionID]; ok {
+		h.e.Catalog.IncrementStatus(0, sql.StatusThreadsConnected, -1)
+		ConnectionsGauge.Add(-1)
+	}
 	delete(h.c, c.ConnectionID)
+	delete(h.failed, c.ConnectionID)
 	h.mu.Unlock()
 
+	h.e.Catalog.CloseSessionStatus(c.ConnectionID)
+
 	// If connection was closed, kill only its associated queries.
 	h.e.Catalog.ProcessList.KillOnlyQueries(c.ConnectionID)
-	if err := h.e.Catalog.UnlockTables(ctx, c.ConnectionID); err != nil {
-		logrus.Errorf("unable to unlock tables on session close: %s", err)
-	}
+	h.releaseLocks(ctx, c)
 
 	logrus.Infof("ConnectionClosed: client %v", c.ConnectionID)
 }
 
+// releaseLocks releases the named locks held by the session of the given context and the tables locked by the given
+// connection.
+func (h *Handler) releaseLocks(ctx *sql.Context, c *mysql.Conn) {
+	if ctx != nil && h.e.LS != nil {
+		if _, err := h.e.LS.ReleaseAll(ctx); err != nil {
+			logrus.Errorf("unable to release named locks for client %v: %s", c.ConnectionID, err)
+		}
+	}
+
+	if err := h.e.Catalog.UnlockTables(ctx, c.ConnectionID); err != nil {
+		logrus.Errorf("unable to unlock tables for client %v: %s", c.ConnectionID, err)
+	}
+}
+
 // ComQuery executes a SQL query on the SQLe engine.
 func (h *Handler) ComQuery(
 	c *mysql.Conn,
@@ -132,6 +218,12 @@ func (h *Handler) ComQuery(
 	callback func(*sqltypes.Result) error,
 ) (err error) {
 	logrus.Tracef("received query %s", query)
+	defer func() {
+		err = castSQLError(err)
+	}()
+
+	h.e.Catalog.IncrementStatus(c.ConnectionID, sql.StatusQuestions, 1)
+	h.e.Catalog.IncrementStatus(c.ConnectionID, sql.StatusBytesReceived, int64(len(query)))
 
 	ctx, err := h.sm.NewContextWithQuery(c, query)
 
@@ -146,6 +238,15 @@ func (h *Handler) ComQuery(
 		defer cancel()
 	}
 
+	if err := h.checkPreviousStatement(c); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			h.statementFailed(c, err)
+		}
+	}()
+
 	handled, err := h.handleKill(c, query)
 	if err != nil {
 		return err
@@ -165,8 +266,12 @@ func (h *Handler) ComQuery(
 
 	schema, rows, err := h.e.Query(ctx, query)
 	defer func() {
+		duration := time.Since(start)
 		if q, ok := h.e.Auth.(*auth.Audit); ok {
-			q.Query(ctx, time.Since(start), err)
+			q.Query(ctx, duration, err)
+		}
+		if duration > longQueryTime(ctx) {
+			h.e.Catalog.IncrementStatus(c.ConnectionID, sql.StatusSlowQueries, 1)
 		}
 	}()
 	if err != nil {
@@ -183,6 +288,7 @@ func (h *Handler) ComQuery(
 
 	var r *sqltypes.Result
 	var proccesedAtLeastOneBatch bool
+	charset := resultsCharacterSet(ctx)
 
 	// Reads rows from the row reading goroutine
 	rowChan := make(chan sql.Row)
@@ -225,7 +331,7 @@ func (h *Handler) ComQuery(
 rowLoop:
 	for {
 		if r == nil {
-			r = &sqltypes.Result{Fields: schemaToFields(schema)}
+			r = &sqltypes.Result{Fields: schemaToFields(schema, charset)}
 		}
 
 		if r.RowsAffected == rowsBatch {
@@ -259,13 +365,14 @@ rowLoop:
 				break rowLoop
 			}
 
-			outputRow, err := rowToSQL(schema, row)
+			outputRow, err := rowToSQL(schema, row, charset)
 			if err != nil {
 				close(quit)
 				return err
 			}
 
 			logrus.Tracef("returning result row %s", outputRow)
+			h.e.Catalog.IncrementStatus(c.ConnectionID, sql.StatusBytesSent, rowSize(outputRow))
 			r.Rows = append(r.Rows, outputRow)
 			r.RowsAffected++
 		case <-timer.C:
@@ -304,11 +411,46 @@ rowLoop:
 	return callback(r)
 }
 
+// checkPreviousStatement returns an error if the statement about to be executed is part of a multi-statement query in
+// which a previous statement failed. MySQL stops executing such a query at the first error.
+func (h *Handler) checkPreviousStatement(c *mysql.Conn) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	failed, ok := h.failed[c.ConnectionID]
+	if !ok {
+		return nil
+	}
+
+	if reads, ok := h.c[c.ConnectionID].reads(); ok && reads == failed.reads {
+		return ErrStatementSkipped.New(failed.err)
+	}
+
+	delete(h.failed, c.ConnectionID)
+	return nil
+}
+
+// statementFailed records that the last statement received on the given connection failed with the given error, so the
+// rest of the statements in the same query are not executed.
+func (h *Handler) statementFailed(c *mysql.Conn, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if reads, ok := h.c[c.ConnectionID].reads(); ok {
+		h.failed[c.ConnectionID] = failedStatement{reads, err}
+	}
+}
+
 // Periodically polls the connection socket to determine if it is has been closed by the client, sending an error on
 // the supplied error channel if it has. Meant to be run in a separate goroutine from the query handler routine.
 // Returns immediately on platforms that can't support TCP socket checks.
 func (h *Handler) pollForClosedConnection(nc conntainer, errChan chan error, quit chan struct{}, query string) {
-	tcpConn, ok := nc.NetConn.(*net.TCPConn)
+	netConn := nc.NetConn
+	if tc, ok := netConn.(*trackedConn); ok {
+		netConn = tc.Conn
+	}
+
+	tcpConn, ok := netConn.(*net.TCPConn)
 	if !ok {
 		logrus.Debug("Connection checker exiting, connection isn't TCP")
 		return
@@ -352,6 +494,32 @@ func (h *Handler) pollForClosedConnection(nc conntainer, errChan chan error, qui
 	}
 }
 
+// longQueryTime returns the duration after which a query of the session is
+// considered slow, as set in the long_query_time session variable.
+func longQueryTime(ctx *sql.Context) time.Duration {
+	_, val := ctx.Get("long_query_time")
+	if val == nil {
+		return defaultLongQueryTime
+	}
+
+	secs, err := sql.Float64.Convert(val)
+	if err != nil {
+		return defaultLongQueryTime
+	}
+
+	return time.Duration(secs.(float64) * float64(time.Second))
+}
+
+// rowSize returns the number of bytes of the values of the given row, as
+// counted by the Bytes_sent status variable.
+func rowSize(row []sqltypes.Value) int64 {
+	var size int64
+	for _, v := range row {
+		size += int64(len(v.Raw()))
+	}
+	return size
+}
+
 func isSessionAutocommit(ctx *sql.Context) bool {
 	typ, autoCommitSessionVar := ctx.Get(sql.AutoCommitSessionVar)
 	autoCommit := false
@@ -459,7 +627,52 @@ func (h *Handler) handleKill(conn *mysql.Conn, query string) (bool, error) {
 	return true, nil
 }
 
-func rowToSQL(s sql.Schema, row sql.Row) ([]sqltypes.Value, error) {
+// castSQLError converts the errors that have a specific MySQL error code into
+// a *mysql.SQLError, so the client receives that code instead of a generic one.
+func castSQLError(err error) error {
+	switch {
+	case sql.ErrQueryTimeout.Is(err):
+		return mysql.NewSQLError(ERQueryTimeout, mysql.SSUnknownSQLState, "%s", err.Error())
+	case sql.ErrWrongFieldWithGroup.Is(err):
+		return mysql.NewSQLError(mysql.ERWrongFieldWithGroup, ssSyntaxErrorOrAccessViolation, "%s", err.Error())
+	case sql.ErrMixOfGroupFuncAndFields.Is(err):
+		return mysql.NewSQLError(mysql.ERMixOfGroupFuncAndFields, ssSyntaxErrorOrAccessViolation, "%s", err.Error())
+	case sql.ErrDataOutOfRange.Is(err):
+		return mysql.NewSQLError(mysql.ERDataOutOfRange, mysql.SSDataOutOfRange, "%s", err.Error())
+	}
+
+	return err
+}
+
+// resultsCharacterSet returns the character set the session wants the strings of the results in, which is binary when
+// they mustn't be converted.
+func resultsCharacterSet(ctx *sql.Context) sql.CharacterSet {
+	_, val := ctx.Get("character_set_results")
+	name, ok := val.(string)
+	if !ok || name == "" {
+		return sql.CharacterSet_binary
+	}
+
+	charset, err := sql.ParseCharacterSet(strings.ToLower(name))
+	if err != nil {
+		return sql.CharacterSet_binary
+	}
+
+	return charset
+}
+
+// transcodes returns whether the values of a column of the given type are converted to the given character set of
+// the results.
+func transcodes(t sql.Type, charset sql.CharacterSet) bool {
+	if charset == sql.CharacterSet_binary || charset == sql.CharacterSet_utf8mb4 || !sql.IsTextOnly(t) {
+		return false
+	}
+
+	st, ok := t.(sql.StringType)
+	return ok && st.CharacterSet() != sql.CharacterSet_binary
+}
+
+func rowToSQL(s sql.Schema, row sql.Row, charset sql.CharacterSet) ([]sqltypes.Value, error) {
 	o := make([]sqltypes.Value, len(row))
 	var err error
 	for i, v := range row {
@@ -472,23 +685,30 @@ func rowToSQL(s sql.Schema, row sql.Row) ([]sqltypes.Value, error) {
 		if err != nil {
 			return nil, err
 		}
+
+		if transcodes(s[i].Type, charset) {
+			encoded, _ := charset.Encode(o[i].ToString())
+			o[i] = sqltypes.MakeTrusted(o[i].Type(), encoded)
+		}
 	}
 
 	return o, nil
 }
 
-func schemaToFields(s sql.Schema) []*query.Field {
+func schemaToFields(s sql.Schema, charset sql.CharacterSet) []*query.Field {
 	fields := make([]*query.Field, len(s))
 	for i, c := range s {
-		var charset uint32 = mysql.CharacterSetUtf8
-		if sql.IsBlob(c.Type) {
-			charset = mysql.CharacterSetBinary
+		var fieldCharset uint32 = mysql.CharacterSetUtf8
+		if sql.IsBlob(c.Type) || sql.IsSpatial(c.Type) {
+			fieldCharset = mysql.CharacterSetBinary
+		} else if id, ok := mysql.CharacterSetMap[charset.String()]; ok && transcodes(c.Type, charset) {
+			fieldCharset = uint32(id)
 		}
 
 		fields[i] = &query.Field{
 			Name:    c.Name,
 			Type:    c.Type.Type(),
-			Charset: charset,
+			Charset: fieldCharset,
 		}
 	}
 
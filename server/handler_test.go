@@ -15,6 +15,7 @@ import (
 
 	sqle "github.com/dolthub/go-mysql-server"
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/information_schema"
 )
 
 func TestHandlerOutput(t *testing.T) {
@@ -207,16 +208,65 @@ func TestSchemaToFields(t *testing.T) {
 		{Name: "foo", Type: sql.Blob},
 		{Name: "bar", Type: sql.Text},
 		{Name: "baz", Type: sql.Int64},
+		{Name: "qux", Type: sql.Point},
+		{Name: "quux", Type: sql.CreateText(sql.Collation_latin1_swedish_ci)},
 	}
 
 	expected := []*query.Field{
 		{Name: "foo", Type: query.Type_BLOB, Charset: mysql.CharacterSetBinary},
 		{Name: "bar", Type: query.Type_TEXT, Charset: mysql.CharacterSetUtf8},
 		{Name: "baz", Type: query.Type_INT64, Charset: mysql.CharacterSetUtf8},
+		{Name: "qux", Type: query.Type_GEOMETRY, Charset: mysql.CharacterSetBinary},
+		{Name: "quux", Type: query.Type_TEXT, Charset: mysql.CharacterSetUtf8},
 	}
 
-	fields := schemaToFields(schema)
+	fields := schemaToFields(schema, sql.CharacterSet_utf8mb4)
 	require.Equal(expected, fields)
+
+	expected[1].Charset = uint32(mysql.CharacterSetMap["latin1"])
+	expected[4].Charset = uint32(mysql.CharacterSetMap["latin1"])
+	fields = schemaToFields(schema, sql.CharacterSet_latin1)
+	require.Equal(expected, fields)
+}
+
+func TestHandlerCharacterSetResults(t *testing.T) {
+	require := require.New(t)
+	e := setupMemDB(require)
+
+	handler := NewHandler(
+		e, NewSessionManager(testSessionBuilder,
+			opentracing.NoopTracer{},
+			func(db string) bool { return db == "test" },
+			sql.NewMemoryManager(nil),
+			"foo"),
+		0)
+
+	conn := newConn(1)
+	handler.NewConnection(conn)
+	require.NoError(handler.ComInitDB(conn, "test"))
+
+	var result *sqltypes.Result
+	callback := func(res *sqltypes.Result) error {
+		result = res
+		return nil
+	}
+
+	query := "SELECT 'aé€', CONVERT('aé€' USING `binary`), 1"
+
+	require.NoError(handler.ComQuery(conn, query, callback))
+	require.Equal("aé€", result.Rows[0][0].ToString())
+	require.Equal(uint32(mysql.CharacterSetUtf8), result.Fields[0].Charset)
+
+	require.NoError(handler.ComQuery(conn, "SET character_set_results = 'latin1'", callback))
+	require.NoError(handler.ComQuery(conn, query, callback))
+	require.Equal([]byte{'a', 0xE9, 0x80}, result.Rows[0][0].Raw())
+	require.Equal(uint32(mysql.CharacterSetMap["latin1"]), result.Fields[0].Charset)
+	require.Equal([]byte("aé€"), result.Rows[0][1].Raw())
+	require.Equal("1", result.Rows[0][2].ToString())
+
+	require.NoError(handler.ComQuery(conn, "SET character_set_results = 'binary'", callback))
+	require.NoError(handler.ComQuery(conn, query, callback))
+	require.Equal([]byte("aé€"), result.Rows[0][0].Raw())
 }
 
 func TestHandlerTimeout(t *testing.T) {
@@ -267,6 +317,306 @@ func TestHandlerTimeout(t *testing.T) {
 	require.NoError(err)
 }
 
+func TestHandlerOnlyFullGroupByErrors(t *testing.T) {
+	require := require.New(t)
+	e := setupMemDB(require)
+
+	handler := NewHandler(
+		e, NewSessionManager(testSessionBuilder,
+			opentracing.NoopTracer{},
+			func(db string) bool { return db == "test" },
+			sql.NewMemoryManager(nil),
+			"foo"),
+		0)
+
+	conn := newConn(1)
+	handler.NewConnection(conn)
+	require.NoError(handler.ComInitDB(conn, "test"))
+
+	noop := func(res *sqltypes.Result) error {
+		return nil
+	}
+
+	err := handler.ComQuery(conn, "SELECT c1, COUNT(*) FROM test GROUP BY c1 + 1", noop)
+	require.Error(err)
+	sqlErr, ok := err.(*mysql.SQLError)
+	require.True(ok)
+	require.Equal(mysql.ERWrongFieldWithGroup, sqlErr.Number())
+	require.Equal("42000", sqlErr.SQLState())
+
+	err = handler.ComQuery(conn, "SELECT c1, COUNT(*) FROM test", noop)
+	require.Error(err)
+	sqlErr, ok = err.(*mysql.SQLError)
+	require.True(ok)
+	require.Equal(mysql.ERMixOfGroupFuncAndFields, sqlErr.Number())
+
+	require.NoError(handler.ComQuery(conn, "SET sql_mode = ''", noop))
+	require.NoError(handler.ComQuery(conn, "SELECT c1, COUNT(*) FROM test", noop))
+}
+
+func TestHandlerDataOutOfRangeError(t *testing.T) {
+	require := require.New(t)
+	e := setupMemDB(require)
+
+	handler := NewHandler(
+		e, NewSessionManager(testSessionBuilder,
+			opentracing.NoopTracer{},
+			func(db string) bool { return db == "test" },
+			sql.NewMemoryManager(nil),
+			"foo"),
+		0)
+
+	conn := newConn(1)
+	handler.NewConnection(conn)
+	require.NoError(handler.ComInitDB(conn, "test"))
+
+	err := handler.ComQuery(conn, "SELECT 9223372036854775807 + 1", func(res *sqltypes.Result) error {
+		return nil
+	})
+	require.Error(err)
+	sqlErr, ok := err.(*mysql.SQLError)
+	require.True(ok)
+	require.Equal(mysql.ERDataOutOfRange, sqlErr.Number())
+	require.Equal(mysql.SSDataOutOfRange, sqlErr.SQLState())
+}
+
+func TestHandlerMaxExecutionTime(t *testing.T) {
+	require := require.New(t)
+	e := setupMemDB(require)
+
+	handler := NewHandler(
+		e, NewSessionManager(testSessionBuilder,
+			opentracing.NoopTracer{},
+			func(db string) bool { return db == "test" },
+			sql.NewMemoryManager(nil),
+			"foo"),
+		0)
+
+	conn := newConn(1)
+	handler.NewConnection(conn)
+	require.NoError(handler.ComInitDB(conn, "test"))
+
+	noop := func(res *sqltypes.Result) error {
+		return nil
+	}
+
+	err := handler.ComQuery(conn, "SELECT /*+ MAX_EXECUTION_TIME(100) */ SLEEP(2)", noop)
+	require.Error(err)
+	sqlErr, ok := err.(*mysql.SQLError)
+	require.True(ok)
+	require.Equal(ERQueryTimeout, sqlErr.Number())
+
+	require.NoError(handler.ComQuery(conn, "SET max_execution_time = 100", noop))
+	err = handler.ComQuery(conn, "SELECT SLEEP(2)", noop)
+	require.Error(err)
+	sqlErr, ok = err.(*mysql.SQLError)
+	require.True(ok)
+	require.Equal(ERQueryTimeout, sqlErr.Number())
+
+	require.NoError(handler.ComQuery(conn, "SELECT SLEEP(0.01)", noop))
+
+	// Only SELECT statements are limited.
+	require.NoError(handler.ComQuery(conn, "SET @sleep = SLEEP(0.2)", noop))
+
+	require.NoError(handler.ComQuery(conn, "SET max_execution_time = 0", noop))
+	require.NoError(handler.ComQuery(conn, "SELECT SLEEP(0.2)", noop))
+
+	// The global value is the default of the sessions created afterwards.
+	require.NoError(handler.ComQuery(conn, "SET GLOBAL max_execution_time = 100", noop))
+	defer func() {
+		require.NoError(sql.SetGlobal("max_execution_time", 0))
+	}()
+
+	require.NoError(handler.ComQuery(conn, "SELECT SLEEP(0.2)", noop))
+
+	conn2 := newConn(2)
+	handler.NewConnection(conn2)
+	require.NoError(handler.ComInitDB(conn2, "test"))
+
+	var value interface{}
+	err = handler.ComQuery(conn2, "SELECT @@global.max_execution_time, @@max_execution_time", func(res *sqltypes.Result) error {
+		value = res.Rows[0][1].ToString()
+		return nil
+	})
+	require.NoError(err)
+	require.Equal("100", value)
+
+	err = handler.ComQuery(conn2, "SELECT SLEEP(2)", noop)
+	require.Error(err)
+	sqlErr, ok = err.(*mysql.SQLError)
+	require.True(ok)
+	require.Equal(ERQueryTimeout, sqlErr.Number())
+}
+
+func TestHandlerMultiStatementError(t *testing.T) {
+	require := require.New(t)
+	e := setupMemDB(require)
+
+	handler := NewHandler(
+		e, NewSessionManager(testSessionBuilder,
+			opentracing.NoopTracer{},
+			func(db string) bool { return db == "test" },
+			sql.NewMemoryManager(nil),
+			"foo"),
+		0)
+
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	var netConn net.Conn = &trackedConn{Conn: server}
+	handler.AddNetConnection(&netConn)
+
+	conn := newConn(1)
+	handler.NewConnection(conn)
+	require.NoError(handler.ComInitDB(conn, "test"))
+
+	noop := func(res *sqltypes.Result) error {
+		return nil
+	}
+
+	// Statements of the same query are all received with the same read count
+	err := handler.ComQuery(conn, "SELECT * FROM nope", noop)
+	require.True(sql.ErrTableNotFound.Is(err))
+	err = handler.ComQuery(conn, "INSERT INTO test VALUES (2000)", noop)
+	require.True(ErrStatementSkipped.Is(err))
+
+	// A new query needs a new read
+	netConn.(*trackedConn).reads++
+
+	var rows []*sqltypes.Result
+	err = handler.ComQuery(conn, "SELECT * FROM test WHERE c1 = 2000", func(res *sqltypes.Result) error {
+		rows = append(rows, res)
+		return nil
+	})
+	require.NoError(err)
+	require.Len(rows, 1)
+	require.Len(rows[0].Rows, 0)
+}
+
+func TestHandlerComResetConnection(t *testing.T) {
+	require := require.New(t)
+	e := setupMemDB(require)
+
+	handler := NewHandler(
+		e, NewSessionManager(testSessionBuilder,
+			opentracing.NoopTracer{},
+			func(db string) bool { return db == "test" },
+			sql.NewMemoryManager(nil),
+			"foo"),
+		0)
+
+	conn := newConn(1)
+	handler.NewConnection(conn)
+	require.NoError(handler.ComInitDB(conn, "test"))
+
+	noop := func(res *sqltypes.Result) error {
+		return nil
+	}
+
+	require.NoError(handler.ComQuery(conn, "SET @foo = 1", noop))
+	require.NoError(handler.ComQuery(conn, "SET autocommit = 1", noop))
+	require.NoError(handler.ComQuery(conn, "SELECT GET_LOCK('reset_lock', 0)", noop))
+	require.NoError(handler.ComQuery(conn, "CREATE VIEW reset_view AS SELECT * FROM test", noop))
+	require.NoError(handler.ComQuery(conn, "SELECT * FROM reset_view", noop))
+
+	state, owner := e.LS.GetLockState("reset_lock")
+	require.Equal(sql.LockInUse, state)
+	require.Equal(conn.ConnectionID, owner)
+
+	sess := handler.sm.session(conn)
+	sess.Warn(&sql.Warning{Level: "Warning", Message: "foo"})
+
+	handler.ComResetConnection(conn)
+
+	state, _ = e.LS.GetLockState("reset_lock")
+	require.Equal(sql.LockFree, state)
+
+	newSess := handler.sm.session(conn)
+	require.True(sess != newSess)
+	require.Equal("test", newSess.GetCurrentDatabase())
+	require.Equal(uint16(0), newSess.WarningCount())
+
+	_, val := newSess.Get("foo")
+	require.Nil(val)
+
+	isDefault, _ := sql.HasDefaultValue(newSess, "autocommit")
+	require.True(isDefault)
+
+	require.Equal(int64(0), e.Catalog.SessionStatus(conn.ConnectionID)[sql.StatusQuestions])
+	require.Equal(int64(5), e.Catalog.GlobalStatus()[sql.StatusQuestions])
+
+	err := handler.ComQuery(conn, "SELECT * FROM reset_view", noop)
+	require.Error(err)
+	require.Contains(err.Error(), "table not found")
+
+	require.NoError(handler.ComQuery(conn, "SELECT * FROM test", noop))
+}
+
+func TestHandlerStatus(t *testing.T) {
+	require := require.New(t)
+	e := setupMemDB(require)
+	e.Catalog.AddDatabase(information_schema.NewInformationSchemaDatabase(e.Catalog))
+
+	handler := NewHandler(
+		e, NewSessionManager(testSessionBuilder,
+			opentracing.NoopTracer{},
+			func(db string) bool { return db == "test" },
+			sql.NewMemoryManager(nil),
+			"foo"),
+		0)
+
+	conn1, conn2 := newConn(1), newConn(2)
+	handler.NewConnection(conn1)
+	handler.NewConnection(conn2)
+	require.NoError(handler.ComInitDB(conn1, "test"))
+	require.NoError(handler.ComInitDB(conn2, "test"))
+
+	noop := func(res *sqltypes.Result) error {
+		return nil
+	}
+
+	require.NoError(handler.ComQuery(conn1, "SELECT * FROM test", noop))
+	require.NoError(handler.ComQuery(conn1, "SELECT * FROM test", noop))
+	require.NoError(handler.ComQuery(conn2, "SELECT * FROM test", noop))
+	require.NoError(handler.ComQuery(conn2, "SET long_query_time = 0", noop))
+
+	status := func(c *mysql.Conn, query string) map[string]string {
+		result := make(map[string]string)
+		err := handler.ComQuery(c, query, func(res *sqltypes.Result) error {
+			for _, row := range res.Rows {
+				result[row[0].ToString()] = row[1].ToString()
+			}
+			return nil
+		})
+		require.NoError(err)
+		return result
+	}
+
+	session := status(conn1, "SHOW STATUS")
+	require.Equal("2", session["Com_select"])
+	require.Equal("3", session["Questions"])
+	require.Equal("2", session["Threads_connected"])
+	require.Equal("0", session["Slow_queries"])
+	require.NotEqual("0", session["Bytes_sent"])
+
+	global := status(conn1, "SHOW GLOBAL STATUS LIKE 'Com_s%'")
+	require.Equal(map[string]string{
+		"Com_select":      "3",
+		"Com_set_option":  "1",
+		"Com_show_status": "2",
+	}, global)
+
+	session = status(conn2, "SELECT * FROM information_schema.session_status")
+	require.Equal("2", session["Com_select"])
+	require.Equal("1", session["Slow_queries"])
+
+	handler.ConnectionClosed(conn2)
+
+	global = status(conn1, "SELECT * FROM information_schema.global_status WHERE variable_name LIKE 'Threads%'")
+	require.Equal(map[string]string{"Threads_connected": "1"}, global)
+}
+
 func TestOkClosedConnection(t *testing.T) {
 	require := require.New(t)
 	e := setupMemDB(require)
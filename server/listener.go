@@ -2,6 +2,7 @@ package server
 
 import (
 	"net"
+	"sync/atomic"
 )
 
 type Listener struct {
@@ -24,6 +25,27 @@ func (l *Listener) Accept() (net.Conn, error) {
 		return nil, err
 	}
 
+	conn = &trackedConn{Conn: conn}
 	l.h.AddNetConnection(&conn)
 	return conn, err
 }
+
+// trackedConn is a net.Conn that counts the reads made on it. Each command
+// sent by the client needs at least one read, while all the statements of a
+// multi-statement query are read at once, so the handler can use the count to
+// know whether two statements belong to the same query.
+type trackedConn struct {
+	reads uint64
+	net.Conn
+}
+
+// Read implements the net.Conn interface.
+func (c *trackedConn) Read(b []byte) (int, error) {
+	atomic.AddUint64(&c.reads, 1)
+	return c.Conn.Read(b)
+}
+
+// Reads returns the number of reads made on the connection.
+func (c *trackedConn) Reads() uint64 {
+	return atomic.LoadUint64(&c.reads)
+}
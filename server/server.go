@@ -5,15 +5,20 @@ import (
 
 	"github.com/dolthub/vitess/go/mysql"
 	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus"
 
 	sqle "github.com/dolthub/go-mysql-server"
 	"github.com/dolthub/go-mysql-server/auth"
+	"github.com/dolthub/go-mysql-server/metrics"
 )
 
 // Server is a MySQL server for SQLe engines.
 type Server struct {
 	Listener *mysql.Listener
 	h        *Handler
+	// memory collects the memory usage of the engine while the server is
+	// open. It's nil if another server already registered its own.
+	memory prometheus.Collector
 }
 
 // Config for the mysql server.
@@ -96,7 +101,12 @@ func NewServer(cfg Config, e *sqle.Engine, sb SessionBuilder) (*Server, error) {
 		vtListnr.ServerVersion = cfg.Version
 	}
 
-	return &Server{Listener: vtListnr, h: handler}, nil
+	memory := metrics.NewMemoryCollector(e.Catalog.MemoryManager)
+	if err := metrics.Registry.Register(memory); err != nil {
+		memory = nil
+	}
+
+	return &Server{Listener: vtListnr, h: handler, memory: memory}, nil
 }
 
 // Start starts accepting connections on the server.
@@ -108,5 +118,8 @@ func (s *Server) Start() error {
 // Close closes the server connection.
 func (s *Server) Close() error {
 	s.Listener.Close()
+	if s.memory != nil {
+		metrics.Registry.Unregister(s.memory)
+	}
 	return nil
 }
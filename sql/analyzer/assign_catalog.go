@@ -35,6 +35,10 @@ func assignCatalog(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql
 			nc.Database = ctx.GetCurrentDatabase()
 			nc.ProcessList = a.Catalog.ProcessList
 			return &nc, nil
+		case *plan.ShowStatus:
+			nc := *node
+			nc.StatusVariables = a.Catalog.StatusVariables
+			return &nc, nil
 		case *plan.ShowTableStatus:
 			nc := *node
 			nc.Catalog = a.Catalog
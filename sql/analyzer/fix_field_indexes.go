@@ -134,6 +134,17 @@ func FixFieldIndexesForExpressions(node sql.Node, scope *Scope) (sql.Node, error
 		}
 
 		n = plan.NewLeftJoin(j.Left, j.Right, cond)
+	case *plan.JSONTable:
+		if j.Cond == nil {
+			break
+		}
+
+		cond, err := FixFieldIndexes(scope, j.Schema(), j.Cond)
+		if err != nil {
+			return nil, err
+		}
+
+		n = j.AsLeftJoin(cond)
 	}
 
 	return n, nil
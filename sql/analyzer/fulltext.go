@@ -0,0 +1,158 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// resolveFullTextIndexes binds every MATCH expression to the FULLTEXT index on exactly the columns it searches.
+func resolveFullTextIndexes(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("resolve_fulltext_indexes")
+	defer span.Finish()
+
+	if !n.Resolved() || !containsMatch(n) {
+		return n, nil
+	}
+
+	tableAliases, err := getTableAliases(n, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	ia, err := getIndexesForNode(ctx, a, n)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan.TransformExpressionsUp(n, func(e sql.Expression) (sql.Expression, error) {
+		m, ok := e.(*expression.Match)
+		if !ok || m.Index != nil {
+			return e, nil
+		}
+
+		idx := fullTextIndexByColumns(ctx, ia, normalizeExpressions(nil, tableAliases, m.Columns...))
+		if idx == nil {
+			return nil, sql.ErrNoFullTextIndex.New()
+		}
+
+		a.Log("MATCH expression %s bound to index %s", m, idx.ID())
+		return m.WithIndex(idx), nil
+	})
+}
+
+// fullTextIndexByColumns returns the FULLTEXT index on exactly the columns given, in any order, or nil if there's none.
+func fullTextIndexByColumns(ctx *sql.Context, ia *indexAnalyzer, columns []sql.Expression) sql.FullTextIndex {
+	var table string
+	exprStrs := make([]string, len(columns))
+	for i, c := range columns {
+		gf, ok := c.(*expression.GetField)
+		if !ok || (table != "" && !strings.EqualFold(table, gf.Table())) {
+			return nil
+		}
+		table = gf.Table()
+		exprStrs[i] = strings.ToLower(gf.String())
+	}
+
+	for _, idx := range ia.IndexesByTable(ctx, ctx.GetCurrentDatabase(), table) {
+		ft, ok := idx.(sql.FullTextIndex)
+		if !ok || len(ft.Expressions()) != len(exprStrs) {
+			continue
+		}
+
+		idxExprs := make([]string, len(exprStrs))
+		for i, e := range ft.Expressions() {
+			idxExprs[i] = strings.ToLower(e)
+		}
+
+		if isSublist(idxExprs, exprStrs) {
+			return ft
+		}
+	}
+
+	return nil
+}
+
+func containsMatch(n sql.Node) bool {
+	var found bool
+	plan.InspectExpressions(n, func(e sql.Expression) bool {
+		if _, ok := e.(*expression.Match); ok {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// eraseRelevanceSort removes the sort of a query ordered by descending relevance when the rows already come in that
+// order from a FULLTEXT index lookup for the same search. This is only possible for tables with a single partition,
+// since rows are sorted by relevance within each partition.
+func eraseRelevanceSort(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("erase_relevance_sort")
+	defer span.Finish()
+
+	if !n.Resolved() || !containsMatch(n) {
+		return n, nil
+	}
+
+	return plan.TransformUp(n, func(n sql.Node) (sql.Node, error) {
+		sort, ok := n.(*plan.Sort)
+		if !ok || len(sort.SortFields) != 1 || sort.SortFields[0].Order != plan.Descending {
+			return n, nil
+		}
+
+		m, ok := sort.SortFields[0].Column.(*expression.Match)
+		if !ok {
+			return n, nil
+		}
+
+		if !isRelevanceOrdered(sort.Child, m) {
+			return n, nil
+		}
+
+		counter, ok := getTable(sort.Child).(sql.PartitionCounter)
+		if !ok {
+			return n, nil
+		}
+
+		partitions, err := counter.PartitionCount(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if partitions > 1 {
+			return n, nil
+		}
+
+		a.Log("erasing sort by relevance of %s", m)
+		return sort.Child, nil
+	})
+}
+
+// isRelevanceOrdered returns whether the node given is a table filtered by the MATCH expression given, and accessed
+// through the index lookup of its search.
+func isRelevanceOrdered(n sql.Node, m *expression.Match) bool {
+	var filtered, indexed bool
+	for {
+		switch node := n.(type) {
+		case *plan.Filter:
+			if filtered || node.Expression.String() != m.String() {
+				return false
+			}
+			filtered = true
+			n = node.Child
+		case *plan.DecoratedNode:
+			if indexed || node.DecorationType != plan.DecorationTypeIndexedAccess {
+				return false
+			}
+			indexed = true
+			n = node.Child
+		case *plan.ResolvedTable:
+			return filtered && indexed
+		default:
+			return false
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// resolveGeneratedColumnUpdates validates the assignments of an UPDATE to the generated columns of its table, see
+// resolveUpdateExprs.
+func resolveGeneratedColumnUpdates(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("resolve_generated_column_updates")
+	defer span.Finish()
+
+	return plan.TransformUp(n, func(n sql.Node) (sql.Node, error) {
+		us, ok := n.(*plan.UpdateSource)
+		if !ok {
+			return n, nil
+		}
+
+		table := getTable(us.Child)
+		if table == nil {
+			return n, nil
+		}
+
+		exprs, err := resolveUpdateExprs(table.Name(), table.Schema(), us.UpdateExprs)
+		if err != nil {
+			return nil, err
+		}
+
+		return plan.NewUpdateSource(us.Child, exprs), nil
+	})
+}
+
+// resolveUpdateExprs checks that the only value assigned to a generated column is DEFAULT, and removes those
+// assignments, since generated columns are computed again every time a row is updated. Any other assignment of DEFAULT
+// is replaced with the default value of the column, when it's a literal.
+func resolveUpdateExprs(tableName string, schema sql.Schema, exprs []sql.Expression) ([]sql.Expression, error) {
+	var newExprs []sql.Expression
+	for _, e := range exprs {
+		sf, ok := e.(*expression.SetField)
+		if !ok {
+			newExprs = append(newExprs, e)
+			continue
+		}
+
+		gf, ok := sf.Left.(*expression.GetField)
+		if !ok {
+			newExprs = append(newExprs, e)
+			continue
+		}
+
+		col := columnByName(schema, gf.Name())
+		if col == nil {
+			newExprs = append(newExprs, e)
+			continue
+		}
+
+		_, isDefault := sf.Right.(*expression.DefaultColumn)
+		switch {
+		case col.Generated != nil && !isDefault:
+			return nil, sql.ErrGeneratedColumnValue.New(col.Name, tableName)
+		case col.Generated != nil:
+			continue
+		case isDefault && col.Default.IsLiteral():
+			e = expression.NewSetField(sf.Left, literalDefault(col))
+		}
+		newExprs = append(newExprs, e)
+	}
+	return newExprs, nil
+}
+
+// resolveInsertValues checks that the only value inserted in a generated column is DEFAULT, and replaces every DEFAULT
+// in the rows inserted with the default value of its column, when it's a literal. The values of generated columns are
+// computed once the rest of the row is known, so they're replaced with NULL.
+func resolveInsertValues(tableName string, schema sql.Schema, columnNames []string, source sql.Node) (sql.Node, error) {
+	values, ok := source.(*plan.Values)
+	if !ok {
+		for _, name := range columnNames {
+			if col := columnByName(schema, name); col != nil && col.Generated != nil {
+				return nil, sql.ErrGeneratedColumnValue.New(col.Name, tableName)
+			}
+		}
+		return source, nil
+	}
+
+	tuples := make([][]sql.Expression, len(values.ExpressionTuples))
+	for i, tuple := range values.ExpressionTuples {
+		tuples[i] = make([]sql.Expression, len(tuple))
+		for j, e := range tuple {
+			col := columnByName(schema, columnNames[j])
+			_, isDefault := e.(*expression.DefaultColumn)
+			switch {
+			case col.Generated != nil && !isDefault:
+				return nil, sql.ErrGeneratedColumnValue.New(col.Name, tableName)
+			case col.Generated != nil:
+				e = expression.NewLiteral(nil, sql.Null)
+			case isDefault && col.Default.IsLiteral():
+				e = literalDefault(col)
+			}
+			tuples[i][j] = e
+		}
+	}
+
+	return plan.NewValues(tuples), nil
+}
+
+// literalDefault returns the literal default value of the column given.
+func literalDefault(col *sql.Column) sql.Expression {
+	if col.Default == nil {
+		return expression.NewLiteral(nil, sql.Null)
+	}
+	return col.Default
+}
+
+func columnByName(schema sql.Schema, name string) *sql.Column {
+	for _, col := range schema {
+		if strings.EqualFold(col.Name, name) {
+			return col
+		}
+	}
+	return nil
+}
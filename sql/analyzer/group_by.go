@@ -0,0 +1,351 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// validateGroupBy checks, when ONLY_FULL_GROUP_BY is enabled, that the columns a grouped query selects, filters by in
+// its HAVING clause or sorts by are either aggregated or functionally dependent on the grouping columns, since their
+// value would be taken from any row of the group otherwise.
+func validateGroupBy(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
+	span, _ := ctx.Span("validate_group_by")
+	defer span.Finish()
+
+	if !sql.LoadSqlMode(ctx).Has(sql.SqlMode_OnlyFullGroupBy) {
+		return n, nil
+	}
+
+	// The columns a GroupBy node selects only to evaluate HAVING or ORDER BY are hidden by the projections on top of
+	// it, so when there's one, only the expressions the outermost one projects are validated as the SELECT list.
+	projected := make(map[*plan.GroupBy]bool)
+	var err error
+	plan.Inspect(n, func(node sql.Node) bool {
+		if err != nil {
+			return false
+		}
+
+		switch node := node.(type) {
+		case *plan.SubqueryAlias:
+			// Subqueries are validated on their own when they're analyzed.
+			return false
+		case *plan.Project:
+			if gb := groupByChild(node.Child); gb != nil && !projected[gb] {
+				projected[gb] = true
+				err = newGroupByDependencies(gb).validateOutput(node.Child, "SELECT list", node.Projections)
+			}
+		case *plan.Having:
+			if gb := groupByChild(node.Child); gb != nil {
+				err = newGroupByDependencies(gb).validateOutput(node.Child, "HAVING clause", []sql.Expression{node.Cond})
+			}
+		case *plan.Sort:
+			if gb := groupByChild(node.Child); gb != nil {
+				err = newGroupByDependencies(gb).validateOutput(node.Child, "ORDER BY clause", sortFieldExprs(node))
+			}
+		case *plan.GroupBy:
+			d := newGroupByDependencies(node)
+			if !projected[node] {
+				err = d.validate("SELECT list", node.SelectedExprs)
+			}
+			// ORDER BY is pushed below the GroupBy node when it sorts by columns that aren't selected.
+			if sort, ok := node.Child.(*plan.Sort); ok && err == nil {
+				err = d.validate("ORDER BY clause", sortFieldExprs(sort))
+			}
+		}
+
+		return err == nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// groupByChild returns the GroupBy node whose rows the given node sees, if any, skipping the projections, HAVING
+// and ORDER BY clauses between them.
+func groupByChild(n sql.Node) *plan.GroupBy {
+	switch n := n.(type) {
+	case *plan.GroupBy:
+		return n
+	case *plan.Project:
+		return groupByChild(n.Child)
+	case *plan.Having:
+		return groupByChild(n.Child)
+	case *plan.Sort:
+		return groupByChild(n.Child)
+	default:
+		return nil
+	}
+}
+
+func sortFieldExprs(s *plan.Sort) []sql.Expression {
+	exprs := make([]sql.Expression, len(s.SortFields))
+	for i, f := range s.SortFields {
+		exprs[i] = f.Column
+	}
+	return exprs
+}
+
+// groupByDependencies tracks which columns of the rows of a GroupBy node have a single value in each group: the
+// grouping columns, the columns equal to a constant or to another such column, and all the columns of a table whose
+// primary key, or one of whose unique keys, has a single value.
+type groupByDependencies struct {
+	groupBy    *plan.GroupBy
+	columns    map[string]bool
+	grouped    map[string]bool
+	determined map[string]bool
+	// uniqueKeys are the unique keys of the tables that declare them, by table name or alias.
+	uniqueKeys map[string][][]string
+}
+
+func newGroupByDependencies(gb *plan.GroupBy) *groupByDependencies {
+	d := &groupByDependencies{
+		groupBy:    gb,
+		columns:    make(map[string]bool),
+		grouped:    make(map[string]bool),
+		determined: make(map[string]bool),
+		uniqueKeys: make(map[string][][]string),
+	}
+
+	schema := gb.Child.Schema()
+	for _, col := range schema {
+		d.columns[columnKey(col.Source, col.Name)] = true
+	}
+
+	for _, e := range gb.GroupByExprs {
+		if alias, ok := e.(*expression.Alias); ok {
+			e = alias.Child
+		}
+		d.grouped[strings.ToLower(e.String())] = true
+		if gf, ok := e.(*expression.GetField); ok {
+			d.determined[columnKey(gf.Table(), gf.Name())] = true
+		}
+	}
+
+	var equalities []*expression.Equals
+	plan.Inspect(gb.Child, func(n sql.Node) bool {
+		var cond sql.Expression
+		switch n := n.(type) {
+		case *plan.SubqueryAlias:
+			return false
+		case *plan.TableAlias:
+			if rt, ok := n.Child.(*plan.ResolvedTable); ok {
+				d.addUniqueKeys(n.Name(), rt.Table)
+			}
+			return false
+		case *plan.ResolvedTable:
+			d.addUniqueKeys(n.Name(), n.Table)
+			return false
+		case *plan.Filter:
+			cond = n.Expression
+		case *plan.InnerJoin:
+			cond = n.Cond
+		default:
+			return true
+		}
+
+		for _, e := range splitConjunction(cond) {
+			if eq, ok := e.(*expression.Equals); ok {
+				equalities = append(equalities, eq)
+			}
+		}
+		return true
+	})
+
+	for changed := true; changed; {
+		changed = false
+		for _, eq := range equalities {
+			if d.determineEqual(eq.Left(), eq.Right()) {
+				changed = true
+			}
+			if d.determineEqual(eq.Right(), eq.Left()) {
+				changed = true
+			}
+		}
+		if d.determineByPrimaryKeys(schema) {
+			changed = true
+		}
+	}
+
+	return d
+}
+
+// addUniqueKeys records the unique keys of the given table, if it declares any, for the columns of the given name.
+func (d *groupByDependencies) addUniqueKeys(name string, table sql.Table) {
+	if ukt, ok := table.(sql.UniqueKeysTable); ok {
+		d.uniqueKeys[strings.ToLower(name)] = ukt.UniqueKeys()
+	}
+}
+
+// determineEqual marks the given column as determined if the expression it's equal to is, and returns whether it
+// wasn't already.
+func (d *groupByDependencies) determineEqual(left, right sql.Expression) bool {
+	gf, ok := left.(*expression.GetField)
+	if !ok {
+		return false
+	}
+
+	key := columnKey(gf.Table(), gf.Name())
+	if d.determined[key] || d.undetermined(right) != nil {
+		return false
+	}
+
+	d.determined[key] = true
+	return true
+}
+
+// determineByPrimaryKeys marks as determined all the columns of the tables whose primary key columns, or the columns
+// of one of whose unique keys, are, and returns whether any of them wasn't already.
+func (d *groupByDependencies) determineByPrimaryKeys(schema sql.Schema) bool {
+	keyDetermined := make(map[string]bool)
+	for _, col := range schema {
+		if !col.PrimaryKey {
+			continue
+		}
+
+		source := strings.ToLower(col.Source)
+		determined, ok := keyDetermined[source]
+		keyDetermined[source] = (determined || !ok) && d.determined[columnKey(col.Source, col.Name)]
+	}
+
+	for source, keys := range d.uniqueKeys {
+		for _, key := range keys {
+			if d.allDetermined(source, key) {
+				keyDetermined[source] = true
+			}
+		}
+	}
+
+	var changed bool
+	for _, col := range schema {
+		key := columnKey(col.Source, col.Name)
+		if keyDetermined[strings.ToLower(col.Source)] && !d.determined[key] {
+			d.determined[key] = true
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// allDetermined returns whether all the given columns of the given table are determined.
+func (d *groupByDependencies) allDetermined(table string, columns []string) bool {
+	for _, col := range columns {
+		if !d.determined[columnKey(table, col)] {
+			return false
+		}
+	}
+	return true
+}
+
+// undetermined returns the first column in the given expression that isn't aggregated nor determined by the grouping
+// columns, if any. Columns of an outer scope have a single value for the whole query.
+func (d *groupByDependencies) undetermined(e sql.Expression) *expression.GetField {
+	if d.grouped[strings.ToLower(e.String())] {
+		return nil
+	}
+
+	switch e := e.(type) {
+	case sql.Aggregation, *plan.Subquery:
+		return nil
+	case *expression.Alias:
+		return d.undetermined(e.Child)
+	case *expression.GetField:
+		key := columnKey(e.Table(), e.Name())
+		if d.determined[key] || !d.columns[key] {
+			return nil
+		}
+		return e
+	}
+
+	for _, child := range e.Children() {
+		if gf := d.undetermined(child); gf != nil {
+			return gf
+		}
+	}
+
+	return nil
+}
+
+// validate checks that the given expressions, evaluated on the rows of the child of the GroupBy node, only use
+// aggregated or determined columns.
+func (d *groupByDependencies) validate(clause string, exprs []sql.Expression) error {
+	for i, e := range exprs {
+		gf := d.undetermined(e)
+		if gf == nil {
+			continue
+		}
+
+		if len(d.groupBy.GroupByExprs) == 0 {
+			return sql.ErrMixOfGroupFuncAndFields.New(i+1, clause, gf.String())
+		}
+		return sql.ErrWrongFieldWithGroup.New(i+1, clause, gf.String())
+	}
+
+	return nil
+}
+
+// validateOutput checks expressions evaluated on the rows of the given node, which returns the rows of the GroupBy
+// node, maybe filtered, sorted or projected.
+func (d *groupByDependencies) validateOutput(n sql.Node, clause string, exprs []sql.Expression) error {
+	inlined := make([]sql.Expression, len(exprs))
+	for i, e := range exprs {
+		var err error
+		inlined[i], err = inlineOutput(n, e)
+		if err != nil {
+			return err
+		}
+	}
+
+	return d.validate(clause, inlined)
+}
+
+// inlineOutput returns the expression that, evaluated on the rows of the child of the GroupBy node under the given
+// one, uses the same columns as the given expression does evaluated on the rows of the given node.
+func inlineOutput(n sql.Node, e sql.Expression) (sql.Expression, error) {
+	switch n := n.(type) {
+	case *plan.GroupBy:
+		return inlineColumns(e, n.Schema(), n.SelectedExprs)
+	case *plan.Project:
+		e, err := inlineColumns(e, n.Schema(), n.Projections)
+		if err != nil {
+			return nil, err
+		}
+		return inlineOutput(n.Child, e)
+	case *plan.Having:
+		return inlineOutput(n.Child, e)
+	case *plan.Sort:
+		return inlineOutput(n.Child, e)
+	default:
+		return e, nil
+	}
+}
+
+// inlineColumns replaces the columns of the given schema in the expression with the expressions computing them.
+func inlineColumns(e sql.Expression, schema sql.Schema, exprs []sql.Expression) (sql.Expression, error) {
+	return expression.TransformUp(e, func(e sql.Expression) (sql.Expression, error) {
+		gf, ok := e.(*expression.GetField)
+		if !ok {
+			return e, nil
+		}
+
+		for i, col := range schema {
+			if strings.EqualFold(col.Name, gf.Name()) && strings.EqualFold(col.Source, gf.Table()) {
+				if alias, ok := exprs[i].(*expression.Alias); ok {
+					return alias.Child, nil
+				}
+				return exprs[i], nil
+			}
+		}
+		return e, nil
+	})
+}
+
+func columnKey(table, column string) string {
+	return strings.ToLower(table + "." + column)
+}
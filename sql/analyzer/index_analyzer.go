@@ -96,6 +96,11 @@ func (r *indexAnalyzer) IndexByExpression(ctx *sql.Context, db string, expr ...s
 
 	for _, idxes := range r.indexesByTable {
 		for _, idx := range idxes {
+			// FULLTEXT indexes can only be used by MATCH expressions
+			if _, ok := idx.(sql.FullTextIndex); ok {
+				continue
+			}
+
 			if isSublist(idx.Expressions(), exprStrs) {
 				return idx
 			}
@@ -120,6 +125,10 @@ func (r *indexAnalyzer) ExpressionsWithIndexes(db string, exprs ...sql.Expressio
 	for _, idxes := range r.indexesByTable {
 	Indexes:
 		for _, idx := range idxes {
+			if _, ok := idx.(sql.FullTextIndex); ok {
+				continue
+			}
+
 			if ln := len(idx.Expressions()); ln <= len(exprs) && ln > 1 {
 				var used = make(map[int]bool)
 				var matched []sql.Expression
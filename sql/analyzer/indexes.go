@@ -162,14 +162,14 @@ func getIndexes(
 					return nil, errInvalidInRightEvaluation.New(value)
 				}
 
-				lookup, errLookup := idx.Get(values[0])
+				lookup, errLookup := idx.Get(sql.TimestampFromSession(ctx, e.Left().Type(), values[0]))
 
 				if errLookup != nil {
 					return nil, err
 				}
 
 				for _, v := range values[1:] {
-					lookup2, errLookup := idx.Get(v)
+					lookup2, errLookup := idx.Get(sql.TimestampFromSession(ctx, e.Left().Type(), v))
 
 					if errLookup != nil {
 						return nil, err
@@ -206,6 +206,18 @@ func getIndexes(
 			indexes: []sql.Index{idx},
 			lookup:  lookup,
 		}
+	case *expression.Match:
+		if e.Index != nil && isEvaluable(e.Against) {
+			lookup, err := e.Lookup(ctx, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			result[e.Index.Table()] = &indexLookup{
+				indexes: []sql.Index{e.Index},
+				lookup:  lookup,
+			}
+		}
 	case *expression.Not:
 		r, err := getNegatedIndexes(ctx, a, ia, e, exprAliases, tableAliases)
 		if err != nil {
@@ -232,8 +244,8 @@ func getIndexes(
 
 				lookup, err := betweenIndexLookup(
 					idx,
-					[]interface{}{upper},
-					[]interface{}{lower},
+					[]interface{}{sql.TimestampFromSession(ctx, e.Val.Type(), upper)},
+					[]interface{}{sql.TimestampFromSession(ctx, e.Val.Type(), lower)},
 				)
 				if err != nil {
 					return nil, err
@@ -361,7 +373,7 @@ func getComparisonIndex(
 				return nil, nil, err
 			}
 
-			lookup, err := comparisonIndexLookup(e, idx, value)
+			lookup, err := comparisonIndexLookup(e, idx, sql.TimestampFromSession(ctx, left.Type(), value))
 			if err != nil || lookup == nil {
 				return nil, nil, err
 			}
@@ -470,7 +482,7 @@ func getNegatedIndexes(
 			return nil, err
 		}
 
-		lookup, err := index.Not(value)
+		lookup, err := index.Not(sql.TimestampFromSession(ctx, left.Type(), value))
 		if err != nil || lookup == nil {
 			return nil, err
 		}
@@ -505,13 +517,13 @@ func getNegatedIndexes(
 					return nil, errInvalidInRightEvaluation.New(value)
 				}
 
-				lookup, errLookup := nidx.Not(values[0])
+				lookup, errLookup := nidx.Not(sql.TimestampFromSession(ctx, e.Left().Type(), values[0]))
 				if errLookup != nil {
 					return nil, err
 				}
 
 				for _, v := range values[1:] {
-					lookup2, errLookup := nidx.Not(v)
+					lookup2, errLookup := nidx.Not(sql.TimestampFromSession(ctx, e.Left().Type(), v))
 					if errLookup != nil {
 						return nil, err
 					}
@@ -700,7 +712,7 @@ func getMultiColumnIndexForExpressions(
 				if err != nil {
 					return
 				}
-				values[i] = val
+				values[i] = sql.TimestampFromSession(ctx, col.col.Type(), val)
 			}
 
 			lookup, err = comparisonIndexLookup(e.(expression.Comparer), index, values...)
@@ -719,6 +731,9 @@ func getMultiColumnIndexForExpressions(
 				if err != nil {
 					return
 				}
+
+				lowers[i] = sql.TimestampFromSession(ctx, col.col.Type(), lowers[i])
+				uppers[i] = sql.TimestampFromSession(ctx, col.col.Type(), uppers[i])
 			}
 
 			lookup, err = betweenIndexLookup(index, uppers, lowers)
@@ -68,6 +68,17 @@ func resolveInsertRows(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope)
 		return nil, err
 	}
 
+	source, err := resolveInsertValues(insertable.Name(), dstSchema, columnNames, insert.Right)
+	if err != nil {
+		return nil, err
+	}
+
+	onDupExprs, err := resolveUpdateExprs(insertable.Name(), dstSchema, insert.OnDupExprs)
+	if err != nil {
+		return nil, err
+	}
+
+	insert = plan.NewInsertInto(insert.Left, source, insert.IsReplace, insert.ColumnNames, onDupExprs)
 	project, err := wrapRowSource(ctx, insert, insertable, columnNames)
 	if err != nil {
 		return nil, err
@@ -81,6 +92,12 @@ func resolveInsertRows(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope)
 func wrapRowSource(ctx *sql.Context, insert *plan.InsertInto, destTbl sql.Table, columnNames []string) (sql.Node, error) {
 	projExprs := make([]sql.Expression, len(destTbl.Schema()))
 	for i, f := range destTbl.Schema() {
+		// Generated columns are computed from the rest of the row once it's projected
+		if f.Generated != nil {
+			projExprs[i] = f.Generated
+			continue
+		}
+
 		found := false
 		for j, col := range columnNames {
 			if f.Name == col {
@@ -159,7 +176,7 @@ func validateValueCount(columnNames []string, values sql.Node) error {
 func assertCompatibleSchemas(projExprs []sql.Expression, schema sql.Schema) error {
 	for _, expr := range projExprs {
 		switch e := expr.(type) {
-		case *expression.Literal:
+		case *expression.Literal, *sql.ColumnDefaultValue:
 			continue
 		case *expression.GetField:
 			otherCol := schema[e.Index()]
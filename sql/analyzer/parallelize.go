@@ -3,8 +3,9 @@ package analyzer
 import (
 	"strconv"
 
-	"github.com/go-kit/kit/metrics/discard"
+	kitmetrics "github.com/go-kit/kit/metrics"
 
+	"github.com/dolthub/go-mysql-server/metrics"
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/plan"
 )
@@ -12,7 +13,7 @@ import (
 var (
 	// ParallelQueryCounter describes a metric that accumulates
 	// number of parallel queries monotonically.
-	ParallelQueryCounter = discard.NewCounter()
+	ParallelQueryCounter kitmetrics.Counter = metrics.NewCounter("gms_parallel_queries_total", "Number of parallelized nodes in the analyzed queries.", "parallelism")
 )
 
 func shouldParallelize(node sql.Node, scope *Scope) bool {
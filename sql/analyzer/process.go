@@ -1,10 +1,21 @@
 package analyzer
 
 import (
+	"sync/atomic"
+
+	kitmetrics "github.com/go-kit/kit/metrics"
+
+	"github.com/dolthub/go-mysql-server/metrics"
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/plan"
 )
 
+var (
+	// RowsReadCounter describes a metric that accumulates the number of rows
+	// read from tables monotonically.
+	RowsReadCounter kitmetrics.Counter = metrics.NewCounter("gms_rows_read_total", "Number of rows read from tables.")
+)
+
 // trackProcess will wrap the query in a process node and add progress items
 // to the already existing process.
 func trackProcess(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
@@ -44,7 +55,10 @@ func trackProcess(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.
 
 			seen[name] = struct{}{}
 
+			// The rows read are added to RowsReadCounter when each partition is done, rather than one by one.
+			var rowsRead int64
 			onPartitionDone := func(partitionName string) {
+				RowsReadCounter.Add(float64(atomic.SwapInt64(&rowsRead, 0)))
 				processList.UpdateTableProgress(ctx.Pid(), name, 1)
 				processList.RemovePartitionProgress(ctx.Pid(), name, partitionName)
 			}
@@ -54,6 +68,7 @@ func trackProcess(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.
 			}
 
 			onRowNext := func(partitionName string) {
+				atomic.AddInt64(&rowsRead, 1)
 				processList.UpdatePartitionProgress(ctx.Pid(), name, partitionName, 1)
 			}
 
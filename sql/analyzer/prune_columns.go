@@ -272,6 +272,12 @@ func fixRemainingFieldsIndexes(n sql.Node, scope *Scope) (sql.Node, error) {
 				schema = append(schema, c.Schema()...)
 			}
 
+			// The condition of a LEFT JOIN with a JSON_TABLE also refers to
+			// its own columns, which follow those of its child.
+			if jt, ok := n.(*plan.JSONTable); ok && jt.Cond != nil {
+				schema = jt.Schema()
+			}
+
 			if len(schema) == 0 {
 				return n, nil
 			}
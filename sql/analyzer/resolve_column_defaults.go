@@ -1,8 +1,6 @@
 package analyzer
 
 import (
-	"strings"
-
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
 	"github.com/dolthub/go-mysql-server/sql/plan"
@@ -427,82 +425,97 @@ func resolveColumnDefaults(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Sco
 		switch node := n.(type) {
 		case *plan.CreateTable, *plan.AddColumn, *plan.ModifyColumn:
 			sch := node.Schema()
-			var columns = make(map[string]indexedCol)
-			for i, col := range sch {
-				columns[strings.ToLower(col.Name)] = indexedCol{col, i}
-			}
-			newDefaults := make([]sql.Expression, len(sch))
-
+			// The default values of the columns come first, followed by their generated expressions
+			newExprs := make([]sql.Expression, 2*len(sch))
 			for i, col := range sch {
-				newDefault := col.Default
-				if col.Default.Resolved() {
-					newDefaults[i] = expression.WrapExpression(newDefault)
-					continue
-				}
-				newDefault = &(*newDefault)
-				if sql.IsTextBlob(col.Type) && newDefault.IsLiteral() {
-					return nil, sql.ErrInvalidTextBlobColumnDefault.New()
-				}
-				var err error
-				newDefault.Expression, err = expression.TransformUp(newDefault.Expression, func(e sql.Expression) (sql.Expression, error) {
-					if expr, ok := e.(*expression.GetField); ok {
-						// Default values can only reference their host table, so we can remove the table name, removing
-						// the necessity to update default values on table renames.
-						return expr.WithTable(""), nil
-					}
-					return e, nil
-				})
+				newDefault, err := resolveColumnDefault(col, col.Default)
 				if err != nil {
 					return nil, err
 				}
-				sql.Inspect(newDefault.Expression, func(e sql.Expression) bool {
-					switch expr := e.(type) {
-					case sql.FunctionExpression:
-						funcName := expr.FunctionName()
-						if _, isValid := validColumnDefaultFuncs[funcName]; !isValid {
-							err = sql.ErrInvalidColumnDefaultFunction.New(funcName, col.Name)
-							return false
-						}
-						if (funcName == "now" || funcName == "current_timestamp") &&
-							newDefault.IsLiteral() &&
-							(!sql.IsTime(col.Type) || sql.Date == col.Type) {
-							err = sql.ErrColumnDefaultDatetimeOnlyFunc.New()
-							return false
-						}
-						return true
-					case *plan.Subquery:
-						err = sql.ErrColumnDefaultSubquery.New(col.Name)
-						return false
-					default:
-						return true
-					}
-				})
-				if err != nil {
-					return nil, err
-				}
-				//TODO: fix the vitess parser so that it parses negative numbers as numbers and not negation of an expression
-				isLiteral := newDefault.IsLiteral()
-				if unaryMinusExpr, ok := newDefault.Expression.(*expression.UnaryMinus); ok {
-					if literalExpr, ok := unaryMinusExpr.Child.(*expression.Literal); ok {
-						switch val := literalExpr.Value().(type) {
-						case float32:
-							newDefault.Expression = expression.NewLiteral(-val, sql.Float32)
-							isLiteral = true
-						case float64:
-							newDefault.Expression = expression.NewLiteral(-val, sql.Float64)
-							isLiteral = true
-						}
-					}
-				}
-				newDefault, err = sql.NewColumnDefaultValue(newDefault.Expression, col.Type, isLiteral, col.Nullable)
+				newExprs[i] = expression.WrapExpression(newDefault)
+
+				newGenerated, err := resolveColumnDefault(col, col.Generated)
 				if err != nil {
 					return nil, err
 				}
-				newDefaults[i] = expression.WrapExpression(newDefault)
+				newExprs[len(sch)+i] = expression.WrapExpression(newGenerated)
 			}
-			return node.(sql.Expressioner).WithExpressions(newDefaults...)
+			return node.(sql.Expressioner).WithExpressions(newExprs...)
 		default:
 			return node, nil
 		}
 	})
 }
+
+// resolveColumnDefault validates the given default value or generated expression of a column, and returns it with the
+// type of the column.
+func resolveColumnDefault(col *sql.Column, colDefault *sql.ColumnDefaultValue) (*sql.ColumnDefaultValue, error) {
+	if colDefault.Resolved() {
+		return colDefault, nil
+	}
+	newDefault := &(*colDefault)
+	if sql.IsTextBlob(col.Type) && newDefault.IsLiteral() {
+		return nil, sql.ErrInvalidTextBlobColumnDefault.New()
+	}
+	var err error
+	newDefault.Expression, err = expression.TransformUp(newDefault.Expression, func(e sql.Expression) (sql.Expression, error) {
+		if expr, ok := e.(*expression.GetField); ok {
+			// Default values can only reference their host table, so we can remove the table name, removing
+			// the necessity to update default values on table renames.
+			return expr.WithTable(""), nil
+		}
+		return e, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sql.Inspect(newDefault.Expression, func(e sql.Expression) bool {
+		switch expr := e.(type) {
+		case sql.FunctionExpression:
+			funcName := expr.FunctionName()
+			if _, isValid := validColumnDefaultFuncs[funcName]; !isValid {
+				err = sql.ErrInvalidColumnDefaultFunction.New(funcName, col.Name)
+				return false
+			}
+			if (funcName == "now" || funcName == "current_timestamp") &&
+				newDefault.IsLiteral() &&
+				(!sql.IsTime(col.Type) || sql.Date == col.Type) {
+				err = sql.ErrColumnDefaultDatetimeOnlyFunc.New()
+				return false
+			}
+			return true
+		case *plan.Subquery:
+			err = sql.ErrColumnDefaultSubquery.New(col.Name)
+			return false
+		default:
+			return true
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	//TODO: fix the vitess parser so that it parses negative numbers as numbers and not negation of an expression
+	isLiteral := newDefault.IsLiteral()
+	if unaryMinusExpr, ok := newDefault.Expression.(*expression.UnaryMinus); ok {
+		if literalExpr, ok := unaryMinusExpr.Child.(*expression.Literal); ok {
+			switch val := literalExpr.Value().(type) {
+			case float32:
+				newDefault.Expression = expression.NewLiteral(-val, sql.Float32)
+				isLiteral = true
+			case float64:
+				newDefault.Expression = expression.NewLiteral(-val, sql.Float64)
+				isLiteral = true
+			case string:
+				if dt, ok := literalExpr.Type().(sql.DecimalType); ok {
+					dec, err := dt.ConvertToDecimal(val)
+					if err != nil {
+						return nil, err
+					}
+					newDefault.Expression = expression.NewLiteral(dt.MustConvert(dec.Decimal.Neg()), dt)
+					isLiteral = true
+				}
+			}
+		}
+	}
+	return sql.NewColumnDefaultValue(newDefault.Expression, col.Type, isLiteral, col.Nullable)
+}
@@ -264,6 +264,11 @@ func getNodeAvailableNames(n sql.Node, scope *Scope) availableNames {
 					names.indexTable(alias, name, i)
 				}
 				return false
+			case *plan.JSONTable:
+				// The tables that precede a JSON_TABLE are its child.
+				name := strings.ToLower(n.Name())
+				names.indexTable(name, name, i)
+				return true
 			}
 
 			return true
@@ -399,6 +404,10 @@ func getColumnsInNodes(nodes []sql.Node, names availableNames, nestingLevel int)
 			indexExpressions(n.Projections)
 		case *plan.GroupBy:
 			indexExpressions(n.SelectedExprs)
+		case *plan.JSONTable:
+			for _, col := range n.Schema() {
+				names.indexColumn(col.Source, col.Name, nestingLevel)
+			}
 		default:
 			getColumnsInNodes(n.Children(), names, nestingLevel)
 		}
@@ -427,6 +436,7 @@ var errGlobalVariablesNotSupported = errors.NewKind("can't resolve global variab
 const (
 	sessionTable  = "@@" + sqlparser.SessionStr
 	sessionPrefix = sqlparser.SessionStr + "."
+	globalTable   = "@@" + sqlparser.GlobalStr
 	globalPrefix  = sqlparser.GlobalStr + "."
 )
 
@@ -534,6 +544,10 @@ func indexColumns(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) map[t
 	}
 
 	switch node := n.(type) {
+	case *plan.JSONTable: // The condition of a LEFT JOIN with a JSON_TABLE also refers to its own columns.
+		if node.Cond != nil {
+			indexSchema(node.Schema()[len(schemas(node.Children())):])
+		}
 	case *plan.CreateTable: // For this node in particular, the columns will only come into existence after the analyzer step, so we forge them here.
 		for _, col := range node.Schema() {
 			columns[tableCol{
@@ -564,6 +578,21 @@ func indexColumns(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) map[t
 }
 
 func resolveSystemVariable(ctx *sql.Context, a *Analyzer, col column) (sql.Expression, error) {
+	if isGlobalVariable(col) {
+		name := trimVarName(col.Name())
+		if !sql.IsGlobalVariable(name) {
+			return nil, errGlobalVariablesNotSupported.New(col)
+		}
+
+		typ, _, err := sql.GetGlobal(name)
+		if err != nil {
+			return nil, err
+		}
+
+		a.Log("resolved column %s to global system variable (type %s)", col, typ)
+		return expression.NewGlobalSystemVar(name, typ), nil
+	}
+
 	if col.Table() != "" && strings.ToLower(col.Table()) != sessionTable {
 		return nil, errGlobalVariablesNotSupported.New(col)
 	}
@@ -784,6 +813,12 @@ func isSystemVariable(col column) bool {
 	return strings.HasPrefix(col.Name(), "@@") || strings.HasPrefix(col.Table(), "@@")
 }
 
+// isGlobalVariable returns whether the column refers to the global value of a system variable.
+func isGlobalVariable(col column) bool {
+	return strings.ToLower(col.Table()) == globalTable ||
+		strings.HasPrefix(strings.ToLower(col.Name()), "@@"+globalPrefix)
+}
+
 func isUserVariable(col column) bool {
 	return !isSystemVariable(col) &&
 		(strings.HasPrefix(col.Name(), "@") || strings.HasPrefix(col.Table(), "@"))
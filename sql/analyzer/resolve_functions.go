@@ -1,11 +1,18 @@
 package analyzer
 
 import (
+	"gopkg.in/src-d/go-errors.v1"
+
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
 	"github.com/dolthub/go-mysql-server/sql/plan"
 )
 
+// ErrDistinctNonAggregation is returned when DISTINCT is used in a function
+// that is not an aggregation.
+var ErrDistinctNonAggregation = errors.NewKind("DISTINCT is not supported by function %s")
+
 // resolveFunctions replaces UnresolvedFunction nodes with equivalent functions from the Catalog.
 func resolveFunctions(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
 	span, _ := ctx.Span("resolve_functions")
@@ -42,6 +49,14 @@ func resolveFunctionsInExpr(a *Analyzer) sql.TransformExprFunc {
 			return nil, err
 		}
 
+		if uf.Distinct {
+			agg, ok := rf.(sql.Aggregation)
+			if !ok {
+				return nil, ErrDistinctNonAggregation.New(n)
+			}
+			rf = aggregation.NewDistinct(agg)
+		}
+
 		a.Log("resolved function %q", n)
 		return rf, nil
 	}
@@ -430,6 +430,13 @@ func aggregationEquals(a, b sql.Expression) bool {
 		}
 
 		return aggregationChildEquals(a.Child, b.Child)
+	case *aggregation.Distinct:
+		b, ok := b.(*aggregation.Distinct)
+		if !ok {
+			return false
+		}
+
+		return aggregationEquals(a.Aggregation(), b.Aggregation())
 	default:
 		return false
 	}
@@ -58,6 +58,15 @@ func resolveSetVariables(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope
 		// These are all equivalent, and all distinct from setting a user variable with the same name:
 		// set @sql_mode = "abc"
 		if uc, ok := sf.Left.(*expression.UnresolvedColumn); ok {
+			if isGlobalVariable(uc) {
+				typ, _, err := sql.GetGlobal(varName)
+				if err != nil {
+					return nil, err
+				}
+
+				return sf.WithChildren(expression.NewGlobalSystemVar(varName, typ), setVal)
+			}
+
 			if isSystemVariable(uc) {
 				// TODO: clean up distinction between system and user vars in this interface
 				typ, _ := ctx.Session.Get(varName)
@@ -41,22 +41,29 @@ var DefaultRules = []Rule{
 // OnceAfterDefault contains the rules to be applied just once after the
 // DefaultRules.
 var OnceAfterDefault = []Rule{
+	// Grouped queries are validated before the filters are pushed down, since
+	// the equalities in them make more columns functionally dependent on the
+	// grouping ones.
+	{validateGroupByRule, validateGroupBy},
 	{"load_triggers", loadTriggers},
 	{"resolve_column_defaults", resolveColumnDefaults},
 	{"resolve_generators", resolveGenerators},
 	{"remove_unnecessary_converts", removeUnnecessaryConverts},
 	{"assign_catalog", assignCatalog},
 	{"assign_info_schema", assignInfoSchema},
+	{"resolve_fulltext_indexes", resolveFullTextIndexes},
 	{"prune_columns", pruneColumns},
 	{"optimize_joins", optimizeJoins},
 	{"pushdown_filters", pushdownFilters},
 	{"subquery_indexes", applyIndexesFromOuterScope},
+	{"erase_relevance_sort", eraseRelevanceSort},
 	{"pushdown_projections", pushdownProjections},
 	{"erase_projection", eraseProjection},
 	// One final pass at analyzing subqueries to handle rewriting field indexes after changes to outer scope by
 	// previous rules.
 	{"resolve_subquery_exprs", resolveSubqueryExpressions},
 	{"cache_subquery_results", cacheSubqueryResults},
+	{"resolve_generated_column_updates", resolveGeneratedColumnUpdates},
 	{"resolve_insert_rows", resolveInsertRows},
 	{"apply_triggers", applyTriggers},
 	{"apply_row_update_accumulators", applyUpdateAccumulators},
@@ -35,6 +35,9 @@ var (
 	ErrValidationOrderBy = errors.NewKind("OrderBy does not support aggregation expressions")
 	// ErrValidationGroupBy is returned when the aggregation expression does not
 	// appear in the grouping columns.
+	//
+	// Deprecated: grouped queries are validated according to ONLY_FULL_GROUP_BY,
+	// which returns sql.ErrWrongFieldWithGroup instead.
 	ErrValidationGroupBy = errors.NewKind("GroupBy aggregate expression '%v' doesn't appear in the grouping columns")
 	// ErrValidationSchemaSource is returned when there is any column source
 	// that does not match the table name.
@@ -86,7 +89,6 @@ var (
 var DefaultValidationRules = []Rule{
 	{validateResolvedRule, validateIsResolved},
 	{validateOrderByRule, validateOrderBy},
-	{validateGroupByRule, validateGroupBy},
 	{validateSchemaSourceRule, validateSchemaSource},
 	{validateProjectTuplesRule, validateProjectTuples},
 	{validateIndexCreationRule, validateIndexCreation},
@@ -154,51 +156,6 @@ func validateOrderBy(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (s
 	return n, nil
 }
 
-func validateGroupBy(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
-	span, _ := ctx.Span("validate_group_by")
-	defer span.Finish()
-
-	switch n := n.(type) {
-	case *plan.GroupBy:
-		// Allow the parser use the GroupBy node to eval the aggregation functions
-		// for sql statements that don't make use of the GROUP BY expression.
-		if len(n.GroupByExprs) == 0 {
-			return n, nil
-		}
-
-		var validAggs []string
-		for _, expr := range n.GroupByExprs {
-			validAggs = append(validAggs, expr.String())
-		}
-
-		// TODO: validate columns inside aggregations
-		// and allow any kind of expression that make use of the grouping
-		// columns.
-		for _, expr := range n.SelectedExprs {
-			if _, ok := expr.(sql.Aggregation); !ok {
-				if !isValidAgg(validAggs, expr) {
-					return nil, ErrValidationGroupBy.New(expr.String())
-				}
-			}
-		}
-
-		return n, nil
-	}
-
-	return n, nil
-}
-
-func isValidAgg(validAggs []string, expr sql.Expression) bool {
-	switch expr := expr.(type) {
-	case sql.Aggregation:
-		return true
-	case *expression.Alias:
-		return isValidAgg(validAggs, expr.Child)
-	default:
-		return stringContains(validAggs, expr.String())
-	}
-}
-
 func validateSchemaSource(ctx *sql.Context, a *Analyzer, n sql.Node, scope *Scope) (sql.Node, error) {
 	span, _ := ctx.Span("validate_schema_source")
 	defer span.Finish()
@@ -3,6 +3,8 @@ package analyzer
 import (
 	"testing"
 
+	"gopkg.in/src-d/go-errors.v1"
+
 	"github.com/dolthub/go-mysql-server/memory"
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
@@ -45,7 +47,7 @@ func TestValidateOrderBy(t *testing.T) {
 func TestValidateGroupBy(t *testing.T) {
 	require := require.New(t)
 
-	vr := getValidationRule(validateGroupByRule)
+	vr := getRule(validateGroupByRule)
 
 	_, err := vr.Apply(sql.NewEmptyContext(), nil, dummyNode{true}, nil)
 	require.NoError(err)
@@ -90,7 +92,7 @@ func TestValidateGroupBy(t *testing.T) {
 func TestValidateGroupByErr(t *testing.T) {
 	require := require.New(t)
 
-	vr := getValidationRule(validateGroupByRule)
+	vr := getRule(validateGroupByRule)
 
 	_, err := vr.Apply(sql.NewEmptyContext(), nil, dummyNode{true}, nil)
 	require.NoError(err)
@@ -129,6 +131,120 @@ func TestValidateGroupByErr(t *testing.T) {
 
 	_, err = vr.Apply(sql.NewEmptyContext(), nil, p, nil)
 	require.Error(err)
+	require.True(sql.ErrWrongFieldWithGroup.Is(err))
+
+	ctx := sql.NewEmptyContext()
+	require.NoError(ctx.Set(ctx, "sql_mode", sql.LongText, "STRICT_TRANS_TABLES"))
+	_, err = vr.Apply(ctx, nil, p, nil)
+	require.NoError(err)
+}
+
+func TestValidateGroupByFunctionalDependence(t *testing.T) {
+	table := memory.NewTable("t", sql.Schema{
+		{Name: "pk", Type: sql.Int64, Source: "t", PrimaryKey: true},
+		{Name: "a", Type: sql.Int64, Source: "t"},
+		{Name: "b", Type: sql.Int64, Source: "t"},
+	})
+	pk := expression.NewGetFieldWithTable(0, sql.Int64, "t", "pk", false)
+	a := expression.NewGetFieldWithTable(1, sql.Int64, "t", "a", true)
+	b := expression.NewGetFieldWithTable(2, sql.Int64, "t", "b", true)
+	count := aggregation.NewCount(expression.NewStar())
+
+	testCases := []struct {
+		name     string
+		node     sql.Node
+		expected *errors.Kind
+	}{
+		{
+			"grouping column",
+			plan.NewGroupBy([]sql.Expression{a, count}, []sql.Expression{a}, plan.NewResolvedTable(table)),
+			nil,
+		},
+		{
+			"expression of grouping columns",
+			plan.NewGroupBy(
+				[]sql.Expression{expression.NewAlias("x", expression.NewArithmetic(a, b, "+"))},
+				[]sql.Expression{b, a},
+				plan.NewResolvedTable(table),
+			),
+			nil,
+		},
+		{
+			"nonaggregated column",
+			plan.NewGroupBy([]sql.Expression{a, b}, []sql.Expression{a}, plan.NewResolvedTable(table)),
+			sql.ErrWrongFieldWithGroup,
+		},
+		{
+			"primary key",
+			plan.NewGroupBy([]sql.Expression{a, b}, []sql.Expression{pk}, plan.NewResolvedTable(table)),
+			nil,
+		},
+		{
+			"column equal to a constant",
+			plan.NewGroupBy(
+				[]sql.Expression{a, b},
+				[]sql.Expression{a},
+				plan.NewFilter(
+					expression.NewEquals(b, expression.NewLiteral(int64(1), sql.Int64)),
+					plan.NewResolvedTable(table),
+				),
+			),
+			nil,
+		},
+		{
+			"column equal to a grouping column",
+			plan.NewGroupBy(
+				[]sql.Expression{a, b},
+				[]sql.Expression{a},
+				plan.NewFilter(expression.NewEquals(a, b), plan.NewResolvedTable(table)),
+			),
+			nil,
+		},
+		{
+			"column compared to a constant",
+			plan.NewGroupBy(
+				[]sql.Expression{a, b},
+				[]sql.Expression{a},
+				plan.NewFilter(
+					expression.NewGreaterThan(b, expression.NewLiteral(int64(1), sql.Int64)),
+					plan.NewResolvedTable(table),
+				),
+			),
+			sql.ErrWrongFieldWithGroup,
+		},
+		{
+			"aggregation without grouping",
+			plan.NewGroupBy([]sql.Expression{count, a}, nil, plan.NewResolvedTable(table)),
+			sql.ErrMixOfGroupFuncAndFields,
+		},
+		{
+			"hidden having column",
+			plan.NewProject(
+				[]sql.Expression{expression.NewGetFieldWithTable(0, sql.Int64, "t", "a", true)},
+				plan.NewHaving(
+					expression.NewGreaterThan(
+						expression.NewGetFieldWithTable(1, sql.Int64, "t", "b", true),
+						expression.NewLiteral(int64(1), sql.Int64),
+					),
+					plan.NewGroupBy([]sql.Expression{a, b}, []sql.Expression{a}, plan.NewResolvedTable(table)),
+				),
+			),
+			sql.ErrWrongFieldWithGroup,
+		},
+	}
+
+	rule := getRule(validateGroupByRule)
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := rule.Apply(sql.NewEmptyContext(), nil, tt.node, nil)
+			if tt.expected == nil {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.True(t, tt.expected.Is(err), "unexpected error: %s", err)
+			}
+		})
+	}
 }
 
 func TestValidateSchemaSource(t *testing.T) {
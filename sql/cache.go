@@ -22,6 +22,37 @@ func HashOf(v Row) (uint64, error) {
 	return hash.Sum64(), nil
 }
 
+// HashOfTyped returns a hash of the given row as HashOf does, but the strings
+// in columns of the given types are hashed by their sort key in the collation
+// of their type, so rows that are equal according to their collations get the
+// same hash.
+func HashOfTyped(v Row, types []Type) (uint64, error) {
+	key := make(Row, len(v))
+	for i, x := range v {
+		if i < len(types) {
+			key[i] = CollationKey(types[i], x)
+		} else {
+			key[i] = x
+		}
+	}
+	return HashOf(key)
+}
+
+// CollationKey returns the value that must be used in place of the given one
+// of the given type when it's hashed or used as a map key. Strings of string
+// types are replaced by their sort key in the collation of the type, other
+// values are returned as is.
+func CollationKey(t Type, v interface{}) interface{} {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+	if st, ok := t.(StringType); ok {
+		return st.Collation().WeightString(s)
+	}
+	return v
+}
+
 // ErrKeyNotFound is returned when the key could not be found in the cache.
 var ErrKeyNotFound = errors.NewKind("memory: key %d not found in cache")
 
@@ -28,6 +28,7 @@ type Catalog struct {
 	FunctionRegistry
 	*ProcessList
 	*MemoryManager
+	*StatusVariables
 
 	mu    sync.RWMutex
 	dbs   Databases
@@ -46,6 +47,7 @@ func NewCatalog() *Catalog {
 		FunctionRegistry: NewFunctionRegistry(),
 		MemoryManager:    NewMemoryManager(ProcessMemory),
 		ProcessList:      NewProcessList(),
+		StatusVariables:  NewStatusVariables(),
 		locks:            make(sessionLocks),
 	}
 }
@@ -1166,6 +1166,7 @@ var (
 
 	ErrCharacterSetNotSupported = errors.NewKind("Unknown character set: %v")
 	ErrCollationNotSupported    = errors.NewKind("Unknown collation: %v")
+	ErrCollationUnsupported     = errors.NewKind("Unsupported collation: %v")
 )
 
 const (
@@ -0,0 +1,332 @@
+package sql
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// collationRules describe how the strings of a collation are compared.
+type collationRules struct {
+	// binary collations compare the bytes of the strings.
+	binary bool
+	// padSpace collations ignore trailing spaces.
+	padSpace bool
+	// fold returns the primary weight of a character, that is, the character
+	// with its case and accents removed. Characters with the same primary
+	// weight are equal in accent and case insensitive collations. It's nil for
+	// binary collations.
+	fold func(r rune) rune
+	// expand is whether ligatures weigh as the letters they are made of.
+	expand bool
+	// accentSensitive collations compare the accents of the characters with
+	// the same primary weight.
+	accentSensitive bool
+	// caseSensitive collations compare the case of the characters with the
+	// same primary weight and accents. Lowercase sorts first.
+	caseSensitive bool
+	// upper is whether the primary weight of a letter is its uppercase form
+	// instead of the lowercase one.
+	upper bool
+}
+
+var (
+	binaryRules    = collationRules{binary: true}
+	padBinaryRules = collationRules{binary: true, padSpace: true}
+	generalRules   = collationRules{fold: foldGeneral, padSpace: true, upper: true}
+)
+
+// collationRuleSets are the rules of the collations strings can be compared
+// in. Columns can't be declared with other collations.
+var collationRuleSets = map[Collation]collationRules{
+	Collation_binary:             binaryRules,
+	Collation_utf8mb4_0900_bin:   binaryRules,
+	Collation_utf8mb4_bin:        padBinaryRules,
+	Collation_utf8mb3_bin:        padBinaryRules,
+	Collation_utf16_bin:          padBinaryRules,
+	Collation_latin1_bin:         padBinaryRules,
+	Collation_ascii_bin:          padBinaryRules,
+	Collation_utf8mb4_0900_ai_ci: {fold: foldUnicode, expand: true},
+	Collation_utf8mb4_0900_as_ci: {fold: foldUnicode, expand: true, accentSensitive: true},
+	Collation_utf8mb4_0900_as_cs: {fold: foldUnicode, expand: true, accentSensitive: true, caseSensitive: true},
+	Collation_utf8mb4_unicode_ci: {fold: foldUnicode, expand: true, padSpace: true},
+	Collation_utf8mb3_unicode_ci: {fold: foldUnicode, expand: true, padSpace: true},
+	Collation_utf8mb4_general_ci: generalRules,
+	Collation_utf8mb3_general_ci: generalRules,
+	Collation_utf8_general_ci:    generalRules,
+	Collation_utf16_general_ci:   generalRules,
+	Collation_ascii_general_ci:   generalRules,
+	Collation_latin1_swedish_ci:  {fold: foldSwedish, padSpace: true, upper: true},
+}
+
+// IsSupported returns whether strings can be compared in the collation.
+func (c Collation) IsSupported() bool {
+	_, ok := collationRuleSets[c]
+	return ok
+}
+
+// rules returns the rules used to compare strings with the collation. The
+// unsupported collations, which only the types created internally can have,
+// compare the bytes of the strings.
+func (c Collation) rules() collationRules {
+	if r, ok := collationRuleSets[c]; ok {
+		return r
+	}
+	return padBinaryRules
+}
+
+// IsCaseSensitive returns whether the collation distinguishes characters that
+// only differ in their case.
+func (c Collation) IsCaseSensitive() bool {
+	r := c.rules()
+	return r.binary || r.caseSensitive
+}
+
+// Compare compares two strings according to the collation. It returns 0 if
+// they are equal, -1 if a sorts before b and 1 otherwise.
+func (c Collation) Compare(a, b string) int {
+	r := c.rules()
+	if r.padSpace {
+		a, b = strings.TrimRight(a, " "), strings.TrimRight(b, " ")
+	}
+
+	if r.binary {
+		return strings.Compare(a, b)
+	}
+
+	if !r.accentSensitive && isASCII(a) && isASCII(b) {
+		return compareFoldedASCII(a, b, r.upper)
+	}
+
+	return strings.Compare(r.weightString(a), r.weightString(b))
+}
+
+// WeightString returns the sort key of the given string in the collation. Two
+// strings are equal in the collation if and only if their sort keys are
+// equal, and they sort in the byte order of their sort keys, so the sort key
+// can be used to hash or index strings.
+func (c Collation) WeightString(s string) string {
+	r := c.rules()
+	if r.padSpace {
+		s = strings.TrimRight(s, " ")
+	}
+
+	if r.binary {
+		return s
+	}
+
+	return r.weightString(s)
+}
+
+// Fold returns the given string with every character replaced by its primary
+// weight if the collation is accent and case insensitive, or by its lowercase
+// form if it's only case insensitive, so that characters which are equal in
+// the collation are equal in the folded form. Case sensitive collations return
+// the string as is. Unlike WeightString, characters are folded one by one,
+// without expansions and keeping trailing spaces, so the result can be used
+// for pattern matching.
+func (c Collation) Fold(s string) string {
+	r := c.rules()
+	switch {
+	case r.binary || r.caseSensitive:
+		return s
+	case r.accentSensitive:
+		return strings.Map(unicode.ToLower, s)
+	default:
+		return strings.Map(r.fold, s)
+	}
+}
+
+// primaryWeights returns the primary weights of the characters in the given
+// string.
+func (r collationRules) primaryWeights(s string) string {
+	if !r.expand {
+		return strings.Map(r.fold, s)
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(s))
+	for _, c := range s {
+		if exp, ok := expansions[c]; ok {
+			sb.WriteString(exp)
+		} else {
+			sb.WriteRune(r.fold(c))
+		}
+	}
+	return sb.String()
+}
+
+// weightString builds the sort key of a string for a non binary collation.
+// It's made of the primary weights of its characters, followed by their
+// accents and case when the collation is sensitive to them.
+func (r collationRules) weightString(s string) string {
+	primary := r.primaryWeights(s)
+	if !r.accentSensitive && !r.caseSensitive {
+		return primary
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(primary) + 2*len(s) + 2)
+	sb.WriteString(primary)
+
+	sb.WriteByte(0)
+	for _, c := range s {
+		sb.WriteRune(unicode.ToLower(c))
+	}
+
+	if r.caseSensitive {
+		sb.WriteByte(0)
+		for _, c := range s {
+			if unicode.IsUpper(c) {
+				sb.WriteByte(2)
+			} else {
+				sb.WriteByte(1)
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// compareFoldedASCII compares two ASCII strings by the primary weight of their
+// characters without allocating.
+func compareFoldedASCII(a, b string, upper bool) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		ca, cb := foldASCII(a[i], upper), foldASCII(b[i], upper)
+		if ca != cb {
+			if ca < cb {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func foldASCII(c byte, upper bool) byte {
+	if upper && 'a' <= c && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	if !upper && 'A' <= c && c <= 'Z' {
+		return c - 'A' + 'a'
+	}
+	return c
+}
+
+// baseLetters maps the uppercase latin letters with diacritics to the letter
+// without them.
+var baseLetters = func() map[rune]rune {
+	letters := map[rune]string{
+		'A': "ÀÁÂÃÄÅĀĂĄǍ",
+		'C': "ÇĆĈĊČ",
+		'D': "ĎĐ",
+		'E': "ÈÉÊËĒĔĖĘĚ",
+		'G': "ĜĞĠĢ",
+		'H': "ĤĦ",
+		'I': "ÌÍÎÏĨĪĬĮİǏ",
+		'J': "Ĵ",
+		'K': "Ķ",
+		'L': "ĹĻĽĿŁ",
+		'N': "ÑŃŅŇ",
+		'O': "ÒÓÔÕÖØŌŎŐǑ",
+		'R': "ŔŖŘ",
+		'S': "ŚŜŞŠ",
+		'T': "ŢŤŦ",
+		'U': "ÙÚÛÜŨŪŬŮŰŲǓ",
+		'W': "Ŵ",
+		'Y': "ÝŶŸ",
+		'Z': "ŹŻŽ",
+	}
+
+	m := make(map[rune]rune)
+	for base, chars := range letters {
+		for _, c := range chars {
+			m[c] = base
+			m[unicode.ToLower(c)] = unicode.ToLower(base)
+		}
+	}
+	return m
+}()
+
+// removeAccent returns the given letter without diacritics.
+func removeAccent(r rune) rune {
+	if r < utf8.RuneSelf {
+		return r
+	}
+	if base, ok := baseLetters[r]; ok {
+		return base
+	}
+	return r
+}
+
+// expansions are the characters that weigh as several letters in the
+// utf8mb4_0900 collations.
+var expansions = map[rune]string{
+	'ß': "ss",
+	'ẞ': "ss",
+	'Æ': "ae",
+	'æ': "ae",
+	'Œ': "oe",
+	'œ': "oe",
+}
+
+// foldUnicode folds a character as the utf8mb4_0900 collations do: case and
+// accents are removed and letters are compared in lowercase.
+func foldUnicode(r rune) rune {
+	if r < utf8.RuneSelf {
+		return rune(foldASCII(byte(r), false))
+	}
+	return unicode.ToLower(removeAccent(r))
+}
+
+// foldGeneral folds a character as utf8mb4_general_ci does: case and accents
+// are removed and letters are compared in uppercase.
+func foldGeneral(r rune) rune {
+	if r < utf8.RuneSelf {
+		return rune(foldASCII(byte(r), true))
+	}
+
+	switch r {
+	case 'ß':
+		return 'S'
+	case 'Æ', 'æ':
+		return 'A'
+	default:
+		return unicode.ToUpper(removeAccent(r))
+	}
+}
+
+// foldSwedish folds a character as latin1_swedish_ci does. It's like
+// utf8mb4_general_ci, but Å, Ä and Ö are distinct letters that sort after Z,
+// Æ and Ø are equal to Ä and Ö, and Ü is equal to Y.
+func foldSwedish(r rune) rune {
+	switch r {
+	case 'Å', 'å':
+		return '['
+	case 'Ä', 'ä', 'Æ', 'æ':
+		return '\\'
+	case 'Ö', 'ö', 'Ø', 'ø':
+		return ']'
+	case 'Ü', 'ü':
+		return 'Y'
+	default:
+		return foldGeneral(r)
+	}
+}
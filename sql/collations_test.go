@@ -0,0 +1,122 @@
+package sql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollationCompare(t *testing.T) {
+	tests := []struct {
+		collation Collation
+		a, b      string
+		expected  int
+	}{
+		{Collation_binary, "a", "A", 1},
+		{Collation_binary, "a", "a ", -1},
+		{Collation_utf8mb4_bin, "a", "A", 1},
+		{Collation_utf8mb4_bin, "a", "a ", 0},
+		{Collation_utf8mb4_bin, "a", "á", -1},
+		{Collation_utf8mb4_0900_bin, "a", "a ", -1},
+
+		{Collation_utf8mb4_0900_ai_ci, "a", "A", 0},
+		{Collation_utf8mb4_0900_ai_ci, "a", "á", 0},
+		{Collation_utf8mb4_0900_ai_ci, "résumé", "RESUME", 0},
+		{Collation_utf8mb4_0900_ai_ci, "straße", "STRASSE", 0},
+		{Collation_utf8mb4_0900_ai_ci, "æ", "ae", 0},
+		{Collation_utf8mb4_0900_ai_ci, "a", "a ", -1},
+		{Collation_utf8mb4_0900_ai_ci, "ñ", "o", -1},
+		{Collation_utf8mb4_0900_ai_ci, "B", "a", 1},
+		{Collation_utf8mb4_0900_ai_ci, "a", "_", 1},
+
+		{Collation_utf8mb4_0900_as_cs, "a", "A", -1},
+		{Collation_utf8mb4_0900_as_cs, "a", "á", -1},
+		{Collation_utf8mb4_0900_as_cs, "A", "b", -1},
+		{Collation_utf8mb4_0900_as_cs, "á", "b", -1},
+		{Collation_utf8mb4_0900_as_cs, "abc", "abc", 0},
+
+		{Collation_utf8mb4_unicode_ci, "straße", "STRASSE", 0},
+		{Collation_utf8mb4_unicode_ci, "a", "a ", 0},
+
+		{Collation_utf8mb4_general_ci, "a", "A", 0},
+		{Collation_utf8mb4_general_ci, "a", "á", 0},
+		{Collation_utf8mb4_general_ci, "ß", "s", 0},
+		{Collation_utf8mb4_general_ci, "a", "a ", 0},
+		{Collation_utf8mb4_general_ci, "a", "_", -1},
+
+		{Collation_latin1_swedish_ci, "a", "A", 0},
+		{Collation_latin1_swedish_ci, "é", "E", 0},
+		{Collation_latin1_swedish_ci, "ü", "y", 0},
+		{Collation_latin1_swedish_ci, "å", "z", 1},
+		{Collation_latin1_swedish_ci, "å", "ä", -1},
+		{Collation_latin1_swedish_ci, "ä", "ö", -1},
+		{Collation_latin1_swedish_ci, "æ", "Ä", 0},
+		{Collation_latin1_swedish_ci, "a ", "A", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %q %q", tt.collation, tt.a, tt.b), func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.collation.Compare(tt.a, tt.b))
+			assert.Equal(t, -tt.expected, tt.collation.Compare(tt.b, tt.a))
+
+			wa, wb := tt.collation.WeightString(tt.a), tt.collation.WeightString(tt.b)
+			switch {
+			case tt.expected == 0:
+				assert.Equal(t, wa, wb)
+			case tt.expected < 0:
+				assert.True(t, wa < wb)
+			default:
+				assert.True(t, wa > wb)
+			}
+		})
+	}
+}
+
+func TestCollationIsSupported(t *testing.T) {
+	for _, c := range []Collation{Collation_binary, Collation_Default, Collation_utf8mb4_0900_as_cs, Collation_utf8mb4_general_ci, Collation_latin1_swedish_ci} {
+		assert.True(t, c.IsSupported(), c.String())
+	}
+	for _, c := range []Collation{Collation_utf8mb4_de_pb_0900_ai_ci, Collation_tis620_thai_ci, Collation_cp1251_bin} {
+		assert.False(t, c.IsSupported(), c.String())
+	}
+}
+
+func TestCollationFold(t *testing.T) {
+	tests := []struct {
+		collation Collation
+		s         string
+		expected  string
+	}{
+		{Collation_binary, "Ábc", "Ábc"},
+		{Collation_utf8mb4_bin, "Ábc ", "Ábc "},
+		{Collation_utf8mb4_0900_as_cs, "Ábc", "Ábc"},
+		{Collation_utf8mb4_0900_as_ci, "ÁbC", "ábc"},
+		{Collation_utf8mb4_0900_ai_ci, "Ábc ", "abc "},
+		{Collation_utf8mb4_0900_ai_ci, "Straße%", "straße%"},
+		{Collation_utf8mb4_general_ci, "Ábc_", "ABC_"},
+		{Collation_latin1_swedish_ci, "Åsa", "[SA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %q", tt.collation, tt.s), func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.collation.Fold(tt.s))
+		})
+	}
+}
+
+func TestHashOfTyped(t *testing.T) {
+	require := require.New(t)
+
+	types := []Type{LongText, CreateLongText(Collation_utf8mb4_bin), Int64}
+	h1, err := HashOfTyped(NewRow("Foo", "bar", int64(1)), types)
+	require.NoError(err)
+	h2, err := HashOfTyped(NewRow("FÓO", "bar", int64(1)), types)
+	require.NoError(err)
+	h3, err := HashOfTyped(NewRow("foo", "BAR", int64(1)), types)
+	require.NoError(err)
+
+	require.Equal(h1, h2)
+	require.NotEqual(h1, h3)
+}
@@ -26,6 +26,10 @@ type Column struct {
 	Comment string
 	// Extra contains any additional information to put in the `extra` column under `information_schema.columns`.
 	Extra string
+	// Generated contains the expression that computes the value of the column, or nil if it's not a generated column.
+	Generated *ColumnDefaultValue
+	// Virtual is true if the column is a VIRTUAL generated column, and false if it's a STORED one.
+	Virtual bool
 }
 
 // Check ensures the value is correct for this column.
@@ -44,5 +48,7 @@ func (c *Column) Equals(c2 *Column) bool {
 		c.Source == c2.Source &&
 		c.Nullable == c2.Nullable &&
 		reflect.DeepEqual(c.Default, c2.Default) &&
+		reflect.DeepEqual(c.Generated, c2.Generated) &&
+		c.Virtual == c2.Virtual &&
 		reflect.DeepEqual(c.Type, c2.Type)
 }
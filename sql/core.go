@@ -3,7 +3,6 @@ package sql
 import (
 	"fmt"
 	"io"
-	"math"
 	"strconv"
 	"strings"
 	"time"
@@ -207,6 +206,15 @@ type FilteredTable interface {
 	Filters() []Expression
 }
 
+// UniqueKeysTable is a table whose rows are identified by the values of some sets of columns other than its primary
+// key, such as the tables of the information schema. No two rows have the same values in all the columns of one of
+// those sets.
+type UniqueKeysTable interface {
+	Table
+	// UniqueKeys returns the names of the columns of each unique key of the table.
+	UniqueKeys() [][]string
+}
+
 // ProjectedTable is a table that can produce a specific RowIter
 // that's more optimized given the columns that are projected.
 type ProjectedTable interface {
@@ -636,9 +644,9 @@ func EvaluateCondition(ctx *Context, cond Expression, row Row) (bool, error) {
 	case time.Time:
 		return b.UnixNano() != 0, nil
 	case float64:
-		return int(math.Round(v.(float64))) != 0, nil
+		return b != 0, nil
 	case float32:
-		return int(math.Round(float64(v.(float32)))) != 0, nil
+		return b != 0, nil
 	case string:
 		parsed, err := strconv.ParseFloat(v.(string), 64)
 		return err == nil && int(parsed) != 0, nil
@@ -28,6 +28,8 @@ var conditions = []struct {
 	{false, float64(0), sql.Float64},
 	{true, float32(0.5), sql.Float32},
 	{true, float64(0.5), sql.Float64},
+	{true, float32(0.2), sql.Float32},
+	{true, float64(-0.2), sql.Float64},
 	{true, "1", sql.LongText},
 	{false, "0", sql.LongText},
 	{false, "foo", sql.LongText},
@@ -1,7 +1,9 @@
 package sql
 
 import (
+	"fmt"
 	"math"
+	"strings"
 	"time"
 
 	"github.com/dolthub/vitess/go/sqltypes"
@@ -28,6 +30,9 @@ var (
 
 	ErrConvertingToTimeOutOfRange = errors.NewKind("value %q is outside of %v range")
 
+	// ErrInvalidTimePrecision is returned when the fractional seconds precision of a temporal type is out of range.
+	ErrInvalidTimePrecision = errors.NewKind("invalid fractional seconds precision %d: the maximum is %d")
+
 	// datetimeTypeMaxDatetime is the maximum representable Datetime/Date value.
 	datetimeTypeMaxDatetime = time.Date(9999, 12, 31, 23, 59, 59, 999999000, time.UTC)
 
@@ -76,8 +81,15 @@ type DatetimeType interface {
 
 type datetimeType struct {
 	baseType query.Type
+	// precision is the number of fractional seconds digits of the values of
+	// the type. A precision of 0 keeps the microseconds of values, and
+	// renders them without their trailing zeros.
+	precision int
 }
 
+// DatetimeTypeMaxPrecision is the largest fractional seconds precision of the temporal types.
+const DatetimeTypeMaxPrecision = 6
+
 // CreateDatetimeType creates a Type dealing with all temporal types that are not TIME nor YEAR.
 func CreateDatetimeType(baseType query.Type) (DatetimeType, error) {
 	switch baseType {
@@ -89,6 +101,22 @@ func CreateDatetimeType(baseType query.Type) (DatetimeType, error) {
 	return nil, ErrInvalidBaseType.New(baseType.String(), "datetime")
 }
 
+// CreateDatetimeTypeWithPrecision creates a DATETIME or TIMESTAMP type whose
+// values are rounded to, and rendered with, the given number of fractional
+// seconds digits.
+func CreateDatetimeTypeWithPrecision(baseType query.Type, precision int) (DatetimeType, error) {
+	if baseType != sqltypes.Datetime && baseType != sqltypes.Timestamp {
+		return nil, ErrInvalidBaseType.New(baseType.String(), "datetime")
+	}
+	if precision < 0 || precision > DatetimeTypeMaxPrecision {
+		return nil, ErrInvalidTimePrecision.New(precision, DatetimeTypeMaxPrecision)
+	}
+	return datetimeType{
+		baseType:  baseType,
+		precision: precision,
+	}, nil
+}
+
 // MustCreateDatetimeType is the same as CreateDatetimeType except it panics on errors.
 func MustCreateDatetimeType(baseType query.Type) DatetimeType {
 	dt, err := CreateDatetimeType(baseType)
@@ -148,6 +176,9 @@ func (t datetimeType) Convert(v interface{}) (interface{}, error) {
 	if res.Equal(zeroTime) {
 		return zeroTime, nil
 	}
+	if t.precision > 0 {
+		res = res.Round(time.Duration(math.Pow10(9 - t.precision)))
+	}
 
 	switch t.baseType {
 	case sqltypes.Date:
@@ -242,36 +273,54 @@ func (t datetimeType) SQL(v interface{}) (sqltypes.Value, error) {
 		if vt.Equal(zeroTime) {
 			return sqltypes.MakeTrusted(
 				sqltypes.Datetime,
-				[]byte(vt.Format(zeroTimestampDatetimeStr)),
+				[]byte(vt.Format(t.layout(zeroTimestampDatetimeStr))),
 			), nil
 		}
 		return sqltypes.MakeTrusted(
 			sqltypes.Datetime,
-			[]byte(vt.Format(TimestampDatetimeLayout)),
+			[]byte(vt.Format(t.layout(TimestampDatetimeLayout))),
 		), nil
 	case sqltypes.Timestamp:
 		if vt.Equal(zeroTime) {
 			return sqltypes.MakeTrusted(
 				sqltypes.Timestamp,
-				[]byte(vt.Format(zeroTimestampDatetimeStr)),
+				[]byte(vt.Format(t.layout(zeroTimestampDatetimeStr))),
 			), nil
 		}
 		return sqltypes.MakeTrusted(
 			sqltypes.Timestamp,
-			[]byte(vt.Format(TimestampDatetimeLayout)),
+			[]byte(vt.Format(t.layout(TimestampDatetimeLayout))),
 		), nil
 	default:
 		panic(ErrInvalidBaseType.New(t.baseType.String(), "datetime"))
 	}
 }
 
+// layout returns the given layout of a date and a time, whose fractional
+// seconds are replaced with the ones of the precision of the type.
+func (t datetimeType) layout(layout string) string {
+	if t.precision == 0 {
+		return layout
+	}
+	if point := strings.IndexByte(layout, '.'); point >= 0 {
+		layout = layout[:point]
+	}
+	return layout + "." + strings.Repeat("0", t.precision)
+}
+
 func (t datetimeType) String() string {
 	switch t.baseType {
 	case sqltypes.Date:
 		return "DATE"
 	case sqltypes.Datetime:
+		if t.precision > 0 {
+			return fmt.Sprintf("DATETIME(%d)", t.precision)
+		}
 		return "DATETIME"
 	case sqltypes.Timestamp:
+		if t.precision > 0 {
+			return fmt.Sprintf("TIMESTAMP(%d)", t.precision)
+		}
 		return "TIMESTAMP"
 	default:
 		panic(ErrInvalidBaseType.New(t.baseType.String(), "datetime"))
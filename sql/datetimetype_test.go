@@ -58,9 +58,9 @@ func TestDatetimeCreate(t *testing.T) {
 		expectedType datetimeType
 		expectedErr  bool
 	}{
-		{sqltypes.Date, datetimeType{sqltypes.Date}, false},
-		{sqltypes.Datetime, datetimeType{sqltypes.Datetime}, false},
-		{sqltypes.Timestamp, datetimeType{sqltypes.Timestamp}, false},
+		{sqltypes.Date, datetimeType{baseType: sqltypes.Date}, false},
+		{sqltypes.Datetime, datetimeType{baseType: sqltypes.Datetime}, false},
+		{sqltypes.Timestamp, datetimeType{baseType: sqltypes.Timestamp}, false},
 	}
 
 	for _, test := range tests {
@@ -247,3 +247,22 @@ func TestDatetimeString(t *testing.T) {
 		})
 	}
 }
+
+func TestDatetimeWithPrecision(t *testing.T) {
+	typ, err := CreateDatetimeTypeWithPrecision(sqltypes.Datetime, 3)
+	require.NoError(t, err)
+	assert.Equal(t, "DATETIME(3)", typ.String())
+
+	val, err := typ.Convert("2020-01-02 03:04:05.6789")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 1, 2, 3, 4, 5, 679000000, time.UTC), val)
+
+	sqlVal, err := typ.SQL("2020-01-02 03:04:05.5")
+	require.NoError(t, err)
+	assert.Equal(t, "2020-01-02 03:04:05.500", sqlVal.ToString())
+
+	_, err = CreateDatetimeTypeWithPrecision(sqltypes.Date, 3)
+	assert.Error(t, err)
+	_, err = CreateDatetimeTypeWithPrecision(sqltypes.Timestamp, 7)
+	assert.Error(t, err)
+}
@@ -23,6 +23,8 @@ var (
 	ErrConvertingToDecimal   = errors.NewKind("value %v is not a valid Decimal")
 	ErrConvertToDecimalLimit = errors.NewKind("value of Decimal is too large for type")
 	ErrMarshalNullDecimal    = errors.NewKind("Decimal cannot marshal a null value")
+
+	ErrInvalidDecimalPrecisionScale = errors.NewKind("invalid DECIMAL(%d,%d): the precision must be at most 65, the scale at most 30 and not larger than the precision")
 )
 
 type DecimalType interface {
@@ -105,72 +107,15 @@ func (t decimalType) Convert(v interface{}) (interface{}, error) {
 	return dec.Decimal.StringFixed(int32(t.scale)), nil
 }
 
-// Precision returns the precision, or total number of digits, that may be held.
+// ConvertToDecimal converts the given value to a decimal rounded to the scale of the type, returning an
+// error if it does not fit in the precision of the type.
 func (t decimalType) ConvertToDecimal(v interface{}) (decimal.NullDecimal, error) {
-	if v == nil {
-		return decimal.NullDecimal{}, nil
-	}
-
-	var res decimal.Decimal
-
-	switch value := v.(type) {
-	case int:
-		return t.ConvertToDecimal(int64(value))
-	case uint:
-		return t.ConvertToDecimal(uint64(value))
-	case int8:
-		return t.ConvertToDecimal(int64(value))
-	case uint8:
-		return t.ConvertToDecimal(uint64(value))
-	case int16:
-		return t.ConvertToDecimal(int64(value))
-	case uint16:
-		return t.ConvertToDecimal(uint64(value))
-	case int32:
-		res = decimal.NewFromInt32(value)
-	case uint32:
-		return t.ConvertToDecimal(uint64(value))
-	case int64:
-		res = decimal.NewFromInt(value)
-	case uint64:
-		res = decimal.NewFromBigInt(new(big.Int).SetUint64(value), 0)
-	case float32:
-		res = decimal.NewFromFloat32(value)
-	case float64:
-		res = decimal.NewFromFloat(value)
-	case string:
-		var err error
-		res, err = decimal.NewFromString(value)
-		if err != nil {
-			// The decimal library cannot handle all of the different formats
-			bf, _, err := new(big.Float).SetPrec(217).Parse(value, 0)
-			if err != nil {
-				return decimal.NullDecimal{}, err
-			}
-			res, err = decimal.NewFromString(bf.Text('f', -1))
-			if err != nil {
-				return decimal.NullDecimal{}, err
-			}
-		}
-	case *big.Float:
-		return t.ConvertToDecimal(value.Text('f', -1))
-	case *big.Int:
-		return t.ConvertToDecimal(value.Text(10))
-	case *big.Rat:
-		return t.ConvertToDecimal(new(big.Float).SetRat(value))
-	case decimal.Decimal:
-		res = value
-	case decimal.NullDecimal:
-		// This is the equivalent of passing in a nil
-		if !value.Valid {
-			return decimal.NullDecimal{}, nil
-		}
-		res = value.Decimal
-	default:
-		return decimal.NullDecimal{}, ErrConvertingToDecimal.New(v)
+	dec, err := ToDecimal(v)
+	if err != nil || !dec.Valid {
+		return dec, err
 	}
 
-	res = res.Round(int32(t.scale))
+	res := dec.Decimal.Round(int32(t.scale))
 	if !res.Abs().LessThan(t.exclusiveUpperBound) {
 		return decimal.NullDecimal{}, ErrConvertToDecimalLimit.New()
 	}
@@ -239,3 +184,72 @@ func (t decimalType) Precision() uint8 {
 func (t decimalType) Scale() uint8 {
 	return t.scale
 }
+
+// ToDecimal converts the given value to an exact decimal, without rounding it or checking it against the
+// bounds of any particular DECIMAL type. A nil value results in an invalid NullDecimal.
+func ToDecimal(v interface{}) (decimal.NullDecimal, error) {
+	if v == nil {
+		return decimal.NullDecimal{}, nil
+	}
+
+	var res decimal.Decimal
+
+	switch value := v.(type) {
+	case int:
+		return ToDecimal(int64(value))
+	case uint:
+		return ToDecimal(uint64(value))
+	case int8:
+		return ToDecimal(int64(value))
+	case uint8:
+		return ToDecimal(uint64(value))
+	case int16:
+		return ToDecimal(int64(value))
+	case uint16:
+		return ToDecimal(uint64(value))
+	case int32:
+		res = decimal.NewFromInt32(value)
+	case uint32:
+		return ToDecimal(uint64(value))
+	case int64:
+		res = decimal.NewFromInt(value)
+	case uint64:
+		res = decimal.NewFromBigInt(new(big.Int).SetUint64(value), 0)
+	case float32:
+		res = decimal.NewFromFloat32(value)
+	case float64:
+		res = decimal.NewFromFloat(value)
+	case string:
+		var err error
+		res, err = decimal.NewFromString(value)
+		if err != nil {
+			// The decimal library cannot handle all of the different formats
+			bf, _, err := new(big.Float).SetPrec(217).Parse(value, 0)
+			if err != nil {
+				return decimal.NullDecimal{}, err
+			}
+			res, err = decimal.NewFromString(bf.Text('f', -1))
+			if err != nil {
+				return decimal.NullDecimal{}, err
+			}
+		}
+	case *big.Float:
+		return ToDecimal(value.Text('f', -1))
+	case *big.Int:
+		return ToDecimal(value.Text(10))
+	case *big.Rat:
+		return ToDecimal(new(big.Float).SetRat(value))
+	case decimal.Decimal:
+		res = value
+	case decimal.NullDecimal:
+		// This is the equivalent of passing in a nil
+		if !value.Valid {
+			return decimal.NullDecimal{}, nil
+		}
+		res = value.Decimal
+	default:
+		return decimal.NullDecimal{}, ErrConvertingToDecimal.New(v)
+	}
+
+	return decimal.NullDecimal{Decimal: res, Valid: true}, nil
+}
@@ -0,0 +1,292 @@
+package sql
+
+import (
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+// ERInvalidCharacterString is the code of the warning added when bytes aren't a valid string in a character set.
+const ERInvalidCharacterString = 1300
+
+var (
+	// ErrInvalidCharacterString is returned when bytes aren't a valid string in a character set.
+	ErrInvalidCharacterString = errors.NewKind("Invalid %s character string: '%X'")
+
+	// ErrIncorrectStringValue is returned when a string has characters that the character set of a column can't
+	// represent.
+	ErrIncorrectStringValue = errors.NewKind("Incorrect string value: '%s' for column '%s' at row %d")
+)
+
+// Strings are kept in memory as UTF-8 whatever their character set is, except the binary ones, which are kept as the
+// bytes they're made of. A character set only matters when a string is converted to bytes or read from them, which is
+// what encodings do. The character sets without an encoding are treated as utf8mb4.
+
+// encoding converts between the characters of a character set and the bytes representing them.
+type encoding struct {
+	// encode appends the bytes of the given character to b, or returns false if the character set can't represent it.
+	encode func(b []byte, r rune) ([]byte, bool)
+	// decode returns the first character in b and its size, which is 0 when b doesn't start with a valid character.
+	decode func(b []byte) (rune, int)
+}
+
+var encodings = map[CharacterSet]encoding{
+	CharacterSet_ascii:   {encodeASCII, decodeASCII},
+	CharacterSet_latin1:  {encodeLatin1, decodeLatin1},
+	CharacterSet_utf8:    {encodeUTF8MB3, decodeUTF8MB3},
+	CharacterSet_utf8mb3: {encodeUTF8MB3, decodeUTF8MB3},
+	CharacterSet_utf8mb4: {encodeUTF8MB4, decodeUTF8MB4},
+	CharacterSet_ucs2:    {encodeUCS2, decodeUCS2},
+	CharacterSet_utf16:   {encodeUTF16(false), decodeUTF16(false)},
+	CharacterSet_utf16le: {encodeUTF16(true), decodeUTF16(true)},
+	CharacterSet_utf32:   {encodeUTF32, decodeUTF32},
+}
+
+// Encode returns the bytes representing the given string in the character set, and whether it could represent all of
+// its characters. The ones it can't are replaced with '?', as MySQL does.
+func (cs CharacterSet) Encode(s string) ([]byte, bool) {
+	enc, ok := encodings[cs]
+	if !ok || cs == CharacterSet_utf8mb4 {
+		return []byte(s), true
+	}
+
+	b := make([]byte, 0, len(s))
+	valid := true
+	for len(s) > 0 {
+		r, size := utf8.DecodeRuneInString(s)
+		s = s[size:]
+
+		var ok bool
+		if r == utf8.RuneError && size == 1 {
+			ok = false
+		} else {
+			b, ok = enc.encode(b, r)
+		}
+
+		if !ok {
+			b, _ = enc.encode(b, '?')
+			valid = false
+		}
+	}
+	return b, valid
+}
+
+// Decode returns the string represented by the given bytes in the character set, and whether they're a valid
+// sequence of characters. The bytes that aren't are replaced with '?'.
+func (cs CharacterSet) Decode(b []byte) (string, bool) {
+	enc, ok := encodings[cs]
+	if !ok || (cs == CharacterSet_utf8mb4 && utf8.Valid(b)) {
+		return string(b), true
+	}
+
+	var sb strings.Builder
+	valid := true
+	for len(b) > 0 {
+		r, size := enc.decode(b)
+		if size == 0 {
+			sb.WriteByte('?')
+			valid = false
+			size = 1
+		} else {
+			sb.WriteRune(r)
+		}
+		b = b[size:]
+	}
+	return sb.String(), valid
+}
+
+// CanEncode returns whether the character set can represent all the characters of the given string.
+func (cs CharacterSet) CanEncode(s string) bool {
+	enc, ok := encodings[cs]
+	if !ok || cs == CharacterSet_utf8mb4 {
+		return true
+	}
+
+	var buf [utf8.UTFMax]byte
+	for len(s) > 0 {
+		r, size := utf8.DecodeRuneInString(s)
+		if r == utf8.RuneError && size == 1 {
+			return false
+		}
+		if _, ok := enc.encode(buf[:0], r); !ok {
+			return false
+		}
+		s = s[size:]
+	}
+	return true
+}
+
+// ByteLength returns the number of bytes of the given string in the character set.
+func (cs CharacterSet) ByteLength(s string) int {
+	switch cs {
+	case CharacterSet_binary, CharacterSet_utf8mb4:
+		return len(s)
+	}
+
+	b, _ := cs.Encode(s)
+	return len(b)
+}
+
+func encodeASCII(b []byte, r rune) ([]byte, bool) {
+	if r >= utf8.RuneSelf {
+		return b, false
+	}
+	return append(b, byte(r)), true
+}
+
+func decodeASCII(b []byte) (rune, int) {
+	if b[0] >= utf8.RuneSelf {
+		return 0, 0
+	}
+	return rune(b[0]), 1
+}
+
+// latin1HighControls are the characters of the bytes from 0x80 to 0x9F in latin1, which MySQL takes from cp1252
+// rather than ISO 8859-1. The bytes cp1252 leaves undefined are the control characters with the same code.
+var latin1HighControls = [32]rune{
+	0x20AC, 0x0081, 0x201A, 0x0192, 0x201E, 0x2026, 0x2020, 0x2021,
+	0x02C6, 0x2030, 0x0160, 0x2039, 0x0152, 0x008D, 0x017D, 0x008F,
+	0x0090, 0x2018, 0x2019, 0x201C, 0x201D, 0x2022, 0x2013, 0x2014,
+	0x02DC, 0x2122, 0x0161, 0x203A, 0x0153, 0x009D, 0x017E, 0x0178,
+}
+
+func encodeLatin1(b []byte, r rune) ([]byte, bool) {
+	if r < 0x80 || (r >= 0xA0 && r <= 0xFF) {
+		return append(b, byte(r)), true
+	}
+	for i, c := range latin1HighControls {
+		if c == r {
+			return append(b, byte(0x80+i)), true
+		}
+	}
+	return b, false
+}
+
+func decodeLatin1(b []byte) (rune, int) {
+	if b[0] >= 0x80 && b[0] < 0xA0 {
+		return latin1HighControls[b[0]-0x80], 1
+	}
+	return rune(b[0]), 1
+}
+
+func encodeUTF8MB4(b []byte, r rune) ([]byte, bool) {
+	var buf [utf8.UTFMax]byte
+	n := utf8.EncodeRune(buf[:], r)
+	return append(b, buf[:n]...), true
+}
+
+func decodeUTF8MB4(b []byte) (rune, int) {
+	r, size := utf8.DecodeRune(b)
+	if r == utf8.RuneError && size <= 1 {
+		return 0, 0
+	}
+	return r, size
+}
+
+func encodeUTF8MB3(b []byte, r rune) ([]byte, bool) {
+	if r > 0xFFFF {
+		return b, false
+	}
+	return encodeUTF8MB4(b, r)
+}
+
+func decodeUTF8MB3(b []byte) (rune, int) {
+	r, size := decodeUTF8MB4(b)
+	if size > 3 {
+		return 0, 0
+	}
+	return r, size
+}
+
+func encodeUCS2(b []byte, r rune) ([]byte, bool) {
+	if r > 0xFFFF || utf16.IsSurrogate(r) {
+		return b, false
+	}
+	return append(b, byte(r>>8), byte(r)), true
+}
+
+func decodeUCS2(b []byte) (rune, int) {
+	if len(b) < 2 {
+		return 0, 0
+	}
+
+	r := rune(b[0])<<8 | rune(b[1])
+	if utf16.IsSurrogate(r) {
+		return 0, 0
+	}
+	return r, 2
+}
+
+func encodeUTF16(littleEndian bool) func([]byte, rune) ([]byte, bool) {
+	return func(b []byte, r rune) ([]byte, bool) {
+		if utf16.IsSurrogate(r) {
+			return b, false
+		}
+
+		units := []uint16{uint16(r)}
+		if r > 0xFFFF {
+			r1, r2 := utf16.EncodeRune(r)
+			units = []uint16{uint16(r1), uint16(r2)}
+		}
+
+		for _, u := range units {
+			if littleEndian {
+				b = append(b, byte(u), byte(u>>8))
+			} else {
+				b = append(b, byte(u>>8), byte(u))
+			}
+		}
+		return b, true
+	}
+}
+
+func decodeUTF16(littleEndian bool) func([]byte) (rune, int) {
+	unit := func(b []byte) rune {
+		if littleEndian {
+			return rune(b[1])<<8 | rune(b[0])
+		}
+		return rune(b[0])<<8 | rune(b[1])
+	}
+
+	return func(b []byte) (rune, int) {
+		if len(b) < 2 {
+			return 0, 0
+		}
+
+		r1 := unit(b)
+		if !utf16.IsSurrogate(r1) {
+			return r1, 2
+		}
+
+		if len(b) < 4 {
+			return 0, 0
+		}
+
+		r := utf16.DecodeRune(r1, unit(b[2:]))
+		if r == utf8.RuneError {
+			return 0, 0
+		}
+		return r, 4
+	}
+}
+
+func encodeUTF32(b []byte, r rune) ([]byte, bool) {
+	if utf16.IsSurrogate(r) {
+		return b, false
+	}
+	return append(b, byte(r>>24), byte(r>>16), byte(r>>8), byte(r)), true
+}
+
+func decodeUTF32(b []byte) (rune, int) {
+	if len(b) < 4 {
+		return 0, 0
+	}
+
+	r := rune(b[0])<<24 | rune(b[1])<<16 | rune(b[2])<<8 | rune(b[3])
+	if r > utf8.MaxRune || utf16.IsSurrogate(r) {
+		return 0, 0
+	}
+	return r, 4
+}
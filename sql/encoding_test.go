@@ -0,0 +1,67 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCharacterSetEncode(t *testing.T) {
+	testCases := []struct {
+		cs       CharacterSet
+		input    string
+		expected []byte
+		valid    bool
+	}{
+		{CharacterSet_utf8mb4, "a€😀", []byte("a€😀"), true},
+		{CharacterSet_utf8mb3, "a€😀", []byte("a€?"), false},
+		{CharacterSet_latin1, "aé€", []byte{'a', 0xE9, 0x80}, true},
+		{CharacterSet_latin1, "a中", []byte{'a', '?'}, false},
+		{CharacterSet_ascii, "añ", []byte{'a', '?'}, false},
+		{CharacterSet_binary, "añ", []byte("añ"), true},
+		{CharacterSet_ucs2, "a😀", []byte{0, 'a', 0, '?'}, false},
+		{CharacterSet_utf16, "a😀", []byte{0, 'a', 0xD8, 0x3D, 0xDE, 0x00}, true},
+		{CharacterSet_utf16le, "a€", []byte{'a', 0, 0xAC, 0x20}, true},
+		{CharacterSet_utf32, "a€", []byte{0, 0, 0, 'a', 0, 0, 0x20, 0xAC}, true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.cs.String(), func(t *testing.T) {
+			require := require.New(t)
+
+			encoded, valid := tt.cs.Encode(tt.input)
+			require.Equal(tt.expected, encoded)
+			require.Equal(tt.valid, valid)
+			require.Equal(tt.valid, tt.cs.CanEncode(tt.input))
+			require.Equal(len(tt.expected), tt.cs.ByteLength(tt.input))
+
+			if tt.valid {
+				decoded, ok := tt.cs.Decode(encoded)
+				require.True(ok)
+				require.Equal(tt.input, decoded)
+			}
+		})
+	}
+}
+
+func TestCharacterSetDecodeInvalid(t *testing.T) {
+	testCases := []struct {
+		cs       CharacterSet
+		input    []byte
+		expected string
+	}{
+		{CharacterSet_utf8mb4, []byte{'a', 0xFF, 'b'}, "a?b"},
+		{CharacterSet_utf8mb3, []byte("a😀"), "a????"},
+		{CharacterSet_ascii, []byte{'a', 0x80}, "a?"},
+		{CharacterSet_utf16, []byte{0, 'a', 0xD8}, "a?"},
+		{CharacterSet_utf32, []byte{0, 0x11, 0, 0}, "????"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.cs.String(), func(t *testing.T) {
+			decoded, ok := tt.cs.Decode(tt.input)
+			require.False(t, ok)
+			require.Equal(t, tt.expected, decoded)
+		})
+	}
+}
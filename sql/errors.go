@@ -91,6 +91,9 @@ var (
 	// ErrDropColumnReferencedInDefault is returned when a column cannot be dropped as it is referenced by another column's default value.
 	ErrDropColumnReferencedInDefault = errors.NewKind(`cannot drop column "%s" as default value of column "%s" references it`)
 
+	// ErrGeneratedColumnValue is returned when a value other than DEFAULT is written to a generated column.
+	ErrGeneratedColumnValue = errors.NewKind("The value specified for generated column '%s' in table '%s' is not allowed.")
+
 	// ErrTriggersNotSupported is returned when attempting to create a trigger on a database that doesn't support them
 	ErrTriggersNotSupported = errors.NewKind(`database "%s" doesn't support triggers`)
 
@@ -109,6 +112,9 @@ var (
 	// ErrUnknownSystemVariable is returned when a query references a system variable that doesn't exist
 	ErrUnknownSystemVariable = errors.NewKind(`Unknown system variable '%s'`)
 
+	// ErrSessionOnlyVariable is returned when SET GLOBAL or @@global refer to a system variable without a global value
+	ErrSessionOnlyVariable = errors.NewKind(`Variable '%s' is a SESSION variable and can't be used with SET GLOBAL`)
+
 	// ErrInvalidUseOfOldNew is returned when a trigger attempts to make use of OLD or NEW references when they don't exist
 	ErrInvalidUseOfOldNew = errors.NewKind("There is no %s row in on %s trigger")
 
@@ -117,4 +123,12 @@ var (
 
 	// ErrInvalidUpdateInAfterTrigger is returned when a trigger attempts to assign to a new row in an AFTER trigger
 	ErrInvalidUpdateInAfterTrigger = errors.NewKind("Updating of new row is not allowed in after trigger")
+
+	// ErrWrongFieldWithGroup is returned when ONLY_FULL_GROUP_BY is enabled and a grouped query uses a column that is
+	// neither aggregated nor functionally dependent on the grouping columns
+	ErrWrongFieldWithGroup = errors.NewKind("Expression #%d of %s is not in GROUP BY clause and contains nonaggregated column '%s' which is not functionally dependent on columns in GROUP BY clause; this is incompatible with sql_mode=only_full_group_by")
+
+	// ErrMixOfGroupFuncAndFields is returned when ONLY_FULL_GROUP_BY is enabled and a query with aggregate functions
+	// but no GROUP BY uses a column outside of them
+	ErrMixOfGroupFuncAndFields = errors.NewKind("In aggregated query without GROUP BY, expression #%d of %s contains nonaggregated column '%s'; this is incompatible with sql_mode=only_full_group_by")
 )
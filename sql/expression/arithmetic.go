@@ -2,11 +2,15 @@ package expression
 
 import (
 	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
 	"reflect"
 	"strings"
 	"time"
 
 	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/shopspring/decimal"
 	errors "gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -120,21 +124,39 @@ func (a *Arithmetic) Type() sql.Type {
 			return sql.Int64
 		}
 
+		if typ, ok := a.decimalType(); ok {
+			return typ
+		}
+
 		if sql.IsInteger(a.Left.Type()) && sql.IsInteger(a.Right.Type()) {
-			if sql.IsUnsigned(a.Left.Type()) && sql.IsUnsigned(a.Right.Type()) {
-				return sql.Uint64
+			if strings.ToLower(a.Op) == sqlparser.DivStr {
+				if sql.IsUnsigned(a.Left.Type()) && sql.IsUnsigned(a.Right.Type()) {
+					return sql.Uint64
+				}
+				return sql.Int64
 			}
-			return sql.Int64
+			return integerType(a.Left, a.Right)
 		}
 
 		return sql.Float64
 
-	case sqlparser.ShiftLeftStr, sqlparser.ShiftRightStr:
+	case sqlparser.ShiftLeftStr, sqlparser.ShiftRightStr,
+		sqlparser.BitAndStr, sqlparser.BitOrStr, sqlparser.BitXorStr:
 		return sql.Uint64
 
-	case sqlparser.BitAndStr, sqlparser.BitOrStr, sqlparser.BitXorStr, sqlparser.IntDivStr, sqlparser.ModStr:
-		if sql.IsUnsigned(a.Left.Type()) && sql.IsUnsigned(a.Right.Type()) {
-			return sql.Uint64
+	case sqlparser.ModStr:
+		if typ, ok := a.decimalType(); ok {
+			return typ
+		}
+		if sql.IsInteger(a.Left.Type()) && sql.IsInteger(a.Right.Type()) {
+			// The remainder has the sign of the dividend.
+			return integerType(a.Left)
+		}
+		return sql.Float64
+
+	case sqlparser.IntDivStr:
+		if sql.IsInteger(a.Left.Type()) && sql.IsInteger(a.Right.Type()) {
+			return integerType(a.Left, a.Right)
 		}
 		return sql.Int64
 	}
@@ -142,6 +164,117 @@ func (a *Arithmetic) Type() sql.Type {
 	return sql.Float64
 }
 
+// integerType returns the type integer operations on the given operands are
+// computed as, which is BIGINT UNSIGNED if any of them is unsigned, and
+// BIGINT otherwise.
+func integerType(operands ...sql.Expression) sql.Type {
+	for _, e := range operands {
+		if isUnsignedOperand(e) {
+			return sql.Uint64
+		}
+	}
+	return sql.Int64
+}
+
+// isUnsignedOperand returns whether the given integer operand is unsigned.
+// Integer literals are parsed as the smallest type that holds them, so only
+// the ones too large for a BIGINT are taken as unsigned, as in MySQL.
+func isUnsignedOperand(e sql.Expression) bool {
+	if !sql.IsUnsigned(e.Type()) {
+		return false
+	}
+	if _, ok := e.(*Literal); ok {
+		return e.Type() == sql.Uint64
+	}
+	return true
+}
+
+// decimalType returns the DECIMAL type of the result when the operation is
+// computed exactly, which is when one of the operands is a DECIMAL and the
+// other one is either a DECIMAL or an integer. The precision and scale of the
+// result are derived from the ones of the operands as in MySQL.
+func (a *Arithmetic) decimalType() (sql.DecimalType, bool) {
+	lt, rt := a.Left.Type(), a.Right.Type()
+	if !sql.IsDecimal(lt) && !sql.IsDecimal(rt) {
+		return nil, false
+	}
+
+	lp, ls, ok := decimalPrecisionScale(lt)
+	if !ok {
+		return nil, false
+	}
+	rp, rs, ok := decimalPrecisionScale(rt)
+	if !ok {
+		return nil, false
+	}
+
+	var precision, scale int
+	switch strings.ToLower(a.Op) {
+	case sqlparser.PlusStr, sqlparser.MinusStr:
+		scale = maxInt(ls, rs)
+		precision = maxInt(lp-ls, rp-rs) + scale + 1
+	case sqlparser.MultStr:
+		scale = ls + rs
+		precision = lp + rp
+	case sqlparser.DivStr:
+		scale = ls + divPrecisionIncrement
+		precision = lp + rs + divPrecisionIncrement
+	case sqlparser.ModStr:
+		scale = maxInt(ls, rs)
+		precision = maxInt(lp-ls, rp-rs) + scale
+	default:
+		return nil, false
+	}
+
+	if scale > sql.DecimalTypeMaxScale {
+		scale = sql.DecimalTypeMaxScale
+	}
+	if precision > sql.DecimalTypeMaxPrecision {
+		precision = sql.DecimalTypeMaxPrecision
+	}
+	if precision < scale {
+		precision = scale
+	}
+
+	return sql.MustCreateDecimalType(uint8(precision), uint8(scale)), true
+}
+
+// divPrecisionIncrement is the number of digits by which the scale of the
+// dividend is increased in the result of a DECIMAL division.
+const divPrecisionIncrement = 4
+
+// decimalPrecisionScale returns the precision and scale of the given DECIMAL
+// or integer type, and false for any other type.
+func decimalPrecisionScale(t sql.Type) (int, int, bool) {
+	if dt, ok := t.(sql.DecimalType); ok {
+		return int(dt.Precision()), int(dt.Scale()), true
+	}
+
+	switch t {
+	case sql.Int8, sql.Uint8:
+		return 3, 0, true
+	case sql.Int16, sql.Uint16:
+		return 5, 0, true
+	case sql.Int24, sql.Uint24:
+		return 8, 0, true
+	case sql.Int32, sql.Uint32:
+		return 10, 0, true
+	case sql.Int64:
+		return 19, 0, true
+	case sql.Uint64:
+		return 20, 0, true
+	}
+
+	return 0, 0, false
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
 func isInterval(expr sql.Expression) bool {
 	_, ok := expr.(*Interval)
 	return ok
@@ -166,7 +299,48 @@ func (a *Arithmetic) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, nil
 	}
 
-	lval, rval, err = a.convertLeftRight(lval, rval)
+	res, err := a.evalOp(lval, rval)
+	if err != nil {
+		return nil, err
+	}
+
+	if res == sql.Null && a.isDivision() && sql.LoadSqlMode(ctx).Has(sql.SqlMode_ErrorForDivisionByZero) {
+		ctx.Warn(sql.ERDivisionByZero, "Division by 0")
+	}
+	return res, nil
+}
+
+// isDivision returns whether the operator is one that divides, which evaluate to NULL when dividing by zero.
+func (a *Arithmetic) isDivision() bool {
+	switch strings.ToLower(a.Op) {
+	case sqlparser.DivStr, sqlparser.IntDivStr, sqlparser.ModStr:
+		return true
+	default:
+		return false
+	}
+}
+
+// evalOp computes the operation on the given values, which aren't NULL.
+func (a *Arithmetic) evalOp(lval, rval interface{}) (interface{}, error) {
+	if typ, ok := a.decimalType(); ok {
+		return a.evalDecimal(typ, lval, rval)
+	}
+
+	switch strings.ToLower(a.Op) {
+	case sqlparser.BitAndStr, sqlparser.BitOrStr, sqlparser.BitXorStr, sqlparser.ShiftLeftStr, sqlparser.ShiftRightStr:
+		return a.evalBitOp(lval, rval)
+	case sqlparser.PlusStr, sqlparser.MinusStr, sqlparser.MultStr, sqlparser.ModStr:
+		if sql.IsInteger(a.Left.Type()) && sql.IsInteger(a.Right.Type()) {
+			return a.evalInteger(lval, rval)
+		}
+	case sqlparser.IntDivStr:
+		if sql.IsInteger(a.Left.Type()) && sql.IsInteger(a.Right.Type()) {
+			return a.evalInteger(lval, rval)
+		}
+		return a.evalIntDiv(lval, rval)
+	}
+
+	lval, rval, err := a.convertLeftRight(lval, rval)
 	if err != nil {
 		return nil, err
 	}
@@ -180,25 +354,251 @@ func (a *Arithmetic) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return mult(lval, rval)
 	case sqlparser.DivStr:
 		return div(lval, rval)
+	case sqlparser.ModStr:
+		return mod(lval, rval)
+	}
+
+	return nil, errUnableToEval.New(lval, a.Op, rval)
+}
+
+// evalInteger computes +, -, *, DIV or % on integer operands exactly, and
+// returns an error if the result doesn't fit in the type of the operation.
+func (a *Arithmetic) evalInteger(lval, rval interface{}) (interface{}, error) {
+	lneg, l, err := signMagnitude(lval)
+	if err != nil {
+		return nil, err
+	}
+	rneg, r, err := signMagnitude(rval)
+	if err != nil {
+		return nil, err
+	}
+
+	var neg, overflow bool
+	var abs uint64
+	switch strings.ToLower(a.Op) {
+	case sqlparser.MinusStr:
+		rneg = !rneg
+		fallthrough
+	case sqlparser.PlusStr:
+		if lneg == rneg {
+			var carry uint64
+			abs, carry = bits.Add64(l, r, 0)
+			neg, overflow = lneg, carry != 0
+		} else if l >= r {
+			abs, neg = l-r, lneg
+		} else {
+			abs, neg = r-l, rneg
+		}
+	case sqlparser.MultStr:
+		var hi uint64
+		hi, abs = bits.Mul64(l, r)
+		neg, overflow = lneg != rneg, hi != 0
+	case sqlparser.IntDivStr:
+		if r == 0 {
+			return sql.Null, nil
+		}
+		abs, neg = l/r, lneg != rneg
+	case sqlparser.ModStr:
+		if r == 0 {
+			return sql.Null, nil
+		}
+		abs, neg = l%r, lneg
+	default:
+		return nil, errUnableToEval.New(lval, a.Op, rval)
+	}
+
+	if a.Type() == sql.Uint64 {
+		if overflow || (neg && abs != 0) {
+			return nil, sql.ErrDataOutOfRange.New("BIGINT UNSIGNED", fmt.Sprintf("(%s)", a))
+		}
+		return abs, nil
+	}
+
+	if overflow || (!neg && abs > math.MaxInt64) || (neg && abs > 1<<63) {
+		return nil, sql.ErrDataOutOfRange.New("BIGINT", fmt.Sprintf("(%s)", a))
+	}
+	if neg {
+		return -int64(abs), nil
+	}
+	return int64(abs), nil
+}
+
+// signMagnitude returns whether the given integer is negative and its
+// absolute value.
+func signMagnitude(v interface{}) (bool, uint64, error) {
+	switch n := v.(type) {
+	case uint:
+		return false, uint64(n), nil
+	case uint8:
+		return false, uint64(n), nil
+	case uint16:
+		return false, uint64(n), nil
+	case uint32:
+		return false, uint64(n), nil
+	case uint64:
+		return false, n, nil
+	}
+
+	i, err := sql.Int64.Convert(v)
+	if err != nil {
+		return false, 0, err
+	}
+
+	n := i.(int64)
+	if n < 0 {
+		// The negation of math.MinInt64 overflows back to it, which is
+		// 1 << 63 as an unsigned integer.
+		return true, uint64(-n), nil
+	}
+	return false, uint64(n), nil
+}
+
+var (
+	minInt64Decimal  = decimal.NewFromInt(math.MinInt64)
+	maxInt64Decimal  = decimal.NewFromInt(math.MaxInt64)
+	maxUint64Decimal = decimal.NewFromBigInt(new(big.Int).SetUint64(math.MaxUint64), 0)
+)
+
+// evalIntDiv computes DIV on operands that aren't both integers, dividing
+// them as exact numbers and truncating the quotient to a BIGINT.
+func (a *Arithmetic) evalIntDiv(lval, rval interface{}) (interface{}, error) {
+	l, err := sql.ToDecimal(lval)
+	if err != nil {
+		return nil, err
+	}
+	r, err := sql.ToDecimal(rval)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Decimal.IsZero() {
+		return sql.Null, nil
+	}
+
+	q := l.Decimal.Div(r.Decimal).Truncate(0)
+	if q.LessThan(minInt64Decimal) || q.GreaterThan(maxInt64Decimal) {
+		return nil, sql.ErrDataOutOfRange.New("BIGINT", fmt.Sprintf("(%s)", a))
+	}
+	return q.IntPart(), nil
+}
+
+// evalBitOp computes a bit operation, which works on the 64 bits of its
+// operands as BIGINT UNSIGNED values.
+func (a *Arithmetic) evalBitOp(lval, rval interface{}) (interface{}, error) {
+	l, err := toBits(lval)
+	if err != nil {
+		return nil, err
+	}
+	r, err := toBits(rval)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(a.Op) {
 	case sqlparser.BitAndStr:
-		return bitAnd(lval, rval)
+		return l & r, nil
 	case sqlparser.BitOrStr:
-		return bitOr(lval, rval)
+		return l | r, nil
 	case sqlparser.BitXorStr:
-		return bitXor(lval, rval)
+		return l ^ r, nil
 	case sqlparser.ShiftLeftStr:
-		return shiftLeft(lval, rval)
+		return l << r, nil
 	case sqlparser.ShiftRightStr:
-		return shiftRight(lval, rval)
-	case sqlparser.IntDivStr:
-		return intDiv(lval, rval)
-	case sqlparser.ModStr:
-		return mod(lval, rval)
+		return l >> r, nil
 	}
 
 	return nil, errUnableToEval.New(lval, a.Op, rval)
 }
 
+// toBits returns the bits of the given value as a BIGINT UNSIGNED. Negative
+// integers are taken in two's complement, and any other number is rounded to
+// the nearest integer, which saturates to the range of a BIGINT UNSIGNED, or
+// of a BIGINT if it's negative.
+func toBits(v interface{}) (uint64, error) {
+	switch n := v.(type) {
+	case bool:
+		if n {
+			return 1, nil
+		}
+		return 0, nil
+	case int:
+		return uint64(n), nil
+	case int8:
+		return uint64(n), nil
+	case int16:
+		return uint64(n), nil
+	case int32:
+		return uint64(n), nil
+	case int64:
+		return uint64(n), nil
+	case uint:
+		return uint64(n), nil
+	case uint8:
+		return uint64(n), nil
+	case uint16:
+		return uint64(n), nil
+	case uint32:
+		return uint64(n), nil
+	case uint64:
+		return n, nil
+	}
+
+	d, err := sql.ToDecimal(v)
+	if err != nil {
+		return 0, err
+	}
+
+	i := d.Decimal.Round(0)
+	switch {
+	case i.LessThan(minInt64Decimal):
+		return 1 << 63, nil
+	case i.Sign() < 0:
+		return uint64(i.IntPart()), nil
+	case i.GreaterThan(maxUint64Decimal):
+		return math.MaxUint64, nil
+	default:
+		// Rounding to no decimals leaves the number as its coefficient.
+		return i.Coefficient().Uint64(), nil
+	}
+}
+
+// evalDecimal computes the operation on the given values exactly and returns
+// the result with the scale of the given type.
+func (a *Arithmetic) evalDecimal(typ sql.DecimalType, lval, rval interface{}) (interface{}, error) {
+	l, err := sql.ToDecimal(lval)
+	if err != nil {
+		return nil, err
+	}
+	r, err := sql.ToDecimal(rval)
+	if err != nil {
+		return nil, err
+	}
+
+	var res decimal.Decimal
+	switch strings.ToLower(a.Op) {
+	case sqlparser.PlusStr:
+		res = l.Decimal.Add(r.Decimal)
+	case sqlparser.MinusStr:
+		res = l.Decimal.Sub(r.Decimal)
+	case sqlparser.MultStr:
+		res = l.Decimal.Mul(r.Decimal)
+	case sqlparser.DivStr:
+		if r.Decimal.IsZero() {
+			return sql.Null, nil
+		}
+		res = l.Decimal.DivRound(r.Decimal, int32(typ.Scale()))
+	case sqlparser.ModStr:
+		if r.Decimal.IsZero() {
+			return sql.Null, nil
+		}
+		res = l.Decimal.Mod(r.Decimal)
+	default:
+		return nil, errUnableToEval.New(lval, a.Op, rval)
+	}
+
+	return typ.Convert(res)
+}
+
 func (a *Arithmetic) evalLeftRight(ctx *sql.Context, row sql.Row) (interface{}, interface{}, error) {
 	var lval, rval interface{}
 	var err error
@@ -379,120 +779,15 @@ func div(lval, rval interface{}) (interface{}, error) {
 	return nil, errUnableToCast.New(lval, rval)
 }
 
-func bitAnd(lval, rval interface{}) (interface{}, error) {
-	switch l := lval.(type) {
-	case uint64:
-		switch r := rval.(type) {
-		case uint64:
-			return l & r, nil
-		}
-
-	case int64:
-		switch r := rval.(type) {
-		case int64:
-			return l & r, nil
-		}
-	}
-
-	return nil, errUnableToCast.New(lval, rval)
-}
-
-func bitOr(lval, rval interface{}) (interface{}, error) {
-	switch l := lval.(type) {
-	case uint64:
-		switch r := rval.(type) {
-		case uint64:
-			return l | r, nil
-		}
-
-	case int64:
-		switch r := rval.(type) {
-		case int64:
-			return l | r, nil
-		}
-	}
-
-	return nil, errUnableToCast.New(lval, rval)
-}
-
-func bitXor(lval, rval interface{}) (interface{}, error) {
-	switch l := lval.(type) {
-	case uint64:
-		switch r := rval.(type) {
-		case uint64:
-			return l ^ r, nil
-		}
-
-	case int64:
-		switch r := rval.(type) {
-		case int64:
-			return l ^ r, nil
-		}
-	}
-
-	return nil, errUnableToCast.New(lval, rval)
-}
-
-func shiftLeft(lval, rval interface{}) (interface{}, error) {
-	switch l := lval.(type) {
-	case uint64:
-		switch r := rval.(type) {
-		case uint64:
-			return l << r, nil
-		}
-	}
-
-	return nil, errUnableToCast.New(lval, rval)
-}
-
-func shiftRight(lval, rval interface{}) (interface{}, error) {
-	switch l := lval.(type) {
-	case uint64:
-		switch r := rval.(type) {
-		case uint64:
-			return l >> r, nil
-		}
-	}
-
-	return nil, errUnableToCast.New(lval, rval)
-}
-
-func intDiv(lval, rval interface{}) (interface{}, error) {
+func mod(lval, rval interface{}) (interface{}, error) {
 	switch l := lval.(type) {
-	case uint64:
-		switch r := rval.(type) {
-		case uint64:
-			if r == 0 {
-				return sql.Null, nil
-			}
-			return uint64(l / r), nil
-		}
-
-	case int64:
+	case float64:
 		switch r := rval.(type) {
-		case int64:
+		case float64:
 			if r == 0 {
 				return sql.Null, nil
 			}
-			return int64(l / r), nil
-		}
-	}
-
-	return nil, errUnableToCast.New(lval, rval)
-}
-
-func mod(lval, rval interface{}) (interface{}, error) {
-	switch l := lval.(type) {
-	case uint64:
-		switch r := rval.(type) {
-		case uint64:
-			return l % r, nil
-		}
-
-	case int64:
-		switch r := rval.(type) {
-		case int64:
-			return l % r, nil
+			return math.Mod(l, r), nil
 		}
 	}
 
@@ -520,6 +815,14 @@ func (e *UnaryMinus) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, nil
 	}
 
+	if dt, ok := e.Child.Type().(sql.DecimalType); ok {
+		dec, err := dt.ConvertToDecimal(child)
+		if err != nil {
+			return nil, err
+		}
+		return dt.Convert(dec.Decimal.Neg())
+	}
+
 	if !sql.IsNumber(e.Child.Type()) {
 		child, err = sql.Float64.Convert(child)
 		if err != nil {
@@ -541,6 +844,9 @@ func (e *UnaryMinus) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	case int32:
 		return -n, nil
 	case int64:
+		if n == math.MinInt64 {
+			return nil, sql.ErrDataOutOfRange.New("BIGINT", fmt.Sprintf("-(%s)", e.Child))
+		}
 		return -n, nil
 	case uint:
 		return -int(n), nil
@@ -551,6 +857,9 @@ func (e *UnaryMinus) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	case uint32:
 		return -int32(n), nil
 	case uint64:
+		if n > 1<<63 {
+			return nil, sql.ErrDataOutOfRange.New("BIGINT", fmt.Sprintf("-(%s)", e.Child))
+		}
 		return -int64(n), nil
 	default:
 		return nil, sql.ErrInvalidType.New(reflect.TypeOf(n))
@@ -586,3 +895,49 @@ func (e *UnaryMinus) WithChildren(children ...sql.Expression) (sql.Expression, e
 	}
 	return NewUnaryMinus(children[0]), nil
 }
+
+// BitNot is the bitwise inversion operator ~, which inverts the 64 bits of its
+// operand as a BIGINT UNSIGNED.
+type BitNot struct {
+	UnaryExpression
+}
+
+// NewBitNot creates a new BitNot expression node.
+func NewBitNot(child sql.Expression) *BitNot {
+	return &BitNot{UnaryExpression{Child: child}}
+}
+
+// Eval implements the sql.Expression interface.
+func (e *BitNot) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	child, err := e.Child.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if child == nil {
+		return nil, nil
+	}
+
+	bits, err := toBits(child)
+	if err != nil {
+		return nil, err
+	}
+	return ^bits, nil
+}
+
+// Type implements the sql.Expression interface.
+func (e *BitNot) Type() sql.Type {
+	return sql.Uint64
+}
+
+func (e *BitNot) String() string {
+	return fmt.Sprintf("~%s", e.Child)
+}
+
+// WithChildren implements the Expression interface.
+func (e *BitNot) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(e, len(children), 1)
+	}
+	return NewBitNot(children[0]), nil
+}
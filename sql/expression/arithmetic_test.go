@@ -1,6 +1,7 @@
 package expression
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -280,7 +281,7 @@ func TestBitAnd(t *testing.T) {
 	var testCases = []struct {
 		name        string
 		left, right int64
-		expected    int64
+		expected    uint64
 	}{
 		{"1 & 1", 1, 1, 1},
 		{"8 & 1", 8, 1, 0},
@@ -306,7 +307,7 @@ func TestBitOr(t *testing.T) {
 	var testCases = []struct {
 		name        string
 		left, right int64
-		expected    int64
+		expected    uint64
 	}{
 		{"1 | 1", 1, 1, 1},
 		{"8 | 1", 8, 1, 9},
@@ -332,13 +333,13 @@ func TestBitXor(t *testing.T) {
 	var testCases = []struct {
 		name        string
 		left, right int64
-		expected    int64
+		expected    uint64
 	}{
 		{"1 ^ 1", 1, 1, 0},
 		{"8 ^ 1", 8, 1, 9},
 		{"3 ^ 1", 3, 1, 2},
 		{"1024 ^ 0", 1024, 0, 1024},
-		{"0 ^ -1024", 0, -1024, -1024},
+		{"0 ^ -1024", 0, -1024, math.MaxUint64 - 1023},
 	}
 
 	for _, tt := range testCases {
@@ -411,6 +412,116 @@ func TestMod(t *testing.T) {
 	}
 }
 
+func TestIntegerArithmetic(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		expr     sql.Expression
+		row      sql.Row
+		expected interface{}
+		err      bool
+	}{
+		{"signed result of unsigned literals", NewMinus(NewLiteral(uint8(1), sql.Uint8), NewLiteral(uint8(200), sql.Uint8)), nil, int64(-199), false},
+		{"signed overflow", NewPlus(NewLiteral(int64(math.MaxInt64), sql.Int64), NewLiteral(int8(1), sql.Int8)), nil, nil, true},
+		{"signed underflow", NewMinus(NewLiteral(int64(math.MinInt64), sql.Int64), NewLiteral(int8(1), sql.Int8)), nil, nil, true},
+		{"signed mult overflow", NewMult(NewLiteral(int64(math.MaxInt64), sql.Int64), NewLiteral(int8(2), sql.Int8)), nil, nil, true},
+		{"smallest signed", NewMinus(NewLiteral(int64(-math.MaxInt64), sql.Int64), NewLiteral(int8(1), sql.Int8)), nil, int64(math.MinInt64), false},
+		{"signed mult", NewMult(NewLiteral(int64(-3), sql.Int64), NewLiteral(int32(7), sql.Int32)), nil, int64(-21), false},
+		{"unsigned plus", NewPlus(NewGetField(0, sql.Uint64, "u", false), NewLiteral(int8(1), sql.Int8)), sql.NewRow(uint64(math.MaxUint64 - 1)), uint64(math.MaxUint64), false},
+		{"unsigned overflow", NewPlus(NewGetField(0, sql.Uint64, "u", false), NewLiteral(int8(1), sql.Int8)), sql.NewRow(uint64(math.MaxUint64)), nil, true},
+		{"unsigned plus negative", NewPlus(NewGetField(0, sql.Uint64, "u", false), NewLiteral(int8(-1), sql.Int8)), sql.NewRow(uint64(1)), uint64(0), false},
+		{"negative unsigned", NewMinus(NewGetField(0, sql.Uint64, "u", false), NewLiteral(int8(2), sql.Int8)), sql.NewRow(uint64(1)), nil, true},
+		{"unsigned mult negative", NewMult(NewGetField(0, sql.Uint64, "u", false), NewLiteral(int8(-1), sql.Int8)), sql.NewRow(uint64(0)), uint64(0), false},
+		{"unsigned literal", NewPlus(NewLiteral(uint64(math.MaxUint64-1), sql.Uint64), NewLiteral(int8(1), sql.Int8)), nil, uint64(math.MaxUint64), false},
+		{"div truncates", NewIntDiv(NewLiteral(int8(-7), sql.Int8), NewLiteral(int8(2), sql.Int8)), nil, int64(-3), false},
+		{"div negative divisor", NewIntDiv(NewLiteral(int8(7), sql.Int8), NewLiteral(int8(-2), sql.Int8)), nil, int64(-3), false},
+		{"div overflow", NewIntDiv(NewLiteral(int64(math.MinInt64), sql.Int64), NewLiteral(int8(-1), sql.Int8)), nil, nil, true},
+		{"div unsigned", NewIntDiv(NewGetField(0, sql.Uint64, "u", false), NewLiteral(int8(2), sql.Int8)), sql.NewRow(uint64(math.MaxUint64)), uint64(math.MaxInt64), false},
+		{"div negative unsigned", NewIntDiv(NewGetField(0, sql.Uint64, "u", false), NewLiteral(int8(-1), sql.Int8)), sql.NewRow(uint64(1)), nil, true},
+		{"div by zero", NewIntDiv(NewLiteral(int8(7), sql.Int8), NewLiteral(int8(0), sql.Int8)), nil, sql.Null, false},
+		{"div float", NewIntDiv(NewLiteral(5.5, sql.Float64), NewLiteral(int8(2), sql.Int8)), nil, int64(2), false},
+		{"div negative float", NewIntDiv(NewLiteral(-5.5, sql.Float64), NewLiteral(int8(2), sql.Int8)), nil, int64(-2), false},
+		{"div string", NewIntDiv(NewLiteral("7", sql.LongText), NewLiteral(int8(2), sql.Int8)), nil, int64(3), false},
+		{"div float overflow", NewIntDiv(NewLiteral(1e30, sql.Float64), NewLiteral(int8(1), sql.Int8)), nil, nil, true},
+		{"mod negative dividend", NewMod(NewLiteral(int8(-7), sql.Int8), NewLiteral(int8(3), sql.Int8)), nil, int64(-1), false},
+		{"mod negative divisor", NewMod(NewLiteral(int8(7), sql.Int8), NewLiteral(int8(-3), sql.Int8)), nil, int64(1), false},
+		{"mod unsigned dividend", NewMod(NewGetField(0, sql.Uint64, "u", false), NewLiteral(int8(-3), sql.Int8)), sql.NewRow(uint64(math.MaxUint64)), uint64(0), false},
+		{"mod unsigned divisor", NewMod(NewLiteral(int8(-7), sql.Int8), NewGetField(0, sql.Uint64, "u", false)), sql.NewRow(uint64(3)), int64(-1), false},
+		{"mod smallest signed", NewMod(NewLiteral(int64(math.MinInt64), sql.Int64), NewLiteral(int8(-1), sql.Int8)), nil, int64(0), false},
+		{"mod float", NewMod(NewLiteral(-5.5, sql.Float64), NewLiteral(int8(2), sql.Int8)), nil, -1.5, false},
+		{"mod by zero", NewMod(NewLiteral(int8(7), sql.Int8), NewLiteral(int8(0), sql.Int8)), nil, sql.Null, false},
+		{"negate smallest signed", NewUnaryMinus(NewLiteral(int64(math.MinInt64), sql.Int64)), nil, nil, true},
+		{"negate large unsigned", NewUnaryMinus(NewGetField(0, sql.Uint64, "u", false)), sql.NewRow(uint64(1<<63 + 1)), nil, true},
+		{"negate 1 << 63", NewUnaryMinus(NewGetField(0, sql.Uint64, "u", false)), sql.NewRow(uint64(1 << 63)), int64(math.MinInt64), false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			result, err := tt.expr.Eval(sql.NewEmptyContext(), tt.row)
+			if tt.err {
+				require.Error(err)
+				require.True(sql.ErrDataOutOfRange.Is(err), "%v", err)
+			} else {
+				require.NoError(err)
+				require.Equal(tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestIntegerArithmeticType(t *testing.T) {
+	unsigned := NewGetField(0, sql.Uint64, "u", false)
+	signed := NewGetField(1, sql.Int64, "i", false)
+	smallUnsigned := NewLiteral(uint8(200), sql.Uint8)
+
+	require := require.New(t)
+	require.Equal(sql.Uint64, NewPlus(unsigned, signed).Type())
+	require.Equal(sql.Uint64, NewMinus(signed, unsigned).Type())
+	require.Equal(sql.Int64, NewMinus(signed, smallUnsigned).Type())
+	require.Equal(sql.Uint64, NewMult(unsigned, smallUnsigned).Type())
+	require.Equal(sql.Uint64, NewIntDiv(signed, unsigned).Type())
+	require.Equal(sql.Uint64, NewMod(unsigned, signed).Type())
+	require.Equal(sql.Int64, NewMod(signed, unsigned).Type())
+	require.Equal(sql.Float64, NewMod(signed, NewLiteral(1.5, sql.Float64)).Type())
+	require.Equal(sql.Int64, NewIntDiv(signed, NewLiteral(1.5, sql.Float64)).Type())
+	require.Equal(sql.Uint64, NewBitAnd(signed, signed).Type())
+	require.Equal(sql.Uint64, NewBitNot(signed).Type())
+}
+
+func TestBitOperationsOnNegatives(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		expr     sql.Expression
+		expected uint64
+	}{
+		{"-1 & 1", NewBitAnd(NewLiteral(int8(-1), sql.Int8), NewLiteral(int8(1), sql.Int8)), 1},
+		{"-1 | 0", NewBitOr(NewLiteral(int8(-1), sql.Int8), NewLiteral(int8(0), sql.Int8)), math.MaxUint64},
+		{"1 ^ -1", NewBitXor(NewLiteral(int8(1), sql.Int8), NewLiteral(int8(-1), sql.Int8)), math.MaxUint64 - 1},
+		{"-1 >> 1", NewShiftRight(NewLiteral(int8(-1), sql.Int8), NewLiteral(int8(1), sql.Int8)), math.MaxInt64},
+		{"1 << 64", NewShiftLeft(NewLiteral(int8(1), sql.Int8), NewLiteral(int8(64), sql.Int8)), 0},
+		{"1 << 63", NewShiftLeft(NewLiteral(int8(1), sql.Int8), NewLiteral(int8(63), sql.Int8)), 1 << 63},
+		{"1.5 | 0", NewBitOr(NewLiteral(1.5, sql.Float64), NewLiteral(int8(0), sql.Int8)), 2},
+		{"-1.5 | 0", NewBitOr(NewLiteral(-1.5, sql.Float64), NewLiteral(int8(0), sql.Int8)), math.MaxUint64 - 1},
+		{"1e30 | 0", NewBitOr(NewLiteral(1e30, sql.Float64), NewLiteral(int8(0), sql.Int8)), math.MaxUint64},
+		{"'-1' | 0", NewBitOr(NewLiteral("-1", sql.LongText), NewLiteral(int8(0), sql.Int8)), math.MaxUint64},
+		{"~0", NewBitNot(NewLiteral(int8(0), sql.Int8)), math.MaxUint64},
+		{"~-1", NewBitNot(NewLiteral(int8(-1), sql.Int8)), 0},
+		{"~18446744073709551614", NewBitNot(NewLiteral(uint64(math.MaxUint64-1), sql.Uint64)), 1},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.expr.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+
+	result, err := NewBitNot(NewLiteral(nil, sql.Null)).Eval(sql.NewEmptyContext(), nil)
+	require.NoError(t, err)
+	require.Nil(t, result)
+}
+
 func TestAllFloat64(t *testing.T) {
 	var testCases = []struct {
 		op       string
@@ -443,13 +554,13 @@ func TestAllInt64(t *testing.T) {
 	var testCases = []struct {
 		op       string
 		value    int64
-		expected int64
+		expected interface{}
 	}{
-		{"|", 1, 1},
-		{"&", 3, 1},
-		{"^", 1024, 1025},
-		{"%", 1024, 1},
-		{"div", 1024, 0},
+		{"|", 1, uint64(1)},
+		{"&", 3, uint64(1)},
+		{"^", 1024, uint64(1025)},
+		{"%", 1024, int64(1)},
+		{"div", 1024, int64(0)},
 	}
 
 	// (((((0 | 1) & 3) ^ 1024) % 1024) div 1024) == 0
@@ -468,6 +579,43 @@ func TestAllInt64(t *testing.T) {
 	}
 }
 
+func TestDecimalArithmetic(t *testing.T) {
+	dec52 := sql.MustCreateDecimalType(5, 2)
+	dec103 := sql.MustCreateDecimalType(10, 3)
+
+	testCases := []struct {
+		name     string
+		op       string
+		left     *Literal
+		right    *Literal
+		typ      sql.Type
+		expected interface{}
+	}{
+		{"plus", "+", NewLiteral("0.10", dec52), NewLiteral("0.20", dec52), sql.MustCreateDecimalType(6, 2), "0.30"},
+		{"plus mixed scale", "+", NewLiteral("1.25", dec52), NewLiteral("0.125", dec103), sql.MustCreateDecimalType(11, 3), "1.375"},
+		{"plus integer", "+", NewLiteral("999.99", dec52), NewLiteral(int8(1), sql.Int8), sql.MustCreateDecimalType(6, 2), "1000.99"},
+		{"minus", "-", NewLiteral("0.30", dec52), NewLiteral("0.10", dec52), sql.MustCreateDecimalType(6, 2), "0.20"},
+		{"mult", "*", NewLiteral("1.10", dec52), NewLiteral("1.10", dec52), sql.MustCreateDecimalType(10, 4), "1.2100"},
+		{"div", "/", NewLiteral("1.00", dec52), NewLiteral("3.00", dec52), sql.MustCreateDecimalType(11, 6), "0.333333"},
+		{"div integer", "/", NewLiteral(int64(2), sql.Int64), NewLiteral("3.00", dec52), sql.MustCreateDecimalType(25, 4), "0.6667"},
+		{"div by zero", "/", NewLiteral("1.00", dec52), NewLiteral("0.00", dec52), sql.MustCreateDecimalType(11, 6), sql.Null},
+		{"mod", "%", NewLiteral("5.50", dec52), NewLiteral("2.00", dec52), sql.MustCreateDecimalType(5, 2), "1.50"},
+		{"float is not exact", "+", NewLiteral("0.10", dec52), NewLiteral(0.2, sql.Float64), sql.Float64, 0.30000000000000004},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			a := NewArithmetic(tt.left, tt.right, tt.op)
+			require.Equal(tt.typ, a.Type())
+
+			result, err := a.Eval(sql.NewEmptyContext(), sql.NewRow())
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+}
+
 func TestUnaryMinus(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -483,6 +631,7 @@ func TestUnaryMinus(t *testing.T) {
 		{"float64", float64(1), sql.Float64, float64(-1)},
 		{"int text", "1", sql.LongText, float64(-1)},
 		{"float text", "1.2", sql.LongText, float64(-1.2)},
+		{"decimal", "1.20", sql.MustCreateDecimalType(5, 2), "-1.20"},
 		{"nil", nil, sql.LongText, nil},
 	}
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/shopspring/decimal"
 	errors "gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/go-mysql-server/internal/regex"
@@ -57,6 +58,21 @@ func (c *comparison) Compare(ctx *sql.Context, row sql.Row) (int, error) {
 	return compareType.Compare(left, right)
 }
 
+// jsonOperand returns the JSON document a value of the given type is compared
+// as when the other operand is JSON. Strings that are not JSON are compared as
+// JSON strings, not parsed.
+func jsonOperand(t sql.Type, v interface{}) (interface{}, error) {
+	if t == sql.JSON {
+		return sql.JSON.Convert(v)
+	}
+
+	val, err := sql.JSONValueOf(t, v)
+	if err != nil {
+		return nil, err
+	}
+	return sql.JSONDocument{Val: val}, nil
+}
+
 func (c *comparison) evalLeftAndRight(ctx *sql.Context, row sql.Row) (interface{}, interface{}, error) {
 	left, err := c.Left().Eval(ctx, row)
 	if err != nil {
@@ -74,19 +90,24 @@ func (c *comparison) evalLeftAndRight(ctx *sql.Context, row sql.Row) (interface{
 func (c *comparison) castLeftAndRight(left, right interface{}) (interface{}, interface{}, sql.Type, error) {
 	leftType := c.Left().Type()
 	rightType := c.Right().Type()
+	if leftType == sql.JSON || rightType == sql.JSON {
+		l, err := jsonOperand(leftType, left)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		r, err := jsonOperand(rightType, right)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		return l, r, sql.JSON, nil
+	}
+
 	if sql.IsNumber(leftType) || sql.IsNumber(rightType) {
 		if sql.IsDecimal(leftType) || sql.IsDecimal(rightType) {
-			//TODO: We need to set to the actual DECIMAL type
-			l, r, err := convertLeftAndRight(left, right, ConvertToDecimal)
-			if err != nil {
-				return nil, nil, nil, err
-			}
-
-			if sql.IsDecimal(leftType) {
-				return l, r, leftType, nil
-			} else {
-				return l, r, rightType, nil
-			}
+			l, r := decimalOperand(left), decimalOperand(right)
+			return l, r, decimalComparisonType(l, r), nil
 		}
 
 		if sql.IsFloat(leftType) || sql.IsFloat(rightType) {
@@ -120,16 +141,66 @@ func (c *comparison) castLeftAndRight(left, right interface{}) (interface{}, int
 		return nil, nil, nil, err
 	}
 
-	return left, right, sql.LongText, nil
+	return left, right, sql.CreateLongText(c.collation()), nil
+}
+
+// collation returns the collation used to compare both sides as strings. A
+// binary string on either side makes the comparison binary. Otherwise, as in
+// MySQL, the collation of a column takes precedence over the one of a
+// literal, and the left side is used when both are of the same kind.
+func (c *comparison) collation() sql.Collation {
+	leftType, leftOk := c.Left().Type().(sql.StringType)
+	rightType, rightOk := c.Right().Type().(sql.StringType)
+	switch {
+	case leftOk && leftType.Collation() == sql.Collation_binary,
+		rightOk && rightType.Collation() == sql.Collation_binary:
+		return sql.Collation_binary
+	case leftOk && rightOk:
+		if _, ok := c.Left().(*Literal); ok {
+			return rightType.Collation()
+		}
+		return leftType.Collation()
+	case leftOk:
+		return leftType.Collation()
+	case rightOk:
+		return rightType.Collation()
+	default:
+		return sql.Collation_Default
+	}
+}
+
+// decimalOperand returns the exact decimal value of an operand compared with
+// a DECIMAL. Values that are not numbers are compared as 0.
+func decimalOperand(v interface{}) decimal.Decimal {
+	dec, err := sql.ToDecimal(v)
+	if err != nil || !dec.Valid {
+		return decimal.Zero
+	}
+	return dec.Decimal
+}
+
+// decimalComparisonType returns a DECIMAL type with enough scale to compare
+// the given values without rounding either of them.
+func decimalComparisonType(l, r decimal.Decimal) sql.Type {
+	scale := int32(0)
+	for _, d := range []decimal.Decimal{l, r} {
+		if -d.Exponent() > scale {
+			scale = -d.Exponent()
+		}
+	}
+	if scale > sql.DecimalTypeMaxScale {
+		scale = sql.DecimalTypeMaxScale
+	}
+	return sql.MustCreateDecimalType(sql.DecimalTypeMaxPrecision, uint8(scale))
 }
 
 func convertLeftAndRight(left, right interface{}, convertTo string) (interface{}, interface{}, error) {
-	l, err := convertValue(left, convertTo)
+	l, err := convertValue(left, convertTo, 0, 0)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	r, err := convertValue(right, convertTo)
+	r, err := convertValue(right, convertTo, 0, 0)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -173,6 +173,18 @@ func TestGreaterThan(t *testing.T) {
 	}
 }
 
+func TestDecimalComparison(t *testing.T) {
+	require := require.New(t)
+
+	dec := expression.NewGetField(0, sql.MustCreateDecimalType(10, 2), "col1", true)
+	row := sql.NewRow("1.23")
+
+	require.Equal(true, eval(t, expression.NewEquals(dec, expression.NewLiteral("1.230", sql.LongText)), row))
+	require.Equal(false, eval(t, expression.NewEquals(dec, expression.NewLiteral(1.234, sql.Float64)), row))
+	require.Equal(true, eval(t, expression.NewLessThan(dec, expression.NewLiteral(1.234, sql.Float64)), row))
+	require.Equal(true, eval(t, expression.NewGreaterThan(expression.NewLiteral(int64(123456789012), sql.Int64), dec), row))
+}
+
 func TestRegexp(t *testing.T) {
 	for _, engine := range regex.Engines() {
 		regex.SetDefault(engine)
@@ -1,13 +1,14 @@
 package expression
 
 import (
-	"encoding/json"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/spf13/cast"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/shopspring/decimal"
 	"gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -16,6 +17,13 @@ import (
 // ErrConvertExpression is returned when a conversion is not possible.
 var ErrConvertExpression = errors.NewKind("expression '%v': couldn't convert to %v")
 
+// ErrConvertPrecisionTooBig is returned when the precision of the type to cast is larger than the maximum one.
+var ErrConvertPrecisionTooBig = errors.NewKind("Too-big precision %d specified for '%s'. Maximum is %d.")
+
+// ERTruncatedWrongValue is the code of the warning added when a value is truncated or can't be read as the type
+// it's cast to.
+const ERTruncatedWrongValue = 1292
+
 const (
 	// ConvertToBinary is a conversion to binary.
 	ConvertToBinary = "binary"
@@ -31,6 +39,8 @@ const (
 	ConvertToDecimal = "decimal"
 	// ConvertToDouble is a conversion to double.
 	ConvertToDouble = "double"
+	// ConvertToFloat is a conversion to float.
+	ConvertToFloat = "float"
 	// ConvertToJSON is a conversion to json.
 	ConvertToJSON = "json"
 	// ConvertToReal is a conversion to double.
@@ -41,6 +51,17 @@ const (
 	ConvertToTime = "time"
 	// ConvertToUnsigned is a conversion to unsigned.
 	ConvertToUnsigned = "unsigned"
+	// ConvertToYear is a conversion to year.
+	ConvertToYear = "year"
+)
+
+const (
+	// ConvertMaxFractionalSeconds is the largest precision of the TIME and DATETIME types to cast.
+	ConvertMaxFractionalSeconds = 6
+	// ConvertMaxFloatPrecision is the largest precision of the FLOAT type to cast.
+	ConvertMaxFloatPrecision = 53
+	// convertMaxFloat32Precision is the largest precision of a FLOAT that is single-precision.
+	convertMaxFloat32Precision = 24
 )
 
 // Convert represent a CAST(x AS T) or CONVERT(x, T) operation that casts x expression to type T.
@@ -48,21 +69,47 @@ type Convert struct {
 	UnaryExpression
 	// Type to cast
 	castToType string
+	// Length and scale of the type to cast, when it has them. The length is
+	// the number of characters or bytes of a string, the precision of a
+	// number or the fractional seconds precision of a time.
+	typeLength int
+	typeScale  int
+	// Character set of the string to cast to, when it's given
+	charset sql.CharacterSet
 }
 
 // NewConvert creates a new Convert expression.
 func NewConvert(expr sql.Expression, castToType string) *Convert {
+	return NewConvertWithLengthAndScale(expr, castToType, 0, 0)
+}
+
+// NewConvertWithLengthAndScale creates a new Convert expression with the
+// given length and scale for the type to cast, such as the precision and
+// scale of a DECIMAL. A length of 0 uses the default one for the type.
+func NewConvertWithLengthAndScale(expr sql.Expression, castToType string, typeLength, typeScale int) *Convert {
 	return &Convert{
 		UnaryExpression: UnaryExpression{Child: expr},
 		castToType:      strings.ToLower(castToType),
+		typeLength:      typeLength,
+		typeScale:       typeScale,
 	}
 }
 
+// NewConvertWithCharset creates a new Convert expression that casts to a
+// string of the given length and character set, as CHAR(n) CHARACTER SET cs.
+func NewConvertWithCharset(expr sql.Expression, castToType string, typeLength int, charset sql.CharacterSet) *Convert {
+	c := NewConvertWithLengthAndScale(expr, castToType, typeLength, 0)
+	c.charset = charset
+	return c
+}
+
 // IsNullable implements the Expression interface.
 func (c *Convert) IsNullable() bool {
 	switch c.castToType {
-	case ConvertToDate, ConvertToDatetime:
+	case ConvertToDate, ConvertToDatetime, ConvertToTime, ConvertToYear:
 		return true
+	case ConvertToChar, ConvertToNChar:
+		return c.charset != "" || c.Child.IsNullable()
 	default:
 		return c.Child.IsNullable()
 	}
@@ -72,34 +119,107 @@ func (c *Convert) IsNullable() bool {
 func (c *Convert) Type() sql.Type {
 	switch c.castToType {
 	case ConvertToBinary:
-		return sql.LongBlob
+		return convertToStringType(c.typeLength, sql.Collation_binary)
 	case ConvertToChar, ConvertToNChar:
-		return sql.LongText
+		return convertToStringType(c.typeLength, c.collation())
 	case ConvertToDate:
 		return sql.Date
 	case ConvertToDatetime:
+		if typ, err := sql.CreateDatetimeTypeWithPrecision(sqltypes.Datetime, c.typeLength); err == nil {
+			return typ
+		}
 		return sql.Datetime
 	case ConvertToDecimal:
-		//TODO: these values are completely arbitrary, we need to get the given precision/scale and store it
-		return sql.MustCreateDecimalType(65, 10)
+		return convertToDecimalType(c.typeLength, c.typeScale)
 	case ConvertToDouble, ConvertToReal:
 		return sql.Float64
+	case ConvertToFloat:
+		if c.typeLength > convertMaxFloat32Precision {
+			return sql.Float64
+		}
+		return sql.Float32
 	case ConvertToJSON:
 		return sql.JSON
 	case ConvertToSigned:
 		return sql.Int64
 	case ConvertToTime:
+		if typ, err := sql.CreateTimeTypeWithPrecision(c.typeLength); err == nil {
+			return typ
+		}
 		return sql.Time
 	case ConvertToUnsigned:
 		return sql.Uint64
+	case ConvertToYear:
+		return sql.Year
 	default:
 		return sql.Null
 	}
 }
 
+// collation returns the collation of the string to cast to, which is the
+// default one of its character set.
+func (c *Convert) collation() sql.Collation {
+	switch {
+	case c.charset != "":
+		return c.charset.DefaultCollation()
+	case c.castToType == ConvertToNChar:
+		return sql.CharacterSet_utf8mb3.DefaultCollation()
+	default:
+		return sql.Collation_Default
+	}
+}
+
+// convertToStringType returns the string type with the given length and
+// collation, which is a VARCHAR, or a VARBINARY for the binary collation. It's
+// a LONGTEXT or a LONGBLOB when no length is given or the length is too large.
+func convertToStringType(length int, collation sql.Collation) sql.StringType {
+	if length > 0 {
+		if typ, err := sql.CreateString(sqltypes.VarChar, int64(length), collation); err == nil {
+			return typ
+		}
+	}
+	if collation == sql.Collation_binary {
+		return sql.LongBlob
+	}
+	return sql.CreateLongText(collation)
+}
+
+// convertToDecimalType returns the DECIMAL type with the given precision and
+// scale, which is DECIMAL(10,0) when no precision is given, as in MySQL.
+// Precisions and scales out of range are clamped to the maximum ones.
+func convertToDecimalType(precision, scale int) sql.DecimalType {
+	if precision > sql.DecimalTypeMaxPrecision {
+		precision = sql.DecimalTypeMaxPrecision
+	}
+	if scale > sql.DecimalTypeMaxScale {
+		scale = sql.DecimalTypeMaxScale
+	}
+	if precision <= 0 {
+		precision = 10
+	}
+	if scale > precision {
+		scale = precision
+	}
+	return sql.MustCreateDecimalType(uint8(precision), uint8(scale))
+}
+
 // Name implements the Expression interface.
 func (c *Convert) String() string {
-	return fmt.Sprintf("convert(%v, %v)", c.Child, c.castToType)
+	return fmt.Sprintf("convert(%v, %v)", c.Child, c.typeString())
+}
+
+// typeString returns the type to cast to as written in a query.
+func (c *Convert) typeString() string {
+	s := c.castToType
+	if c.castToType == ConvertToDecimal && c.typeLength > 0 {
+		s = fmt.Sprintf("%s(%d,%d)", s, c.typeLength, c.typeScale)
+	} else if c.typeLength > 0 {
+		s = fmt.Sprintf("%s(%d)", s, c.typeLength)
+	}
+	if c.charset != "" {
+		s = fmt.Sprintf("%s character set %s", s, c.charset)
+	}
+	return s
 }
 
 // WithChildren implements the Expression interface.
@@ -107,7 +227,9 @@ func (c *Convert) WithChildren(children ...sql.Expression) (sql.Expression, erro
 	if len(children) != 1 {
 		return nil, sql.ErrInvalidChildrenNumber.New(c, len(children), 1)
 	}
-	return NewConvert(children[0], c.castToType), nil
+	nc := *c
+	nc.Child = children[0]
+	return &nc, nil
 }
 
 // Eval implements the Expression interface.
@@ -121,7 +243,7 @@ func (c *Convert) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, nil
 	}
 
-	casted, err := convertValue(val, c.castToType)
+	casted, err := c.castValue(ctx, val)
 	if err != nil {
 		return nil, ErrConvertExpression.Wrap(err, c.String(), c.castToType)
 	}
@@ -129,10 +251,277 @@ func (c *Convert) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	return casted, nil
 }
 
+// castValue only returns an error if converting to JSON. Values that can't be read as the type to cast are converted
+// with a warning to the zero value of number types, or to nil for the rest of types.
+func (c *Convert) castValue(ctx *sql.Context, val interface{}) (interface{}, error) {
+	// JSON scalars are converted to other types by their value, while
+	// strings get the JSON text of the document.
+	if doc, ok := val.(sql.JSONDocument); ok {
+		switch c.castToType {
+		case ConvertToBinary, ConvertToChar, ConvertToNChar, ConvertToJSON:
+		default:
+			val = doc.Val
+		}
+	}
+
+	switch c.castToType {
+	case ConvertToBinary:
+		return c.convertToString(ctx, val, sql.CharacterSet_binary), nil
+	case ConvertToChar, ConvertToNChar:
+		return c.convertToString(ctx, val, c.collation().CharacterSet()), nil
+	case ConvertToDate, ConvertToDatetime:
+		_, isTime := val.(time.Time)
+		_, isString := val.(string)
+		if !(isTime || isString) {
+			ctx.Warn(ERTruncatedWrongValue, "Incorrect datetime value: '%v'", val)
+			return nil, nil
+		}
+		typ := c.Type()
+		d, err := typ.Convert(val)
+		if err == nil && c.castToType == ConvertToDatetime {
+			d, err = typ.Convert(roundTime(d.(time.Time), c.typeLength))
+		}
+		if err != nil {
+			ctx.Warn(ERTruncatedWrongValue, "Incorrect datetime value: '%v'", val)
+			return nil, nil
+		}
+		return d, nil
+	case ConvertToDecimal:
+		typ := convertToDecimalType(c.typeLength, c.typeScale)
+		d := c.toDecimal(ctx, val, "DECIMAL", false).Round(int32(typ.Scale()))
+		max := typ.ExclusiveUpperBound().Sub(decimal.New(1, -int32(typ.Scale())))
+		if d.Abs().GreaterThan(max) {
+			ctx.Warn(sql.ERWarnDataOutOfRange, "Out of range value for column '%s' at row 1", c)
+			if d.Sign() < 0 {
+				d = max.Neg()
+			} else {
+				d = max
+			}
+		}
+		return d.StringFixed(int32(typ.Scale())), nil
+	case ConvertToDouble, ConvertToReal, ConvertToFloat:
+		f := c.toFloat64(ctx, val)
+		if c.Type() == sql.Float32 {
+			return float32(math.Max(-math.MaxFloat32, math.Min(f, math.MaxFloat32))), nil
+		}
+		return f, nil
+	case ConvertToJSON:
+		return sql.JSON.Convert(val)
+	case ConvertToSigned:
+		d := c.toDecimal(ctx, val, "INTEGER", true)
+		switch {
+		case d.GreaterThan(maxUint64Decimal) || d.LessThan(minInt64Decimal):
+			ctx.Warn(ERTruncatedWrongValue, "Truncated incorrect INTEGER value: '%v'", val)
+			if d.Sign() < 0 {
+				return int64(math.MinInt64), nil
+			}
+			return int64(math.MaxInt64), nil
+		case d.GreaterThan(maxInt64Decimal):
+			// integers that only fit in a BIGINT UNSIGNED keep their bits
+			return int64(d.Coefficient().Uint64()), nil
+		default:
+			return d.IntPart(), nil
+		}
+	case ConvertToTime:
+		if t, ok := val.(time.Time); ok {
+			val = t.Format("15:04:05.000000")
+		}
+		t, err := sql.Time.Marshal(val)
+		if err != nil {
+			ctx.Warn(ERTruncatedWrongValue, "Truncated incorrect time value: '%v'", val)
+			return nil, nil
+		}
+		return c.Type().Convert(sql.Time.Unmarshal(roundMicroseconds(t, c.typeLength)))
+	case ConvertToUnsigned:
+		d := c.toDecimal(ctx, val, "INTEGER", true)
+		switch {
+		case d.GreaterThan(maxUint64Decimal) || d.LessThan(minInt64Decimal):
+			ctx.Warn(ERTruncatedWrongValue, "Truncated incorrect INTEGER value: '%v'", val)
+			if d.Sign() < 0 {
+				return uint64(1 << 63), nil
+			}
+			return uint64(math.MaxUint64), nil
+		case d.Sign() < 0:
+			// negative integers keep their bits
+			return uint64(d.IntPart()), nil
+		default:
+			return d.Coefficient().Uint64(), nil
+		}
+	case ConvertToYear:
+		if s, ok := val.(string); ok {
+			val = strings.TrimSpace(s)
+		}
+		y, err := sql.Year.Convert(val)
+		if err != nil {
+			ctx.Warn(ERTruncatedWrongValue, "Truncated incorrect YEAR value: '%v'", val)
+			return nil, nil
+		}
+		return y, nil
+	default:
+		return nil, nil
+	}
+}
+
+// convertToString converts a value to a string of the given character set, cut to the length of the type to cast, or
+// padded to it with zero bytes for binary strings.
+func (c *Convert) convertToString(ctx *sql.Context, val interface{}, charset sql.CharacterSet) interface{} {
+	v, err := sql.LongText.Convert(val)
+	if err != nil {
+		return nil
+	}
+	s := v.(string)
+
+	if c.charset != "" || charset == sql.CharacterSet_binary {
+		from := sql.Collation_Default.CharacterSet()
+		if st, ok := c.Child.Type().(sql.StringType); ok {
+			from = st.CharacterSet()
+		}
+		v := convertToCharset(ctx, s, from, charset)
+		if v == nil {
+			return nil
+		}
+		s = v.(string)
+	}
+
+	if c.typeLength <= 0 {
+		return s
+	}
+
+	if charset == sql.CharacterSet_binary {
+		if len(s) > c.typeLength {
+			ctx.Warn(ERTruncatedWrongValue, "Truncated incorrect BINARY(%d) value: '%s'", c.typeLength, s)
+			return s[:c.typeLength]
+		}
+		return s + strings.Repeat("\x00", c.typeLength-len(s))
+	}
+
+	var chars int
+	for i := range s {
+		if chars == c.typeLength {
+			ctx.Warn(ERTruncatedWrongValue, "Truncated incorrect CHAR(%d) value: '%s'", c.typeLength, s)
+			return s[:i]
+		}
+		chars++
+	}
+	return s
+}
+
+// toDecimal reads a value as a number for a cast, which is rounded to an integer if integer is true. Strings are read
+// up to the first character that isn't part of a number, or of an integer if integer is true, with a warning with the
+// given name of the type to cast, and dates and times are read as numbers such as YYYYMMDDhhmmss.
+func (c *Convert) toDecimal(ctx *sql.Context, val interface{}, typeName string, integer bool) decimal.Decimal {
+	switch v := val.(type) {
+	case bool:
+		if v {
+			return decimal.New(1, 0)
+		}
+		return decimal.Zero
+	case float64:
+		// doubles are rounded half to even, as MySQL does with rint()
+		if integer && !math.IsNaN(v) && !math.IsInf(v, 0) {
+			val = math.RoundToEven(v)
+		}
+	case float32:
+		if integer {
+			val = math.RoundToEven(float64(v))
+		}
+	case time.Time:
+		layout := "20060102150405.000000"
+		if c.Child.Type() == sql.Date {
+			layout = "20060102"
+		}
+		val = v.Format(layout)
+	case string:
+		if sql.IsDecimal(c.Child.Type()) {
+			break
+		}
+		trimmed := strings.TrimSpace(v)
+		prefix := sql.NumberPrefix(trimmed)
+		if integer {
+			prefix = prefix[:len(prefix)-len(strings.TrimLeft(prefix, "+-0123456789"))]
+		}
+		if prefix == "" || prefix != trimmed {
+			ctx.Warn(ERTruncatedWrongValue, "Truncated incorrect %s value: '%s'", typeName, v)
+		}
+		d, err := decimal.NewFromString(prefix)
+		if err != nil {
+			return decimal.Zero
+		}
+		return d
+	}
+
+	d, err := sql.ToDecimal(val)
+	if err != nil || !d.Valid {
+		ctx.Warn(ERTruncatedWrongValue, "Truncated incorrect %s value: '%v'", typeName, val)
+		return decimal.Zero
+	}
+	if integer {
+		return d.Decimal.Round(0)
+	}
+	return d.Decimal
+}
+
+// toFloat64 reads a value as a number for a cast to a floating point type.
+func (c *Convert) toFloat64(ctx *sql.Context, val interface{}) float64 {
+	switch v := val.(type) {
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	case string:
+		if !sql.IsDecimal(c.Child.Type()) {
+			trimmed := strings.TrimSpace(v)
+			prefix := sql.NumberPrefix(trimmed)
+			if prefix == "" || prefix != trimmed {
+				ctx.Warn(ERTruncatedWrongValue, "Truncated incorrect DOUBLE value: '%s'", v)
+			}
+			f, err := strconv.ParseFloat(prefix, 64)
+			if err != nil && !math.IsInf(f, 0) {
+				return 0
+			}
+			return math.Max(-math.MaxFloat64, math.Min(f, math.MaxFloat64))
+		}
+	}
+
+	f, _ := c.toDecimal(ctx, val, "DOUBLE", false).Float64()
+	return f
+}
+
+// roundTime rounds a time to the given number of fractional seconds digits.
+func roundTime(t time.Time, fsp int) time.Time {
+	return t.Round(time.Duration(math.Pow10(9 - fsp)))
+}
+
+// roundMicroseconds rounds a number of microseconds to the given number of fractional seconds digits, away from zero.
+func roundMicroseconds(micros int64, fsp int) int64 {
+	unit := int64(math.Pow10(ConvertMaxFractionalSeconds - fsp))
+	neg := micros < 0
+	if neg {
+		micros = -micros
+	}
+	micros = (micros + unit/2) / unit * unit
+	if neg {
+		return -micros
+	}
+	return micros
+}
+
 // convertValue only returns an error if converting to JSON, and returns the zero value for float types.
-// Nil is returned in all other cases.
-func convertValue(val interface{}, castTo string) (interface{}, error) {
-	switch strings.ToLower(castTo) {
+// Nil is returned in all other cases. The length and scale are only used by DECIMAL conversions.
+func convertValue(val interface{}, castTo string, typeLength, typeScale int) (interface{}, error) {
+	castTo = strings.ToLower(castTo)
+
+	// JSON scalars are converted to other types by their value, while
+	// strings get the JSON text of the document.
+	if doc, ok := val.(sql.JSONDocument); ok {
+		switch castTo {
+		case ConvertToBinary, ConvertToChar, ConvertToNChar, ConvertToJSON:
+		default:
+			val = doc.Val
+		}
+	}
+
+	switch castTo {
 	case ConvertToBinary:
 		b, err := sql.LongBlob.Convert(val)
 		if err != nil {
@@ -168,8 +557,7 @@ func convertValue(val interface{}, castTo string) (interface{}, error) {
 		}
 		return d, nil
 	case ConvertToDecimal:
-		//TODO: these values are completely arbitrary, we need to get the given precision/scale and store it
-		typ := sql.MustCreateDecimalType(65, 10)
+		typ := convertToDecimalType(typeLength, typeScale)
 		d, err := typ.Convert(val)
 		if err != nil {
 			return typ.Zero(), nil
@@ -182,18 +570,7 @@ func convertValue(val interface{}, castTo string) (interface{}, error) {
 		}
 		return d, nil
 	case ConvertToJSON:
-		s, err := cast.ToStringE(val)
-		if err != nil {
-			return nil, err
-		}
-
-		var jsn interface{}
-		err = json.Unmarshal([]byte(s), &jsn)
-		if err != nil {
-			return nil, err
-		}
-
-		return []byte(s), nil
+		return sql.JSON.Convert(val)
 	case ConvertToSigned:
 		num, err := sql.Int64.Convert(val)
 		if err != nil {
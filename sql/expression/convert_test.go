@@ -1,9 +1,11 @@
 package expression
 
 import (
+	"math"
 	"testing"
 	"time"
 
+	"github.com/dolthub/vitess/go/sqltypes"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -119,7 +121,7 @@ func TestConvert(t *testing.T) {
 			row:         nil,
 			castTo:      ConvertToJSON,
 			expression:  NewLiteral(`{"a":2}`, sql.LongText),
-			expected:    []byte(`{"a":2}`),
+			expected:    sql.MustJSON(`{"a":2}`),
 			expectedErr: false,
 		},
 		{
@@ -127,7 +129,23 @@ func TestConvert(t *testing.T) {
 			row:         nil,
 			castTo:      ConvertToJSON,
 			expression:  NewLiteral(2, sql.Int32),
-			expected:    []byte("2"),
+			expected:    sql.MustJSON("2"),
+			expectedErr: false,
+		},
+		{
+			name:        "json to signed",
+			row:         nil,
+			castTo:      ConvertToSigned,
+			expression:  NewLiteral(sql.MustJSON("3"), sql.JSON),
+			expected:    int64(3),
+			expectedErr: false,
+		},
+		{
+			name:        "json to char",
+			row:         nil,
+			castTo:      ConvertToChar,
+			expression:  NewLiteral(sql.MustJSON(`{"b":1,"a":[true]}`), sql.JSON),
+			expected:    `{"a": [true], "b": 1}`,
 			expectedErr: false,
 		},
 		{
@@ -171,3 +189,148 @@ func TestConvert(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertDecimal(t *testing.T) {
+	require := require.New(t)
+
+	c := NewConvert(NewLiteral("12.5", sql.LongText), ConvertToDecimal)
+	require.Equal(sql.MustCreateDecimalType(10, 0), c.Type())
+	v, err := c.Eval(sql.NewEmptyContext(), nil)
+	require.NoError(err)
+	require.Equal("13", v)
+
+	c = NewConvertWithLengthAndScale(NewLiteral(1.005, sql.Float64), ConvertToDecimal, 5, 3)
+	require.Equal(sql.MustCreateDecimalType(5, 3), c.Type())
+	require.Equal("convert(1.005, decimal(5,3))", c.String())
+	v, err = c.Eval(sql.NewEmptyContext(), nil)
+	require.NoError(err)
+	require.Equal("1.005", v)
+
+	e, err := c.WithChildren(NewLiteral("0.1", sql.LongText))
+	require.NoError(err)
+	require.Equal(sql.MustCreateDecimalType(5, 3), e.Type())
+	v, err = e.Eval(sql.NewEmptyContext(), nil)
+	require.NoError(err)
+	require.Equal("0.100", v)
+}
+
+func TestConvertWithLength(t *testing.T) {
+	tests := []struct {
+		name     string
+		convert  *Convert
+		typ      sql.Type
+		expected interface{}
+		warnings int
+	}{
+		{
+			name:     "char with length",
+			convert:  NewConvertWithLengthAndScale(NewLiteral("abcdef", sql.LongText), ConvertToChar, 3, 0),
+			typ:      sql.MustCreateStringWithDefaults(sqltypes.VarChar, 3),
+			expected: "abc",
+			warnings: 1,
+		},
+		{
+			name:     "char with length and character set",
+			convert:  NewConvertWithCharset(NewLiteral("ñu", sql.LongText), ConvertToChar, 5, sql.CharacterSet_latin1),
+			typ:      sql.MustCreateString(sqltypes.VarChar, 5, sql.CharacterSet_latin1.DefaultCollation()),
+			expected: "ñu",
+		},
+		{
+			name:     "char with character set binary",
+			convert:  NewConvertWithCharset(NewLiteral("ab", sql.LongText), ConvertToChar, 0, sql.CharacterSet_binary),
+			typ:      sql.LongBlob,
+			expected: "ab",
+		},
+		{
+			name:     "binary with length",
+			convert:  NewConvertWithLengthAndScale(NewLiteral("ab", sql.LongText), ConvertToBinary, 4, 0),
+			typ:      sql.MustCreateBinary(sqltypes.VarBinary, 4),
+			expected: "ab\x00\x00",
+		},
+		{
+			name:     "datetime with fractional seconds",
+			convert:  NewConvertWithLengthAndScale(NewLiteral("2020-01-02 03:04:05.678", sql.LongText), ConvertToDatetime, 1, 0),
+			typ:      mustCreateDatetimeTypeWithPrecision(1),
+			expected: time.Date(2020, time.January, 2, 3, 4, 5, 700000000, time.UTC),
+		},
+		{
+			name:     "time with fractional seconds",
+			convert:  NewConvertWithLengthAndScale(NewLiteral("-03:04:05.678", sql.LongText), ConvertToTime, 2, 0),
+			typ:      mustCreateTimeTypeWithPrecision(2),
+			expected: "-03:04:05.68",
+		},
+		{
+			name:     "time with a fractional second",
+			convert:  NewConvertWithLengthAndScale(NewLiteral("03:04:05.678", sql.LongText), ConvertToTime, 1, 0),
+			typ:      mustCreateTimeTypeWithPrecision(1),
+			expected: "03:04:05.7",
+		},
+		{
+			name:     "float with single precision",
+			convert:  NewConvertWithLengthAndScale(NewLiteral("1.5x", sql.LongText), ConvertToFloat, 10, 0),
+			typ:      sql.Float32,
+			expected: float32(1.5),
+			warnings: 1,
+		},
+		{
+			name:     "float with double precision",
+			convert:  NewConvertWithLengthAndScale(NewLiteral(int64(3), sql.Int64), ConvertToFloat, 25, 0),
+			typ:      sql.Float64,
+			expected: float64(3),
+		},
+		{
+			name:     "decimal out of range",
+			convert:  NewConvertWithLengthAndScale(NewLiteral(int64(-1000), sql.Int64), ConvertToDecimal, 4, 2),
+			typ:      sql.MustCreateDecimalType(4, 2),
+			expected: "-99.99",
+			warnings: 1,
+		},
+		{
+			name:     "year",
+			convert:  NewConvert(NewLiteral("99", sql.LongText), ConvertToYear),
+			typ:      sql.Year,
+			expected: int16(1999),
+		},
+		{
+			name:     "signed out of range",
+			convert:  NewConvert(NewLiteral("99999999999999999999", sql.LongText), ConvertToSigned),
+			typ:      sql.Int64,
+			expected: int64(math.MaxInt64),
+			warnings: 1,
+		},
+		{
+			name:     "unsigned double",
+			convert:  NewConvert(NewLiteral(float64(2.5), sql.Float64), ConvertToUnsigned),
+			typ:      sql.Uint64,
+			expected: uint64(2),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := sql.NewEmptyContext()
+			require.Equal(test.typ, test.convert.Type())
+			val, err := test.convert.Eval(ctx, nil)
+			require.NoError(err)
+			require.Equal(test.expected, val)
+			require.Len(ctx.Warnings(), test.warnings)
+		})
+	}
+}
+
+func mustCreateDatetimeTypeWithPrecision(precision int) sql.Type {
+	typ, err := sql.CreateDatetimeTypeWithPrecision(sqltypes.Datetime, precision)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
+
+func mustCreateTimeTypeWithPrecision(precision int) sql.Type {
+	typ, err := sql.CreateTimeTypeWithPrecision(precision)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
@@ -0,0 +1,100 @@
+package expression
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// ConvertUsing represents a CONVERT(x USING charset) operation, which converts the string x to the given character set.
+// Characters the character set can't represent are replaced with '?'. Binary strings are read as bytes of the
+// character set, and converted to NULL with a warning if they aren't valid.
+type ConvertUsing struct {
+	UnaryExpression
+	charset sql.CharacterSet
+}
+
+var _ sql.Expression = (*ConvertUsing)(nil)
+
+// NewConvertUsing creates a new ConvertUsing expression.
+func NewConvertUsing(expr sql.Expression, charset sql.CharacterSet) *ConvertUsing {
+	return &ConvertUsing{UnaryExpression{Child: expr}, charset}
+}
+
+// CharacterSet returns the character set the expression converts to.
+func (c *ConvertUsing) CharacterSet() sql.CharacterSet {
+	return c.charset
+}
+
+// IsNullable implements the Expression interface.
+func (c *ConvertUsing) IsNullable() bool {
+	return true
+}
+
+// Type implements the Expression interface.
+func (c *ConvertUsing) Type() sql.Type {
+	if c.charset == sql.CharacterSet_binary {
+		return sql.LongBlob
+	}
+	return sql.CreateLongText(c.charset.DefaultCollation())
+}
+
+func (c *ConvertUsing) String() string {
+	return fmt.Sprintf("CONVERT(%s USING %s)", c.Child, c.charset)
+}
+
+// WithChildren implements the Expression interface.
+func (c *ConvertUsing) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(c, len(children), 1)
+	}
+	return NewConvertUsing(children[0], c.charset), nil
+}
+
+// Eval implements the Expression interface.
+func (c *ConvertUsing) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := c.Child.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if val == nil {
+		return nil, nil
+	}
+
+	val, err = sql.LongText.Convert(val)
+	if err != nil {
+		return nil, err
+	}
+	s := val.(string)
+
+	// values other than strings are converted as strings of the default character set
+	from := sql.Collation_Default.CharacterSet()
+	if st, ok := c.Child.Type().(sql.StringType); ok {
+		from = st.CharacterSet()
+	}
+
+	return convertToCharset(ctx, s, from, c.charset), nil
+}
+
+// convertToCharset converts a string of the given character set to another one, replacing the characters it can't
+// represent with '?'. Binary strings are read as bytes of the character set, and converted to NULL with a warning if
+// they aren't valid.
+func convertToCharset(ctx *sql.Context, s string, from, to sql.CharacterSet) interface{} {
+	switch {
+	case to == sql.CharacterSet_binary:
+		b, _ := from.Encode(s)
+		return string(b)
+	case from == sql.CharacterSet_binary:
+		res, ok := to.Decode([]byte(s))
+		if !ok {
+			ctx.Warn(sql.ERInvalidCharacterString, "%s", sql.ErrInvalidCharacterString.New(to, []byte(s)).Error())
+			return nil
+		}
+		return res
+	default:
+		b, _ := to.Encode(s)
+		res, _ := to.Decode(b)
+		return res
+	}
+}
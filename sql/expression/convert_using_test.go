@@ -0,0 +1,57 @@
+package expression
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestConvertUsing(t *testing.T) {
+	latin1 := sql.CreateText(sql.Collation_latin1_swedish_ci)
+
+	tests := []struct {
+		name       string
+		expression sql.Expression
+		charset    sql.CharacterSet
+		expected   interface{}
+		warnings   int
+	}{
+		{"nil", NewLiteral(nil, sql.Null), sql.CharacterSet_latin1, nil, 0},
+		{"utf8mb4 to latin1", NewLiteral("aé中", sql.LongText), sql.CharacterSet_latin1, "aé?", 0},
+		{"utf8mb4 to ascii", NewLiteral("aé", sql.LongText), sql.CharacterSet_ascii, "a?", 0},
+		{"utf8mb4 to utf8mb3", NewLiteral("a😀", sql.LongText), sql.CharacterSet_utf8mb3, "a?", 0},
+		{"latin1 to utf16", NewLiteral("aé", latin1), sql.CharacterSet_utf16, "aé", 0},
+		{"utf8mb4 to binary", NewLiteral("aé", sql.LongText), sql.CharacterSet_binary, "aé", 0},
+		{"latin1 to binary", NewLiteral("aé", latin1), sql.CharacterSet_binary, "a\xE9", 0},
+		{"utf16 to binary", NewLiteral("a", sql.CreateText(sql.Collation_utf16_general_ci)), sql.CharacterSet_binary, "\x00a", 0},
+		{"binary to latin1", NewLiteral("a\xE9", sql.LongBlob), sql.CharacterSet_latin1, "aé", 0},
+		{"binary to utf16", NewLiteral("\x00a\x00\xE9", sql.LongBlob), sql.CharacterSet_utf16, "aé", 0},
+		{"invalid binary to utf8mb4", NewLiteral("a\xE9", sql.LongBlob), sql.CharacterSet_utf8mb4, nil, 1},
+		{"number to latin1", NewLiteral(int64(12), sql.Int64), sql.CharacterSet_latin1, "12", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := sql.NewEmptyContext()
+
+			result, err := NewConvertUsing(tt.expression, tt.charset).Eval(ctx, nil)
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+			require.Len(ctx.Warnings(), tt.warnings)
+		})
+	}
+}
+
+func TestConvertUsingType(t *testing.T) {
+	require := require.New(t)
+
+	e := NewConvertUsing(NewLiteral("a", sql.LongText), sql.CharacterSet_latin1)
+	require.Equal(sql.CreateLongText(sql.Collation_latin1_swedish_ci), e.Type())
+	require.Equal(`CONVERT("a" USING latin1)`, e.String())
+
+	e = NewConvertUsing(NewLiteral("a", sql.LongText), sql.CharacterSet_binary)
+	require.Equal(sql.LongBlob, e.Type())
+}
@@ -0,0 +1,79 @@
+package aggregation
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// AnyValue aggregation returns the value of the selected column in any of
+// the rows, which may be NULL. It implements the Aggregation interface.
+type AnyValue struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*AnyValue)(nil)
+var _ sql.Aggregation = (*AnyValue)(nil)
+
+// NewAnyValue returns a new AnyValue node.
+func NewAnyValue(e sql.Expression) *AnyValue {
+	return &AnyValue{expression.UnaryExpression{Child: e}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (a *AnyValue) FunctionName() string {
+	return "any_value"
+}
+
+// Type returns the resultant type of the aggregation.
+func (a *AnyValue) Type() sql.Type {
+	return a.Child.Type()
+}
+
+func (a *AnyValue) String() string {
+	return fmt.Sprintf("ANY_VALUE(%s)", a.Child)
+}
+
+// WithChildren implements the Expression interface.
+func (a *AnyValue) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(a, len(children), 1)
+	}
+	return NewAnyValue(children[0]), nil
+}
+
+// NewBuffer creates a new buffer to compute the result. The buffer holds the
+// value and whether a row has been seen already.
+func (a *AnyValue) NewBuffer() sql.Row {
+	return sql.NewRow(nil, false)
+}
+
+// Update implements the Aggregation interface.
+func (a *AnyValue) Update(ctx *sql.Context, buffer, row sql.Row) error {
+	if buffer[1].(bool) {
+		return nil
+	}
+
+	v, err := a.Child.Eval(ctx, row)
+	if err != nil {
+		return err
+	}
+
+	buffer[0] = v
+	buffer[1] = true
+	return nil
+}
+
+// Merge implements the Aggregation interface.
+func (a *AnyValue) Merge(ctx *sql.Context, buffer, partial sql.Row) error {
+	if !buffer[1].(bool) {
+		copy(buffer, partial)
+	}
+	return nil
+}
+
+// Eval implements the Aggregation interface.
+func (a *AnyValue) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
+	return buffer[0], nil
+}
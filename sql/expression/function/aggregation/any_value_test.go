@@ -0,0 +1,33 @@
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestAnyValue(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	a := NewAnyValue(expression.NewGetField(0, sql.Int32, "field", true))
+	require.Equal("ANY_VALUE(field)", a.String())
+	require.Equal(sql.Int32, a.Type())
+
+	require.Nil(aggregate(t, a))
+	require.Equal(int32(1), aggregate(t, a, sql.NewRow(int32(1)), sql.NewRow(int32(2))))
+	require.Nil(aggregate(t, a, sql.NewRow(nil), sql.NewRow(int32(2))))
+
+	b := a.NewBuffer()
+	partial := a.NewBuffer()
+	require.NoError(a.Update(ctx, partial, sql.NewRow(int32(3))))
+	require.NoError(a.Merge(ctx, b, partial))
+	require.NoError(a.Merge(ctx, b, a.NewBuffer()))
+
+	v, err := a.Eval(ctx, b)
+	require.NoError(err)
+	require.Equal(int32(3), v)
+}
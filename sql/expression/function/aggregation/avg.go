@@ -3,6 +3,8 @@ package aggregation
 import (
 	"fmt"
 
+	"github.com/shopspring/decimal"
+
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
 )
@@ -29,7 +31,12 @@ func (a *Avg) String() string {
 }
 
 // Type implements AggregationExpression interface. (AggregationExpression[Expression]])
+// The average of a DECIMAL column is an exact DECIMAL with 4 more digits of
+// precision and scale, and any other average is a DOUBLE.
 func (a *Avg) Type() sql.Type {
+	if typ, ok := decimalResultType(a.Child.Type(), 4, 4); ok {
+		return typ
+	}
 	return sql.Float64
 }
 
@@ -45,9 +52,18 @@ func (a *Avg) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
 		return nil, nil
 	}
 
-	sum := buffer[0].(float64)
 	rows := buffer[1].(int64)
 
+	if dec, ok := buffer[0].(decimal.Decimal); ok {
+		typ := a.Type().(sql.DecimalType)
+		if rows == 0 {
+			return typ.Zero(), nil
+		}
+		return typ.Convert(dec.DivRound(decimal.NewFromInt(rows), int32(typ.Scale())))
+	}
+
+	sum := buffer[0].(float64)
+
 	if rows == 0 {
 		return float64(0), nil
 	}
@@ -71,6 +87,10 @@ func (a *Avg) NewBuffer() sql.Row {
 		nulls = false
 	)
 
+	if sql.IsDecimal(a.Child.Type()) {
+		return sql.NewRow(decimal.Zero, rows, nulls)
+	}
+
 	return sql.NewRow(sum, rows, nulls)
 }
 
@@ -91,6 +111,16 @@ func (a *Avg) Update(ctx *sql.Context, buffer, row sql.Row) error {
 		return nil
 	}
 
+	if sum, ok := buffer[0].(decimal.Decimal); ok {
+		dec, err := sql.ToDecimal(v)
+		if err != nil {
+			return err
+		}
+		buffer[0] = sum.Add(dec.Decimal)
+		buffer[1] = buffer[1].(int64) + 1
+		return nil
+	}
+
 	v, err = sql.Float64.Convert(v)
 	if err != nil {
 		v = float64(0)
@@ -104,15 +134,17 @@ func (a *Avg) Update(ctx *sql.Context, buffer, row sql.Row) error {
 
 // Merge implements AggregationExpression interface. (AggregationExpression)
 func (a *Avg) Merge(ctx *sql.Context, buffer, partial sql.Row) error {
-	bsum := buffer[0].(float64)
 	brows := buffer[1].(int64)
 	bnulls := buffer[2].(bool)
 
-	psum := partial[0].(float64)
 	prows := partial[1].(int64)
 	pnulls := buffer[2].(bool)
 
-	buffer[0] = bsum + psum
+	if bsum, ok := buffer[0].(decimal.Decimal); ok {
+		buffer[0] = bsum.Add(partial[0].(decimal.Decimal))
+	} else {
+		buffer[0] = buffer[0].(float64) + partial[0].(float64)
+	}
 	buffer[1] = brows + prows
 	buffer[2] = bnulls || pnulls
 
@@ -118,3 +118,21 @@ func TestAvg_NULL(t *testing.T) {
 	require.NoError(err)
 	require.Equal(nil, eval(t, avgNode, buffer))
 }
+
+func TestAvg_Decimal(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	avgNode := NewAvg(expression.NewGetField(0, sql.MustCreateDecimalType(10, 2), "col1", true))
+	require.Equal(sql.MustCreateDecimalType(14, 6), avgNode.Type())
+
+	buffer1 := avgNode.NewBuffer()
+	require.NoError(avgNode.Update(ctx, buffer1, sql.NewRow("0.10")))
+	require.NoError(avgNode.Update(ctx, buffer1, sql.NewRow("0.20")))
+	require.Equal("0.150000", eval(t, avgNode, buffer1))
+
+	buffer2 := avgNode.NewBuffer()
+	require.NoError(avgNode.Update(ctx, buffer2, sql.NewRow("0.30")))
+	require.NoError(avgNode.Merge(ctx, buffer1, buffer2))
+	require.Equal("0.200000", eval(t, avgNode, buffer1))
+}
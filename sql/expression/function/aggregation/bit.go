@@ -0,0 +1,203 @@
+package aggregation
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// bitOp is a bitwise operation computed by a bitAggregation.
+type bitOp byte
+
+const (
+	bitOpAnd bitOp = iota
+	bitOpOr
+	bitOpXor
+)
+
+// bitAggregation computes a bitwise operation over the non-NULL values of an
+// expression as 64-bit unsigned integers. Its buffer holds the result so far,
+// and it is shared by BIT_AND, BIT_OR and BIT_XOR.
+type bitAggregation struct {
+	expression.UnaryExpression
+	op bitOp
+}
+
+// apply returns the result of the operation on the given operands.
+func (b *bitAggregation) apply(x, y uint64) uint64 {
+	switch b.op {
+	case bitOpAnd:
+		return x & y
+	case bitOpOr:
+		return x | y
+	default:
+		return x ^ y
+	}
+}
+
+// Type returns the resultant type of the aggregation.
+func (b *bitAggregation) Type() sql.Type {
+	return sql.Uint64
+}
+
+// IsNullable returns whether the return value can be null.
+func (b *bitAggregation) IsNullable() bool {
+	return false
+}
+
+// NewBuffer creates a new buffer to compute the result.
+func (b *bitAggregation) NewBuffer() sql.Row {
+	if b.op == bitOpAnd {
+		return sql.NewRow(uint64(math.MaxUint64))
+	}
+	return sql.NewRow(uint64(0))
+}
+
+// Update implements the Aggregation interface.
+func (b *bitAggregation) Update(ctx *sql.Context, buffer, row sql.Row) error {
+	v, err := b.Child.Eval(ctx, row)
+	if err != nil {
+		return err
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	buffer[0] = b.apply(buffer[0].(uint64), bitValue(b.Child.Type(), v))
+	return nil
+}
+
+// Merge implements the Aggregation interface.
+func (b *bitAggregation) Merge(ctx *sql.Context, buffer, partial sql.Row) error {
+	buffer[0] = b.apply(buffer[0].(uint64), partial[0].(uint64))
+	return nil
+}
+
+// Eval implements the Aggregation interface.
+func (b *bitAggregation) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
+	return buffer[0], nil
+}
+
+// bitValue returns the given value as a 64-bit unsigned integer. Signed
+// values keep their two's complement representation, as in MySQL, and values
+// that are not numbers are taken as 0.
+func bitValue(t sql.Type, v interface{}) uint64 {
+	if sql.IsUnsigned(t) {
+		u, err := sql.Uint64.Convert(v)
+		if err != nil {
+			return 0
+		}
+		return u.(uint64)
+	}
+
+	i, err := sql.Int64.Convert(v)
+	if err != nil {
+		return 0
+	}
+	return uint64(i.(int64))
+}
+
+// BitAnd aggregation returns the bitwise AND of all the values of the
+// selected column. It implements the Aggregation interface.
+type BitAnd struct {
+	bitAggregation
+}
+
+var _ sql.FunctionExpression = (*BitAnd)(nil)
+var _ sql.Aggregation = (*BitAnd)(nil)
+
+// NewBitAnd returns a new BitAnd node.
+func NewBitAnd(e sql.Expression) *BitAnd {
+	return &BitAnd{bitAggregation{
+		UnaryExpression: expression.UnaryExpression{Child: e},
+		op:              bitOpAnd,
+	}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (b *BitAnd) FunctionName() string {
+	return "bit_and"
+}
+
+func (b *BitAnd) String() string {
+	return fmt.Sprintf("BIT_AND(%s)", b.Child)
+}
+
+// WithChildren implements the Expression interface.
+func (b *BitAnd) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(b, len(children), 1)
+	}
+	return NewBitAnd(children[0]), nil
+}
+
+// BitOr aggregation returns the bitwise OR of all the values of the selected
+// column. It implements the Aggregation interface.
+type BitOr struct {
+	bitAggregation
+}
+
+var _ sql.FunctionExpression = (*BitOr)(nil)
+var _ sql.Aggregation = (*BitOr)(nil)
+
+// NewBitOr returns a new BitOr node.
+func NewBitOr(e sql.Expression) *BitOr {
+	return &BitOr{bitAggregation{
+		UnaryExpression: expression.UnaryExpression{Child: e},
+		op:              bitOpOr,
+	}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (b *BitOr) FunctionName() string {
+	return "bit_or"
+}
+
+func (b *BitOr) String() string {
+	return fmt.Sprintf("BIT_OR(%s)", b.Child)
+}
+
+// WithChildren implements the Expression interface.
+func (b *BitOr) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(b, len(children), 1)
+	}
+	return NewBitOr(children[0]), nil
+}
+
+// BitXor aggregation returns the bitwise XOR of all the values of the
+// selected column. It implements the Aggregation interface.
+type BitXor struct {
+	bitAggregation
+}
+
+var _ sql.FunctionExpression = (*BitXor)(nil)
+var _ sql.Aggregation = (*BitXor)(nil)
+
+// NewBitXor returns a new BitXor node.
+func NewBitXor(e sql.Expression) *BitXor {
+	return &BitXor{bitAggregation{
+		UnaryExpression: expression.UnaryExpression{Child: e},
+		op:              bitOpXor,
+	}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (b *BitXor) FunctionName() string {
+	return "bit_xor"
+}
+
+func (b *BitXor) String() string {
+	return fmt.Sprintf("BIT_XOR(%s)", b.Child)
+}
+
+// WithChildren implements the Expression interface.
+func (b *BitXor) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(b, len(children), 1)
+	}
+	return NewBitXor(children[0]), nil
+}
@@ -0,0 +1,68 @@
+package aggregation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestBitAggregations(t *testing.T) {
+	field := expression.NewGetField(0, sql.Int64, "field", true)
+	rows := []sql.Row{{int64(7)}, {nil}, {int64(13)}, {int64(5)}}
+
+	testCases := []struct {
+		agg      sql.Aggregation
+		rows     []sql.Row
+		expected uint64
+	}{
+		{NewBitAnd(field), rows, 5},
+		{NewBitOr(field), rows, 15},
+		{NewBitXor(field), rows, 15},
+		{NewBitAnd(field), nil, math.MaxUint64},
+		{NewBitOr(field), nil, 0},
+		{NewBitXor(field), nil, 0},
+		{NewBitOr(field), []sql.Row{{int64(-1)}}, math.MaxUint64},
+		{NewBitOr(expression.NewGetField(0, sql.Uint64, "field", true)), []sql.Row{{uint64(math.MaxUint64)}}, math.MaxUint64},
+		{NewBitOr(expression.NewGetField(0, sql.LongText, "field", true)), []sql.Row{{"6"}, {"a"}}, 6},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.agg.String(), func(t *testing.T) {
+			require.Equal(t, tt.expected, aggregate(t, tt.agg, tt.rows...))
+		})
+	}
+}
+
+func TestBitAggregationsMerge(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	field := expression.NewGetField(0, sql.Int64, "field", true)
+	testCases := []struct {
+		agg      sql.Aggregation
+		expected uint64
+	}{
+		{NewBitAnd(field), 4},
+		{NewBitOr(field), 15},
+		{NewBitXor(field), 13},
+	}
+
+	for _, tt := range testCases {
+		b := tt.agg.NewBuffer()
+		require.NoError(tt.agg.Update(ctx, b, sql.NewRow(int64(6))))
+		require.NoError(tt.agg.Update(ctx, b, sql.NewRow(int64(12))))
+
+		partial := tt.agg.NewBuffer()
+		require.NoError(tt.agg.Update(ctx, partial, sql.NewRow(int64(7))))
+		require.NoError(tt.agg.Merge(ctx, b, partial))
+		require.NoError(tt.agg.Merge(ctx, b, tt.agg.NewBuffer()))
+
+		v, err := tt.agg.Eval(ctx, b)
+		require.NoError(err)
+		require.Equal(tt.expected, v, tt.agg.String())
+	}
+}
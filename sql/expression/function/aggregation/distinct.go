@@ -0,0 +1,127 @@
+package aggregation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/hashstructure"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// Distinct wraps an aggregation so that it only takes into account the rows
+// with distinct values for the arguments of the aggregation. The arguments of
+// the wrapped aggregation are the children of Distinct, so the wrapped
+// aggregation is never visited on its own when transforming expressions.
+// It implements the Aggregation interface.
+type Distinct struct {
+	agg sql.Aggregation
+}
+
+var _ sql.Aggregation = (*Distinct)(nil)
+
+// NewDistinct returns a new Distinct node wrapping the given aggregation.
+func NewDistinct(agg sql.Aggregation) *Distinct {
+	return &Distinct{agg}
+}
+
+// Aggregation returns the wrapped aggregation.
+func (d *Distinct) Aggregation() sql.Aggregation {
+	return d.agg
+}
+
+// Resolved implements the Expression interface.
+func (d *Distinct) Resolved() bool {
+	return d.agg.Resolved()
+}
+
+// Type returns the resultant type of the aggregation.
+func (d *Distinct) Type() sql.Type {
+	return d.agg.Type()
+}
+
+// IsNullable returns whether the return value can be null.
+func (d *Distinct) IsNullable() bool {
+	return d.agg.IsNullable()
+}
+
+// Children implements the Expression interface.
+func (d *Distinct) Children() []sql.Expression {
+	return d.agg.Children()
+}
+
+func (d *Distinct) String() string {
+	fn, ok := d.agg.(sql.FunctionExpression)
+	if !ok {
+		return fmt.Sprintf("DISTINCT %s", d.agg)
+	}
+
+	var args = make([]string, len(d.agg.Children()))
+	for i, e := range d.agg.Children() {
+		args[i] = e.String()
+	}
+	return fmt.Sprintf("%s(DISTINCT %s)", strings.ToUpper(fn.FunctionName()), strings.Join(args, ", "))
+}
+
+// WithChildren implements the Expression interface.
+func (d *Distinct) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	agg, err := d.agg.WithChildren(children...)
+	if err != nil {
+		return nil, err
+	}
+	return NewDistinct(agg.(sql.Aggregation)), nil
+}
+
+// NewBuffer creates a new buffer to compute the result. The buffer holds the
+// hashes of the values seen so far and the rows that had them.
+func (d *Distinct) NewBuffer() sql.Row {
+	return sql.NewRow(make(map[uint64]struct{}), []sql.Row(nil))
+}
+
+// Update implements the Aggregation interface.
+func (d *Distinct) Update(ctx *sql.Context, buffer, row sql.Row) error {
+	children := d.agg.Children()
+	var values = make([]interface{}, len(children))
+	for i, e := range children {
+		v, err := e.Eval(ctx, row)
+		if err != nil {
+			return err
+		}
+		values[i] = sql.CollationKey(e.Type(), v)
+	}
+
+	hash, err := hashstructure.Hash(values, nil)
+	if err != nil {
+		return fmt.Errorf("distinct unable to hash value: %s", err)
+	}
+
+	seen := buffer[0].(map[uint64]struct{})
+	if _, ok := seen[hash]; ok {
+		return nil
+	}
+
+	seen[hash] = struct{}{}
+	buffer[1] = append(buffer[1].([]sql.Row), row.Copy())
+	return nil
+}
+
+// Merge implements the Aggregation interface.
+func (d *Distinct) Merge(ctx *sql.Context, buffer, partial sql.Row) error {
+	for _, row := range partial[1].([]sql.Row) {
+		if err := d.Update(ctx, buffer, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Eval implements the Aggregation interface.
+func (d *Distinct) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
+	buf := d.agg.NewBuffer()
+	for _, row := range buffer[1].([]sql.Row) {
+		if err := d.agg.Update(ctx, buf, row); err != nil {
+			return nil, err
+		}
+	}
+	return d.agg.Eval(ctx, buf)
+}
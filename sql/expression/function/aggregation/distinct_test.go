@@ -0,0 +1,69 @@
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestDistinct(t *testing.T) {
+	require := require.New(t)
+
+	i := expression.NewGetField(0, sql.Int64, "i", true)
+	s := expression.NewGetField(1, sql.LongText, "s", true)
+
+	rows := []sql.Row{
+		{int64(1), "a"},
+		{int64(2), "A"},
+		{int64(1), "b"},
+		{nil, "b"},
+		{int64(3), "á"},
+	}
+
+	sum := NewDistinct(NewSum(i))
+	require.Equal("SUM(DISTINCT i)", sum.String())
+	require.Equal(float64(6), aggregate(t, sum, rows...))
+
+	count := NewDistinct(NewCount(s))
+	require.Equal(int64(2), aggregate(t, count, rows...))
+
+	arr := NewDistinct(NewJSONArrayAgg(i))
+	require.Equal(sql.MustJSON(`[1, 2, null, 3]`), aggregate(t, arr, rows...))
+}
+
+func TestDistinctMerge(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	d := NewDistinct(NewSum(expression.NewGetField(0, sql.Int64, "i", true)))
+
+	b := d.NewBuffer()
+	require.NoError(d.Update(ctx, b, sql.NewRow(int64(1))))
+	require.NoError(d.Update(ctx, b, sql.NewRow(int64(2))))
+
+	partial := d.NewBuffer()
+	require.NoError(d.Update(ctx, partial, sql.NewRow(int64(2))))
+	require.NoError(d.Update(ctx, partial, sql.NewRow(int64(3))))
+	require.NoError(d.Merge(ctx, b, partial))
+
+	v, err := d.Eval(ctx, b)
+	require.NoError(err)
+	require.Equal(float64(6), v)
+}
+
+func TestDistinctWithChildren(t *testing.T) {
+	require := require.New(t)
+
+	d := NewDistinct(NewMax(expression.NewUnresolvedColumn("i")))
+	require.False(d.Resolved())
+	require.Len(d.Children(), 1)
+
+	e, err := d.WithChildren(expression.NewGetField(0, sql.Int64, "i", true))
+	require.NoError(err)
+	require.True(e.Resolved())
+	require.Equal("MAX(DISTINCT i)", e.String())
+	require.Equal(sql.Int64, e.Type())
+}
@@ -0,0 +1,312 @@
+package aggregation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mitchellh/hashstructure"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+const (
+	// DefaultGroupConcatSeparator is the separator used by GROUP_CONCAT when
+	// none is given.
+	DefaultGroupConcatSeparator = ","
+
+	// defaultGroupConcatMaxLen is the maximum length in bytes of the result of
+	// GROUP_CONCAT when the group_concat_max_len variable is not set.
+	defaultGroupConcatMaxLen = 1024
+
+	// errCutGroupConcat is the MySQL code of the warning raised when the result
+	// of GROUP_CONCAT is truncated.
+	errCutGroupConcat = 1260
+)
+
+// SortField is an expression by which the values aggregated by GROUP_CONCAT
+// are sorted.
+type SortField struct {
+	// Column to order by.
+	Column sql.Expression
+	// Descending order, instead of ascending.
+	Descending bool
+}
+
+// GroupConcat aggregation returns the concatenation of the non-NULL values of
+// the selected expressions in all rows, optionally with no duplicates, sorted
+// and joined with a separator. It implements the Aggregation interface.
+type GroupConcat struct {
+	distinct    bool
+	selectExprs []sql.Expression
+	sortFields  []SortField
+	separator   string
+}
+
+var _ sql.FunctionExpression = (*GroupConcat)(nil)
+var _ sql.Aggregation = (*GroupConcat)(nil)
+
+// NewGroupConcat returns a new GroupConcat node.
+func NewGroupConcat(distinct bool, selectExprs []sql.Expression, sortFields []SortField, separator string) *GroupConcat {
+	return &GroupConcat{
+		distinct:    distinct,
+		selectExprs: selectExprs,
+		sortFields:  sortFields,
+		separator:   separator,
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (g *GroupConcat) FunctionName() string {
+	return "group_concat"
+}
+
+// Resolved implements the Expression interface.
+func (g *GroupConcat) Resolved() bool {
+	for _, e := range g.Children() {
+		if !e.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// Type returns the resultant type of the aggregation.
+func (g *GroupConcat) Type() sql.Type {
+	return sql.LongText
+}
+
+// IsNullable returns whether the return value can be null.
+func (g *GroupConcat) IsNullable() bool {
+	return true
+}
+
+// Children implements the Expression interface. The selected expressions
+// come first, followed by the ones to sort by.
+func (g *GroupConcat) Children() []sql.Expression {
+	var children = make([]sql.Expression, 0, len(g.selectExprs)+len(g.sortFields))
+	children = append(children, g.selectExprs...)
+	for _, f := range g.sortFields {
+		children = append(children, f.Column)
+	}
+	return children
+}
+
+func (g *GroupConcat) String() string {
+	var sb strings.Builder
+	sb.WriteString("GROUP_CONCAT(")
+	if g.distinct {
+		sb.WriteString("DISTINCT ")
+	}
+
+	var exprs = make([]string, len(g.selectExprs))
+	for i, e := range g.selectExprs {
+		exprs[i] = e.String()
+	}
+	sb.WriteString(strings.Join(exprs, ", "))
+
+	if len(g.sortFields) > 0 {
+		var fields = make([]string, len(g.sortFields))
+		for i, f := range g.sortFields {
+			fields[i] = f.Column.String()
+			if f.Descending {
+				fields[i] += " DESC"
+			}
+		}
+		sb.WriteString(" ORDER BY ")
+		sb.WriteString(strings.Join(fields, ", "))
+	}
+
+	sb.WriteString(fmt.Sprintf(" SEPARATOR '%s')", g.separator))
+	return sb.String()
+}
+
+// WithChildren implements the Expression interface.
+func (g *GroupConcat) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != len(g.selectExprs)+len(g.sortFields) {
+		return nil, sql.ErrInvalidChildrenNumber.New(g, len(children), len(g.selectExprs)+len(g.sortFields))
+	}
+
+	selectExprs := children[:len(g.selectExprs)]
+	var sortFields = make([]SortField, len(g.sortFields))
+	for i, f := range g.sortFields {
+		sortFields[i] = SortField{Column: children[len(selectExprs)+i], Descending: f.Descending}
+	}
+
+	return NewGroupConcat(g.distinct, selectExprs, sortFields, g.separator), nil
+}
+
+// NewBuffer creates a new buffer to compute the result. The buffer holds the
+// values of the selected and sorting expressions of every row aggregated.
+func (g *GroupConcat) NewBuffer() sql.Row {
+	return sql.NewRow([]sql.Row(nil))
+}
+
+// Update implements the Aggregation interface.
+func (g *GroupConcat) Update(ctx *sql.Context, buffer, row sql.Row) error {
+	children := g.Children()
+	var values = make(sql.Row, len(children))
+	for i, e := range children {
+		v, err := e.Eval(ctx, row)
+		if err != nil {
+			return err
+		}
+
+		if i < len(g.selectExprs) {
+			if v == nil {
+				return nil
+			}
+
+			v, err = sql.LongText.Convert(v)
+			if err != nil {
+				return err
+			}
+		}
+
+		values[i] = v
+	}
+
+	buffer[0] = append(buffer[0].([]sql.Row), values)
+	return nil
+}
+
+// Merge implements the Aggregation interface.
+func (g *GroupConcat) Merge(ctx *sql.Context, buffer, partial sql.Row) error {
+	buffer[0] = append(buffer[0].([]sql.Row), partial[0].([]sql.Row)...)
+	return nil
+}
+
+// Eval implements the Aggregation interface.
+func (g *GroupConcat) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
+	rows := buffer[0].([]sql.Row)
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	if g.distinct {
+		var err error
+		rows, err = g.distinctRows(rows)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(g.sortFields) > 0 {
+		rows = append([]sql.Row(nil), rows...)
+		var sortErr error
+		sort.SliceStable(rows, func(i, j int) bool {
+			less, err := g.less(rows[i], rows[j])
+			if err != nil && sortErr == nil {
+				sortErr = err
+			}
+			return less
+		})
+		if sortErr != nil {
+			return nil, sortErr
+		}
+	}
+
+	maxLen := groupConcatMaxLen(ctx)
+	var sb strings.Builder
+	for i, row := range rows {
+		if i > 0 {
+			sb.WriteString(g.separator)
+		}
+		for _, v := range row[:len(g.selectExprs)] {
+			sb.WriteString(v.(string))
+		}
+
+		if sb.Len() > maxLen {
+			ctx.Warn(errCutGroupConcat, "Row %d was cut by GROUP_CONCAT()", i+1)
+			return truncateUTF8(sb.String(), maxLen), nil
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// distinctRows returns the rows with distinct selected values, keeping the
+// first occurrence of each.
+func (g *GroupConcat) distinctRows(rows []sql.Row) ([]sql.Row, error) {
+	seen := make(map[uint64]struct{}, len(rows))
+	var result = make([]sql.Row, 0, len(rows))
+	for _, row := range rows {
+		var values = make([]interface{}, len(g.selectExprs))
+		for i, e := range g.selectExprs {
+			values[i] = sql.CollationKey(e.Type(), row[i])
+		}
+
+		hash, err := hashstructure.Hash(values, nil)
+		if err != nil {
+			return nil, fmt.Errorf("group_concat unable to hash value: %s", err)
+		}
+
+		if _, ok := seen[hash]; ok {
+			continue
+		}
+		seen[hash] = struct{}{}
+		result = append(result, row)
+	}
+	return result, nil
+}
+
+// less returns whether the row a goes before the row b according to the
+// sorting expressions. NULL values go first in ascending order.
+func (g *GroupConcat) less(a, b sql.Row) (bool, error) {
+	for i, f := range g.sortFields {
+		idx := len(g.selectExprs) + i
+		av, bv := a[idx], b[idx]
+
+		var cmp int
+		switch {
+		case av == nil && bv == nil:
+			continue
+		case av == nil:
+			cmp = -1
+		case bv == nil:
+			cmp = 1
+		default:
+			var err error
+			cmp, err = f.Column.Type().Compare(av, bv)
+			if err != nil {
+				return false, err
+			}
+		}
+
+		if cmp == 0 {
+			continue
+		}
+		if f.Descending {
+			return cmp > 0, nil
+		}
+		return cmp < 0, nil
+	}
+	return false, nil
+}
+
+// groupConcatMaxLen returns the maximum length in bytes of the result of
+// GROUP_CONCAT for the session.
+func groupConcatMaxLen(ctx *sql.Context) int {
+	_, v := ctx.Session.Get("group_concat_max_len")
+	if v == nil {
+		return defaultGroupConcatMaxLen
+	}
+
+	n, err := sql.Int64.Convert(v)
+	if err != nil || n.(int64) < 0 {
+		return defaultGroupConcatMaxLen
+	}
+	return int(n.(int64))
+}
+
+// truncateUTF8 truncates s to at most n bytes without splitting a character.
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,122 @@
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestGroupConcat(t *testing.T) {
+	i := expression.NewGetField(0, sql.Int64, "i", true)
+	s := expression.NewGetField(1, sql.LongText, "s", true)
+
+	rows := []sql.Row{
+		{int64(2), "b"},
+		{int64(1), "a"},
+		{int64(3), nil},
+		{int64(1), "A"},
+		{nil, "c"},
+	}
+
+	testCases := []struct {
+		name     string
+		agg      *GroupConcat
+		expected interface{}
+	}{
+		{
+			"default separator",
+			NewGroupConcat(false, []sql.Expression{i}, nil, ","),
+			"2,1,3,1",
+		},
+		{
+			"several expressions skip NULL",
+			NewGroupConcat(false, []sql.Expression{i, s}, nil, ";"),
+			"2b;1a;1A",
+		},
+		{
+			"order by",
+			NewGroupConcat(false, []sql.Expression{s}, []SortField{{Column: i}}, ","),
+			"c,a,A,b",
+		},
+		{
+			"order by descending",
+			NewGroupConcat(false, []sql.Expression{i}, []SortField{{Column: i, Descending: true}}, ""),
+			"3211",
+		},
+		{
+			"distinct",
+			NewGroupConcat(true, []sql.Expression{i}, []SortField{{Column: i}}, ","),
+			"1,2,3",
+		},
+		{
+			"distinct with collation",
+			NewGroupConcat(true, []sql.Expression{s}, []SortField{{Column: s}}, ","),
+			"a,b,c",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, aggregate(t, tt.agg, rows...))
+		})
+	}
+
+	require.Nil(t, aggregate(t, NewGroupConcat(false, []sql.Expression{i}, nil, ",")))
+	require.Nil(t, aggregate(t, NewGroupConcat(false, []sql.Expression{i}, nil, ","), sql.NewRow(nil, "a")))
+}
+
+func TestGroupConcatMerge(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	i := expression.NewGetField(0, sql.Int64, "i", true)
+	g := NewGroupConcat(true, []sql.Expression{i}, []SortField{{Column: i}}, ",")
+
+	b := g.NewBuffer()
+	require.NoError(g.Update(ctx, b, sql.NewRow(int64(3))))
+	require.NoError(g.Update(ctx, b, sql.NewRow(int64(1))))
+
+	partial := g.NewBuffer()
+	require.NoError(g.Update(ctx, partial, sql.NewRow(int64(2))))
+	require.NoError(g.Update(ctx, partial, sql.NewRow(int64(1))))
+	require.NoError(g.Merge(ctx, b, partial))
+
+	v, err := g.Eval(ctx, b)
+	require.NoError(err)
+	require.Equal("1,2,3", v)
+}
+
+func TestGroupConcatMaxLen(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+	require.NoError(ctx.Set(ctx, "group_concat_max_len", sql.Int64, int64(6)))
+
+	s := expression.NewGetField(0, sql.LongText, "s", true)
+	g := NewGroupConcat(false, []sql.Expression{s}, nil, ",")
+
+	b := g.NewBuffer()
+	for _, v := range []string{"ab", "cd", "éf"} {
+		require.NoError(g.Update(ctx, b, sql.NewRow(v)))
+	}
+
+	v, err := g.Eval(ctx, b)
+	require.NoError(err)
+	require.Equal("ab,cd,", v)
+	require.Equal(uint16(1), ctx.WarningCount())
+	require.Equal(1260, ctx.Warnings()[0].Code)
+}
+
+func TestGroupConcatString(t *testing.T) {
+	i := expression.NewGetField(0, sql.Int64, "i", true)
+	s := expression.NewGetField(1, sql.LongText, "s", true)
+
+	g := NewGroupConcat(true, []sql.Expression{i, s}, []SortField{{Column: s, Descending: true}, {Column: i}}, ";")
+	require.Equal(t, "GROUP_CONCAT(DISTINCT i, s ORDER BY s DESC, i SEPARATOR ';')", g.String())
+
+	g2, err := g.WithChildren(s, i, i, s)
+	require.NoError(t, err)
+	require.Equal(t, "GROUP_CONCAT(DISTINCT s, i ORDER BY i DESC, s SEPARATOR ';')", g2.String())
+}
@@ -0,0 +1,211 @@
+package aggregation
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// JSONArrayAgg aggregation returns a JSON array with all the values of the
+// selected column, including NULLs. It implements the Aggregation interface.
+type JSONArrayAgg struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*JSONArrayAgg)(nil)
+var _ sql.Aggregation = (*JSONArrayAgg)(nil)
+
+// NewJSONArrayAgg returns a new JSONArrayAgg node.
+func NewJSONArrayAgg(e sql.Expression) *JSONArrayAgg {
+	return &JSONArrayAgg{expression.UnaryExpression{Child: e}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (j *JSONArrayAgg) FunctionName() string {
+	return "json_arrayagg"
+}
+
+// Type returns the resultant type of the aggregation.
+func (j *JSONArrayAgg) Type() sql.Type {
+	return sql.JSON
+}
+
+// IsNullable returns whether the return value can be null.
+func (j *JSONArrayAgg) IsNullable() bool {
+	return true
+}
+
+func (j *JSONArrayAgg) String() string {
+	return fmt.Sprintf("JSON_ARRAYAGG(%s)", j.Child)
+}
+
+// WithChildren implements the Expression interface.
+func (j *JSONArrayAgg) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), 1)
+	}
+	return NewJSONArrayAgg(children[0]), nil
+}
+
+// NewBuffer creates a new buffer to compute the result. The buffer holds the
+// JSON values aggregated so far, or nil if no row has been aggregated.
+func (j *JSONArrayAgg) NewBuffer() sql.Row {
+	return sql.NewRow(nil)
+}
+
+// Update implements the Aggregation interface.
+func (j *JSONArrayAgg) Update(ctx *sql.Context, buffer, row sql.Row) error {
+	v, err := j.Child.Eval(ctx, row)
+	if err != nil {
+		return err
+	}
+
+	v, err = sql.JSONValueOf(j.Child.Type(), v)
+	if err != nil {
+		return err
+	}
+
+	arr, _ := buffer[0].([]interface{})
+	buffer[0] = append(arr, v)
+	return nil
+}
+
+// Merge implements the Aggregation interface.
+func (j *JSONArrayAgg) Merge(ctx *sql.Context, buffer, partial sql.Row) error {
+	parr, ok := partial[0].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	arr, _ := buffer[0].([]interface{})
+	buffer[0] = append(arr, parr...)
+	return nil
+}
+
+// Eval implements the Aggregation interface.
+func (j *JSONArrayAgg) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
+	arr, ok := buffer[0].([]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	return sql.JSONDocument{Val: append([]interface{}(nil), arr...)}, nil
+}
+
+// JSONObjectAgg aggregation returns a JSON object built out of the key and
+// value pairs of every row. When a key is repeated, the last value wins. It
+// implements the Aggregation interface.
+type JSONObjectAgg struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*JSONObjectAgg)(nil)
+var _ sql.Aggregation = (*JSONObjectAgg)(nil)
+
+// NewJSONObjectAgg returns a new JSONObjectAgg node.
+func NewJSONObjectAgg(key, value sql.Expression) *JSONObjectAgg {
+	return &JSONObjectAgg{expression.BinaryExpression{Left: key, Right: value}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (j *JSONObjectAgg) FunctionName() string {
+	return "json_objectagg"
+}
+
+// Type returns the resultant type of the aggregation.
+func (j *JSONObjectAgg) Type() sql.Type {
+	return sql.JSON
+}
+
+// IsNullable returns whether the return value can be null.
+func (j *JSONObjectAgg) IsNullable() bool {
+	return true
+}
+
+func (j *JSONObjectAgg) String() string {
+	return fmt.Sprintf("JSON_OBJECTAGG(%s, %s)", j.Left, j.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (j *JSONObjectAgg) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), 2)
+	}
+	return NewJSONObjectAgg(children[0], children[1]), nil
+}
+
+// NewBuffer creates a new buffer to compute the result. The buffer holds the
+// object members aggregated so far, or nil if no row has been aggregated.
+func (j *JSONObjectAgg) NewBuffer() sql.Row {
+	return sql.NewRow(nil)
+}
+
+// Update implements the Aggregation interface.
+func (j *JSONObjectAgg) Update(ctx *sql.Context, buffer, row sql.Row) error {
+	key, err := j.Left.Eval(ctx, row)
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		return sql.ErrJSONNullKey.New()
+	}
+
+	key, err = sql.LongText.Convert(key)
+	if err != nil {
+		return err
+	}
+
+	v, err := j.Right.Eval(ctx, row)
+	if err != nil {
+		return err
+	}
+
+	v, err = sql.JSONValueOf(j.Right.Type(), v)
+	if err != nil {
+		return err
+	}
+
+	obj, ok := buffer[0].(map[string]interface{})
+	if !ok {
+		obj = make(map[string]interface{})
+		buffer[0] = obj
+	}
+
+	obj[key.(string)] = v
+	return nil
+}
+
+// Merge implements the Aggregation interface. The members of the partial
+// buffer come after the ones in the global buffer, so they take precedence.
+func (j *JSONObjectAgg) Merge(ctx *sql.Context, buffer, partial sql.Row) error {
+	pobj, ok := partial[0].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	obj, ok := buffer[0].(map[string]interface{})
+	if !ok {
+		obj = make(map[string]interface{}, len(pobj))
+		buffer[0] = obj
+	}
+
+	for k, v := range pobj {
+		obj[k] = v
+	}
+	return nil
+}
+
+// Eval implements the Aggregation interface.
+func (j *JSONObjectAgg) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
+	obj, ok := buffer[0].(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	val := make(map[string]interface{}, len(obj))
+	for k, v := range obj {
+		val[k] = v
+	}
+	return sql.JSONDocument{Val: val}, nil
+}
@@ -0,0 +1,109 @@
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestJSONArrayAgg(t *testing.T) {
+	require := require.New(t)
+
+	j := NewJSONArrayAgg(expression.NewGetField(0, sql.LongText, "field", true))
+	require.Equal("JSON_ARRAYAGG(field)", j.String())
+
+	require.Nil(aggregate(t, j))
+	require.Equal(
+		sql.MustJSON(`["a", null, "b"]`),
+		aggregate(t, j, sql.NewRow("a"), sql.NewRow(nil), sql.NewRow("b")),
+	)
+
+	j = NewJSONArrayAgg(expression.NewGetField(0, sql.JSON, "field", true))
+	require.Equal(
+		sql.MustJSON(`[{"a": 1}, [2], 3]`),
+		aggregate(t, j, sql.NewRow(sql.MustJSON(`{"a": 1}`)), sql.NewRow(`[2]`), sql.NewRow(sql.MustJSON(`3`))),
+	)
+}
+
+func TestJSONArrayAggMerge(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	j := NewJSONArrayAgg(expression.NewGetField(0, sql.Int64, "field", true))
+
+	b := j.NewBuffer()
+	require.NoError(j.Update(ctx, b, sql.NewRow(int64(1))))
+
+	empty := j.NewBuffer()
+	require.NoError(j.Merge(ctx, b, empty))
+
+	partial := j.NewBuffer()
+	require.NoError(j.Update(ctx, partial, sql.NewRow(int64(2))))
+	require.NoError(j.Update(ctx, partial, sql.NewRow(int64(3))))
+	require.NoError(j.Merge(ctx, b, partial))
+
+	v, err := j.Eval(ctx, b)
+	require.NoError(err)
+	require.Equal(sql.MustJSON(`[1, 2, 3]`), v)
+
+	b = j.NewBuffer()
+	require.NoError(j.Merge(ctx, b, partial))
+	v, err = j.Eval(ctx, b)
+	require.NoError(err)
+	require.Equal(sql.MustJSON(`[2, 3]`), v)
+}
+
+func TestJSONObjectAgg(t *testing.T) {
+	require := require.New(t)
+
+	j := NewJSONObjectAgg(
+		expression.NewGetField(0, sql.LongText, "k", true),
+		expression.NewGetField(1, sql.Int64, "v", true),
+	)
+	require.Equal("JSON_OBJECTAGG(k, v)", j.String())
+
+	require.Nil(aggregate(t, j))
+	require.Equal(
+		sql.MustJSON(`{"a": 3, "b": null}`),
+		aggregate(t, j, sql.NewRow("a", int64(1)), sql.NewRow("b", nil), sql.NewRow("a", int64(3))),
+	)
+
+	ctx := sql.NewEmptyContext()
+	err := j.Update(ctx, j.NewBuffer(), sql.NewRow(nil, int64(1)))
+	require.Error(err)
+	require.True(sql.ErrJSONNullKey.Is(err))
+}
+
+func TestJSONObjectAggMerge(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	j := NewJSONObjectAgg(
+		expression.NewGetField(0, sql.LongText, "k", true),
+		expression.NewGetField(1, sql.Int64, "v", true),
+	)
+
+	b := j.NewBuffer()
+	require.NoError(j.Update(ctx, b, sql.NewRow("a", int64(1))))
+	require.NoError(j.Update(ctx, b, sql.NewRow("b", int64(2))))
+
+	require.NoError(j.Merge(ctx, b, j.NewBuffer()))
+
+	partial := j.NewBuffer()
+	require.NoError(j.Update(ctx, partial, sql.NewRow("b", int64(3))))
+	require.NoError(j.Update(ctx, partial, sql.NewRow("c", int64(4))))
+	require.NoError(j.Merge(ctx, b, partial))
+
+	v, err := j.Eval(ctx, b)
+	require.NoError(err)
+	require.Equal(sql.MustJSON(`{"a": 1, "b": 3, "c": 4}`), v)
+
+	b = j.NewBuffer()
+	require.NoError(j.Merge(ctx, b, partial))
+	v, err = j.Eval(ctx, b)
+	require.NoError(err)
+	require.Equal(sql.MustJSON(`{"b": 3, "c": 4}`), v)
+}
@@ -37,7 +37,7 @@ func TestMin_Eval_Text(t *testing.T) {
 	assert := require.New(t)
 	ctx := sql.NewEmptyContext()
 
-	m := NewMin(expression.NewGetField(0, sql.Text, "field", true))
+	m := NewMin(expression.NewGetField(0, sql.CreateText(sql.Collation_utf8mb4_bin), "field", true))
 	b := m.NewBuffer()
 
 	m.Update(ctx, b, sql.NewRow("a"))
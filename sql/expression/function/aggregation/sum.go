@@ -3,6 +3,8 @@ package aggregation
 import (
 	"fmt"
 
+	"github.com/shopspring/decimal"
+
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
 )
@@ -25,11 +27,37 @@ func (m *Sum) FunctionName() string {
 	return "sum"
 }
 
-// Type returns the resultant type of the aggregation.
+// Type returns the resultant type of the aggregation. The sum of a DECIMAL
+// column is an exact DECIMAL with the same scale and 22 more digits of
+// precision, and any other sum is a DOUBLE.
 func (m *Sum) Type() sql.Type {
+	if typ, ok := decimalResultType(m.Child.Type(), 22, 0); ok {
+		return typ
+	}
 	return sql.Float64
 }
 
+// decimalResultType returns the type of an aggregation over a DECIMAL column
+// of the given type, which has the given number of additional digits of
+// precision and scale, and false if the type is not a DECIMAL.
+func decimalResultType(t sql.Type, precision, scale int) (sql.DecimalType, bool) {
+	dt, ok := t.(sql.DecimalType)
+	if !ok {
+		return nil, false
+	}
+
+	s := int(dt.Scale()) + scale
+	if s > sql.DecimalTypeMaxScale {
+		s = sql.DecimalTypeMaxScale
+	}
+	p := int(dt.Precision()) + precision
+	if p > sql.DecimalTypeMaxPrecision {
+		p = sql.DecimalTypeMaxPrecision
+	}
+
+	return sql.MustCreateDecimalType(uint8(p), uint8(s)), true
+}
+
 func (m *Sum) String() string {
 	return fmt.Sprintf("SUM(%s)", m.Child)
 }
@@ -58,6 +86,14 @@ func (m *Sum) Update(ctx *sql.Context, buffer, row sql.Row) error {
 		return nil
 	}
 
+	if sql.IsDecimal(m.Child.Type()) {
+		dec, err := sql.ToDecimal(v)
+		if err != nil {
+			return err
+		}
+		return m.add(buffer, dec.Decimal)
+	}
+
 	val, err := sql.Float64.Convert(v)
 	if err != nil {
 		val = float64(0)
@@ -72,8 +108,21 @@ func (m *Sum) Update(ctx *sql.Context, buffer, row sql.Row) error {
 	return nil
 }
 
+// add adds the given decimal to the exact sum held by the buffer.
+func (m *Sum) add(buffer sql.Row, dec decimal.Decimal) error {
+	if buffer[0] == nil {
+		buffer[0] = decimal.Zero
+	}
+
+	buffer[0] = buffer[0].(decimal.Decimal).Add(dec)
+	return nil
+}
+
 // Merge implements the Aggregation interface.
 func (m *Sum) Merge(ctx *sql.Context, buffer, partial sql.Row) error {
+	if dec, ok := partial[0].(decimal.Decimal); ok {
+		return m.add(buffer, dec)
+	}
 	return m.Update(ctx, buffer, partial)
 }
 
@@ -81,5 +130,9 @@ func (m *Sum) Merge(ctx *sql.Context, buffer, partial sql.Row) error {
 func (m *Sum) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
 	sum := buffer[0]
 
+	if dec, ok := sum.(decimal.Decimal); ok {
+		return m.Type().Convert(dec)
+	}
+
 	return sum, nil
 }
@@ -74,3 +74,28 @@ func TestSum(t *testing.T) {
 		})
 	}
 }
+
+func TestSumDecimal(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	sum := NewSum(expression.NewGetField(0, sql.MustCreateDecimalType(10, 2), "d", true))
+	require.Equal(sql.MustCreateDecimalType(32, 2), sum.Type())
+
+	buf := sum.NewBuffer()
+	for _, v := range []interface{}{"0.10", "0.20", nil, "0.30"} {
+		require.NoError(sum.Update(ctx, buf, sql.NewRow(v)))
+	}
+
+	partial := sum.NewBuffer()
+	require.NoError(sum.Update(ctx, partial, sql.NewRow("0.01")))
+	require.NoError(sum.Merge(ctx, buf, partial))
+
+	result, err := sum.Eval(ctx, buf)
+	require.NoError(err)
+	require.Equal("0.61", result)
+
+	result, err = sum.Eval(ctx, sum.NewBuffer())
+	require.NoError(err)
+	require.Nil(result)
+}
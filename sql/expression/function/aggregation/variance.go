@@ -0,0 +1,253 @@
+package aggregation
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// moments computes the count, mean and sum of squared differences from the
+// mean of the non-NULL values of an expression using Welford's online
+// algorithm, which is numerically stable. Its buffer holds the count, the mean
+// and the sum of squares, and it is shared by the variance and standard
+// deviation aggregations.
+type moments struct {
+	expression.UnaryExpression
+}
+
+// Type returns the resultant type of the aggregation.
+func (m *moments) Type() sql.Type {
+	return sql.Float64
+}
+
+// IsNullable returns whether the return value can be null.
+func (m *moments) IsNullable() bool {
+	return true
+}
+
+// NewBuffer creates a new buffer to compute the result.
+func (m *moments) NewBuffer() sql.Row {
+	return sql.NewRow(int64(0), float64(0), float64(0))
+}
+
+// Update implements the Aggregation interface.
+func (m *moments) Update(ctx *sql.Context, buffer, row sql.Row) error {
+	v, err := m.Child.Eval(ctx, row)
+	if err != nil {
+		return err
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	val, err := sql.Float64.Convert(v)
+	if err != nil {
+		val = float64(0)
+	}
+	x := val.(float64)
+
+	count := buffer[0].(int64) + 1
+	mean := buffer[1].(float64)
+	delta := x - mean
+	mean += delta / float64(count)
+
+	buffer[0] = count
+	buffer[1] = mean
+	buffer[2] = buffer[2].(float64) + delta*(x-mean)
+	return nil
+}
+
+// Merge implements the Aggregation interface. The partial results are
+// combined with the parallel algorithm by Chan et al.
+func (m *moments) Merge(ctx *sql.Context, buffer, partial sql.Row) error {
+	pcount := partial[0].(int64)
+	if pcount == 0 {
+		return nil
+	}
+
+	bcount := buffer[0].(int64)
+	if bcount == 0 {
+		copy(buffer, partial)
+		return nil
+	}
+
+	count := bcount + pcount
+	bmean, pmean := buffer[1].(float64), partial[1].(float64)
+	delta := pmean - bmean
+
+	buffer[0] = count
+	buffer[1] = bmean + delta*float64(pcount)/float64(count)
+	buffer[2] = buffer[2].(float64) + partial[2].(float64) +
+		delta*delta*float64(bcount)*float64(pcount)/float64(count)
+	return nil
+}
+
+// variance returns the population or sample variance of the buffer, or nil
+// if there are not enough values to compute it.
+func (m *moments) variance(buffer sql.Row, sample bool) interface{} {
+	count := buffer[0].(int64)
+	if sample {
+		count--
+	}
+	if count <= 0 {
+		return nil
+	}
+	return buffer[2].(float64) / float64(count)
+}
+
+// stddev returns the population or sample standard deviation of the buffer,
+// or nil if there are not enough values to compute it.
+func (m *moments) stddev(buffer sql.Row, sample bool) interface{} {
+	v := m.variance(buffer, sample)
+	if v == nil {
+		return nil
+	}
+	return math.Sqrt(v.(float64))
+}
+
+// VarPop aggregation returns the population variance of the selected column.
+// It implements the Aggregation interface.
+type VarPop struct {
+	moments
+}
+
+var _ sql.FunctionExpression = (*VarPop)(nil)
+var _ sql.Aggregation = (*VarPop)(nil)
+
+// NewVarPop returns a new VarPop node.
+func NewVarPop(e sql.Expression) *VarPop {
+	return &VarPop{moments{expression.UnaryExpression{Child: e}}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (v *VarPop) FunctionName() string {
+	return "var_pop"
+}
+
+func (v *VarPop) String() string {
+	return fmt.Sprintf("VAR_POP(%s)", v.Child)
+}
+
+// WithChildren implements the Expression interface.
+func (v *VarPop) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(v, len(children), 1)
+	}
+	return NewVarPop(children[0]), nil
+}
+
+// Eval implements the Aggregation interface.
+func (v *VarPop) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
+	return v.variance(buffer, false), nil
+}
+
+// VarSamp aggregation returns the sample variance of the selected column.
+// It implements the Aggregation interface.
+type VarSamp struct {
+	moments
+}
+
+var _ sql.FunctionExpression = (*VarSamp)(nil)
+var _ sql.Aggregation = (*VarSamp)(nil)
+
+// NewVarSamp returns a new VarSamp node.
+func NewVarSamp(e sql.Expression) *VarSamp {
+	return &VarSamp{moments{expression.UnaryExpression{Child: e}}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (v *VarSamp) FunctionName() string {
+	return "var_samp"
+}
+
+func (v *VarSamp) String() string {
+	return fmt.Sprintf("VAR_SAMP(%s)", v.Child)
+}
+
+// WithChildren implements the Expression interface.
+func (v *VarSamp) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(v, len(children), 1)
+	}
+	return NewVarSamp(children[0]), nil
+}
+
+// Eval implements the Aggregation interface.
+func (v *VarSamp) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
+	return v.variance(buffer, true), nil
+}
+
+// StdDevPop aggregation returns the population standard deviation of the
+// selected column. It implements the Aggregation interface.
+type StdDevPop struct {
+	moments
+}
+
+var _ sql.FunctionExpression = (*StdDevPop)(nil)
+var _ sql.Aggregation = (*StdDevPop)(nil)
+
+// NewStdDevPop returns a new StdDevPop node.
+func NewStdDevPop(e sql.Expression) *StdDevPop {
+	return &StdDevPop{moments{expression.UnaryExpression{Child: e}}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *StdDevPop) FunctionName() string {
+	return "stddev_pop"
+}
+
+func (s *StdDevPop) String() string {
+	return fmt.Sprintf("STDDEV_POP(%s)", s.Child)
+}
+
+// WithChildren implements the Expression interface.
+func (s *StdDevPop) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 1)
+	}
+	return NewStdDevPop(children[0]), nil
+}
+
+// Eval implements the Aggregation interface.
+func (s *StdDevPop) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
+	return s.stddev(buffer, false), nil
+}
+
+// StdDevSamp aggregation returns the sample standard deviation of the
+// selected column. It implements the Aggregation interface.
+type StdDevSamp struct {
+	moments
+}
+
+var _ sql.FunctionExpression = (*StdDevSamp)(nil)
+var _ sql.Aggregation = (*StdDevSamp)(nil)
+
+// NewStdDevSamp returns a new StdDevSamp node.
+func NewStdDevSamp(e sql.Expression) *StdDevSamp {
+	return &StdDevSamp{moments{expression.UnaryExpression{Child: e}}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *StdDevSamp) FunctionName() string {
+	return "stddev_samp"
+}
+
+func (s *StdDevSamp) String() string {
+	return fmt.Sprintf("STDDEV_SAMP(%s)", s.Child)
+}
+
+// WithChildren implements the Expression interface.
+func (s *StdDevSamp) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 1)
+	}
+	return NewStdDevSamp(children[0]), nil
+}
+
+// Eval implements the Aggregation interface.
+func (s *StdDevSamp) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
+	return s.stddev(buffer, true), nil
+}
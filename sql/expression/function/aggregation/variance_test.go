@@ -0,0 +1,84 @@
+package aggregation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestVariance(t *testing.T) {
+	field := expression.NewGetField(0, sql.Float64, "field", true)
+
+	rows := []sql.Row{{float64(2)}, {nil}, {float64(4)}, {float64(4)}, {float64(4)}, {float64(5)}, {float64(5)}, {float64(7)}, {float64(9)}}
+
+	testCases := []struct {
+		agg      sql.Aggregation
+		rows     []sql.Row
+		expected interface{}
+	}{
+		{NewVarPop(field), rows, float64(4)},
+		{NewVarSamp(field), rows, float64(32) / 7},
+		{NewStdDevPop(field), rows, float64(2)},
+		{NewStdDevSamp(field), rows, math.Sqrt(float64(32) / 7)},
+		{NewVarPop(field), nil, nil},
+		{NewStdDevPop(field), []sql.Row{{nil}}, nil},
+		{NewVarPop(field), []sql.Row{{float64(3)}}, float64(0)},
+		{NewVarSamp(field), []sql.Row{{float64(3)}}, nil},
+		{NewStdDevSamp(field), []sql.Row{{float64(3)}}, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.agg.String(), func(t *testing.T) {
+			v := aggregate(t, tt.agg, tt.rows...)
+			if tt.expected == nil {
+				require.Nil(t, v)
+				return
+			}
+			require.InDelta(t, tt.expected, v, 1e-9)
+		})
+	}
+}
+
+func TestVarianceStability(t *testing.T) {
+	v := NewVarSamp(expression.NewGetField(0, sql.Float64, "field", true))
+
+	var rows []sql.Row
+	for _, x := range []float64{4, 7, 13, 16} {
+		rows = append(rows, sql.NewRow(1e9+x))
+	}
+
+	require.InDelta(t, float64(30), aggregate(t, v, rows...), 1e-6)
+}
+
+func TestVarianceMerge(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	field := expression.NewGetField(0, sql.Int64, "field", true)
+	for _, agg := range []sql.Aggregation{NewVarPop(field), NewVarSamp(field), NewStdDevPop(field), NewStdDevSamp(field)} {
+		var all []sql.Row
+		var partials []sql.Row
+		for p := 0; p < 3; p++ {
+			b := agg.NewBuffer()
+			for i := 0; i < p*4; i++ {
+				row := sql.NewRow(int64(p*10 + i*i))
+				all = append(all, row)
+				require.NoError(agg.Update(ctx, b, row))
+			}
+			partials = append(partials, b)
+		}
+
+		b := agg.NewBuffer()
+		for _, p := range partials {
+			require.NoError(agg.Merge(ctx, b, p))
+		}
+
+		v, err := agg.Eval(ctx, b)
+		require.NoError(err)
+		require.InDelta(aggregate(t, agg, all...), v, 1e-9, agg.String())
+	}
+}
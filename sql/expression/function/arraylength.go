@@ -56,6 +56,10 @@ func (f *ArrayLength) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, nil
 	}
 
+	if doc, ok := child.(sql.JSONDocument); ok {
+		child = doc.Val
+	}
+
 	array, ok := child.([]interface{})
 	if !ok {
 		return nil, nil
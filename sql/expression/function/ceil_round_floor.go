@@ -247,6 +247,14 @@ func (r *Round) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		}
 	}
 
+	if sql.IsDecimal(r.Left.Type()) {
+		dec, err := sql.ToDecimal(xVal)
+		if err != nil {
+			return nil, err
+		}
+		return r.Type().Convert(dec.Decimal.Round(int32(dVal)))
+	}
+
 	if !sql.IsNumber(r.Left.Type()) {
 		xVal, err = sql.Float64.Convert(xVal)
 		if err != nil {
@@ -306,12 +314,47 @@ func (r *Round) Resolved() bool {
 // Type implements the Expression interface.
 func (r *Round) Type() sql.Type {
 	leftChildType := r.Left.Type()
+	if dt, ok := leftChildType.(sql.DecimalType); ok {
+		return r.decimalType(dt)
+	}
 	if sql.IsNumber(leftChildType) {
 		return leftChildType
 	}
 	return sql.Int32
 }
 
+// decimalType returns the type of the result of rounding a DECIMAL of the
+// given type. When the number of decimal places is a constant the result has
+// that scale, and one more digit of precision in case rounding carries over.
+func (r *Round) decimalType(dt sql.DecimalType) sql.Type {
+	places := int64(0)
+	if r.Right != nil {
+		lit, ok := r.Right.(*expression.Literal)
+		if !ok {
+			return dt
+		}
+		v, err := sql.Int64.Convert(lit.Value())
+		if err != nil || v == nil {
+			return dt
+		}
+		places = v.(int64)
+	}
+
+	scale := int(places)
+	if scale < 0 {
+		scale = 0
+	}
+	if scale > sql.DecimalTypeMaxScale {
+		scale = sql.DecimalTypeMaxScale
+	}
+	precision := int(dt.Precision()) - int(dt.Scale()) + scale + 1
+	if precision > sql.DecimalTypeMaxPrecision {
+		precision = sql.DecimalTypeMaxPrecision
+	}
+
+	return sql.MustCreateDecimalType(uint8(precision), uint8(scale))
+}
+
 // WithChildren implements the Expression interface.
 func (r *Round) WithChildren(children ...sql.Expression) (sql.Expression, error) {
 	return NewRound(children...)
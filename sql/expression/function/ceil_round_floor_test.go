@@ -267,3 +267,40 @@ func TestRound(t *testing.T) {
 	req.NoError(err)
 	req.Equal(int32(0), result)
 }
+
+func TestRoundDecimal(t *testing.T) {
+	dec := sql.MustCreateDecimalType(10, 3)
+
+	testCases := []struct {
+		name     string
+		d        sql.Expression
+		row      sql.Row
+		typ      sql.Type
+		expected interface{}
+	}{
+		{"without d", nil, sql.NewRow("5.500"), sql.MustCreateDecimalType(8, 0), "6"},
+		{"with d", expression.NewLiteral(int8(2), sql.Int8), sql.NewRow("5.855"), sql.MustCreateDecimalType(10, 2), "5.86"},
+		{"negative value", expression.NewLiteral(int8(1), sql.Int8), sql.NewRow("-2.250"), sql.MustCreateDecimalType(9, 1), "-2.3"},
+		{"with negative d", expression.NewLiteral(int8(-1), sql.Int8), sql.NewRow("55.000"), sql.MustCreateDecimalType(8, 0), "60"},
+		{"with column d", expression.NewGetField(1, sql.Int32, "d", false), sql.NewRow("5.855", int32(1)), dec, "5.900"},
+		{"nil", nil, sql.NewRow(nil), sql.MustCreateDecimalType(8, 0), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			args := []sql.Expression{expression.NewGetField(0, dec, "x", true)}
+			if tt.d != nil {
+				args = append(args, tt.d)
+			}
+			f, err := NewRound(args...)
+			require.NoError(err)
+			require.Equal(tt.typ, f.Type())
+
+			result, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+}
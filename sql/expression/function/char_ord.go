@@ -0,0 +1,151 @@
+package function
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// Char is a function that returns the binary string made of the bytes of its integer arguments. Each one of them
+// gives the bytes of its value as a 4 byte integer, without the leading zero bytes. NULL arguments are skipped.
+// CHAR(... USING charset) is parsed as CONVERT(CHAR(...) USING charset).
+type Char struct {
+	args []sql.Expression
+}
+
+var _ sql.FunctionExpression = (*Char)(nil)
+
+// NewChar creates a new Char expression.
+func NewChar(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) == 0 {
+		return nil, sql.ErrInvalidArgumentNumber.New("CHAR", "1 or more", 0)
+	}
+	return &Char{args}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (c *Char) FunctionName() string {
+	return "char"
+}
+
+// Children implements the Expression interface.
+func (c *Char) Children() []sql.Expression {
+	return c.args
+}
+
+// Resolved implements the Expression interface.
+func (c *Char) Resolved() bool {
+	return argsResolved(c.args)
+}
+
+// IsNullable implements the Expression interface.
+func (c *Char) IsNullable() bool {
+	return false
+}
+
+// Type implements the Expression interface.
+func (c *Char) Type() sql.Type { return sql.LongBlob }
+
+func (c *Char) String() string {
+	return fmt.Sprintf("CHAR(%s)", joinArgs(c.args))
+}
+
+// WithChildren implements the Expression interface.
+func (c *Char) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewChar(children...)
+}
+
+// Eval implements the Expression interface.
+func (c *Char) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	var b []byte
+	for _, arg := range c.args {
+		val, err := arg.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if val == nil {
+			continue
+		}
+
+		var n uint32
+		if u, ok := val.(uint64); ok {
+			n = uint32(u)
+		} else if i, err := sql.Int64.Convert(val); err == nil {
+			n = uint32(i.(int64))
+		}
+
+		switch {
+		case n > 0xFFFFFF:
+			b = append(b, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+		case n > 0xFFFF:
+			b = append(b, byte(n>>16), byte(n>>8), byte(n))
+		case n > 0xFF:
+			b = append(b, byte(n>>8), byte(n))
+		default:
+			b = append(b, byte(n))
+		}
+	}
+
+	return string(b), nil
+}
+
+// Ord is a function that returns the code of the first character of a string, which is made of the bytes that
+// represent it in the character set of the string, the first one being the most significant.
+type Ord struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*Ord)(nil)
+
+// NewOrd creates a new Ord expression.
+func NewOrd(e sql.Expression) sql.Expression {
+	return &Ord{expression.UnaryExpression{Child: e}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (o *Ord) FunctionName() string {
+	return "ord"
+}
+
+// Eval implements the Expression interface.
+func (o *Ord) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	str, err := evalString(ctx, o.Child, row)
+	if str == nil || err != nil {
+		return nil, err
+	}
+
+	chars := characters(str.(string), isBinaryString(o.Child))
+	if len(chars) == 0 {
+		return int64(0), nil
+	}
+
+	charset := sql.Collation_Default.CharacterSet()
+	if st, ok := o.Child.Type().(sql.StringType); ok {
+		charset = st.CharacterSet()
+	}
+
+	b, _ := charset.Encode(chars[0])
+	var code int64
+	for _, c := range b {
+		code = code<<8 | int64(c)
+	}
+	return code, nil
+}
+
+func (o *Ord) String() string {
+	return fmt.Sprintf("ORD(%s)", o.Child)
+}
+
+// WithChildren implements the Expression interface.
+func (o *Ord) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(o, len(children), 1)
+	}
+	return NewOrd(children[0]), nil
+}
+
+// Type implements the Expression interface.
+func (o *Ord) Type() sql.Type {
+	return sql.Int64
+}
@@ -0,0 +1,66 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestChar(t *testing.T) {
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"bytes", sql.NewRow(77, 121, 83, 81, 76), "MySQL"},
+		{"two bytes", sql.NewRow(256), "\x01\x00"},
+		{"four bytes", sql.NewRow(0x1020304), "\x01\x02\x03\x04"},
+		{"null", sql.NewRow(nil, 65), "A"},
+		{"zero", sql.NewRow(0), "\x00"},
+		{"string", sql.NewRow("66"), "B"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			var args []sql.Expression
+			for i := range tt.row {
+				args = append(args, expression.NewGetField(i, sql.Int64, "", true))
+			}
+			f, err := NewChar(args...)
+			require.NoError(err)
+
+			v, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+}
+
+func TestOrd(t *testing.T) {
+	testCases := []struct {
+		name     string
+		typ      sql.Type
+		row      sql.Row
+		expected interface{}
+	}{
+		{"null", sql.LongText, sql.NewRow(nil), nil},
+		{"empty", sql.LongText, sql.NewRow(""), int64(0)},
+		{"ascii", sql.LongText, sql.NewRow("2"), int64(50)},
+		{"multibyte", sql.LongText, sql.NewRow("éa"), int64(0xC3A9)},
+		{"latin1", sql.CreateLongText(sql.Collation_latin1_swedish_ci), sql.NewRow("é"), int64(0xE9)},
+		{"binary", sql.LongBlob, sql.NewRow("é"), int64(0xC3)},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewOrd(expression.NewGetField(0, tt.typ, "str", true))
+			v, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, v)
+		})
+	}
+}
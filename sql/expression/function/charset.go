@@ -0,0 +1,146 @@
+package function
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// Charset is a function that returns the character set of a string. The values of other types are binary.
+type Charset struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*Charset)(nil)
+
+// NewCharset creates a new Charset expression.
+func NewCharset(e sql.Expression) sql.Expression {
+	return &Charset{expression.UnaryExpression{Child: e}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (c *Charset) FunctionName() string {
+	return "charset"
+}
+
+// Eval implements the Expression interface.
+func (c *Charset) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	if st, ok := c.Child.Type().(sql.StringType); ok {
+		return st.CharacterSet().String(), nil
+	}
+	return sql.CharacterSet_binary.String(), nil
+}
+
+// IsNullable implements the Expression interface.
+func (c *Charset) IsNullable() bool {
+	return false
+}
+
+func (c *Charset) String() string {
+	return fmt.Sprintf("CHARSET(%s)", c.Child)
+}
+
+// WithChildren implements the Expression interface.
+func (c *Charset) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(c, len(children), 1)
+	}
+	return NewCharset(children[0]), nil
+}
+
+// Type implements the Expression interface.
+func (c *Charset) Type() sql.Type {
+	return sql.LongText
+}
+
+// Collation is a function that returns the collation of a string. The values of other types are binary.
+type Collation struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*Collation)(nil)
+
+// NewCollation creates a new Collation expression.
+func NewCollation(e sql.Expression) sql.Expression {
+	return &Collation{expression.UnaryExpression{Child: e}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (c *Collation) FunctionName() string {
+	return "collation"
+}
+
+// Eval implements the Expression interface.
+func (c *Collation) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	if st, ok := c.Child.Type().(sql.StringType); ok {
+		return st.Collation().String(), nil
+	}
+	return sql.Collation_binary.String(), nil
+}
+
+// IsNullable implements the Expression interface.
+func (c *Collation) IsNullable() bool {
+	return false
+}
+
+func (c *Collation) String() string {
+	return fmt.Sprintf("COLLATION(%s)", c.Child)
+}
+
+// WithChildren implements the Expression interface.
+func (c *Collation) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(c, len(children), 1)
+	}
+	return NewCollation(children[0]), nil
+}
+
+// Type implements the Expression interface.
+func (c *Collation) Type() sql.Type {
+	return sql.LongText
+}
+
+// isBinaryString returns whether the values of the given expression are binary strings, whose characters are their
+// bytes.
+func isBinaryString(e sql.Expression) bool {
+	st, ok := e.Type().(sql.StringType)
+	return ok && st.CharacterSet() == sql.CharacterSet_binary
+}
+
+// argsCollation returns the collation used to compare the given arguments as strings: binary if any of them is a
+// binary string, otherwise the collation of the first one that's a string, where, as in MySQL, columns and other
+// expressions take precedence over literals, or the default one if none is.
+func argsCollation(args ...sql.Expression) sql.Collation {
+	collation := sql.Collation_Default
+	found, fromLiteral := false, false
+	for _, arg := range args {
+		st, ok := arg.Type().(sql.StringType)
+		if !ok {
+			continue
+		}
+		if st.CharacterSet() == sql.CharacterSet_binary {
+			return sql.Collation_binary
+		}
+		_, isLiteral := arg.(*expression.Literal)
+		if !found || (fromLiteral && !isLiteral) {
+			collation, found, fromLiteral = st.Collation(), true, isLiteral
+		}
+	}
+	return collation
+}
+
+// characters returns the characters of the given string, which are its bytes if it's binary.
+func characters(s string, binary bool) []string {
+	chars := make([]string, 0, len(s))
+	for len(s) > 0 {
+		size := 1
+		if !binary {
+			_, size = utf8.DecodeRuneInString(s)
+		}
+		chars = append(chars, s[:size])
+		s = s[size:]
+	}
+	return chars
+}
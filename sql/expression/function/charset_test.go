@@ -0,0 +1,39 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestCharsetAndCollation(t *testing.T) {
+	testCases := []struct {
+		name      string
+		inputType sql.Type
+		charset   string
+		collation string
+	}{
+		{"default text", sql.LongText, "utf8mb4", "utf8mb4_0900_ai_ci"},
+		{"latin1 text", sql.CreateText(sql.Collation_latin1_swedish_ci), "latin1", "latin1_swedish_ci"},
+		{"blob", sql.Blob, "binary", "binary"},
+		{"integer", sql.Int64, "binary", "binary"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			field := expression.NewGetField(0, tt.inputType, "foo", true)
+
+			result, err := NewCharset(field).Eval(sql.NewEmptyContext(), sql.Row{nil})
+			require.NoError(err)
+			require.Equal(tt.charset, result)
+
+			result, err = NewCollation(field).Eval(sql.NewEmptyContext(), sql.Row{nil})
+			require.NoError(err)
+			require.Equal(tt.collation, result)
+		})
+	}
+}
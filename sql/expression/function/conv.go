@@ -0,0 +1,149 @@
+package function
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// Conv is a function that converts a number written in a base to another base. Bases go from 2 to 36. The number is
+// read as unsigned, up to its first invalid digit, unless the base it's written in is negative, and it's written as
+// unsigned unless the base it's converted to is negative. NULL is returned if any base is invalid.
+type Conv struct {
+	n        sql.Expression
+	fromBase sql.Expression
+	toBase   sql.Expression
+}
+
+var _ sql.FunctionExpression = (*Conv)(nil)
+
+// NewConv creates a new Conv expression.
+func NewConv(n, fromBase, toBase sql.Expression) sql.Expression {
+	return &Conv{n, fromBase, toBase}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (c *Conv) FunctionName() string {
+	return "conv"
+}
+
+// Children implements the Expression interface.
+func (c *Conv) Children() []sql.Expression {
+	return []sql.Expression{c.n, c.fromBase, c.toBase}
+}
+
+// Resolved implements the Expression interface.
+func (c *Conv) Resolved() bool {
+	return argsResolved(c.Children())
+}
+
+// IsNullable implements the Expression interface.
+func (c *Conv) IsNullable() bool {
+	return true
+}
+
+// Type implements the Expression interface.
+func (c *Conv) Type() sql.Type { return sql.LongText }
+
+func (c *Conv) String() string {
+	return fmt.Sprintf("CONV(%s, %s, %s)", c.n, c.fromBase, c.toBase)
+}
+
+// WithChildren implements the Expression interface.
+func (c *Conv) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 3 {
+		return nil, sql.ErrInvalidChildrenNumber.New(c, len(children), 3)
+	}
+	return NewConv(children[0], children[1], children[2]), nil
+}
+
+// Eval implements the Expression interface.
+func (c *Conv) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	n, err := evalString(ctx, c.n, row)
+	if n == nil || err != nil {
+		return nil, err
+	}
+
+	fromBase, err := evalInt64(ctx, c.fromBase, row)
+	if fromBase == nil || err != nil {
+		return nil, err
+	}
+
+	toBase, err := evalInt64(ctx, c.toBase, row)
+	if toBase == nil || err != nil {
+		return nil, err
+	}
+
+	from, to := fromBase.(int64), toBase.(int64)
+	if !validBase(from) || !validBase(to) {
+		return nil, nil
+	}
+
+	val := parseDigits(n.(string), from)
+	if to < 0 {
+		return strings.ToUpper(strconv.FormatInt(int64(val), int(-to))), nil
+	}
+	return strings.ToUpper(strconv.FormatUint(val, int(to))), nil
+}
+
+func validBase(base int64) bool {
+	if base < 0 {
+		base = -base
+	}
+	return base >= 2 && base <= 36
+}
+
+// parseDigits returns the number written in the given string in the given base, up to its first invalid digit. The
+// number saturates to the largest unsigned one, or to the signed ones if the base is negative. Negative numbers are
+// returned in two's complement.
+func parseDigits(s string, base int64) uint64 {
+	s = strings.TrimSpace(s)
+	negative := strings.HasPrefix(s, "-")
+	if negative {
+		s = s[1:]
+	}
+
+	signed := base < 0
+	if signed {
+		base = -base
+	}
+
+	var val uint64
+	overflow := false
+	for _, c := range strings.ToLower(s) {
+		var digit uint64
+		switch {
+		case '0' <= c && c <= '9':
+			digit = uint64(c - '0')
+		case 'a' <= c && c <= 'z':
+			digit = uint64(c-'a') + 10
+		default:
+			digit = math.MaxUint64
+		}
+		if digit >= uint64(base) {
+			break
+		}
+
+		if val > (math.MaxUint64-digit)/uint64(base) {
+			overflow = true
+			break
+		}
+		val = val*uint64(base) + digit
+	}
+
+	switch {
+	case signed && negative && (overflow || val > -math.MinInt64):
+		return uint64(1) << 63
+	case signed && !negative && (overflow || val > math.MaxInt64):
+		return math.MaxInt64
+	case overflow:
+		return math.MaxUint64
+	case negative:
+		return -val
+	default:
+		return val
+	}
+}
@@ -0,0 +1,46 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestConv(t *testing.T) {
+	f := NewConv(
+		expression.NewGetField(0, sql.LongText, "n", true),
+		expression.NewGetField(1, sql.Int64, "from_base", true),
+		expression.NewGetField(2, sql.Int64, "to_base", true),
+	)
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"null number", sql.NewRow(nil, 16, 2), nil},
+		{"null from base", sql.NewRow("a", nil, 2), nil},
+		{"null to base", sql.NewRow("a", 16, nil), nil},
+		{"hex to binary", sql.NewRow("a", 16, 2), "1010"},
+		{"base 18 to octal", sql.NewRow("6E", 18, 8), "172"},
+		{"signed", sql.NewRow("-17", 10, -18), "-H"},
+		{"negative unsigned", sql.NewRow("-1", 10, 16), "FFFFFFFFFFFFFFFF"},
+		{"invalid digits", sql.NewRow("12z", 10, 10), "12"},
+		{"no digits", sql.NewRow("z", 10, 10), "0"},
+		{"overflow", sql.NewRow("FFFFFFFFFFFFFFFFFFFFF", 16, 10), "18446744073709551615"},
+		{"signed overflow", sql.NewRow("FFFFFFFFFFFFFFFFFFFFF", -16, -10), "9223372036854775807"},
+		{"invalid from base", sql.NewRow("a", 37, 10), nil},
+		{"invalid to base", sql.NewRow("a", 16, 1), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, v)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package function
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// ConvertTz converts a datetime from a time zone to another one. Time zones
+// can be offsets such as '+01:00', named zones such as 'Europe/Madrid' or
+// 'SYSTEM'. It returns NULL if any of the arguments is not valid.
+type ConvertTz struct {
+	dt     sql.Expression
+	fromTz sql.Expression
+	toTz   sql.Expression
+}
+
+var _ sql.FunctionExpression = (*ConvertTz)(nil)
+
+// NewConvertTz creates a new ConvertTz expression.
+func NewConvertTz(dt, fromTz, toTz sql.Expression) sql.Expression {
+	return &ConvertTz{dt, fromTz, toTz}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (c *ConvertTz) FunctionName() string {
+	return "convert_tz"
+}
+
+// Children implements the Expression interface.
+func (c *ConvertTz) Children() []sql.Expression {
+	return []sql.Expression{c.dt, c.fromTz, c.toTz}
+}
+
+// Resolved implements the Expression interface.
+func (c *ConvertTz) Resolved() bool {
+	return c.dt.Resolved() && c.fromTz.Resolved() && c.toTz.Resolved()
+}
+
+// IsNullable implements the Expression interface.
+func (c *ConvertTz) IsNullable() bool {
+	return true
+}
+
+func (c *ConvertTz) String() string {
+	return fmt.Sprintf("CONVERT_TZ(%s, %s, %s)", c.dt, c.fromTz, c.toTz)
+}
+
+// Type implements the Expression interface.
+func (c *ConvertTz) Type() sql.Type {
+	return sql.Datetime
+}
+
+// WithChildren implements the Expression interface.
+func (c *ConvertTz) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 3 {
+		return nil, sql.ErrInvalidChildrenNumber.New(c, len(children), 3)
+	}
+	return NewConvertTz(children[0], children[1], children[2]), nil
+}
+
+// Eval implements the Expression interface.
+func (c *ConvertTz) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	dt, err := c.dt.Eval(ctx, row)
+	if dt == nil || err != nil {
+		return nil, err
+	}
+
+	dt, err = sql.Datetime.Convert(dt)
+	if err != nil {
+		return nil, nil
+	}
+
+	from, err := c.evalTimeZone(ctx, c.fromTz, row)
+	if from == nil || err != nil {
+		return nil, err
+	}
+
+	to, err := c.evalTimeZone(ctx, c.toTz, row)
+	if to == nil || err != nil {
+		return nil, err
+	}
+
+	return sql.ConvertTimeZone(dt.(time.Time), from, to), nil
+}
+
+// evalTimeZone returns the location of the time zone given by the
+// expression, or nil if it is not a valid time zone.
+func (c *ConvertTz) evalTimeZone(ctx *sql.Context, e sql.Expression, row sql.Row) (*time.Location, error) {
+	tz, err := e.Eval(ctx, row)
+	if tz == nil || err != nil {
+		return nil, err
+	}
+
+	tz, err = sql.LongText.Convert(tz)
+	if err != nil {
+		return nil, nil
+	}
+
+	loc, err := sql.ResolveTimeZone(ctx, tz.(string))
+	if err != nil {
+		return nil, nil
+	}
+	return loc, nil
+}
@@ -0,0 +1,47 @@
+package function
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestConvertTz(t *testing.T) {
+	dt := expression.NewGetField(0, sql.Datetime, "dt", true)
+	from := expression.NewGetField(1, sql.LongText, "from", true)
+	to := expression.NewGetField(2, sql.LongText, "to", true)
+	f := NewConvertTz(dt, from, to)
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"offsets", sql.NewRow("2021-01-15 12:00:00", "+00:00", "+10:00"), time.Date(2021, 1, 15, 22, 0, 0, 0, time.UTC)},
+		{"across days", sql.NewRow("2021-01-15 12:00:00", "+05:30", "-08:00"), time.Date(2021, 1, 14, 22, 30, 0, 0, time.UTC)},
+		{"named zones", sql.NewRow("2021-07-15 12:00:00", "Europe/Madrid", "America/New_York"), time.Date(2021, 7, 15, 6, 0, 0, 0, time.UTC)},
+		{"system", sql.NewRow("2021-07-15 12:00:00", "SYSTEM", "+01:00"), time.Date(2021, 7, 15, 13, 0, 0, 0, time.UTC)},
+		{"time value", sql.NewRow(time.Date(2021, 7, 15, 12, 0, 0, 0, time.UTC), "UTC", "Asia/Tokyo"), time.Date(2021, 7, 15, 21, 0, 0, 0, time.UTC)},
+		{"unknown zone", sql.NewRow("2021-01-15 12:00:00", "+00:00", "Nowhere"), nil},
+		{"invalid offset", sql.NewRow("2021-01-15 12:00:00", "+15:00", "+00:00"), nil},
+		{"invalid date", sql.NewRow("not a date", "+00:00", "+01:00"), nil},
+		{"null date", sql.NewRow(nil, "+00:00", "+01:00"), nil},
+		{"null zone", sql.NewRow("2021-01-15 12:00:00", nil, "+01:00"), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			v, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+
+	require.Equal(t, "CONVERT_TZ(dt, from, to)", f.String())
+	require.Equal(t, sql.Datetime, f.Type())
+}
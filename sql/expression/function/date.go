@@ -2,6 +2,7 @@ package function
 
 import (
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -283,7 +284,8 @@ func NewDatetime(args ...sql.Expression) (sql.Expression, error) {
 	return &DatetimeConversion{args[0]}, nil
 }
 
-// UnixTimestamp converts the argument to the number of seconds since 1970-01-01 00:00:00 UTC.
+// UnixTimestamp converts the argument, a date in the time zone of the session, to the number of seconds since
+// 1970-01-01 00:00:00 UTC.
 // With no argument, returns number of seconds since unix epoch for the current time.
 type UnixTimestamp struct {
 	Date sql.Expression
@@ -351,7 +353,8 @@ func (ut *UnixTimestamp) Eval(ctx *sql.Context, row sql.Row) (interface{}, error
 		return nil, err
 	}
 
-	return toUnixTimestamp(date.(time.Time))
+	// Dates are given in the time zone of the session
+	return toUnixTimestamp(sql.FromTimeZone(date.(time.Time), sql.SessionTimeZone(ctx)))
 }
 
 func toUnixTimestamp(t time.Time) (interface{}, error) {
@@ -366,6 +369,104 @@ func (ut *UnixTimestamp) String() string {
 	}
 }
 
+// maxUnixTimestamp is the largest timestamp accepted by FROM_UNIXTIME, which is
+// 3001-01-19 03:14:07.999999 UTC.
+const maxUnixTimestamp = 32536771199.999999
+
+// FromUnixtime converts a number of seconds since 1970-01-01 00:00:00 UTC,
+// which can have a fractional part, to a datetime in the time zone of the
+// session. With a format, it returns the datetime formatted as DATE_FORMAT
+// does. It returns NULL for negative or too large timestamps.
+type FromUnixtime struct {
+	Timestamp sql.Expression
+	Format    sql.Expression
+}
+
+var _ sql.FunctionExpression = (*FromUnixtime)(nil)
+
+// NewFromUnixtime returns a new FromUnixtime UDF.
+func NewFromUnixtime(args ...sql.Expression) (sql.Expression, error) {
+	switch len(args) {
+	case 1:
+		return &FromUnixtime{args[0], nil}, nil
+	case 2:
+		return &FromUnixtime{args[0], args[1]}, nil
+	default:
+		return nil, sql.ErrInvalidArgumentNumber.New("FROM_UNIXTIME", "1 or 2", len(args))
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (f *FromUnixtime) FunctionName() string {
+	return "from_unixtime"
+}
+
+// Children implements the Expression interface.
+func (f *FromUnixtime) Children() []sql.Expression {
+	if f.Format != nil {
+		return []sql.Expression{f.Timestamp, f.Format}
+	}
+	return []sql.Expression{f.Timestamp}
+}
+
+// Resolved implements the Expression interface.
+func (f *FromUnixtime) Resolved() bool {
+	return f.Timestamp.Resolved() && (f.Format == nil || f.Format.Resolved())
+}
+
+// IsNullable implements the Expression interface.
+func (f *FromUnixtime) IsNullable() bool {
+	return true
+}
+
+// Type implements the Expression interface.
+func (f *FromUnixtime) Type() sql.Type {
+	if f.Format != nil {
+		return sql.LongText
+	}
+	return sql.Datetime
+}
+
+// WithChildren implements the Expression interface.
+func (f *FromUnixtime) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewFromUnixtime(children...)
+}
+
+// Eval implements the Expression interface.
+func (f *FromUnixtime) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := evalFloat64(ctx, f.Timestamp, row)
+	if val == nil || err != nil {
+		return nil, err
+	}
+
+	ts := val.(float64)
+	if ts < 0 || ts > maxUnixTimestamp {
+		return nil, nil
+	}
+
+	secs := math.Floor(ts)
+	micros := math.Round((ts - secs) * float64(time.Second/time.Microsecond))
+	t := time.Unix(int64(secs), int64(micros)*int64(time.Microsecond))
+	t = sql.InTimeZone(t, sql.SessionTimeZone(ctx))
+
+	if f.Format == nil {
+		return t, nil
+	}
+
+	format, err := evalString(ctx, f.Format, row)
+	if format == nil || err != nil {
+		return nil, err
+	}
+	return formatDate(format.(string), t)
+}
+
+func (f *FromUnixtime) String() string {
+	if f.Format != nil {
+		return fmt.Sprintf("FROM_UNIXTIME(%s, %s)", f.Timestamp, f.Format)
+	}
+	return fmt.Sprintf("FROM_UNIXTIME(%s)", f.Timestamp)
+}
+
 type CurrDate struct {
 	NoArgFunc
 }
@@ -385,7 +486,7 @@ func NewCurrentDate() sql.Expression {
 }
 
 func currDateLogic(ctx *sql.Context, _ sql.Row) (interface{}, error) {
-	t := ctx.QueryTime()
+	t := sql.InTimeZone(ctx.QueryTime(), sql.SessionTimeZone(ctx))
 	return fmt.Sprintf("%d-%02d-%02d", t.Year(), t.Month(), t.Day()), nil
 }
 
@@ -0,0 +1,279 @@
+package function
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// DateDiff returns the number of days from a date to another one, ignoring
+// the time of the dates. It returns NULL if any of them is the zero date.
+type DateDiff struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*DateDiff)(nil)
+
+// NewDateDiff creates a new DateDiff UDF.
+func NewDateDiff(date1, date2 sql.Expression) sql.Expression {
+	return &DateDiff{expression.BinaryExpression{Left: date1, Right: date2}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (d *DateDiff) FunctionName() string {
+	return "datediff"
+}
+
+func (d *DateDiff) String() string {
+	return fmt.Sprintf("DATEDIFF(%s, %s)", d.Left, d.Right)
+}
+
+// Type implements the Expression interface.
+func (d *DateDiff) Type() sql.Type { return sql.Int64 }
+
+// IsNullable implements the Expression interface.
+func (d *DateDiff) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (d *DateDiff) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	date1, err := evalDatetime(ctx, d.Left, row)
+	if date1 == nil || err != nil || isZeroDate(date1.(time.Time)) {
+		return nil, err
+	}
+
+	date2, err := evalDatetime(ctx, d.Right, row)
+	if date2 == nil || err != nil || isZeroDate(date2.(time.Time)) {
+		return nil, err
+	}
+
+	return toDaynr(date1.(time.Time)) - toDaynr(date2.(time.Time)), nil
+}
+
+// WithChildren implements the Expression interface.
+func (d *DateDiff) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(d, len(children), 2)
+	}
+	return NewDateDiff(children[0], children[1]), nil
+}
+
+// timestampUnit evaluates the unit of TIMESTAMPADD and TIMESTAMPDIFF, which
+// can also be given with the SQL_TSI_ prefix.
+func timestampUnit(ctx *sql.Context, name string, e sql.Expression, row sql.Row) (string, error) {
+	val, err := evalString(ctx, e, row)
+	if err != nil {
+		return "", err
+	}
+	if val == nil {
+		return "", ErrInvalidArgument.New(name, "the unit cannot be NULL")
+	}
+
+	unit := strings.TrimPrefix(strings.ToUpper(val.(string)), "SQL_TSI_")
+	switch unit {
+	case "MICROSECOND", "SECOND", "MINUTE", "HOUR", "DAY", "WEEK", "MONTH", "QUARTER", "YEAR":
+		return unit, nil
+	default:
+		return "", ErrInvalidArgument.New(name, fmt.Sprintf("invalid unit %s", val))
+	}
+}
+
+// TimestampDiff returns the difference from a datetime to another one as an
+// integer number of units. The unit is one of MICROSECOND, SECOND, MINUTE,
+// HOUR, DAY, WEEK, MONTH, QUARTER or YEAR.
+type TimestampDiff struct {
+	unit sql.Expression
+	from sql.Expression
+	to   sql.Expression
+}
+
+var _ sql.FunctionExpression = (*TimestampDiff)(nil)
+
+// NewTimestampDiff creates a new TimestampDiff UDF.
+func NewTimestampDiff(unit, from, to sql.Expression) sql.Expression {
+	return &TimestampDiff{unit, from, to}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (t *TimestampDiff) FunctionName() string {
+	return "timestampdiff"
+}
+
+// Children implements the Expression interface.
+func (t *TimestampDiff) Children() []sql.Expression {
+	return []sql.Expression{t.unit, t.from, t.to}
+}
+
+// Resolved implements the Expression interface.
+func (t *TimestampDiff) Resolved() bool {
+	return t.unit.Resolved() && t.from.Resolved() && t.to.Resolved()
+}
+
+// IsNullable implements the Expression interface.
+func (t *TimestampDiff) IsNullable() bool {
+	return true
+}
+
+func (t *TimestampDiff) String() string {
+	return fmt.Sprintf("TIMESTAMPDIFF(%s, %s, %s)", t.unit, t.from, t.to)
+}
+
+// Type implements the Expression interface.
+func (t *TimestampDiff) Type() sql.Type {
+	return sql.Int64
+}
+
+// WithChildren implements the Expression interface.
+func (t *TimestampDiff) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 3 {
+		return nil, sql.ErrInvalidChildrenNumber.New(t, len(children), 3)
+	}
+	return NewTimestampDiff(children[0], children[1], children[2]), nil
+}
+
+// Eval implements the Expression interface.
+func (t *TimestampDiff) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	unit, err := timestampUnit(ctx, "timestampdiff", t.unit, row)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := evalDatetime(ctx, t.from, row)
+	if from == nil || err != nil || isZeroDate(from.(time.Time)) {
+		return nil, err
+	}
+
+	to, err := evalDatetime(ctx, t.to, row)
+	if to == nil || err != nil || isZeroDate(to.(time.Time)) {
+		return nil, err
+	}
+
+	return timestampDiff(unit, from.(time.Time), to.(time.Time)), nil
+}
+
+func timestampDiff(unit string, from, to time.Time) int64 {
+	switch unit {
+	case "MONTH", "QUARTER", "YEAR":
+		months := monthsDiff(from, to)
+		switch unit {
+		case "QUARTER":
+			return months / 3
+		case "YEAR":
+			return months / 12
+		}
+		return months
+	}
+
+	micros := (to.Unix()-from.Unix())*int64(time.Second/time.Microsecond) +
+		int64(to.Nanosecond()-from.Nanosecond())/int64(time.Microsecond)
+
+	switch unit {
+	case "MICROSECOND":
+		return micros
+	case "SECOND":
+		return micros / int64(time.Second/time.Microsecond)
+	case "MINUTE":
+		return micros / int64(time.Minute/time.Microsecond)
+	case "HOUR":
+		return micros / int64(time.Hour/time.Microsecond)
+	case "DAY":
+		return micros / int64(24*time.Hour/time.Microsecond)
+	default: // WEEK
+		return micros / int64(7*24*time.Hour/time.Microsecond)
+	}
+}
+
+// monthsDiff returns the number of complete months from a time to another
+// one, where a month is complete when the day and the time of the month are
+// reached.
+func monthsDiff(from, to time.Time) int64 {
+	sign := int64(1)
+	if to.Before(from) {
+		from, to = to, from
+		sign = -1
+	}
+
+	months := int64(to.Year()-from.Year())*12 + int64(to.Month()-from.Month())
+	if to.Day() < from.Day() || (to.Day() == from.Day() && timeOfDay(to) < timeOfDay(from)) {
+		months--
+	}
+	return sign * months
+}
+
+func timeOfDay(t time.Time) time.Duration {
+	return t.Sub(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()))
+}
+
+// TimestampAdd adds an integer number of units to a datetime. The unit is one
+// of MICROSECOND, SECOND, MINUTE, HOUR, DAY, WEEK, MONTH, QUARTER or YEAR.
+type TimestampAdd struct {
+	unit     sql.Expression
+	interval sql.Expression
+	date     sql.Expression
+}
+
+var _ sql.FunctionExpression = (*TimestampAdd)(nil)
+
+// NewTimestampAdd creates a new TimestampAdd UDF.
+func NewTimestampAdd(unit, interval, date sql.Expression) sql.Expression {
+	return &TimestampAdd{unit, interval, date}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (t *TimestampAdd) FunctionName() string {
+	return "timestampadd"
+}
+
+// Children implements the Expression interface.
+func (t *TimestampAdd) Children() []sql.Expression {
+	return []sql.Expression{t.unit, t.interval, t.date}
+}
+
+// Resolved implements the Expression interface.
+func (t *TimestampAdd) Resolved() bool {
+	return t.unit.Resolved() && t.interval.Resolved() && t.date.Resolved()
+}
+
+// IsNullable implements the Expression interface.
+func (t *TimestampAdd) IsNullable() bool {
+	return true
+}
+
+func (t *TimestampAdd) String() string {
+	return fmt.Sprintf("TIMESTAMPADD(%s, %s, %s)", t.unit, t.interval, t.date)
+}
+
+// Type implements the Expression interface.
+func (t *TimestampAdd) Type() sql.Type {
+	return sql.Datetime
+}
+
+// WithChildren implements the Expression interface.
+func (t *TimestampAdd) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 3 {
+		return nil, sql.ErrInvalidChildrenNumber.New(t, len(children), 3)
+	}
+	return NewTimestampAdd(children[0], children[1], children[2]), nil
+}
+
+// Eval implements the Expression interface.
+func (t *TimestampAdd) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	unit, err := timestampUnit(ctx, "timestampadd", t.unit, row)
+	if err != nil {
+		return nil, err
+	}
+
+	delta, err := expression.NewInterval(t.interval, unit).EvalDelta(ctx, row)
+	if delta == nil || err != nil {
+		return nil, err
+	}
+
+	date, err := evalDatetime(ctx, t.date, row)
+	if date == nil || err != nil || isZeroDate(date.(time.Time)) {
+		return nil, err
+	}
+
+	return sql.ValidateTime(delta.Add(date.(time.Time))), nil
+}
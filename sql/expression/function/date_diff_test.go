@@ -0,0 +1,121 @@
+package function
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestDateDiff(t *testing.T) {
+	f := NewDateDiff(
+		expression.NewGetField(0, sql.LongText, "date1", true),
+		expression.NewGetField(1, sql.LongText, "date2", true),
+	)
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"ignores time", sql.NewRow("2007-12-31 23:59:59", "2007-12-30"), int64(1)},
+		{"negative", sql.NewRow("2010-11-30 23:59:59", "2010-12-31"), int64(-31)},
+		{"time values", sql.NewRow(time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 2, 1, 23, 0, 0, 0, time.UTC)), int64(29)},
+		{"zero date", sql.NewRow("0000-00-00", "2010-12-31"), nil},
+		{"invalid date", sql.NewRow("2010-12-31", "2010-02-30"), nil},
+		{"null", sql.NewRow(nil, "2010-12-31"), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			v, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+}
+
+func TestTimestampDiff(t *testing.T) {
+	f := NewTimestampDiff(
+		expression.NewGetField(0, sql.LongText, "unit", false),
+		expression.NewGetField(1, sql.LongText, "from", true),
+		expression.NewGetField(2, sql.LongText, "to", true),
+	)
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+		err      bool
+	}{
+		{"months", sql.NewRow("MONTH", "2003-02-01", "2003-05-01"), int64(3), false},
+		{"incomplete month", sql.NewRow("MONTH", "2003-01-31", "2003-02-28"), int64(0), false},
+		{"incomplete month by time", sql.NewRow("MONTH", "2003-01-01 12:00:00", "2003-02-01 11:59:59"), int64(0), false},
+		{"negative years", sql.NewRow("YEAR", "2002-05-01", "2001-01-01"), int64(-1), false},
+		{"quarters", sql.NewRow("QUARTER", "2002-05-01", "2003-05-01"), int64(4), false},
+		{"minutes", sql.NewRow("MINUTE", "2003-02-01", "2003-05-01 12:05:55"), int64(128885), false},
+		{"weeks", sql.NewRow("WEEK", "2003-02-01", "2003-02-15"), int64(2), false},
+		{"days", sql.NewRow("DAY", "2003-02-01 12:00:00", "2003-02-03 11:00:00"), int64(1), false},
+		{"negative seconds", sql.NewRow("SECOND", "2003-02-01 00:00:01.5", "2003-02-01"), int64(-1), false},
+		{"microseconds", sql.NewRow("MICROSECOND", "2003-02-01", "2003-02-01 00:00:00.25"), int64(250000), false},
+		{"prefixed unit", sql.NewRow("SQL_TSI_HOUR", "2003-02-01", "2003-02-02"), int64(24), false},
+		{"zero date", sql.NewRow("DAY", "0000-00-00", "2003-02-02"), nil, false},
+		{"invalid date", sql.NewRow("DAY", "2003-02-30", "2003-02-02"), nil, false},
+		{"invalid unit", sql.NewRow("DAY_HOUR", "2003-02-01", "2003-02-02"), nil, true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			v, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			if tt.err {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+}
+
+func TestTimestampAdd(t *testing.T) {
+	f := NewTimestampAdd(
+		expression.NewGetField(0, sql.LongText, "unit", false),
+		expression.NewGetField(1, sql.Int64, "interval", true),
+		expression.NewGetField(2, sql.LongText, "date", true),
+	)
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+		err      bool
+	}{
+		{"minutes", sql.NewRow("MINUTE", 1, "2003-01-02"), time.Date(2003, 1, 2, 0, 1, 0, 0, time.UTC), false},
+		{"weeks", sql.NewRow("WEEK", 1, "2003-01-02"), time.Date(2003, 1, 9, 0, 0, 0, 0, time.UTC), false},
+		{"end of month", sql.NewRow("MONTH", 1, "2003-01-31"), time.Date(2003, 2, 28, 0, 0, 0, 0, time.UTC), false},
+		{"negative quarters", sql.NewRow("QUARTER", -1, "2003-01-31 10:00:00"), time.Date(2002, 10, 31, 10, 0, 0, 0, time.UTC), false},
+		{"prefixed unit", sql.NewRow("SQL_TSI_DAY", 2, "2003-01-31"), time.Date(2003, 2, 2, 0, 0, 0, 0, time.UTC), false},
+		{"after year 9999", sql.NewRow("YEAR", 1, "9999-01-01"), nil, false},
+		{"invalid date", sql.NewRow("DAY", 1, "2003-02-30"), nil, false},
+		{"null interval", sql.NewRow("DAY", nil, "2003-02-01"), nil, false},
+		{"invalid unit", sql.NewRow("DAY_HOUR", 1, "2003-02-01"), nil, true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			v, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			if tt.err {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+}
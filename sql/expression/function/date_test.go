@@ -136,4 +136,53 @@ func TestUnixTimestamp(t *testing.T) {
 	result, err = ut.Eval(ctx, nil)
 	require.NoError(err)
 	require.Equal(expected, result)
+	require.NoError(ctx.Set(ctx, "time_zone", sql.LongText, "+02:00"))
+	ut, err = NewUnixTimestamp(expression.NewLiteral("2018-05-02", sql.LongText))
+	require.NoError(err)
+	expected = float64(time.Date(2018, 5, 1, 22, 0, 0, 0, time.UTC).Unix())
+	result, err = ut.Eval(ctx, nil)
+	require.NoError(err)
+	require.Equal(expected, result)
+}
+
+func TestFromUnixtime(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NewFromUnixtime()
+	require.Error(err)
+
+	ts := expression.NewGetField(0, sql.Float64, "ts", true)
+	f, err := NewFromUnixtime(ts)
+	require.NoError(err)
+	require.Equal(sql.Datetime, f.Type())
+
+	ctx := sql.NewEmptyContext()
+	result, err := f.Eval(ctx, sql.Row{1447430881})
+	require.NoError(err)
+	require.Equal(time.Date(2015, time.November, 13, 16, 8, 1, 0, time.UTC), result)
+
+	result, err = f.Eval(ctx, sql.Row{1447430881.5})
+	require.NoError(err)
+	require.Equal(time.Date(2015, time.November, 13, 16, 8, 1, 500000000, time.UTC), result)
+
+	result, err = f.Eval(ctx, sql.Row{-1})
+	require.NoError(err)
+	require.Nil(result)
+
+	result, err = f.Eval(ctx, sql.Row{nil})
+	require.NoError(err)
+	require.Nil(result)
+
+	f, err = NewFromUnixtime(ts, expression.NewLiteral("%Y %D %M %h:%i:%s", sql.LongText))
+	require.NoError(err)
+	require.Equal(sql.LongText, f.Type())
+
+	result, err = f.Eval(ctx, sql.Row{1447430881})
+	require.NoError(err)
+	require.Equal("2015 13th November 04:08:01", result)
+
+	require.NoError(ctx.Set(ctx, "time_zone", sql.LongText, "+02:00"))
+	result, err = f.Eval(ctx, sql.Row{1447430881})
+	require.NoError(err)
+	require.Equal("2015 13th November 06:08:01", result)
 }
@@ -0,0 +1,448 @@
+package function
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// evalDatetime evaluates the given expression as a datetime. As MySQL does,
+// it returns NULL both for NULL and for values that are not valid dates.
+func evalDatetime(ctx *sql.Context, e sql.Expression, row sql.Row) (interface{}, error) {
+	val, err := e.Eval(ctx, row)
+	if val == nil || err != nil {
+		return nil, err
+	}
+
+	if _, ok := val.(time.Time); !ok {
+		if val, err = sql.LongText.Convert(val); err != nil {
+			return nil, nil
+		}
+	}
+
+	t, err := sql.Datetime.ConvertWithoutRangeCheck(val)
+	if err != nil {
+		return nil, nil
+	}
+	return t, nil
+}
+
+// isZeroDate returns whether the given time is the zero date, 0000-00-00.
+func isZeroDate(t time.Time) bool {
+	return t.Equal(sql.Datetime.Zero().(time.Time))
+}
+
+// unixEpochDaynr is the day number of 1970-01-01.
+const unixEpochDaynr = 719528
+
+// toDaynr returns the number of days of the date of the given time since
+// year 0, as TO_DAYS does.
+func toDaynr(t time.Time) int64 {
+	return int64(calcDaynr(int32(t.Year()), int32(t.Month()), int32(t.Day())))
+}
+
+// dateFromDaynr returns the date of the given number of days since year 0.
+func dateFromDaynr(days int64) time.Time {
+	return time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(days-unixEpochDaynr))
+}
+
+// ToDays returns the number of days since year 0 of a date. It returns NULL
+// for the zero date.
+type ToDays struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*ToDays)(nil)
+
+// NewToDays creates a new ToDays UDF.
+func NewToDays(date sql.Expression) sql.Expression {
+	return &ToDays{expression.UnaryExpression{Child: date}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (t *ToDays) FunctionName() string {
+	return "to_days"
+}
+
+func (t *ToDays) String() string { return fmt.Sprintf("TO_DAYS(%s)", t.Child) }
+
+// Type implements the Expression interface.
+func (t *ToDays) Type() sql.Type { return sql.Int64 }
+
+// IsNullable implements the Expression interface.
+func (t *ToDays) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (t *ToDays) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	date, err := evalDatetime(ctx, t.Child, row)
+	if date == nil || err != nil || isZeroDate(date.(time.Time)) {
+		return nil, err
+	}
+	return toDaynr(date.(time.Time)), nil
+}
+
+// WithChildren implements the Expression interface.
+func (t *ToDays) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(t, len(children), 1)
+	}
+	return NewToDays(children[0]), nil
+}
+
+// FromDays returns the date of a number of days since year 0. As MySQL does,
+// it returns the zero date for the days before year 1 or after year 9999.
+type FromDays struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*FromDays)(nil)
+
+// NewFromDays creates a new FromDays UDF.
+func NewFromDays(days sql.Expression) sql.Expression {
+	return &FromDays{expression.UnaryExpression{Child: days}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (f *FromDays) FunctionName() string {
+	return "from_days"
+}
+
+func (f *FromDays) String() string { return fmt.Sprintf("FROM_DAYS(%s)", f.Child) }
+
+// Type implements the Expression interface.
+func (f *FromDays) Type() sql.Type { return sql.Date }
+
+// Eval implements the Expression interface.
+func (f *FromDays) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := f.Child.Eval(ctx, row)
+	if val == nil || err != nil {
+		return nil, err
+	}
+
+	days, err := sql.Int64.Convert(val)
+	if err != nil {
+		return nil, err
+	}
+
+	// 366 is the day number of 0001-01-01
+	if days.(int64) < 366 || days.(int64) > maxDaynr {
+		return sql.Date.Zero(), nil
+	}
+	return dateFromDaynr(days.(int64)), nil
+}
+
+// WithChildren implements the Expression interface.
+func (f *FromDays) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(f, len(children), 1)
+	}
+	return NewFromDays(children[0]), nil
+}
+
+// LastDay returns the last day of the month of a date. It returns NULL for
+// the zero date.
+type LastDay struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*LastDay)(nil)
+
+// NewLastDay creates a new LastDay UDF.
+func NewLastDay(date sql.Expression) sql.Expression {
+	return &LastDay{expression.UnaryExpression{Child: date}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (l *LastDay) FunctionName() string {
+	return "last_day"
+}
+
+func (l *LastDay) String() string { return fmt.Sprintf("LAST_DAY(%s)", l.Child) }
+
+// Type implements the Expression interface.
+func (l *LastDay) Type() sql.Type { return sql.Date }
+
+// IsNullable implements the Expression interface.
+func (l *LastDay) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (l *LastDay) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	date, err := evalDatetime(ctx, l.Child, row)
+	if date == nil || err != nil || isZeroDate(date.(time.Time)) {
+		return nil, err
+	}
+
+	t := date.(time.Time)
+	// The day 0 of the next month is the last day of this one.
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC), nil
+}
+
+// WithChildren implements the Expression interface.
+func (l *LastDay) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(l, len(children), 1)
+	}
+	return NewLastDay(children[0]), nil
+}
+
+// Quarter returns the quarter of the year of a date, from 1 to 4, or 0 for
+// the zero date.
+type Quarter struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*Quarter)(nil)
+
+// NewQuarter creates a new Quarter UDF.
+func NewQuarter(date sql.Expression) sql.Expression {
+	return &Quarter{expression.UnaryExpression{Child: date}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (q *Quarter) FunctionName() string {
+	return "quarter"
+}
+
+func (q *Quarter) String() string { return fmt.Sprintf("QUARTER(%s)", q.Child) }
+
+// Type implements the Expression interface.
+func (q *Quarter) Type() sql.Type { return sql.Int32 }
+
+// IsNullable implements the Expression interface.
+func (q *Quarter) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (q *Quarter) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	date, err := evalDatetime(ctx, q.Child, row)
+	if date == nil || err != nil {
+		return nil, err
+	}
+
+	t := date.(time.Time)
+	if isZeroDate(t) {
+		return int32(0), nil
+	}
+	return int32(t.Month()+2) / 3, nil
+}
+
+// WithChildren implements the Expression interface.
+func (q *Quarter) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(q, len(children), 1)
+	}
+	return NewQuarter(children[0]), nil
+}
+
+// MakeDate returns the date of a day of the year of a year. Years from 0 to
+// 99 are taken as 1970-2069. It returns NULL when the day is not positive or
+// the date is after year 9999.
+type MakeDate struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*MakeDate)(nil)
+
+// NewMakeDate creates a new MakeDate UDF.
+func NewMakeDate(year, dayOfYear sql.Expression) sql.Expression {
+	return &MakeDate{expression.BinaryExpression{Left: year, Right: dayOfYear}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (m *MakeDate) FunctionName() string {
+	return "makedate"
+}
+
+func (m *MakeDate) String() string {
+	return fmt.Sprintf("MAKEDATE(%s, %s)", m.Left, m.Right)
+}
+
+// Type implements the Expression interface.
+func (m *MakeDate) Type() sql.Type { return sql.Date }
+
+// IsNullable implements the Expression interface.
+func (m *MakeDate) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (m *MakeDate) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	year, err := evalInt64(ctx, m.Left, row)
+	if year == nil || err != nil {
+		return nil, err
+	}
+
+	day, err := evalInt64(ctx, m.Right, row)
+	if day == nil || err != nil {
+		return nil, err
+	}
+
+	y, d := year.(int64), day.(int64)
+	if d <= 0 || y < 0 || y > 9999 {
+		return nil, nil
+	}
+	if y < 100 {
+		y = int64(twoDigitYear(int(y)))
+	}
+
+	if d > maxDaynr {
+		return nil, nil
+	}
+	t := time.Date(int(y), time.January, int(d), 0, 0, 0, 0, time.UTC)
+	if t.Year() > 9999 {
+		return nil, nil
+	}
+	return t, nil
+}
+
+// WithChildren implements the Expression interface.
+func (m *MakeDate) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(m, len(children), 2)
+	}
+	return NewMakeDate(children[0], children[1]), nil
+}
+
+func evalInt64(ctx *sql.Context, e sql.Expression, row sql.Row) (interface{}, error) {
+	val, err := e.Eval(ctx, row)
+	if val == nil || err != nil {
+		return nil, err
+	}
+	return sql.Int64.Convert(val)
+}
+
+// periodToMonths returns the number of months of a period in the format YYMM
+// or YYYYMM, or false if it's not a valid period. Years from 0 to 99 are taken
+// as 1970-2069.
+func periodToMonths(period int64) (int64, bool) {
+	if period <= 0 || period%100 == 0 || period%100 > 12 {
+		return 0, false
+	}
+
+	year := period / 100
+	if year < 100 {
+		year = int64(twoDigitYear(int(year)))
+	}
+	return year*12 + period%100 - 1, true
+}
+
+// monthsToPeriod returns the period in the format YYYYMM of a number of
+// months.
+func monthsToPeriod(months int64) int64 {
+	if months <= 0 {
+		return 0
+	}
+
+	year := months / 12
+	if year < 100 {
+		year = int64(twoDigitYear(int(year)))
+	}
+	return year*100 + months%12 + 1
+}
+
+// PeriodAdd adds a number of months to a period in the format YYMM or
+// YYYYMM, returning a period in the format YYYYMM.
+type PeriodAdd struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*PeriodAdd)(nil)
+
+// NewPeriodAdd creates a new PeriodAdd UDF.
+func NewPeriodAdd(period, months sql.Expression) sql.Expression {
+	return &PeriodAdd{expression.BinaryExpression{Left: period, Right: months}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (p *PeriodAdd) FunctionName() string {
+	return "period_add"
+}
+
+func (p *PeriodAdd) String() string {
+	return fmt.Sprintf("PERIOD_ADD(%s, %s)", p.Left, p.Right)
+}
+
+// Type implements the Expression interface.
+func (p *PeriodAdd) Type() sql.Type { return sql.Int64 }
+
+// Eval implements the Expression interface.
+func (p *PeriodAdd) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	period, err := evalInt64(ctx, p.Left, row)
+	if period == nil || err != nil {
+		return nil, err
+	}
+
+	months, err := evalInt64(ctx, p.Right, row)
+	if months == nil || err != nil {
+		return nil, err
+	}
+
+	periodMonths, ok := periodToMonths(period.(int64))
+	if !ok {
+		return nil, ErrInvalidArgument.New("period_add", fmt.Sprintf("%d is not a valid period", period))
+	}
+	return monthsToPeriod(periodMonths + months.(int64)), nil
+}
+
+// WithChildren implements the Expression interface.
+func (p *PeriodAdd) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(p, len(children), 2)
+	}
+	return NewPeriodAdd(children[0], children[1]), nil
+}
+
+// PeriodDiff returns the number of months between two periods in the format
+// YYMM or YYYYMM.
+type PeriodDiff struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*PeriodDiff)(nil)
+
+// NewPeriodDiff creates a new PeriodDiff UDF.
+func NewPeriodDiff(period1, period2 sql.Expression) sql.Expression {
+	return &PeriodDiff{expression.BinaryExpression{Left: period1, Right: period2}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (p *PeriodDiff) FunctionName() string {
+	return "period_diff"
+}
+
+func (p *PeriodDiff) String() string {
+	return fmt.Sprintf("PERIOD_DIFF(%s, %s)", p.Left, p.Right)
+}
+
+// Type implements the Expression interface.
+func (p *PeriodDiff) Type() sql.Type { return sql.Int64 }
+
+// Eval implements the Expression interface.
+func (p *PeriodDiff) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	period1, err := evalInt64(ctx, p.Left, row)
+	if period1 == nil || err != nil {
+		return nil, err
+	}
+
+	period2, err := evalInt64(ctx, p.Right, row)
+	if period2 == nil || err != nil {
+		return nil, err
+	}
+
+	months1, ok := periodToMonths(period1.(int64))
+	if !ok {
+		return nil, ErrInvalidArgument.New("period_diff", fmt.Sprintf("%d is not a valid period", period1))
+	}
+	months2, ok := periodToMonths(period2.(int64))
+	if !ok {
+		return nil, ErrInvalidArgument.New("period_diff", fmt.Sprintf("%d is not a valid period", period2))
+	}
+	return months1 - months2, nil
+}
+
+// WithChildren implements the Expression interface.
+func (p *PeriodDiff) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(p, len(children), 2)
+	}
+	return NewPeriodDiff(children[0], children[1]), nil
+}
@@ -0,0 +1,120 @@
+package function
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestDayFunctions(t *testing.T) {
+	date := expression.NewGetField(0, sql.LongText, "date", true)
+	days := expression.NewGetField(0, sql.Int64, "days", true)
+
+	testCases := []struct {
+		name     string
+		f        sql.Expression
+		row      sql.Row
+		expected interface{}
+	}{
+		{"to_days", NewToDays(date), sql.NewRow("2007-10-07"), int64(733321)},
+		{"to_days of datetime", NewToDays(date), sql.NewRow(time.Date(2007, 10, 7, 12, 30, 0, 0, time.UTC)), int64(733321)},
+		{"to_days of zero date", NewToDays(date), sql.NewRow("0000-00-00"), nil},
+		{"to_days of invalid date", NewToDays(date), sql.NewRow("2007-02-30"), nil},
+		{"to_days of null", NewToDays(date), sql.NewRow(nil), nil},
+		{"from_days", NewFromDays(days), sql.NewRow(int64(730669)), time.Date(2000, 7, 3, 0, 0, 0, 0, time.UTC)},
+		{"from_days of year 1", NewFromDays(days), sql.NewRow(int64(366)), time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"from_days before year 1", NewFromDays(days), sql.NewRow(int64(365)), sql.Date.Zero()},
+		{"from_days after year 9999", NewFromDays(days), sql.NewRow(int64(3652425)), sql.Date.Zero()},
+		{"last_day", NewLastDay(date), sql.NewRow("2003-02-05"), time.Date(2003, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{"last_day of leap year", NewLastDay(date), sql.NewRow("2004-02-05"), time.Date(2004, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"last_day of datetime", NewLastDay(date), sql.NewRow("2004-12-01 01:01:01"), time.Date(2004, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{"last_day of invalid date", NewLastDay(date), sql.NewRow("2003-03-32"), nil},
+		{"last_day of zero date", NewLastDay(date), sql.NewRow("0000-00-00"), nil},
+		{"quarter", NewQuarter(date), sql.NewRow("2008-04-01"), int32(2)},
+		{"quarter of december", NewQuarter(date), sql.NewRow("2008-12-31"), int32(4)},
+		{"quarter of zero date", NewQuarter(date), sql.NewRow("0000-00-00"), int32(0)},
+		{"quarter of invalid date", NewQuarter(date), sql.NewRow("2008-13-01"), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			v, err := tt.f.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+}
+
+func TestMakeDate(t *testing.T) {
+	f := NewMakeDate(
+		expression.NewGetField(0, sql.Int64, "year", true),
+		expression.NewGetField(1, sql.Int64, "day", true),
+	)
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"first month", sql.NewRow(2011, 31), time.Date(2011, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{"next month", sql.NewRow(2011, 32), time.Date(2011, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"next year", sql.NewRow(2011, 366), time.Date(2012, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"two digit year", sql.NewRow(11, 1), time.Date(2011, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"zero day", sql.NewRow(2011, 0), nil},
+		{"after year 9999", sql.NewRow(9999, 366), nil},
+		{"null", sql.NewRow(nil, 1), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			v, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+}
+
+func TestPeriods(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+	p1 := expression.NewGetField(0, sql.Int64, "p1", true)
+	p2 := expression.NewGetField(1, sql.Int64, "p2", true)
+
+	add := NewPeriodAdd(p1, p2)
+	v, err := add.Eval(ctx, sql.NewRow(200801, 2))
+	require.NoError(err)
+	require.Equal(int64(200803), v)
+
+	v, err = add.Eval(ctx, sql.NewRow(801, 12))
+	require.NoError(err)
+	require.Equal(int64(200901), v)
+
+	v, err = add.Eval(ctx, sql.NewRow(200801, -1))
+	require.NoError(err)
+	require.Equal(int64(200712), v)
+
+	v, err = add.Eval(ctx, sql.NewRow(nil, 1))
+	require.NoError(err)
+	require.Nil(v)
+
+	_, err = add.Eval(ctx, sql.NewRow(200813, 1))
+	require.Error(err)
+
+	diff := NewPeriodDiff(p1, p2)
+	v, err = diff.Eval(ctx, sql.NewRow(200802, 200703))
+	require.NoError(err)
+	require.Equal(int64(11), v)
+
+	v, err = diff.Eval(ctx, sql.NewRow(703, 200802))
+	require.NoError(err)
+	require.Equal(int64(-11), v)
+
+	_, err = diff.Eval(ctx, sql.NewRow(200800, 200802))
+	require.Error(err)
+}
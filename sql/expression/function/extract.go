@@ -0,0 +1,228 @@
+package function
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// Extract returns a part of a date or a time, as EXTRACT(unit FROM date)
+// does, which is parsed as extract('unit', date). The units made of two
+// parts, such as DAY_HOUR, return the digits of those parts and of the ones
+// in between them. The units other than DAY, WEEK, MONTH, QUARTER, YEAR and
+// YEAR_MONTH also accept times, which may be negative and longer than a day.
+type Extract struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*Extract)(nil)
+
+// NewExtract creates a new Extract UDF.
+func NewExtract(unit, date sql.Expression) sql.Expression {
+	return &Extract{expression.BinaryExpression{Left: unit, Right: date}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (e *Extract) FunctionName() string {
+	return "extract"
+}
+
+func (e *Extract) String() string {
+	return fmt.Sprintf("EXTRACT(%s FROM %s)", e.Left, e.Right)
+}
+
+// Type implements the Expression interface.
+func (e *Extract) Type() sql.Type { return sql.Int64 }
+
+// IsNullable implements the Expression interface.
+func (e *Extract) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (e *Extract) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	unit, err := extractUnit(ctx, e.Left, row)
+	if err != nil {
+		return nil, err
+	}
+
+	val, err := e.Right.Eval(ctx, row)
+	if val == nil || err != nil {
+		return nil, err
+	}
+
+	parts, ok := datetimeParts(val)
+	if !ok && !dateUnits[unit] {
+		parts, ok = timeParts(val)
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	hms := parts.hour*10000 + parts.minute*100 + parts.second
+	var v int64
+	switch unit {
+	case "MICROSECOND":
+		v = parts.microsecond
+	case "SECOND":
+		v = parts.second
+	case "MINUTE":
+		v = parts.minute
+	case "HOUR":
+		v = parts.hour
+	case "DAY":
+		v = parts.day
+	case "WEEK":
+		_, week := calcWeek(int32(parts.year), int32(parts.month), int32(parts.day), weekMode(0))
+		v = int64(week)
+	case "MONTH":
+		v = parts.month
+	case "QUARTER":
+		v = (parts.month + 2) / 3
+	case "YEAR":
+		v = parts.year
+	case "SECOND_MICROSECOND":
+		v = parts.second*1000000 + parts.microsecond
+	case "MINUTE_MICROSECOND":
+		v = (parts.minute*100+parts.second)*1000000 + parts.microsecond
+	case "MINUTE_SECOND":
+		v = parts.minute*100 + parts.second
+	case "HOUR_MICROSECOND":
+		v = hms*1000000 + parts.microsecond
+	case "HOUR_SECOND":
+		v = hms
+	case "HOUR_MINUTE":
+		v = parts.hour*100 + parts.minute
+	case "DAY_MICROSECOND":
+		v = (parts.day*1000000+hms)*1000000 + parts.microsecond
+	case "DAY_SECOND":
+		v = parts.day*1000000 + hms
+	case "DAY_MINUTE":
+		v = parts.day*10000 + parts.hour*100 + parts.minute
+	case "DAY_HOUR":
+		v = parts.day*100 + parts.hour
+	case "YEAR_MONTH":
+		v = parts.year*100 + parts.month
+	}
+
+	if parts.negative {
+		v = -v
+	}
+	return v, nil
+}
+
+// WithChildren implements the Expression interface.
+func (e *Extract) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(e, len(children), 2)
+	}
+	return NewExtract(children[0], children[1]), nil
+}
+
+// dateUnits holds the units of EXTRACT that only accept dates.
+var dateUnits = map[string]bool{
+	"DAY":        true,
+	"WEEK":       true,
+	"MONTH":      true,
+	"QUARTER":    true,
+	"YEAR":       true,
+	"YEAR_MONTH": true,
+}
+
+// extractUnits holds the units of EXTRACT.
+var extractUnits = map[string]bool{
+	"MICROSECOND":        true,
+	"SECOND":             true,
+	"MINUTE":             true,
+	"HOUR":               true,
+	"DAY":                true,
+	"WEEK":               true,
+	"MONTH":              true,
+	"QUARTER":            true,
+	"YEAR":               true,
+	"SECOND_MICROSECOND": true,
+	"MINUTE_MICROSECOND": true,
+	"MINUTE_SECOND":      true,
+	"HOUR_MICROSECOND":   true,
+	"HOUR_SECOND":        true,
+	"HOUR_MINUTE":        true,
+	"DAY_MICROSECOND":    true,
+	"DAY_SECOND":         true,
+	"DAY_MINUTE":         true,
+	"DAY_HOUR":           true,
+	"YEAR_MONTH":         true,
+}
+
+// extractUnit evaluates the unit of EXTRACT.
+func extractUnit(ctx *sql.Context, e sql.Expression, row sql.Row) (string, error) {
+	val, err := evalString(ctx, e, row)
+	if err != nil {
+		return "", err
+	}
+	if val == nil {
+		return "", ErrInvalidArgument.New("extract", "the unit cannot be NULL")
+	}
+
+	unit := strings.ToUpper(val.(string))
+	if !extractUnits[unit] {
+		return "", ErrInvalidArgument.New("extract", fmt.Sprintf("invalid unit %s", val))
+	}
+	return unit, nil
+}
+
+// extractParts holds the parts of a date or a time that EXTRACT returns.
+type extractParts struct {
+	negative                                            bool
+	year, month, day, hour, minute, second, microsecond int64
+}
+
+// datetimeParts returns the parts of the given value as a datetime. All of
+// them are zero for the zero date.
+func datetimeParts(val interface{}) (extractParts, bool) {
+	if _, ok := val.(time.Time); !ok {
+		var err error
+		if val, err = sql.LongText.Convert(val); err != nil {
+			return extractParts{}, false
+		}
+	}
+
+	t, err := sql.Datetime.ConvertWithoutRangeCheck(val)
+	if err != nil {
+		return extractParts{}, false
+	}
+	if isZeroDate(t) {
+		return extractParts{}, true
+	}
+
+	return extractParts{
+		year:        int64(t.Year()),
+		month:       int64(t.Month()),
+		day:         int64(t.Day()),
+		hour:        int64(t.Hour()),
+		minute:      int64(t.Minute()),
+		second:      int64(t.Second()),
+		microsecond: int64(t.Nanosecond() / int(time.Microsecond)),
+	}, true
+}
+
+// timeParts returns the parts of the given value as a time, whose hours can
+// be more than a day.
+func timeParts(val interface{}) (extractParts, bool) {
+	d, err := sql.Time.ConvertToTimeDuration(val)
+	if err != nil {
+		return extractParts{}, false
+	}
+
+	var parts extractParts
+	if d < 0 {
+		parts.negative = true
+		d = -d
+	}
+
+	parts.hour = int64(d / time.Hour)
+	parts.minute = int64(d % time.Hour / time.Minute)
+	parts.second = int64(d % time.Minute / time.Second)
+	parts.microsecond = int64(d % time.Second / time.Microsecond)
+	return parts, true
+}
@@ -0,0 +1,54 @@
+package function
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestExtract(t *testing.T) {
+	f := NewExtract(
+		expression.NewGetField(0, sql.LongText, "unit", false),
+		expression.NewGetField(1, sql.LongText, "date", true),
+	)
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+		err      bool
+	}{
+		{"year", sql.NewRow("YEAR", "2019-07-02"), int64(2019), false},
+		{"quarter", sql.NewRow("quarter", "2019-07-02"), int64(3), false},
+		{"week", sql.NewRow("WEEK", "2019-07-02"), int64(26), false},
+		{"first week", sql.NewRow("WEEK", "2019-01-01"), int64(0), false},
+		{"year month", sql.NewRow("YEAR_MONTH", "2019-07-02 01:02:03"), int64(201907), false},
+		{"day minute", sql.NewRow("DAY_MINUTE", "2019-07-02 01:02:03"), int64(20102), false},
+		{"day microsecond", sql.NewRow("DAY_MICROSECOND", "2003-01-02 10:30:00.000123"), int64(2103000000123), false},
+		{"hour second", sql.NewRow("HOUR_SECOND", time.Date(2020, 1, 1, 12, 3, 4, 0, time.UTC)), int64(120304), false},
+		{"time", sql.NewRow("HOUR_MINUTE", "30:10:00"), int64(3010), false},
+		{"negative time", sql.NewRow("MINUTE_MICROSECOND", "-00:10:20.5"), int64(-1020500000), false},
+		{"time with a date unit", sql.NewRow("MONTH", "10:30:00"), nil, false},
+		{"zero date", sql.NewRow("DAY", "0000-00-00"), int64(0), false},
+		{"invalid date", sql.NewRow("DAY", "2019-02-30"), nil, false},
+		{"null", sql.NewRow("DAY", nil), nil, false},
+		{"invalid unit", sql.NewRow("DECADE", "2019-07-02"), nil, true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			v, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			if tt.err {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package function
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// Field is a function that returns the position of its first argument in the list of the other ones, counting from
+// 1, or 0 if it isn't in the list or is NULL. The arguments are compared as numbers if all of them are numbers, or as
+// strings otherwise.
+type Field struct {
+	args []sql.Expression
+}
+
+var _ sql.FunctionExpression = (*Field)(nil)
+
+// NewField creates a new Field expression.
+func NewField(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New("FIELD", "2 or more", len(args))
+	}
+	return &Field{args}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (f *Field) FunctionName() string {
+	return "field"
+}
+
+// Children implements the Expression interface.
+func (f *Field) Children() []sql.Expression {
+	return f.args
+}
+
+// Resolved implements the Expression interface.
+func (f *Field) Resolved() bool {
+	return argsResolved(f.args)
+}
+
+// IsNullable implements the Expression interface.
+func (f *Field) IsNullable() bool {
+	return false
+}
+
+// Type implements the Expression interface.
+func (f *Field) Type() sql.Type { return sql.Int64 }
+
+func (f *Field) String() string {
+	return fmt.Sprintf("FIELD(%s)", joinArgs(f.args))
+}
+
+// WithChildren implements the Expression interface.
+func (f *Field) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewField(children...)
+}
+
+// Eval implements the Expression interface.
+func (f *Field) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	numeric := true
+	for _, arg := range f.args {
+		if !sql.IsNumber(arg.Type()) {
+			numeric = false
+			break
+		}
+	}
+
+	var compareType sql.Type = sql.Float64
+	if !numeric {
+		compareType = sql.CreateLongText(argsCollation(f.args...))
+	}
+
+	search, err := f.args[0].Eval(ctx, row)
+	if search == nil || err != nil {
+		return int64(0), err
+	}
+
+	for i, arg := range f.args[1:] {
+		val, err := arg.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if val == nil {
+			continue
+		}
+
+		cmp, err := compareType.Compare(search, val)
+		if err != nil {
+			return nil, err
+		}
+		if cmp == 0 {
+			return int64(i + 1), nil
+		}
+	}
+
+	return int64(0), nil
+}
+
+// Elt is a function that returns the string at the position given by its first argument in the list of the other
+// ones, counting from 1, or NULL if there's none.
+type Elt struct {
+	args []sql.Expression
+}
+
+var _ sql.FunctionExpression = (*Elt)(nil)
+
+// NewElt creates a new Elt expression.
+func NewElt(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New("ELT", "2 or more", len(args))
+	}
+	return &Elt{args}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (e *Elt) FunctionName() string {
+	return "elt"
+}
+
+// Children implements the Expression interface.
+func (e *Elt) Children() []sql.Expression {
+	return e.args
+}
+
+// Resolved implements the Expression interface.
+func (e *Elt) Resolved() bool {
+	return argsResolved(e.args)
+}
+
+// IsNullable implements the Expression interface.
+func (e *Elt) IsNullable() bool {
+	return true
+}
+
+// Type implements the Expression interface.
+func (e *Elt) Type() sql.Type { return sql.LongText }
+
+func (e *Elt) String() string {
+	return fmt.Sprintf("ELT(%s)", joinArgs(e.args))
+}
+
+// WithChildren implements the Expression interface.
+func (e *Elt) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewElt(children...)
+}
+
+// Eval implements the Expression interface.
+func (e *Elt) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	n, err := e.args[0].Eval(ctx, row)
+	if n == nil || err != nil {
+		return nil, err
+	}
+
+	n, err = sql.Int64.Convert(n)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := n.(int64)
+	if idx < 1 || idx >= int64(len(e.args)) {
+		return nil, nil
+	}
+
+	return evalString(ctx, e.args[idx], row)
+}
+
+func argsResolved(args []sql.Expression) bool {
+	for _, arg := range args {
+		if !arg.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+func joinArgs(args []sql.Expression) string {
+	strs := make([]string, len(args))
+	for i, arg := range args {
+		strs[i] = arg.String()
+	}
+	return strings.Join(strs, ", ")
+}
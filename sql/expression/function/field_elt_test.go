@@ -0,0 +1,76 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestField(t *testing.T) {
+	testCases := []struct {
+		name     string
+		typ      sql.Type
+		row      sql.Row
+		expected interface{}
+	}{
+		{"match", sql.LongText, sql.NewRow("Bb", "Aa", "Bb", "Cc"), int64(2)},
+		{"first match", sql.LongText, sql.NewRow("bb", "Aa", "Bb", "bb"), int64(2)},
+		{"case sensitive", sql.CreateLongText(sql.Collation_utf8mb4_bin), sql.NewRow("bb", "Aa", "Bb", "bb"), int64(3)},
+		{"no match", sql.LongText, sql.NewRow("Dd", "Aa", "Bb", "Cc"), int64(0)},
+		{"null", sql.LongText, sql.NewRow(nil, "Aa", nil, "Cc"), int64(0)},
+		{"null in list", sql.LongText, sql.NewRow("Cc", "Aa", nil, "Cc"), int64(3)},
+		{"numbers", sql.Float64, sql.NewRow(2.0, 1.0, 2.0, 3.0), int64(2)},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			var args []sql.Expression
+			for i := range tt.row {
+				args = append(args, expression.NewGetField(i, tt.typ, "", true))
+			}
+			f, err := NewField(args...)
+			require.NoError(err)
+
+			v, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+
+	_, err := NewField(expression.NewLiteral("a", sql.LongText))
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+}
+
+func TestElt(t *testing.T) {
+	f, err := NewElt(
+		expression.NewGetField(0, sql.Int64, "n", true),
+		expression.NewGetField(1, sql.LongText, "str1", true),
+		expression.NewGetField(2, sql.LongText, "str2", true),
+	)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"null index", sql.NewRow(nil, "a", "b"), nil},
+		{"first", sql.NewRow(1, "a", "b"), "a"},
+		{"last", sql.NewRow(2, "a", "b"), "b"},
+		{"null element", sql.NewRow(2, "a", nil), nil},
+		{"index 0", sql.NewRow(0, "a", "b"), nil},
+		{"index out of range", sql.NewRow(3, "a", "b"), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, v)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package function
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// FindInSet is a function that returns the position of a string in a list of comma separated strings, counting from
+// 1, or 0 if it isn't in the list.
+type FindInSet struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*FindInSet)(nil)
+
+// NewFindInSet creates a new FindInSet expression.
+func NewFindInSet(str, strList sql.Expression) sql.Expression {
+	return &FindInSet{expression.BinaryExpression{Left: str, Right: strList}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (f *FindInSet) FunctionName() string {
+	return "find_in_set"
+}
+
+// Type implements the Expression interface.
+func (f *FindInSet) Type() sql.Type { return sql.Int64 }
+
+func (f *FindInSet) String() string {
+	return fmt.Sprintf("FIND_IN_SET(%s, %s)", f.Left, f.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (f *FindInSet) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(f, len(children), 2)
+	}
+	return NewFindInSet(children[0], children[1]), nil
+}
+
+// Eval implements the Expression interface.
+func (f *FindInSet) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	str, err := evalString(ctx, f.Left, row)
+	if str == nil || err != nil {
+		return nil, err
+	}
+
+	strList, err := evalString(ctx, f.Right, row)
+	if strList == nil || err != nil {
+		return nil, err
+	}
+
+	s := str.(string)
+	if strList.(string) == "" || strings.Contains(s, ",") {
+		return int64(0), nil
+	}
+
+	collation := argsCollation(f.Left, f.Right)
+	for i, elem := range strings.Split(strList.(string), ",") {
+		if collation.Compare(s, elem) == 0 {
+			return int64(i + 1), nil
+		}
+	}
+
+	return int64(0), nil
+}
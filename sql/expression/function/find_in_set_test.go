@@ -0,0 +1,41 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestFindInSet(t *testing.T) {
+	testCases := []struct {
+		name     string
+		typ      sql.Type
+		row      sql.Row
+		expected interface{}
+	}{
+		{"null string", sql.LongText, sql.NewRow(nil, "a,b"), nil},
+		{"null list", sql.LongText, sql.NewRow("a", nil), nil},
+		{"match", sql.LongText, sql.NewRow("b", "a,b,c,d"), int64(2)},
+		{"no match", sql.LongText, sql.NewRow("e", "a,b,c,d"), int64(0)},
+		{"empty list", sql.LongText, sql.NewRow("a", ""), int64(0)},
+		{"empty string", sql.LongText, sql.NewRow("", "a,,b"), int64(2)},
+		{"string with comma", sql.LongText, sql.NewRow("a,b", "a,b"), int64(0)},
+		{"case insensitive", sql.LongText, sql.NewRow("B", "a,b"), int64(2)},
+		{"case sensitive", sql.CreateLongText(sql.Collation_utf8mb4_bin), sql.NewRow("B", "a,b"), int64(0)},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFindInSet(
+				expression.NewGetField(0, tt.typ, "str", true),
+				expression.NewGetField(1, tt.typ, "strlist", true),
+			)
+			v, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, v)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package function
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// ERUnknownLocale is the code of the warning added when a locale isn't known.
+const ERUnknownLocale = 1649
+
+// formatMaxDecimals is the largest number of decimals FORMAT rounds numbers to.
+const formatMaxDecimals = 30
+
+// numberLocale has the separators a locale writes numbers with.
+type numberLocale struct {
+	thousands string
+	decimal   string
+}
+
+// numberLocales are the locales FORMAT supports. Numbers are written as in en_US when the locale isn't known.
+var numberLocales = map[string]numberLocale{
+	"en_US": {",", "."},
+	"en_GB": {",", "."},
+	"en_AU": {",", "."},
+	"en_CA": {",", "."},
+	"en_NZ": {",", "."},
+	"en_IE": {",", "."},
+	"de_DE": {".", ","},
+	"de_AT": {".", ","},
+	"de_CH": {"'", "."},
+	"es_ES": {".", ","},
+	"pt_BR": {".", ","},
+	"nl_NL": {".", ","},
+	"id_ID": {".", ","},
+	"ja_JP": {",", "."},
+	"zh_CN": {",", "."},
+}
+
+// Format is a function that returns a number rounded to the given number of decimals, with its thousands grouped, as
+// written in a locale, en_US by default.
+type Format struct {
+	args []sql.Expression
+}
+
+var _ sql.FunctionExpression = (*Format)(nil)
+
+// NewFormat creates a new Format expression.
+func NewFormat(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) != 2 && len(args) != 3 {
+		return nil, sql.ErrInvalidArgumentNumber.New("FORMAT", "2 or 3", len(args))
+	}
+	return &Format{args}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (f *Format) FunctionName() string {
+	return "format"
+}
+
+// Children implements the Expression interface.
+func (f *Format) Children() []sql.Expression {
+	return f.args
+}
+
+// Resolved implements the Expression interface.
+func (f *Format) Resolved() bool {
+	return argsResolved(f.args)
+}
+
+// IsNullable implements the Expression interface.
+func (f *Format) IsNullable() bool {
+	return f.args[0].IsNullable() || f.args[1].IsNullable()
+}
+
+// Type implements the Expression interface.
+func (f *Format) Type() sql.Type { return sql.LongText }
+
+func (f *Format) String() string {
+	return fmt.Sprintf("FORMAT(%s)", joinArgs(f.args))
+}
+
+// WithChildren implements the Expression interface.
+func (f *Format) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewFormat(children...)
+}
+
+// Eval implements the Expression interface.
+func (f *Format) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := f.args[0].Eval(ctx, row)
+	if val == nil || err != nil {
+		return nil, err
+	}
+
+	d, err := evalInt64(ctx, f.args[1], row)
+	if d == nil || err != nil {
+		return nil, err
+	}
+
+	decimals := d.(int64)
+	if decimals < 0 {
+		decimals = 0
+	} else if decimals > formatMaxDecimals {
+		decimals = formatMaxDecimals
+	}
+
+	locale := numberLocales["en_US"]
+	if len(f.args) > 2 {
+		name, err := evalString(ctx, f.args[2], row)
+		if err != nil {
+			return nil, err
+		}
+		if name != nil {
+			l, ok := numberLocales[name.(string)]
+			if ok {
+				locale = l
+			} else {
+				ctx.Warn(ERUnknownLocale, "Unknown locale: '%s'", name)
+			}
+		}
+	}
+
+	num, err := sql.ToDecimal(val)
+	if err != nil || !num.Valid {
+		num = decimal.NullDecimal{Decimal: decimal.Zero, Valid: true}
+	}
+
+	return formatNumber(num.Decimal, int32(decimals), locale), nil
+}
+
+// formatNumber writes the given number rounded to the given number of decimals with the separators of the locale.
+func formatNumber(num decimal.Decimal, decimals int32, locale numberLocale) string {
+	s := num.Round(decimals).StringFixed(decimals)
+
+	var sign string
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+
+	var sb strings.Builder
+	sb.WriteString(sign)
+	for i, c := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			sb.WriteString(locale.thousands)
+		}
+		sb.WriteRune(c)
+	}
+	if fracPart != "" {
+		sb.WriteString(locale.decimal)
+		sb.WriteString(fracPart)
+	}
+
+	return sb.String()
+}
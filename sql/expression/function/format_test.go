@@ -0,0 +1,53 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestFormat(t *testing.T) {
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+		warnings int
+	}{
+		{"null number", sql.NewRow(nil, 2), nil, 0},
+		{"null decimals", sql.NewRow(1.5, nil), nil, 0},
+		{"round", sql.NewRow(12332.123456, 4), "12,332.1235", 0},
+		{"pad", sql.NewRow(12332.1, 4), "12,332.1000", 0},
+		{"no decimals", sql.NewRow(12332.2, 0), "12,332", 0},
+		{"negative decimals", sql.NewRow(12332.2, -1), "12,332", 0},
+		{"negative", sql.NewRow(-1234567.891, 2), "-1,234,567.89", 0},
+		{"small", sql.NewRow(123, 1), "123.0", 0},
+		{"string", sql.NewRow("1234.5", 1), "1,234.5", 0},
+		{"locale", sql.NewRow(12332.2, 2, "de_DE"), "12.332,20", 0},
+		{"null locale", sql.NewRow(12332.2, 2, nil), "12,332.20", 0},
+		{"unknown locale", sql.NewRow(12332.2, 2, "xx_XX"), "12,332.20", 1},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			args := []sql.Expression{
+				expression.NewGetField(0, sql.Float64, "x", true),
+				expression.NewGetField(1, sql.Int64, "d", true),
+			}
+			if len(tt.row) > 2 {
+				args = append(args, expression.NewGetField(2, sql.LongText, "locale", true))
+			}
+			f, err := NewFormat(args...)
+			require.NoError(err)
+
+			ctx := sql.NewEmptyContext()
+			v, err := f.Eval(ctx, tt.row)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+			require.Len(ctx.Warnings(), tt.warnings)
+		})
+	}
+}
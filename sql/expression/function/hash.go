@@ -0,0 +1,380 @@
+package function
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func hashString(h hash.Hash, arg interface{}) (interface{}, error) {
+	val, err := sql.LongText.Convert(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	h.Write([]byte(val.(string)))
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// MD5 returns the MD5 checksum of a string as 32 hexadecimal digits.
+type MD5 struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*MD5)(nil)
+
+// NewMD5 creates a new MD5 UDF.
+func NewMD5(str sql.Expression) sql.Expression {
+	return &MD5{expression.UnaryExpression{Child: str}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (m *MD5) FunctionName() string {
+	return "md5"
+}
+
+func (m *MD5) String() string {
+	return fmt.Sprintf("MD5(%s)", m.Child)
+}
+
+// Type implements the Expression interface.
+func (m *MD5) Type() sql.Type { return sql.LongText }
+
+// Eval implements the Expression interface.
+func (m *MD5) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	str, err := m.Child.Eval(ctx, row)
+	if str == nil || err != nil {
+		return nil, err
+	}
+	return hashString(md5.New(), str)
+}
+
+// WithChildren implements the Expression interface.
+func (m *MD5) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(m, len(children), 1)
+	}
+	return NewMD5(children[0]), nil
+}
+
+// SHA1 returns the SHA-1 checksum of a string as 40 hexadecimal digits.
+type SHA1 struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*SHA1)(nil)
+
+// NewSHA1 creates a new SHA1 UDF.
+func NewSHA1(str sql.Expression) sql.Expression {
+	return &SHA1{expression.UnaryExpression{Child: str}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *SHA1) FunctionName() string {
+	return "sha1"
+}
+
+func (s *SHA1) String() string {
+	return fmt.Sprintf("SHA1(%s)", s.Child)
+}
+
+// Type implements the Expression interface.
+func (s *SHA1) Type() sql.Type { return sql.LongText }
+
+// Eval implements the Expression interface.
+func (s *SHA1) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	str, err := s.Child.Eval(ctx, row)
+	if str == nil || err != nil {
+		return nil, err
+	}
+	return hashString(sha1.New(), str)
+}
+
+// WithChildren implements the Expression interface.
+func (s *SHA1) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 1)
+	}
+	return NewSHA1(children[0]), nil
+}
+
+// SHA2 returns the checksum of a string with one of the hash functions of the
+// SHA-2 family as hexadecimal digits. The second argument is the length of the
+// hash in bits, which is 224, 256, 384, 512 or 0, which means 256. It returns
+// NULL for any other length.
+type SHA2 struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*SHA2)(nil)
+
+// NewSHA2 creates a new SHA2 UDF.
+func NewSHA2(str, length sql.Expression) sql.Expression {
+	return &SHA2{expression.BinaryExpression{Left: str, Right: length}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *SHA2) FunctionName() string {
+	return "sha2"
+}
+
+func (s *SHA2) String() string {
+	return fmt.Sprintf("SHA2(%s, %s)", s.Left, s.Right)
+}
+
+// Type implements the Expression interface.
+func (s *SHA2) Type() sql.Type { return sql.LongText }
+
+// IsNullable implements the Expression interface.
+func (s *SHA2) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (s *SHA2) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	str, err := s.Left.Eval(ctx, row)
+	if str == nil || err != nil {
+		return nil, err
+	}
+
+	length, err := evalInt64(ctx, s.Right, row)
+	if length == nil || err != nil {
+		return nil, err
+	}
+
+	var h hash.Hash
+	switch length.(int64) {
+	case 0, 256:
+		h = sha256.New()
+	case 224:
+		h = sha256.New224()
+	case 384:
+		h = sha512.New384()
+	case 512:
+		h = sha512.New()
+	default:
+		return nil, nil
+	}
+
+	return hashString(h, str)
+}
+
+// WithChildren implements the Expression interface.
+func (s *SHA2) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 2)
+	}
+	return NewSHA2(children[0], children[1]), nil
+}
+
+// aesKey folds a key of any length into a 128-bit AES key the way MySQL does,
+// by XORing each byte of the key into the position of its index modulo 16.
+func aesKey(key string) []byte {
+	k := make([]byte, aes.BlockSize)
+	for i := 0; i < len(key); i++ {
+		k[i%aes.BlockSize] ^= key[i]
+	}
+	return k
+}
+
+// AESEncrypt encrypts a string with a key using AES-128 in ECB mode with
+// PKCS#7 padding, which is the default block_encryption_mode of MySQL.
+type AESEncrypt struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*AESEncrypt)(nil)
+
+// NewAESEncrypt creates a new AESEncrypt UDF.
+func NewAESEncrypt(str, key sql.Expression) sql.Expression {
+	return &AESEncrypt{expression.BinaryExpression{Left: str, Right: key}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (a *AESEncrypt) FunctionName() string {
+	return "aes_encrypt"
+}
+
+func (a *AESEncrypt) String() string {
+	return fmt.Sprintf("AES_ENCRYPT(%s, %s)", a.Left, a.Right)
+}
+
+// Type implements the Expression interface.
+func (a *AESEncrypt) Type() sql.Type { return sql.LongBlob }
+
+// Eval implements the Expression interface.
+func (a *AESEncrypt) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	str, err := evalString(ctx, a.Left, row)
+	if str == nil || err != nil {
+		return nil, err
+	}
+
+	key, err := evalString(ctx, a.Right, row)
+	if key == nil || err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(aesKey(key.(string)))
+	if err != nil {
+		return nil, err
+	}
+
+	padding := aes.BlockSize - len(str.(string))%aes.BlockSize
+	data := append([]byte(str.(string)), bytes.Repeat([]byte{byte(padding)}, padding)...)
+	for i := 0; i < len(data); i += aes.BlockSize {
+		block.Encrypt(data[i:i+aes.BlockSize], data[i:i+aes.BlockSize])
+	}
+
+	return string(data), nil
+}
+
+// WithChildren implements the Expression interface.
+func (a *AESEncrypt) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(a, len(children), 2)
+	}
+	return NewAESEncrypt(children[0], children[1]), nil
+}
+
+// AESDecrypt decrypts a string encrypted by AES_ENCRYPT with a key. It returns
+// NULL if the string was not encrypted with the key.
+type AESDecrypt struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*AESDecrypt)(nil)
+
+// NewAESDecrypt creates a new AESDecrypt UDF.
+func NewAESDecrypt(str, key sql.Expression) sql.Expression {
+	return &AESDecrypt{expression.BinaryExpression{Left: str, Right: key}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (a *AESDecrypt) FunctionName() string {
+	return "aes_decrypt"
+}
+
+func (a *AESDecrypt) String() string {
+	return fmt.Sprintf("AES_DECRYPT(%s, %s)", a.Left, a.Right)
+}
+
+// Type implements the Expression interface.
+func (a *AESDecrypt) Type() sql.Type { return sql.LongBlob }
+
+// IsNullable implements the Expression interface.
+func (a *AESDecrypt) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (a *AESDecrypt) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	str, err := evalString(ctx, a.Left, row)
+	if str == nil || err != nil {
+		return nil, err
+	}
+
+	key, err := evalString(ctx, a.Right, row)
+	if key == nil || err != nil {
+		return nil, err
+	}
+
+	data := []byte(str.(string))
+	if len(data) == 0 || len(data)%aes.BlockSize != 0 {
+		return nil, nil
+	}
+
+	block, err := aes.NewCipher(aesKey(key.(string)))
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < len(data); i += aes.BlockSize {
+		block.Decrypt(data[i:i+aes.BlockSize], data[i:i+aes.BlockSize])
+	}
+
+	padding := int(data[len(data)-1])
+	if padding == 0 || padding > aes.BlockSize {
+		return nil, nil
+	}
+	for _, b := range data[len(data)-padding:] {
+		if int(b) != padding {
+			return nil, nil
+		}
+	}
+
+	return string(data[:len(data)-padding]), nil
+}
+
+// WithChildren implements the Expression interface.
+func (a *AESDecrypt) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(a, len(children), 2)
+	}
+	return NewAESDecrypt(children[0], children[1]), nil
+}
+
+// maxRandomBytes is the largest number of bytes RANDOM_BYTES can return.
+const maxRandomBytes = 1024
+
+// RandomBytes returns a string of random bytes generated with a
+// cryptographically secure random number generator. The length must be
+// between 1 and 1024.
+type RandomBytes struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*RandomBytes)(nil)
+var _ sql.NonDeterministicExpression = (*RandomBytes)(nil)
+
+// NewRandomBytes creates a new RandomBytes UDF.
+func NewRandomBytes(length sql.Expression) sql.Expression {
+	return &RandomBytes{expression.UnaryExpression{Child: length}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (r *RandomBytes) FunctionName() string {
+	return "random_bytes"
+}
+
+func (r *RandomBytes) String() string {
+	return fmt.Sprintf("RANDOM_BYTES(%s)", r.Child)
+}
+
+// Type implements the Expression interface.
+func (r *RandomBytes) Type() sql.Type { return sql.LongBlob }
+
+// IsNonDeterministic implements sql.NonDeterministicExpression
+func (r *RandomBytes) IsNonDeterministic() bool { return true }
+
+// Eval implements the Expression interface.
+func (r *RandomBytes) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	length, err := evalInt64(ctx, r.Child, row)
+	if length == nil || err != nil {
+		return nil, err
+	}
+
+	n := length.(int64)
+	if n < 1 || n > maxRandomBytes {
+		return nil, ErrInvalidArgument.New("random_bytes", fmt.Sprintf("length %d is out of range", n))
+	}
+
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// WithChildren implements the Expression interface.
+func (r *RandomBytes) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(r, len(children), 1)
+	}
+	return NewRandomBytes(children[0]), nil
+}
@@ -0,0 +1,129 @@
+package function
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestHashFuncs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		f        func(sql.Expression) sql.Expression
+		input    interface{}
+		expected interface{}
+	}{
+		{"md5", NewMD5, "testing", "ae2b1fca515949e5d54fb22b8ed95575"},
+		{"md5 empty", NewMD5, "", "d41d8cd98f00b204e9800998ecf8427e"},
+		{"md5 number", NewMD5, int64(1), "c4ca4238a0b923820dcc509a6f75849b"},
+		{"md5 null", NewMD5, nil, nil},
+		{"sha1", NewSHA1, "abc", "a9993e364706816aba3e25717850c26c9cd0d89d"},
+		{"sha1 null", NewSHA1, nil, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := tt.f(expression.NewLiteral(tt.input, sql.LongText)).Eval(sql.NewEmptyContext(), nil)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, v)
+		})
+	}
+}
+
+func TestSHA2(t *testing.T) {
+	testCases := []struct {
+		length   interface{}
+		expected interface{}
+	}{
+		{int64(0), "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{int64(224), "23097d223405d8228642a477bda255b32aadbce4bda0b3f7e36c9da7"},
+		{int64(256), "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{int64(384), "cb00753f45a35e8bb5a03d699ac65007272c32ab0eded1631a8b605a43ff5bed8086072ba1e7cc2358baeca134c825a7"},
+		{int64(512), "ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f"},
+		{int64(128), nil},
+		{nil, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprint(tt.length), func(t *testing.T) {
+			f := NewSHA2(expression.NewLiteral("abc", sql.LongText), expression.NewLiteral(tt.length, sql.Int64))
+			v, err := f.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, v)
+		})
+	}
+}
+
+func TestAESEncryptDecrypt(t *testing.T) {
+	testCases := []struct {
+		name      string
+		str       string
+		key       string
+		encrypted string
+	}{
+		{"short", "text", "password", "f6bd0fa8dcb7f8cd4a2faabc54668044"},
+		{"empty", "", "password", "c6b4234e1d0709c945113e4f2a9607f7"},
+		{"long key", "a longer text that spans blocks", "0123456789abcdefXY", "1dc71d0d0ae4ab6dbf51372da98fbd8c84d23d41e88112d976f31ba81441ac03"},
+	}
+
+	ctx := sql.NewEmptyContext()
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			key := expression.NewLiteral(tt.key, sql.LongText)
+
+			v, err := NewAESEncrypt(expression.NewLiteral(tt.str, sql.LongText), key).Eval(ctx, nil)
+			require.NoError(err)
+			require.Equal(tt.encrypted, hex.EncodeToString([]byte(v.(string))))
+
+			v, err = NewAESDecrypt(expression.NewLiteral(v, sql.LongBlob), key).Eval(ctx, nil)
+			require.NoError(err)
+			require.Equal(tt.str, v)
+		})
+	}
+
+	encrypted, err := hex.DecodeString("f6bd0fa8dcb7f8cd4a2faabc54668044")
+	require.NoError(t, err)
+
+	v, err := NewAESDecrypt(expression.NewLiteral(string(encrypted), sql.LongBlob), expression.NewLiteral("wrong", sql.LongText)).Eval(ctx, nil)
+	require.NoError(t, err)
+	require.Nil(t, v)
+
+	v, err = NewAESDecrypt(expression.NewLiteral("text", sql.LongBlob), expression.NewLiteral("password", sql.LongText)).Eval(ctx, nil)
+	require.NoError(t, err)
+	require.Nil(t, v)
+
+	v, err = NewAESEncrypt(expression.NewLiteral(nil, sql.Null), expression.NewLiteral("password", sql.LongText)).Eval(ctx, nil)
+	require.NoError(t, err)
+	require.Nil(t, v)
+}
+
+func TestRandomBytes(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	f := NewRandomBytes(expression.NewLiteral(int64(16), sql.Int64))
+	require.True(f.(sql.NonDeterministicExpression).IsNonDeterministic())
+
+	v1, err := f.Eval(ctx, nil)
+	require.NoError(err)
+	require.Len(v1, 16)
+
+	v2, err := f.Eval(ctx, nil)
+	require.NoError(err)
+	require.NotEqual(v1, v2)
+
+	for _, length := range []int64{0, 1025} {
+		_, err = NewRandomBytes(expression.NewLiteral(length, sql.Int64)).Eval(ctx, nil)
+		require.True(ErrInvalidArgument.Is(err))
+	}
+
+	v, err := NewRandomBytes(expression.NewLiteral(nil, sql.Null)).Eval(ctx, nil)
+	require.NoError(err)
+	require.Nil(v)
+}
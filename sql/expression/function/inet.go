@@ -0,0 +1,259 @@
+package function
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// InetAton returns the numeric value of an IPv4 address in dotted-quad
+// notation. Short forms are accepted, so that 127.1 is 127.0.0.1. It returns
+// NULL for invalid addresses.
+type InetAton struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*InetAton)(nil)
+
+// NewInetAton creates a new InetAton UDF.
+func NewInetAton(address sql.Expression) sql.Expression {
+	return &InetAton{expression.UnaryExpression{Child: address}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (i *InetAton) FunctionName() string {
+	return "inet_aton"
+}
+
+func (i *InetAton) String() string {
+	return fmt.Sprintf("INET_ATON(%s)", i.Child)
+}
+
+// Type implements the Expression interface.
+func (i *InetAton) Type() sql.Type { return sql.Uint64 }
+
+// IsNullable implements the Expression interface.
+func (i *InetAton) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (i *InetAton) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := evalString(ctx, i.Child, row)
+	if val == nil || err != nil {
+		return nil, err
+	}
+
+	s := val.(string)
+	if s == "" || strings.HasSuffix(s, ".") {
+		return nil, nil
+	}
+
+	var result, part uint64
+	dots := 0
+	for _, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+			part = part*10 + uint64(c-'0')
+			if part > 255 {
+				return nil, nil
+			}
+		case c == '.':
+			result = result<<8 + part
+			part = 0
+			dots++
+		default:
+			return nil, nil
+		}
+	}
+
+	// The last part of a short form is the last byte of the address.
+	if dots < 3 {
+		result <<= 8 * uint(3-dots)
+	}
+	return result<<8 + part, nil
+}
+
+// WithChildren implements the Expression interface.
+func (i *InetAton) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(i, len(children), 1)
+	}
+	return NewInetAton(children[0]), nil
+}
+
+// InetNtoa returns the dotted-quad notation of the numeric value of an IPv4
+// address. It returns NULL for invalid values.
+type InetNtoa struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*InetNtoa)(nil)
+
+// NewInetNtoa creates a new InetNtoa UDF.
+func NewInetNtoa(n sql.Expression) sql.Expression {
+	return &InetNtoa{expression.UnaryExpression{Child: n}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (i *InetNtoa) FunctionName() string {
+	return "inet_ntoa"
+}
+
+func (i *InetNtoa) String() string {
+	return fmt.Sprintf("INET_NTOA(%s)", i.Child)
+}
+
+// Type implements the Expression interface.
+func (i *InetNtoa) Type() sql.Type { return sql.LongText }
+
+// IsNullable implements the Expression interface.
+func (i *InetNtoa) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (i *InetNtoa) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := i.Child.Eval(ctx, row)
+	if val == nil || err != nil {
+		return nil, err
+	}
+
+	val, err = sql.Int64.Convert(val)
+	if err != nil {
+		return nil, nil
+	}
+
+	n := val.(int64)
+	if n < 0 || n > 0xFFFFFFFF {
+		return nil, nil
+	}
+	return fmt.Sprintf("%d.%d.%d.%d", byte(n>>24), byte(n>>16), byte(n>>8), byte(n)), nil
+}
+
+// WithChildren implements the Expression interface.
+func (i *InetNtoa) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(i, len(children), 1)
+	}
+	return NewInetNtoa(children[0]), nil
+}
+
+// Inet6Aton returns the binary form of an IPv4 or IPv6 address, which is 4 or
+// 16 bytes long. It returns NULL for invalid addresses.
+type Inet6Aton struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*Inet6Aton)(nil)
+
+// NewInet6Aton creates a new Inet6Aton UDF.
+func NewInet6Aton(address sql.Expression) sql.Expression {
+	return &Inet6Aton{expression.UnaryExpression{Child: address}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (i *Inet6Aton) FunctionName() string {
+	return "inet6_aton"
+}
+
+func (i *Inet6Aton) String() string {
+	return fmt.Sprintf("INET6_ATON(%s)", i.Child)
+}
+
+// Type implements the Expression interface.
+func (i *Inet6Aton) Type() sql.Type { return sql.LongBlob }
+
+// IsNullable implements the Expression interface.
+func (i *Inet6Aton) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (i *Inet6Aton) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := evalString(ctx, i.Child, row)
+	if val == nil || err != nil {
+		return nil, err
+	}
+
+	s := val.(string)
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, nil
+	}
+
+	if !strings.Contains(s, ":") {
+		ip = ip.To4()
+	}
+	return string(ip), nil
+}
+
+// WithChildren implements the Expression interface.
+func (i *Inet6Aton) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(i, len(children), 1)
+	}
+	return NewInet6Aton(children[0]), nil
+}
+
+// Inet6Ntoa returns the textual form of an IPv4 or IPv6 address in binary
+// form. IPv4-compatible and IPv4-mapped IPv6 addresses end with the IPv4
+// address in dotted-quad notation. It returns NULL if the value is not 4 or
+// 16 bytes long.
+type Inet6Ntoa struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*Inet6Ntoa)(nil)
+
+// NewInet6Ntoa creates a new Inet6Ntoa UDF.
+func NewInet6Ntoa(address sql.Expression) sql.Expression {
+	return &Inet6Ntoa{expression.UnaryExpression{Child: address}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (i *Inet6Ntoa) FunctionName() string {
+	return "inet6_ntoa"
+}
+
+func (i *Inet6Ntoa) String() string {
+	return fmt.Sprintf("INET6_NTOA(%s)", i.Child)
+}
+
+// Type implements the Expression interface.
+func (i *Inet6Ntoa) Type() sql.Type { return sql.LongText }
+
+// IsNullable implements the Expression interface.
+func (i *Inet6Ntoa) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (i *Inet6Ntoa) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, err := evalString(ctx, i.Child, row)
+	if val == nil || err != nil {
+		return nil, err
+	}
+
+	ip := net.IP(val.(string))
+	switch len(ip) {
+	case net.IPv4len:
+		return ip.String(), nil
+	case net.IPv6len:
+		zeros := make([]byte, 12)
+		switch {
+		case bytes.Equal(ip[:10], zeros[:10]) && ip[10] == 0xff && ip[11] == 0xff:
+			return "::ffff:" + ip[12:].String(), nil
+		case bytes.Equal(ip[:12], zeros) && binary.BigEndian.Uint32(ip[12:]) > 1:
+			return "::" + ip[12:].String(), nil
+		}
+		return ip.String(), nil
+	default:
+		return nil, nil
+	}
+}
+
+// WithChildren implements the Expression interface.
+func (i *Inet6Ntoa) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(i, len(children), 1)
+	}
+	return NewInet6Ntoa(children[0]), nil
+}
@@ -0,0 +1,96 @@
+package function
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestInetAton(t *testing.T) {
+	testCases := []struct {
+		input    interface{}
+		expected interface{}
+	}{
+		{"10.0.5.9", uint64(167773449)},
+		{"127.0.0.1", uint64(2130706433)},
+		{"127.1", uint64(2130706433)},
+		{"127.0.1", uint64(2130706433)},
+		{"255.255.255.255", uint64(4294967295)},
+		{"1", uint64(1)},
+		{"256.0.0.1", nil},
+		{"1.2.3.", nil},
+		{"1.2.3.a", nil},
+		{"", nil},
+		{nil, nil},
+	}
+
+	for _, tt := range testCases {
+		v, err := NewInetAton(expression.NewLiteral(tt.input, sql.LongText)).Eval(sql.NewEmptyContext(), nil)
+		require.NoError(t, err)
+		require.Equal(t, tt.expected, v, "INET_ATON(%v)", tt.input)
+	}
+}
+
+func TestInetNtoa(t *testing.T) {
+	testCases := []struct {
+		input    interface{}
+		expected interface{}
+	}{
+		{int64(167773449), "10.0.5.9"},
+		{uint64(4294967295), "255.255.255.255"},
+		{"2130706433", "127.0.0.1"},
+		{int64(0), "0.0.0.0"},
+		{int64(4294967296), nil},
+		{int64(-1), nil},
+		{nil, nil},
+	}
+
+	for _, tt := range testCases {
+		v, err := NewInetNtoa(expression.NewLiteral(tt.input, sql.Int64)).Eval(sql.NewEmptyContext(), nil)
+		require.NoError(t, err)
+		require.Equal(t, tt.expected, v, "INET_NTOA(%v)", tt.input)
+	}
+}
+
+func TestInet6AtonNtoa(t *testing.T) {
+	testCases := []struct {
+		address string
+		binary  string
+	}{
+		{"10.0.5.9", "0a000509"},
+		{"fdfe::5a55:caff:fefa:9089", "fdfe0000000000005a55cafffefa9089"},
+		{"::1", "00000000000000000000000000000001"},
+		{"::", "00000000000000000000000000000000"},
+		{"::1.2.3.4", "00000000000000000000000001020304"},
+		{"::ffff:1.2.3.4", "00000000000000000000ffff01020304"},
+	}
+
+	ctx := sql.NewEmptyContext()
+	for _, tt := range testCases {
+		t.Run(tt.address, func(t *testing.T) {
+			require := require.New(t)
+
+			v, err := NewInet6Aton(expression.NewLiteral(tt.address, sql.LongText)).Eval(ctx, nil)
+			require.NoError(err)
+			require.Equal(tt.binary, hex.EncodeToString([]byte(v.(string))))
+
+			v, err = NewInet6Ntoa(expression.NewLiteral(v, sql.LongBlob)).Eval(ctx, nil)
+			require.NoError(err)
+			require.Equal(tt.address, v)
+		})
+	}
+
+	for _, address := range []string{"1.2.3", "fdfe::5a55::1", "hello"} {
+		v, err := NewInet6Aton(expression.NewLiteral(address, sql.LongText)).Eval(ctx, nil)
+		require.NoError(t, err)
+		require.Nil(t, v, "INET6_ATON(%s)", address)
+	}
+
+	v, err := NewInet6Ntoa(expression.NewLiteral("abc", sql.LongBlob)).Eval(ctx, nil)
+	require.NoError(t, err)
+	require.Nil(t, v)
+}
@@ -0,0 +1,101 @@
+package function
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// Insert is a function that returns a string with the substring of the given length starting at the given position
+// replaced by a new string. The string is returned as is if the position isn't within it, and the rest of the string
+// is replaced if the length is out of it. Positions and lengths are counted in characters, from 1.
+type Insert struct {
+	str    sql.Expression
+	pos    sql.Expression
+	length sql.Expression
+	newStr sql.Expression
+}
+
+var _ sql.FunctionExpression = (*Insert)(nil)
+
+// NewInsert creates a new Insert expression.
+func NewInsert(str, pos, length, newStr sql.Expression) sql.Expression {
+	return &Insert{str, pos, length, newStr}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (i *Insert) FunctionName() string {
+	return "insert"
+}
+
+// Children implements the Expression interface.
+func (i *Insert) Children() []sql.Expression {
+	return []sql.Expression{i.str, i.pos, i.length, i.newStr}
+}
+
+// Resolved implements the Expression interface.
+func (i *Insert) Resolved() bool {
+	return argsResolved(i.Children())
+}
+
+// IsNullable implements the Expression interface.
+func (i *Insert) IsNullable() bool {
+	return i.str.IsNullable() || i.pos.IsNullable() || i.length.IsNullable() || i.newStr.IsNullable()
+}
+
+// Type implements the Expression interface.
+func (i *Insert) Type() sql.Type {
+	if isBinaryString(i.str) {
+		return sql.LongBlob
+	}
+	return sql.LongText
+}
+
+func (i *Insert) String() string {
+	return fmt.Sprintf("INSERT(%s, %s, %s, %s)", i.str, i.pos, i.length, i.newStr)
+}
+
+// WithChildren implements the Expression interface.
+func (i *Insert) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 4 {
+		return nil, sql.ErrInvalidChildrenNumber.New(i, len(children), 4)
+	}
+	return NewInsert(children[0], children[1], children[2], children[3]), nil
+}
+
+// Eval implements the Expression interface.
+func (i *Insert) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	str, err := evalString(ctx, i.str, row)
+	if str == nil || err != nil {
+		return nil, err
+	}
+
+	pos, err := evalInt64(ctx, i.pos, row)
+	if pos == nil || err != nil {
+		return nil, err
+	}
+
+	length, err := evalInt64(ctx, i.length, row)
+	if length == nil || err != nil {
+		return nil, err
+	}
+
+	newStr, err := evalString(ctx, i.newStr, row)
+	if newStr == nil || err != nil {
+		return nil, err
+	}
+
+	chars := characters(str.(string), isBinaryString(i.str))
+	start := pos.(int64) - 1
+	if start < 0 || start >= int64(len(chars)) {
+		return str, nil
+	}
+
+	end := int64(len(chars))
+	if l := length.(int64); l >= 0 && l < end-start {
+		end = start + l
+	}
+
+	return strings.Join(chars[:start], "") + newStr.(string) + strings.Join(chars[end:], ""), nil
+}
@@ -0,0 +1,46 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestInsert(t *testing.T) {
+	testCases := []struct {
+		name     string
+		typ      sql.Type
+		row      sql.Row
+		expected interface{}
+	}{
+		{"null string", sql.LongText, sql.NewRow(nil, 3, 4, "What"), nil},
+		{"null position", sql.LongText, sql.NewRow("Quadratic", nil, 4, "What"), nil},
+		{"null length", sql.LongText, sql.NewRow("Quadratic", 3, nil, "What"), nil},
+		{"null new string", sql.LongText, sql.NewRow("Quadratic", 3, 4, nil), nil},
+		{"replace", sql.LongText, sql.NewRow("Quadratic", 3, 4, "What"), "QuWhattic"},
+		{"position out of range", sql.LongText, sql.NewRow("Quadratic", -1, 4, "What"), "Quadratic"},
+		{"position after string", sql.LongText, sql.NewRow("Quadratic", 10, 4, "What"), "Quadratic"},
+		{"length out of range", sql.LongText, sql.NewRow("Quadratic", 3, 100, "What"), "QuWhat"},
+		{"negative length", sql.LongText, sql.NewRow("Quadratic", 3, -1, "What"), "QuWhat"},
+		{"length 0", sql.LongText, sql.NewRow("Quadratic", 3, 0, "What"), "QuWhatadratic"},
+		{"multibyte characters", sql.LongText, sql.NewRow("añb", 2, 1, "x"), "axb"},
+		{"binary", sql.LongBlob, sql.NewRow("añb", 2, 2, "x"), "axb"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewInsert(
+				expression.NewGetField(0, tt.typ, "str", true),
+				expression.NewGetField(1, sql.Int64, "pos", true),
+				expression.NewGetField(2, sql.Int64, "len", true),
+				expression.NewGetField(3, tt.typ, "newstr", true),
+			)
+			v, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, v)
+		})
+	}
+}
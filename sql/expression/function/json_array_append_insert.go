@@ -0,0 +1,143 @@
+package function
+
+import (
+	errors "gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// ErrJSONPathNotArrayCell is returned when a path that must refer to a
+// position in an array doesn't.
+var ErrJSONPathNotArrayCell = errors.NewKind("A path expression is not a path to a cell in an array: %s")
+
+// JSONArrayAppend appends values to the end of the arrays in a JSON document.
+// A value that is not an array is turned into an array with the value and
+// the appended one.
+type JSONArrayAppend struct {
+	jsonFunc
+}
+
+var _ sql.FunctionExpression = (*JSONArrayAppend)(nil)
+
+// NewJSONArrayAppend creates a new JSONArrayAppend UDF.
+func NewJSONArrayAppend(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 3 || len(args)%2 == 0 {
+		return nil, sql.ErrInvalidArgumentNumber.New("JSON_ARRAY_APPEND", "an odd number (3 or more) of", len(args))
+	}
+	return &JSONArrayAppend{jsonFunc{"json_array_append", args}}, nil
+}
+
+// Type implements the sql.Expression interface.
+func (*JSONArrayAppend) Type() sql.Type { return sql.JSON }
+
+// WithChildren implements the Expression interface.
+func (*JSONArrayAppend) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewJSONArrayAppend(children...)
+}
+
+// Eval implements the sql.Expression interface.
+func (j *JSONArrayAppend) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	doc, ok, err := evalJSON(ctx, j.args[0], row)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	result := sql.CopyJSON(doc.Val)
+	for i := 1; i < len(j.args); i += 2 {
+		path, err := evalJSONPathNoWildcards(ctx, j.args[i], row)
+		if err != nil || path == nil {
+			return nil, err
+		}
+
+		val, err := evalJSONValue(ctx, j.args[i+1], row)
+		if err != nil {
+			return nil, err
+		}
+		val = sql.CopyJSON(val)
+
+		result = path.Update(result, func(cur interface{}, exists bool) (interface{}, bool) {
+			if !exists {
+				return nil, false
+			}
+			if arr, ok := cur.([]interface{}); ok {
+				return append(arr, val), true
+			}
+			return []interface{}{cur, val}, true
+		})
+	}
+
+	return sql.JSONDocument{Val: result}, nil
+}
+
+// JSONArrayInsert inserts values in the arrays of a JSON document, at the
+// positions given by the paths. Positions past the end of an array append
+// the value to it.
+type JSONArrayInsert struct {
+	jsonFunc
+}
+
+var _ sql.FunctionExpression = (*JSONArrayInsert)(nil)
+
+// NewJSONArrayInsert creates a new JSONArrayInsert UDF.
+func NewJSONArrayInsert(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 3 || len(args)%2 == 0 {
+		return nil, sql.ErrInvalidArgumentNumber.New("JSON_ARRAY_INSERT", "an odd number (3 or more) of", len(args))
+	}
+	return &JSONArrayInsert{jsonFunc{"json_array_insert", args}}, nil
+}
+
+// Type implements the sql.Expression interface.
+func (*JSONArrayInsert) Type() sql.Type { return sql.JSON }
+
+// WithChildren implements the Expression interface.
+func (*JSONArrayInsert) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewJSONArrayInsert(children...)
+}
+
+// Eval implements the sql.Expression interface.
+func (j *JSONArrayInsert) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	doc, ok, err := evalJSON(ctx, j.args[0], row)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	result := sql.CopyJSON(doc.Val)
+	for i := 1; i < len(j.args); i += 2 {
+		path, err := evalJSONPathNoWildcards(ctx, j.args[i], row)
+		if err != nil || path == nil {
+			return nil, err
+		}
+
+		if !path.IsArrayCell() {
+			return nil, ErrJSONPathNotArrayCell.New(path)
+		}
+
+		val, err := evalJSONValue(ctx, j.args[i+1], row)
+		if err != nil {
+			return nil, err
+		}
+		val = sql.CopyJSON(val)
+
+		result = path.UpdateParent(result, func(parent interface{}) (interface{}, bool) {
+			arr, ok := parent.([]interface{})
+			if !ok {
+				return nil, false
+			}
+
+			pos, _ := path.LastArrayCell(len(arr))
+			switch {
+			case pos < 0:
+				pos = 0
+			case pos > len(arr):
+				pos = len(arr)
+			}
+
+			arr = append(arr, nil)
+			copy(arr[pos+1:], arr[pos:])
+			arr[pos] = val
+			return arr, true
+		})
+	}
+
+	return sql.JSONDocument{Val: result}, nil
+}
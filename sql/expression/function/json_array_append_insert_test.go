@@ -0,0 +1,31 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+const jsonArrayDoc = `["a", ["b", "c"], "d"]`
+
+func TestJSONArrayAppend(t *testing.T) {
+	testJSONFunction(t, NewJSONArrayAppend, []jsonTestCase{
+		{[]interface{}{jsonArrayDoc, "$[1]", 1}, sql.MustJSON(`["a", ["b", "c", 1], "d"]`), nil},
+		{[]interface{}{jsonArrayDoc, "$[0]", 2}, sql.MustJSON(`[["a", 2], ["b", "c"], "d"]`), nil},
+		{[]interface{}{jsonArrayDoc, "$[1][0]", 3}, sql.MustJSON(`["a", [["b", 3], "c"], "d"]`), nil},
+		{[]interface{}{`{"a": 1, "b": [2, 3], "c": 4}`, "$.b", "x"}, sql.MustJSON(`{"a": 1, "b": [2, 3, "x"], "c": 4}`), nil},
+		{[]interface{}{`{"a": 1}`, "$", "z"}, sql.MustJSON(`[{"a": 1}, "z"]`), nil},
+		{[]interface{}{jsonArrayDoc, "$[5]", 1}, sql.MustJSON(jsonArrayDoc), nil},
+	})
+}
+
+func TestJSONArrayInsert(t *testing.T) {
+	testJSONFunction(t, NewJSONArrayInsert, []jsonTestCase{
+		{[]interface{}{`["a", {"b": [1, 2]}, [3, 4]]`, "$[1]", "x"}, sql.MustJSON(`["a", "x", {"b": [1, 2]}, [3, 4]]`), nil},
+		{[]interface{}{`["a", {"b": [1, 2]}, [3, 4]]`, "$[100]", "x"}, sql.MustJSON(`["a", {"b": [1, 2]}, [3, 4], "x"]`), nil},
+		{[]interface{}{`["a", {"b": [1, 2]}, [3, 4]]`, "$[1].b[0]", "x"}, sql.MustJSON(`["a", {"b": ["x", 1, 2]}, [3, 4]]`), nil},
+		{[]interface{}{`["a", {"b": [1, 2]}, [3, 4]]`, "$[0]", "x", "$[2][1]", "y"}, sql.MustJSON(`["x", "a", {"b": [1, 2]}, [3, 4]]`), nil},
+		{[]interface{}{`["a", [3, 4]]`, "$[0]", "x", "$[2][1]", "y"}, sql.MustJSON(`["x", "a", [3, "y", 4]]`), nil},
+		{[]interface{}{`{"a": 1}`, "$.a", "x"}, nil, ErrJSONPathNotArrayCell},
+	})
+}
@@ -0,0 +1,189 @@
+package function
+
+import (
+	"strings"
+
+	errors "gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// ErrJSONOneOrAll is returned when the argument that tells whether one or all
+// paths must match is not valid.
+var ErrJSONOneOrAll = errors.NewKind("The oneOrAll argument to %s may take these values: 'one' or 'all'")
+
+// JSONContains returns whether a JSON document contains another one, either
+// at its root or at the given path.
+type JSONContains struct {
+	jsonFunc
+}
+
+var _ sql.FunctionExpression = (*JSONContains)(nil)
+
+// NewJSONContains creates a new JSONContains UDF.
+func NewJSONContains(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) != 2 && len(args) != 3 {
+		return nil, sql.ErrInvalidArgumentNumber.New("JSON_CONTAINS", "2 or 3", len(args))
+	}
+	return &JSONContains{jsonFunc{"json_contains", args}}, nil
+}
+
+// Type implements the sql.Expression interface.
+func (*JSONContains) Type() sql.Type { return sql.Boolean }
+
+// WithChildren implements the Expression interface.
+func (*JSONContains) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewJSONContains(children...)
+}
+
+// Eval implements the sql.Expression interface.
+func (j *JSONContains) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	target, ok, err := evalJSON(ctx, j.args[0], row)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	candidate, ok, err := evalJSON(ctx, j.args[1], row)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	val := target.Val
+	if len(j.args) == 3 {
+		path, err := evalJSONPathNoWildcards(ctx, j.args[2], row)
+		if err != nil || path == nil {
+			return nil, err
+		}
+
+		values := path.Extract(val)
+		if len(values) == 0 {
+			return nil, nil
+		}
+		val = values[0]
+	}
+
+	return jsonContains(val, candidate.Val), nil
+}
+
+// jsonContains returns whether the target JSON value contains the candidate.
+// A scalar contains another one if they are equal, an array contains the
+// elements of another array, or any value contained in one of its elements,
+// and an object contains another object if it has all its keys and their
+// values contain the values of the other object.
+func jsonContains(target, candidate interface{}) bool {
+	switch target := target.(type) {
+	case []interface{}:
+		if cand, ok := candidate.([]interface{}); ok {
+			for _, c := range cand {
+				if !jsonContains(target, c) {
+					return false
+				}
+			}
+			return true
+		}
+
+		for _, t := range target {
+			if jsonContains(t, candidate) {
+				return true
+			}
+		}
+		return false
+	case map[string]interface{}:
+		cand, ok := candidate.(map[string]interface{})
+		if !ok {
+			return false
+		}
+
+		for k, c := range cand {
+			t, ok := target[k]
+			if !ok || !jsonContains(t, c) {
+				return false
+			}
+		}
+		return true
+	default:
+		switch candidate.(type) {
+		case []interface{}, map[string]interface{}:
+			return false
+		}
+		return sql.CompareJSON(target, candidate) == 0
+	}
+}
+
+// JSONContainsPath returns whether a JSON document has values at one or all
+// of the given paths.
+type JSONContainsPath struct {
+	jsonFunc
+}
+
+var _ sql.FunctionExpression = (*JSONContainsPath)(nil)
+
+// NewJSONContainsPath creates a new JSONContainsPath UDF.
+func NewJSONContainsPath(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 3 {
+		return nil, sql.ErrInvalidArgumentNumber.New("JSON_CONTAINS_PATH", "3 or more", len(args))
+	}
+	return &JSONContainsPath{jsonFunc{"json_contains_path", args}}, nil
+}
+
+// Type implements the sql.Expression interface.
+func (*JSONContainsPath) Type() sql.Type { return sql.Boolean }
+
+// WithChildren implements the Expression interface.
+func (*JSONContainsPath) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewJSONContainsPath(children...)
+}
+
+// Eval implements the sql.Expression interface.
+func (j *JSONContainsPath) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	doc, ok, err := evalJSON(ctx, j.args[0], row)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	all, ok, err := evalOneOrAll(ctx, j.name, j.args[1], row)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	for _, arg := range j.args[2:] {
+		path, err := evalJSONPath(ctx, arg, row)
+		if err != nil || path == nil {
+			return nil, err
+		}
+
+		found := len(path.Extract(doc.Val)) > 0
+		if found && !all {
+			return true, nil
+		}
+		if !found && all {
+			return false, nil
+		}
+	}
+
+	return all, nil
+}
+
+// evalOneOrAll evaluates the argument of the JSON functions that tells whether
+// one or all the values must match, and returns whether it's "all". It
+// returns false if the argument is NULL.
+func evalOneOrAll(ctx *sql.Context, name string, e sql.Expression, row sql.Row) (all bool, ok bool, err error) {
+	v, err := e.Eval(ctx, row)
+	if err != nil || v == nil {
+		return false, false, err
+	}
+
+	v, err = sql.LongText.Convert(v)
+	if err != nil {
+		return false, false, err
+	}
+
+	switch strings.ToLower(v.(string)) {
+	case "one":
+		return false, true, nil
+	case "all":
+		return true, true, nil
+	default:
+		return false, false, ErrJSONOneOrAll.New(name)
+	}
+}
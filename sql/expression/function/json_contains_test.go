@@ -0,0 +1,35 @@
+package function
+
+import (
+	"testing"
+)
+
+const jsonContainsDoc = `{"a": 1, "b": 2, "c": {"d": 4}}`
+
+func TestJSONContains(t *testing.T) {
+	testJSONFunction(t, NewJSONContains, []jsonTestCase{
+		{[]interface{}{jsonContainsDoc, "1", "$.a"}, true, nil},
+		{[]interface{}{jsonContainsDoc, "1", "$.b"}, false, nil},
+		{[]interface{}{jsonContainsDoc, `{"d": 4}`, "$.a"}, false, nil},
+		{[]interface{}{jsonContainsDoc, `{"d": 4}`, "$.c"}, true, nil},
+		{[]interface{}{jsonContainsDoc, `{"a": 1}`}, true, nil},
+		{[]interface{}{jsonContainsDoc, "1", "$.e"}, nil, nil},
+		{[]interface{}{`[1, [2, 3], {"x": 4}]`, "[3, 1]"}, true, nil},
+		{[]interface{}{`[1, [2, 3], {"x": 4}]`, "3"}, true, nil},
+		{[]interface{}{`[1, [2, 3], {"x": 4}]`, "4"}, false, nil},
+		{[]interface{}{`[1, [2, 3], {"x": 4}]`, "[[3]]"}, true, nil},
+		{[]interface{}{`[1, 2]`, "1.0"}, true, nil},
+		{[]interface{}{nil, "1"}, nil, nil},
+	})
+}
+
+func TestJSONContainsPath(t *testing.T) {
+	testJSONFunction(t, NewJSONContainsPath, []jsonTestCase{
+		{[]interface{}{jsonContainsDoc, "one", "$.a", "$.e"}, true, nil},
+		{[]interface{}{jsonContainsDoc, "all", "$.a", "$.e"}, false, nil},
+		{[]interface{}{jsonContainsDoc, "ONE", "$.c.d"}, true, nil},
+		{[]interface{}{jsonContainsDoc, "one", "$.a.d"}, false, nil},
+		{[]interface{}{jsonContainsDoc, "one", "$**.d"}, true, nil},
+		{[]interface{}{jsonContainsDoc, "some", "$.a"}, nil, ErrJSONOneOrAll},
+	})
+}
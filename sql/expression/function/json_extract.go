@@ -1,15 +1,109 @@
 package function
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 
-	"github.com/oliveagle/jsonpath"
-
 	"github.com/dolthub/go-mysql-server/sql"
 )
 
+// jsonFunc holds the arguments of a JSON function and implements the parts
+// of the sql.Expression interface shared by all of them.
+type jsonFunc struct {
+	name string
+	args []sql.Expression
+}
+
+// FunctionName implements sql.FunctionExpression
+func (f *jsonFunc) FunctionName() string {
+	return f.name
+}
+
+// Resolved implements the sql.Expression interface.
+func (f *jsonFunc) Resolved() bool {
+	for _, arg := range f.args {
+		if !arg.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsNullable implements the sql.Expression interface.
+func (f *jsonFunc) IsNullable() bool {
+	return true
+}
+
+// Children implements the sql.Expression interface.
+func (f *jsonFunc) Children() []sql.Expression {
+	return f.args
+}
+
+func (f *jsonFunc) String() string {
+	var parts = make([]string, len(f.args))
+	for i, arg := range f.args {
+		parts[i] = arg.String()
+	}
+	return fmt.Sprintf("%s(%s)", strings.ToUpper(f.name), strings.Join(parts, ", "))
+}
+
+// evalJSON evaluates the given expression as a JSON document. It returns false
+// if the expression is NULL.
+func evalJSON(ctx *sql.Context, e sql.Expression, row sql.Row) (sql.JSONDocument, bool, error) {
+	v, err := e.Eval(ctx, row)
+	if err != nil || v == nil {
+		return sql.JSONDocument{}, false, err
+	}
+
+	doc, err := sql.JSON.Convert(v)
+	if err != nil {
+		return sql.JSONDocument{}, false, err
+	}
+
+	return doc.(sql.JSONDocument), true, nil
+}
+
+// evalJSONValue evaluates the given expression as a value to be added to a
+// JSON document.
+func evalJSONValue(ctx *sql.Context, e sql.Expression, row sql.Row) (interface{}, error) {
+	v, err := e.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	return sql.JSONValueOf(e.Type(), v)
+}
+
+// evalJSONPath evaluates the given expression as a JSON path. It returns nil
+// if the expression is NULL.
+func evalJSONPath(ctx *sql.Context, e sql.Expression, row sql.Row) (*sql.JSONPath, error) {
+	v, err := e.Eval(ctx, row)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	v, err = sql.LongText.Convert(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return sql.ParseJSONPath(v.(string))
+}
+
+// evalJSONPathNoWildcards evaluates the given expression as a JSON path
+// that refers to a single location. It returns nil if the expression is NULL.
+func evalJSONPathNoWildcards(ctx *sql.Context, e sql.Expression, row sql.Row) (*sql.JSONPath, error) {
+	path, err := evalJSONPath(ctx, e, row)
+	if err != nil || path == nil {
+		return nil, err
+	}
+
+	if path.HasWildcards() {
+		return nil, sql.ErrJSONPathWildcard.New(path)
+	}
+
+	return path, nil
+}
+
 // JSONExtract extracts data from a json document using json paths.
 type JSONExtract struct {
 	JSON  sql.Expression
@@ -45,70 +139,54 @@ func (j *JSONExtract) Resolved() bool {
 // Type implements the sql.Expression interface.
 func (j *JSONExtract) Type() sql.Type { return sql.JSON }
 
-// Eval implements the sql.Expression interface.
+// Eval implements the sql.Expression interface. The result is the value the
+// path refers to, or an array with all the values found when there are
+// several paths or the path has wildcards. It's NULL if nothing is found.
 func (j *JSONExtract) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	span, ctx := ctx.Span("function.JSONExtract")
 	defer span.Finish()
 
-	js, err := j.JSON.Eval(ctx, row)
-	if err != nil {
-		return nil, err
-	}
-
-	doc, err := unmarshalVal(js)
-	if err != nil {
+	doc, ok, err := evalJSON(ctx, j.JSON, row)
+	if err != nil || !ok {
 		return nil, err
 	}
 
-	var result = make([]interface{}, len(j.Paths))
-	for i, p := range j.Paths {
-		path, err := p.Eval(ctx, row)
-		if err != nil {
+	var (
+		result   []interface{}
+		wrapped  = len(j.Paths) > 1
+		anyFound bool
+	)
+	for _, p := range j.Paths {
+		path, err := evalJSONPath(ctx, p, row)
+		if err != nil || path == nil {
 			return nil, err
 		}
 
-		path, err = sql.LongText.Convert(path)
-		if err != nil {
-			return nil, err
+		if path.HasWildcards() {
+			wrapped = true
 		}
 
-		c, err := jsonpath.Compile(path.(string))
-		if err != nil {
-			return nil, err
+		values := path.Extract(doc.Val)
+		if len(values) > 0 {
+			anyFound = true
 		}
-
-		result[i], _ = c.Lookup(doc) // err ignored
+		result = append(result, values...)
 	}
 
-	if len(result) == 1 {
-		return result[0], nil
+	if !anyFound {
+		return nil, nil
 	}
 
-	return result, nil
-}
-
-func unmarshalVal(v interface{}) (interface{}, error) {
-	v, err := sql.JSON.Convert(v)
-	if err != nil {
-		return nil, err
-	}
-
-	var doc interface{}
-	if err := json.Unmarshal(v.([]byte), &doc); err != nil {
-		return nil, err
+	if !wrapped {
+		return sql.JSONDocument{Val: result[0]}, nil
 	}
 
-	return doc, nil
+	return sql.JSONDocument{Val: result}, nil
 }
 
 // IsNullable implements the sql.Expression interface.
 func (j *JSONExtract) IsNullable() bool {
-	for _, p := range j.Paths {
-		if p.IsNullable() {
-			return true
-		}
-	}
-	return j.JSON.IsNullable()
+	return true
 }
 
 // Children implements the sql.Expression interface.
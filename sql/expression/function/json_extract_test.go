@@ -1,10 +1,10 @@
 package function
 
 import (
-	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
@@ -50,16 +50,20 @@ func TestJSONExtract(t *testing.T) {
 		expected interface{}
 		err      error
 	}{
-		{f2, sql.Row{json, "FOO"}, nil, errors.New("should start with '$'")},
+		{f2, sql.Row{json, "FOO"}, nil, sql.ErrInvalidJSONPath.New("FOO")},
+		{f2, sql.Row{`{"a": 1`, "$"}, nil, sql.ErrInvalidJSONText.New(`{"a": 1`)},
 		{f2, sql.Row{nil, "$.b.c"}, nil, nil},
+		{f2, sql.Row{json, nil}, nil, nil},
 		{f2, sql.Row{json, "$.foo"}, nil, nil},
-		{f2, sql.Row{json, "$.b.c"}, "foo", nil},
-		{f3, sql.Row{json, "$.b.c", "$.b.d"}, []interface{}{"foo", true}, nil},
-		{f4, sql.Row{json, "$.b.c", "$.b.d", "$.e[0][*]"}, []interface{}{
-			"foo",
-			true,
-			[]interface{}{1., 2.},
-		}, nil},
+		{f2, sql.Row{json, "$.b.c"}, sql.MustJSON(`"foo"`), nil},
+		{f2, sql.Row{json, "$.a[last]"}, sql.MustJSON(`4`), nil},
+		{f2, sql.Row{json, "$.a[1 to 2]"}, sql.MustJSON(`[2, 3]`), nil},
+		{f2, sql.Row{json, "$.e[*][0]"}, sql.MustJSON(`[1, 3]`), nil},
+		{f2, sql.Row{json, "$**.c"}, sql.MustJSON(`["foo"]`), nil},
+		{f2, sql.Row{`[1, 2]`, "$[0]"}, sql.MustJSON(`1`), nil},
+		{f3, sql.Row{json, "$.b.c", "$.b.d"}, sql.MustJSON(`["foo", true]`), nil},
+		{f3, sql.Row{json, "$.b.c", "$.foo"}, sql.MustJSON(`["foo"]`), nil},
+		{f4, sql.Row{json, "$.b.c", "$.b.d", "$.e[0][*]"}, sql.MustJSON(`["foo", true, 1, 2]`), nil},
 	}
 
 	for _, tt := range testCases {
@@ -76,3 +80,56 @@ func TestJSONExtract(t *testing.T) {
 		})
 	}
 }
+
+// jsonLiterals returns literals with the given values to be used as arguments
+// of JSON functions in tests.
+func jsonLiterals(vals ...interface{}) []sql.Expression {
+	var args = make([]sql.Expression, len(vals))
+	for i, v := range vals {
+		switch v := v.(type) {
+		case nil:
+			args[i] = expression.NewLiteral(nil, sql.Null)
+		case string:
+			args[i] = expression.NewLiteral(v, sql.LongText)
+		case int:
+			args[i] = expression.NewLiteral(int64(v), sql.Int64)
+		case bool:
+			args[i] = expression.NewLiteral(v, sql.Boolean)
+		case float64:
+			args[i] = expression.NewLiteral(v, sql.Float64)
+		case sql.JSONDocument:
+			args[i] = expression.NewLiteral(v, sql.JSON)
+		default:
+			panic("unexpected literal type")
+		}
+	}
+	return args
+}
+
+// jsonTestCase is the evaluation of a JSON function with the given arguments.
+type jsonTestCase struct {
+	args     []interface{}
+	expected interface{}
+	err      *errors.Kind
+}
+
+func testJSONFunction(t *testing.T, newFunc func(...sql.Expression) (sql.Expression, error), testCases []jsonTestCase) {
+	t.Helper()
+	for _, tt := range testCases {
+		f, err := newFunc(jsonLiterals(tt.args...)...)
+		require.NoError(t, err)
+
+		t.Run(f.String(), func(t *testing.T) {
+			require := require.New(t)
+			result, err := f.Eval(sql.NewEmptyContext(), nil)
+			if tt.err != nil {
+				require.Error(err)
+				require.True(tt.err.Is(err), "unexpected error: %s", err)
+				return
+			}
+
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package function
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// JSONKeys returns the keys of a JSON object, either the document or the
+// one at the given path, as a JSON array.
+type JSONKeys struct {
+	jsonFunc
+}
+
+var _ sql.FunctionExpression = (*JSONKeys)(nil)
+
+// NewJSONKeys creates a new JSONKeys UDF.
+func NewJSONKeys(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) != 1 && len(args) != 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New("JSON_KEYS", "1 or 2", len(args))
+	}
+	return &JSONKeys{jsonFunc{"json_keys", args}}, nil
+}
+
+// Type implements the sql.Expression interface.
+func (*JSONKeys) Type() sql.Type { return sql.JSON }
+
+// WithChildren implements the Expression interface.
+func (*JSONKeys) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewJSONKeys(children...)
+}
+
+// Eval implements the sql.Expression interface.
+func (j *JSONKeys) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, ok, err := evalJSONAtPath(ctx, j.args, row)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	obj, ok := val.(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	keys := sql.JSONObjectKeys(obj)
+	result := make([]interface{}, len(keys))
+	for i, k := range keys {
+		result[i] = k
+	}
+
+	return sql.JSONDocument{Val: result}, nil
+}
+
+// evalJSONAtPath evaluates the first of the given arguments as a JSON
+// document and returns the value at the path given by the second argument, if
+// any, or the whole document otherwise. It returns false if any argument is
+// NULL or there's no value at the path.
+func evalJSONAtPath(ctx *sql.Context, args []sql.Expression, row sql.Row) (interface{}, bool, error) {
+	doc, ok, err := evalJSON(ctx, args[0], row)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	if len(args) == 1 {
+		return doc.Val, true, nil
+	}
+
+	path, err := evalJSONPathNoWildcards(ctx, args[1], row)
+	if err != nil || path == nil {
+		return nil, false, err
+	}
+
+	values := path.Extract(doc.Val)
+	if len(values) == 0 {
+		return nil, false, nil
+	}
+
+	return values[0], true, nil
+}
@@ -0,0 +1,17 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestJSONKeys(t *testing.T) {
+	testJSONFunction(t, NewJSONKeys, []jsonTestCase{
+		{[]interface{}{`{"b": 1, "a": {"c": 2}}`}, sql.MustJSON(`["a", "b"]`), nil},
+		{[]interface{}{`{"a": 1, "b": {"c": 30}}`, "$.b"}, sql.MustJSON(`["c"]`), nil},
+		{[]interface{}{`{"a": 1}`, "$.a"}, nil, nil},
+		{[]interface{}{`[1, 2]`}, nil, nil},
+		{[]interface{}{`{"a": 1}`, "$.*"}, nil, sql.ErrJSONPathWildcard},
+	})
+}
@@ -0,0 +1,100 @@
+package function
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// JSONLength returns the length of a JSON document, or of the value at the
+// given path. The length of a scalar is 1, and the length of an array or an
+// object is the number of its elements or members.
+type JSONLength struct {
+	jsonFunc
+}
+
+var _ sql.FunctionExpression = (*JSONLength)(nil)
+
+// NewJSONLength creates a new JSONLength UDF.
+func NewJSONLength(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) != 1 && len(args) != 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New("JSON_LENGTH", "1 or 2", len(args))
+	}
+	return &JSONLength{jsonFunc{"json_length", args}}, nil
+}
+
+// Type implements the sql.Expression interface.
+func (*JSONLength) Type() sql.Type { return sql.Int64 }
+
+// WithChildren implements the Expression interface.
+func (*JSONLength) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewJSONLength(children...)
+}
+
+// Eval implements the sql.Expression interface.
+func (j *JSONLength) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	val, ok, err := evalJSONAtPath(ctx, j.args, row)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	switch val := val.(type) {
+	case []interface{}:
+		return int64(len(val)), nil
+	case map[string]interface{}:
+		return int64(len(val)), nil
+	default:
+		return int64(1), nil
+	}
+}
+
+// JSONDepth returns the maximum depth of a JSON document. Scalars and empty
+// arrays and objects have a depth of 1.
+type JSONDepth struct {
+	jsonFunc
+}
+
+var _ sql.FunctionExpression = (*JSONDepth)(nil)
+
+// NewJSONDepth creates a new JSONDepth UDF.
+func NewJSONDepth(arg sql.Expression) sql.Expression {
+	return &JSONDepth{jsonFunc{"json_depth", []sql.Expression{arg}}}
+}
+
+// Type implements the sql.Expression interface.
+func (*JSONDepth) Type() sql.Type { return sql.Int64 }
+
+// WithChildren implements the Expression interface.
+func (j *JSONDepth) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), 1)
+	}
+	return NewJSONDepth(children[0]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (j *JSONDepth) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	doc, ok, err := evalJSON(ctx, j.args[0], row)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return int64(jsonDepth(doc.Val)), nil
+}
+
+func jsonDepth(v interface{}) int {
+	var children []interface{}
+	switch v := v.(type) {
+	case []interface{}:
+		children = v
+	case map[string]interface{}:
+		for _, c := range v {
+			children = append(children, c)
+		}
+	}
+
+	max := 0
+	for _, c := range children {
+		if d := jsonDepth(c); d > max {
+			max = d
+		}
+	}
+	return max + 1
+}
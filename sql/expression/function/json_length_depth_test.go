@@ -0,0 +1,34 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestJSONLength(t *testing.T) {
+	testJSONFunction(t, NewJSONLength, []jsonTestCase{
+		{[]interface{}{`[1, 2, {"a": 3}]`}, int64(3), nil},
+		{[]interface{}{`{"a": 1, "b": {"c": 30}}`}, int64(2), nil},
+		{[]interface{}{`{"a": 1, "b": {"c": 30}}`, "$.b"}, int64(1), nil},
+		{[]interface{}{`{"a": 1}`, "$.c"}, nil, nil},
+		{[]interface{}{`"abc"`}, int64(1), nil},
+		{[]interface{}{nil}, nil, nil},
+		{[]interface{}{`[1]`, "$[*]"}, nil, sql.ErrJSONPathWildcard},
+	})
+}
+
+func TestJSONDepth(t *testing.T) {
+	newJSONDepth := func(args ...sql.Expression) (sql.Expression, error) {
+		return NewJSONDepth(args[0]), nil
+	}
+	testJSONFunction(t, newJSONDepth, []jsonTestCase{
+		{[]interface{}{`{}`}, int64(1), nil},
+		{[]interface{}{`[]`}, int64(1), nil},
+		{[]interface{}{`true`}, int64(1), nil},
+		{[]interface{}{`[10, 20]`}, int64(2), nil},
+		{[]interface{}{`[[], {}]`}, int64(2), nil},
+		{[]interface{}{`[10, {"a": 20}]`}, int64(3), nil},
+		{[]interface{}{nil}, nil, nil},
+	})
+}
@@ -0,0 +1,142 @@
+package function
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// JSONMerge merges two or more JSON documents, either preserving all the
+// values, as JSON_MERGE_PRESERVE does, or following RFC 7396, as
+// JSON_MERGE_PATCH does.
+type JSONMerge struct {
+	jsonFunc
+	patch bool
+}
+
+var _ sql.FunctionExpression = (*JSONMerge)(nil)
+
+func newJSONMerge(name string, patch bool, args []sql.Expression) (sql.Expression, error) {
+	if len(args) < 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New(name, "2 or more", len(args))
+	}
+	return &JSONMerge{jsonFunc{name, args}, patch}, nil
+}
+
+// NewJSONMergePreserve creates a new JSON_MERGE_PRESERVE UDF. Arrays are
+// concatenated, objects are merged combining the values of the same keys,
+// and any other values are wrapped in arrays and concatenated.
+func NewJSONMergePreserve(args ...sql.Expression) (sql.Expression, error) {
+	return newJSONMerge("json_merge_preserve", false, args)
+}
+
+// NewJSONMerge creates a new JSON_MERGE UDF, the deprecated synonym of
+// JSON_MERGE_PRESERVE.
+func NewJSONMerge(args ...sql.Expression) (sql.Expression, error) {
+	return newJSONMerge("json_merge", false, args)
+}
+
+// NewJSONMergePatch creates a new JSON_MERGE_PATCH UDF. Objects are merged
+// replacing the values of the same keys and removing the keys set to null,
+// and any other values replace the previous ones.
+func NewJSONMergePatch(args ...sql.Expression) (sql.Expression, error) {
+	return newJSONMerge("json_merge_patch", true, args)
+}
+
+// Type implements the sql.Expression interface.
+func (*JSONMerge) Type() sql.Type { return sql.JSON }
+
+// WithChildren implements the Expression interface.
+func (j *JSONMerge) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return newJSONMerge(j.name, j.patch, children)
+}
+
+// Eval implements the sql.Expression interface. JSON_MERGE_PRESERVE is NULL
+// if any argument is NULL. As in MySQL, a NULL argument of JSON_MERGE_PATCH
+// only makes the result NULL until a later patch that is not an object
+// replaces it.
+func (j *JSONMerge) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	var (
+		result interface{}
+		isNull bool
+	)
+	for i, arg := range j.args {
+		doc, ok, err := evalJSON(ctx, arg, row)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			if !j.patch {
+				return nil, nil
+			}
+			isNull = true
+			continue
+		}
+
+		val := sql.CopyJSON(doc.Val)
+		switch {
+		case i == 0:
+			result = val
+		case j.patch:
+			// A patch that is not an object replaces the target, even if
+			// it's NULL, while an object can't be merged into a NULL one.
+			if _, isObject := val.(map[string]interface{}); !isObject {
+				result, isNull = val, false
+			} else if !isNull {
+				result = mergeJSONPatch(result, val)
+			}
+		default:
+			result = mergeJSONPreserve(result, val)
+		}
+	}
+
+	if isNull {
+		return nil, nil
+	}
+
+	return sql.JSONDocument{Val: result}, nil
+}
+
+func mergeJSONPreserve(a, b interface{}) interface{} {
+	ao, aok := a.(map[string]interface{})
+	bo, bok := b.(map[string]interface{})
+	if aok && bok {
+		for k, bv := range bo {
+			if av, ok := ao[k]; ok {
+				ao[k] = mergeJSONPreserve(av, bv)
+			} else {
+				ao[k] = bv
+			}
+		}
+		return ao
+	}
+
+	return append(jsonToArray(a), jsonToArray(b)...)
+}
+
+func jsonToArray(v interface{}) []interface{} {
+	if arr, ok := v.([]interface{}); ok {
+		return arr
+	}
+	return []interface{}{v}
+}
+
+func mergeJSONPatch(target, patch interface{}) interface{} {
+	po, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	to, ok := target.(map[string]interface{})
+	if !ok {
+		to = make(map[string]interface{})
+	}
+
+	for k, v := range po {
+		if v == nil {
+			delete(to, k)
+		} else {
+			to[k] = mergeJSONPatch(to[k], v)
+		}
+	}
+	return to
+}
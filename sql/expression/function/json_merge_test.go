@@ -0,0 +1,33 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestJSONMergePreserve(t *testing.T) {
+	testJSONFunction(t, NewJSONMergePreserve, []jsonTestCase{
+		{[]interface{}{`[1, 2]`, `[true, false]`}, sql.MustJSON(`[1, 2, true, false]`), nil},
+		{[]interface{}{`{"name": "x"}`, `{"id": 47}`}, sql.MustJSON(`{"id": 47, "name": "x"}`), nil},
+		{[]interface{}{`1`, `true`}, sql.MustJSON(`[1, true]`), nil},
+		{[]interface{}{`[1, 2]`, `{"id": 47}`}, sql.MustJSON(`[1, 2, {"id": 47}]`), nil},
+		{[]interface{}{`{"a": 1, "b": 2}`, `{"a": 3, "c": 4}`}, sql.MustJSON(`{"a": [1, 3], "b": 2, "c": 4}`), nil},
+		{[]interface{}{`{"a": 1, "b": 2}`, `{"a": 3, "c": 4}`, `{"a": 5, "d": 6}`}, sql.MustJSON(`{"a": [1, 3, 5], "b": 2, "c": 4, "d": 6}`), nil},
+		{[]interface{}{`[1]`, nil}, nil, nil},
+	})
+}
+
+func TestJSONMergePatch(t *testing.T) {
+	testJSONFunction(t, NewJSONMergePatch, []jsonTestCase{
+		{[]interface{}{`[1, 2]`, `[true, false]`}, sql.MustJSON(`[true, false]`), nil},
+		{[]interface{}{`{"name": "x"}`, `{"id": 47}`}, sql.MustJSON(`{"id": 47, "name": "x"}`), nil},
+		{[]interface{}{`1`, `true`}, sql.MustJSON(`true`), nil},
+		{[]interface{}{`{"a": 1, "b": 2}`, `{"a": 3, "c": 4}`}, sql.MustJSON(`{"a": 3, "b": 2, "c": 4}`), nil},
+		{[]interface{}{`{"a": 1, "b": 2}`, `{"b": null}`}, sql.MustJSON(`{"a": 1}`), nil},
+		{[]interface{}{`{"a": {"x": 1}}`, `{"a": {"y": 2}}`}, sql.MustJSON(`{"a": {"x": 1, "y": 2}}`), nil},
+		{[]interface{}{nil, `{"a": 1}`}, nil, nil},
+		{[]interface{}{`{"a": 1}`, nil, `[1]`}, sql.MustJSON(`[1]`), nil},
+		{[]interface{}{`{"a": 1}`, nil}, nil, nil},
+	})
+}
@@ -0,0 +1,96 @@
+package function
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// JSONObject creates a JSON object out of a list of key and value pairs.
+type JSONObject struct {
+	jsonFunc
+}
+
+var _ sql.FunctionExpression = (*JSONObject)(nil)
+
+// NewJSONObject creates a new JSONObject UDF.
+func NewJSONObject(args ...sql.Expression) (sql.Expression, error) {
+	if len(args)%2 != 0 {
+		return nil, sql.ErrInvalidArgumentNumber.New("JSON_OBJECT", "an even number of", len(args))
+	}
+	return &JSONObject{jsonFunc{"json_object", args}}, nil
+}
+
+// Type implements the sql.Expression interface.
+func (*JSONObject) Type() sql.Type { return sql.JSON }
+
+// IsNullable implements the sql.Expression interface.
+func (*JSONObject) IsNullable() bool { return false }
+
+// WithChildren implements the Expression interface.
+func (*JSONObject) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewJSONObject(children...)
+}
+
+// Eval implements the sql.Expression interface.
+func (j *JSONObject) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	obj := make(map[string]interface{}, len(j.args)/2)
+	for i := 0; i < len(j.args); i += 2 {
+		key, err := j.args[i].Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if key == nil {
+			return nil, sql.ErrJSONNullKey.New()
+		}
+
+		key, err = sql.LongText.Convert(key)
+		if err != nil {
+			return nil, err
+		}
+
+		val, err := evalJSONValue(ctx, j.args[i+1], row)
+		if err != nil {
+			return nil, err
+		}
+
+		obj[key.(string)] = val
+	}
+
+	return sql.JSONDocument{Val: obj}, nil
+}
+
+// JSONArray creates a JSON array out of a list of values.
+type JSONArray struct {
+	jsonFunc
+}
+
+var _ sql.FunctionExpression = (*JSONArray)(nil)
+
+// NewJSONArray creates a new JSONArray UDF.
+func NewJSONArray(args ...sql.Expression) (sql.Expression, error) {
+	return &JSONArray{jsonFunc{"json_array", args}}, nil
+}
+
+// Type implements the sql.Expression interface.
+func (*JSONArray) Type() sql.Type { return sql.JSON }
+
+// IsNullable implements the sql.Expression interface.
+func (*JSONArray) IsNullable() bool { return false }
+
+// WithChildren implements the Expression interface.
+func (*JSONArray) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewJSONArray(children...)
+}
+
+// Eval implements the sql.Expression interface.
+func (j *JSONArray) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	arr := make([]interface{}, len(j.args))
+	for i, arg := range j.args {
+		val, err := evalJSONValue(ctx, arg, row)
+		if err != nil {
+			return nil, err
+		}
+		arr[i] = val
+	}
+
+	return sql.JSONDocument{Val: arr}, nil
+}
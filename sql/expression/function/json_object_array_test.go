@@ -0,0 +1,27 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestJSONObject(t *testing.T) {
+	testJSONFunction(t, NewJSONObject, []jsonTestCase{
+		{nil, sql.MustJSON(`{}`), nil},
+		{[]interface{}{"id", 87, "name", "carrot"}, sql.MustJSON(`{"id": 87, "name": "carrot"}`), nil},
+		{[]interface{}{"a", nil, "b", true}, sql.MustJSON(`{"a": null, "b": true}`), nil},
+		{[]interface{}{"a", "[1]"}, sql.MustJSON(`{"a": "[1]"}`), nil},
+		{[]interface{}{"a", sql.MustJSON(`[1]`)}, sql.MustJSON(`{"a": [1]}`), nil},
+		{[]interface{}{"a", 1, "a", 2}, sql.MustJSON(`{"a": 2}`), nil},
+		{[]interface{}{nil, 1}, nil, sql.ErrJSONNullKey},
+	})
+}
+
+func TestJSONArray(t *testing.T) {
+	testJSONFunction(t, NewJSONArray, []jsonTestCase{
+		{nil, sql.MustJSON(`[]`), nil},
+		{[]interface{}{1, "abc", nil, true}, sql.MustJSON(`[1, "abc", null, true]`), nil},
+		{[]interface{}{1.5, sql.MustJSON(`{"a": 1}`)}, sql.MustJSON(`[1.5, {"a": 1}]`), nil},
+	})
+}
@@ -0,0 +1,55 @@
+package function
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// JSONQuote quotes a string as a JSON string.
+type JSONQuote struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*JSONQuote)(nil)
+
+// NewJSONQuote creates a new JSONQuote UDF.
+func NewJSONQuote(arg sql.Expression) sql.Expression {
+	return &JSONQuote{expression.UnaryExpression{Child: arg}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (*JSONQuote) FunctionName() string {
+	return "json_quote"
+}
+
+func (j *JSONQuote) String() string {
+	return fmt.Sprintf("JSON_QUOTE(%s)", j.Child)
+}
+
+// Type implements the sql.Expression interface.
+func (*JSONQuote) Type() sql.Type { return sql.LongText }
+
+// WithChildren implements the Expression interface.
+func (j *JSONQuote) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), 1)
+	}
+	return NewJSONQuote(children[0]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (j *JSONQuote) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	v, err := j.Child.Eval(ctx, row)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	s, err := sql.LongText.Convert(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return sql.JSONDocument{Val: s}.String(), nil
+}
@@ -0,0 +1,20 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestJSONQuote(t *testing.T) {
+	newJSONQuote := func(args ...sql.Expression) (sql.Expression, error) {
+		return NewJSONQuote(args[0]), nil
+	}
+	testJSONFunction(t, newJSONQuote, []jsonTestCase{
+		{[]interface{}{`null`}, `"null"`, nil},
+		{[]interface{}{`"null"`}, `"\"null\""`, nil},
+		{[]interface{}{`[1, 2, 3]`}, `"[1, 2, 3]"`, nil},
+		{[]interface{}{"a\tb\n"}, `"a\tb\n"`, nil},
+		{[]interface{}{nil}, nil, nil},
+	})
+}
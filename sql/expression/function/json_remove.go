@@ -0,0 +1,74 @@
+package function
+
+import (
+	errors "gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// ErrJSONRootPath is returned when the root of a document is given as the path
+// of a value to remove.
+var ErrJSONRootPath = errors.NewKind("The path expression '$' is not allowed in this context")
+
+// JSONRemove removes values from a JSON document.
+type JSONRemove struct {
+	jsonFunc
+}
+
+var _ sql.FunctionExpression = (*JSONRemove)(nil)
+
+// NewJSONRemove creates a new JSONRemove UDF.
+func NewJSONRemove(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New("JSON_REMOVE", "2 or more", len(args))
+	}
+	return &JSONRemove{jsonFunc{"json_remove", args}}, nil
+}
+
+// Type implements the sql.Expression interface.
+func (*JSONRemove) Type() sql.Type { return sql.JSON }
+
+// WithChildren implements the Expression interface.
+func (*JSONRemove) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewJSONRemove(children...)
+}
+
+// Eval implements the sql.Expression interface.
+func (j *JSONRemove) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	doc, ok, err := evalJSON(ctx, j.args[0], row)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	result := sql.CopyJSON(doc.Val)
+	for _, arg := range j.args[1:] {
+		path, err := evalJSONPathNoWildcards(ctx, arg, row)
+		if err != nil || path == nil {
+			return nil, err
+		}
+
+		if path.IsRoot() {
+			return nil, ErrJSONRootPath.New()
+		}
+
+		result = path.UpdateParent(result, func(parent interface{}) (interface{}, bool) {
+			switch parent := parent.(type) {
+			case map[string]interface{}:
+				if key, ok := path.LastMember(); ok {
+					delete(parent, key)
+				}
+				return parent, true
+			case []interface{}:
+				i, ok := path.LastArrayCell(len(parent))
+				if !ok || i < 0 || i >= len(parent) {
+					return parent, true
+				}
+				return append(parent[:i], parent[i+1:]...), true
+			default:
+				return parent, false
+			}
+		})
+	}
+
+	return sql.JSONDocument{Val: result}, nil
+}
@@ -0,0 +1,19 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestJSONRemove(t *testing.T) {
+	testJSONFunction(t, NewJSONRemove, []jsonTestCase{
+		{[]interface{}{`["a", ["b", "c"], "d"]`, "$[1]"}, sql.MustJSON(`["a", "d"]`), nil},
+		{[]interface{}{`{"a": 1, "b": {"c": 2}}`, "$.b.c", "$.a"}, sql.MustJSON(`{"b": {}}`), nil},
+		{[]interface{}{`{"a": 1}`, "$.b"}, sql.MustJSON(`{"a": 1}`), nil},
+		{[]interface{}{`[1, 2, 3]`, "$[0]", "$[0]"}, sql.MustJSON(`[3]`), nil},
+		{[]interface{}{`[1, 2]`, nil}, nil, nil},
+		{[]interface{}{`[1, 2]`, "$"}, nil, ErrJSONRootPath},
+		{[]interface{}{`[1, 2]`, "$[*]"}, nil, sql.ErrJSONPathWildcard},
+	})
+}
@@ -0,0 +1,188 @@
+package function
+
+import (
+	"unicode/utf8"
+
+	errors "gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// ErrInvalidEscapeChar is returned when the escape character of a pattern is
+// more than one character long.
+var ErrInvalidEscapeChar = errors.NewKind("Incorrect arguments to ESCAPE: %s")
+
+// JSONSearch returns the paths to the strings of a JSON document that match a
+// LIKE pattern. Depending on its second argument, it returns the path to the
+// first match or to all of them.
+type JSONSearch struct {
+	jsonFunc
+}
+
+var _ sql.FunctionExpression = (*JSONSearch)(nil)
+
+// NewJSONSearch creates a new JSONSearch UDF.
+func NewJSONSearch(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 3 {
+		return nil, sql.ErrInvalidArgumentNumber.New("JSON_SEARCH", "3 or more", len(args))
+	}
+	return &JSONSearch{jsonFunc{"json_search", args}}, nil
+}
+
+// Type implements the sql.Expression interface.
+func (*JSONSearch) Type() sql.Type { return sql.JSON }
+
+// WithChildren implements the Expression interface.
+func (*JSONSearch) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewJSONSearch(children...)
+}
+
+// Eval implements the sql.Expression interface.
+func (j *JSONSearch) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	doc, ok, err := evalJSON(ctx, j.args[0], row)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	all, ok, err := evalOneOrAll(ctx, j.name, j.args[1], row)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	search, err := j.args[2].Eval(ctx, row)
+	if err != nil || search == nil {
+		return nil, err
+	}
+	search, err = sql.LongText.Convert(search)
+	if err != nil {
+		return nil, err
+	}
+
+	escape := '\\'
+	if len(j.args) > 3 {
+		e, err := j.args[3].Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if e != nil {
+			e, err = sql.LongText.Convert(e)
+			if err != nil {
+				return nil, err
+			}
+			s := e.(string)
+			switch utf8.RuneCountInString(s) {
+			case 0:
+			case 1:
+				escape, _ = utf8.DecodeRuneInString(s)
+			default:
+				return nil, ErrInvalidEscapeChar.New(s)
+			}
+		}
+	}
+
+	paths := []*sql.JSONPath{nil}
+	if len(j.args) > 4 {
+		paths = paths[:0]
+		for _, arg := range j.args[4:] {
+			path, err := evalJSONPath(ctx, arg, row)
+			if err != nil || path == nil {
+				return nil, err
+			}
+			paths = append(paths, path)
+		}
+	}
+
+	pattern := compileLikePattern(search.(string), escape)
+	var (
+		found []interface{}
+		seen  = make(map[string]bool)
+	)
+	for _, path := range paths {
+		if path == nil {
+			path, _ = sql.ParseJSONPath("$")
+		}
+
+		path.WalkAll(doc.Val, func(p string, v interface{}) {
+			s, ok := v.(string)
+			if !ok || seen[p] || !pattern.match(s) {
+				return
+			}
+			seen[p] = true
+			found = append(found, p)
+		})
+
+		if len(found) > 0 && !all {
+			break
+		}
+	}
+
+	switch {
+	case len(found) == 0:
+		return nil, nil
+	case len(found) == 1 || !all:
+		return sql.JSONDocument{Val: found[0]}, nil
+	default:
+		return sql.JSONDocument{Val: found}, nil
+	}
+}
+
+type likeToken struct {
+	r    rune
+	kind byte // 'c' for a character, '_' for any character, '%' for any string
+}
+
+// likePattern is a compiled LIKE pattern that matches strings byte by byte.
+type likePattern []likeToken
+
+func compileLikePattern(pattern string, escape rune) likePattern {
+	var tokens likePattern
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			tokens = append(tokens, likeToken{r, 'c'})
+			escaped = false
+		case r == escape:
+			escaped = true
+		case r == '%':
+			tokens = append(tokens, likeToken{r, '%'})
+		case r == '_':
+			tokens = append(tokens, likeToken{r, '_'})
+		default:
+			tokens = append(tokens, likeToken{r, 'c'})
+		}
+	}
+	if escaped {
+		tokens = append(tokens, likeToken{escape, 'c'})
+	}
+	return tokens
+}
+
+func (p likePattern) match(s string) bool {
+	str := []rune(s)
+	var (
+		si, pi         int
+		starPi, starSi = -1, 0
+	)
+	for si < len(str) {
+		switch {
+		case pi < len(p) && p[pi].kind == '%':
+			starPi, starSi = pi, si
+			pi++
+		case pi < len(p) && (p[pi].kind == '_' || p[pi].r == str[si]):
+			si++
+			pi++
+		case starPi >= 0:
+			starSi++
+			si = starSi
+			pi = starPi + 1
+		default:
+			return false
+		}
+	}
+
+	for pi < len(p) && p[pi].kind == '%' {
+		pi++
+	}
+	return pi == len(p)
+}
@@ -0,0 +1,30 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+const jsonSearchDoc = `["abc", [{"k": "10"}, "def"], {"x":"abc"}, {"y":"bcd"}]`
+
+func TestJSONSearch(t *testing.T) {
+	testJSONFunction(t, NewJSONSearch, []jsonTestCase{
+		{[]interface{}{jsonSearchDoc, "one", "abc"}, sql.MustJSON(`"$[0]"`), nil},
+		{[]interface{}{jsonSearchDoc, "all", "abc"}, sql.MustJSON(`["$[0]", "$[2].x"]`), nil},
+		{[]interface{}{jsonSearchDoc, "all", "ghi"}, nil, nil},
+		{[]interface{}{jsonSearchDoc, "all", "10"}, sql.MustJSON(`"$[1][0].k"`), nil},
+		{[]interface{}{jsonSearchDoc, "all", "10", nil, "$"}, sql.MustJSON(`"$[1][0].k"`), nil},
+		{[]interface{}{jsonSearchDoc, "all", "10", nil, "$[*]"}, sql.MustJSON(`"$[1][0].k"`), nil},
+		{[]interface{}{jsonSearchDoc, "all", "10", nil, "$[*][0].k"}, sql.MustJSON(`"$[1][0].k"`), nil},
+		{[]interface{}{jsonSearchDoc, "all", "10", nil, "$[1][*]"}, sql.MustJSON(`"$[1][0].k"`), nil},
+		{[]interface{}{jsonSearchDoc, "all", "10", nil, "$[3]"}, nil, nil},
+		{[]interface{}{jsonSearchDoc, "all", "%a%"}, sql.MustJSON(`["$[0]", "$[2].x"]`), nil},
+		{[]interface{}{jsonSearchDoc, "all", "%b%"}, sql.MustJSON(`["$[0]", "$[2].x", "$[3].y"]`), nil},
+		{[]interface{}{jsonSearchDoc, "all", "_b_"}, sql.MustJSON(`["$[0]", "$[2].x"]`), nil},
+		{[]interface{}{`["a%c", "abc"]`, "all", "a|%c", "|"}, sql.MustJSON(`"$[0]"`), nil},
+		{[]interface{}{jsonSearchDoc, "all", nil}, nil, nil},
+		{[]interface{}{jsonSearchDoc, "any", "abc"}, nil, ErrJSONOneOrAll},
+		{[]interface{}{jsonSearchDoc, "all", "abc", "ab"}, nil, ErrInvalidEscapeChar},
+	})
+}
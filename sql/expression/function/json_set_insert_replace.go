@@ -0,0 +1,79 @@
+package function
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// JSONSet sets values in a JSON document. Depending on the function, values
+// that already exist are replaced, values that don't exist are inserted, or
+// both.
+type JSONSet struct {
+	jsonFunc
+	insert  bool
+	replace bool
+}
+
+var _ sql.FunctionExpression = (*JSONSet)(nil)
+
+func newJSONSet(name string, insert, replace bool, args []sql.Expression) (sql.Expression, error) {
+	if len(args) < 3 || len(args)%2 == 0 {
+		return nil, sql.ErrInvalidArgumentNumber.New(name, "an odd number (3 or more) of", len(args))
+	}
+	return &JSONSet{jsonFunc{name, args}, insert, replace}, nil
+}
+
+// NewJSONSet creates a new JSON_SET UDF, which inserts or replaces values.
+func NewJSONSet(args ...sql.Expression) (sql.Expression, error) {
+	return newJSONSet("json_set", true, true, args)
+}
+
+// NewJSONInsert creates a new JSON_INSERT UDF, which only inserts values that
+// don't exist.
+func NewJSONInsert(args ...sql.Expression) (sql.Expression, error) {
+	return newJSONSet("json_insert", true, false, args)
+}
+
+// NewJSONReplace creates a new JSON_REPLACE UDF, which only replaces values
+// that exist.
+func NewJSONReplace(args ...sql.Expression) (sql.Expression, error) {
+	return newJSONSet("json_replace", false, true, args)
+}
+
+// Type implements the sql.Expression interface.
+func (*JSONSet) Type() sql.Type { return sql.JSON }
+
+// WithChildren implements the Expression interface.
+func (j *JSONSet) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return newJSONSet(j.name, j.insert, j.replace, children)
+}
+
+// Eval implements the sql.Expression interface.
+func (j *JSONSet) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	doc, ok, err := evalJSON(ctx, j.args[0], row)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	result := sql.CopyJSON(doc.Val)
+	for i := 1; i < len(j.args); i += 2 {
+		path, err := evalJSONPathNoWildcards(ctx, j.args[i], row)
+		if err != nil || path == nil {
+			return nil, err
+		}
+
+		val, err := evalJSONValue(ctx, j.args[i+1], row)
+		if err != nil {
+			return nil, err
+		}
+		val = sql.CopyJSON(val)
+
+		result = path.Update(result, func(_ interface{}, exists bool) (interface{}, bool) {
+			if exists {
+				return val, j.replace
+			}
+			return val, j.insert
+		})
+	}
+
+	return sql.JSONDocument{Val: result}, nil
+}
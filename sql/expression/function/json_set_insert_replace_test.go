@@ -0,0 +1,37 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+const jsonSetDoc = `{"a": 1, "b": [2, 3]}`
+
+func TestJSONSet(t *testing.T) {
+	testJSONFunction(t, NewJSONSet, []jsonTestCase{
+		{[]interface{}{jsonSetDoc, "$.a", 10, "$.c", "[true, false]"}, sql.MustJSON(`{"a": 10, "b": [2, 3], "c": "[true, false]"}`), nil},
+		{[]interface{}{jsonSetDoc, "$.b[5]", 4}, sql.MustJSON(`{"a": 1, "b": [2, 3, 4]}`), nil},
+		{[]interface{}{jsonSetDoc, "$.c.d", 4}, sql.MustJSON(jsonSetDoc), nil},
+		{[]interface{}{jsonSetDoc, "$", 4}, sql.MustJSON(`4`), nil},
+		{[]interface{}{nil, "$.a", 4}, nil, nil},
+		{[]interface{}{jsonSetDoc, "$.a", nil}, sql.MustJSON(`{"a": null, "b": [2, 3]}`), nil},
+		{[]interface{}{jsonSetDoc, "$.*", 4}, nil, sql.ErrJSONPathWildcard},
+		{[]interface{}{jsonSetDoc, "a", 4}, nil, sql.ErrInvalidJSONPath},
+	})
+}
+
+func TestJSONInsert(t *testing.T) {
+	testJSONFunction(t, NewJSONInsert, []jsonTestCase{
+		{[]interface{}{jsonSetDoc, "$.a", 10, "$.c", "[true, false]"}, sql.MustJSON(`{"a": 1, "b": [2, 3], "c": "[true, false]"}`), nil},
+		{[]interface{}{jsonSetDoc, "$.b[0]", 4, "$.b[2]", 5}, sql.MustJSON(`{"a": 1, "b": [2, 3, 5]}`), nil},
+		{[]interface{}{"1", "$[1]", 2}, sql.MustJSON(`[1, 2]`), nil},
+	})
+}
+
+func TestJSONReplace(t *testing.T) {
+	testJSONFunction(t, NewJSONReplace, []jsonTestCase{
+		{[]interface{}{jsonSetDoc, "$.a", 10, "$.c", "[true, false]"}, sql.MustJSON(`{"a": 10, "b": [2, 3]}`), nil},
+		{[]interface{}{jsonSetDoc, "$.b[last]", 4, "$.b[2]", 5}, sql.MustJSON(`{"a": 1, "b": [2, 4]}`), nil},
+	})
+}
@@ -0,0 +1,100 @@
+package function
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// JSONType returns the type of a JSON value, such as OBJECT, ARRAY, STRING or
+// INTEGER.
+type JSONType struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*JSONType)(nil)
+
+// NewJSONType creates a new JSONType UDF.
+func NewJSONType(arg sql.Expression) sql.Expression {
+	return &JSONType{expression.UnaryExpression{Child: arg}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (*JSONType) FunctionName() string {
+	return "json_type"
+}
+
+func (j *JSONType) String() string {
+	return fmt.Sprintf("JSON_TYPE(%s)", j.Child)
+}
+
+// Type implements the sql.Expression interface.
+func (*JSONType) Type() sql.Type { return sql.LongText }
+
+// WithChildren implements the Expression interface.
+func (j *JSONType) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), 1)
+	}
+	return NewJSONType(children[0]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (j *JSONType) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	doc, ok, err := evalJSON(ctx, j.Child, row)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return sql.JSONTypeName(doc.Val), nil
+}
+
+// JSONValid returns whether a value is a valid JSON document.
+type JSONValid struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*JSONValid)(nil)
+
+// NewJSONValid creates a new JSONValid UDF.
+func NewJSONValid(arg sql.Expression) sql.Expression {
+	return &JSONValid{expression.UnaryExpression{Child: arg}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (*JSONValid) FunctionName() string {
+	return "json_valid"
+}
+
+func (j *JSONValid) String() string {
+	return fmt.Sprintf("JSON_VALID(%s)", j.Child)
+}
+
+// Type implements the sql.Expression interface.
+func (*JSONValid) Type() sql.Type { return sql.Boolean }
+
+// WithChildren implements the Expression interface.
+func (j *JSONValid) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(j, len(children), 1)
+	}
+	return NewJSONValid(children[0]), nil
+}
+
+// Eval implements the sql.Expression interface.
+func (j *JSONValid) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	v, err := j.Child.Eval(ctx, row)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	switch v.(type) {
+	case sql.JSONDocument:
+		return true, nil
+	case string, []byte:
+		_, err := sql.JSON.Convert(v)
+		return err == nil, nil
+	default:
+		return false, nil
+	}
+}
@@ -0,0 +1,38 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestJSONType(t *testing.T) {
+	newJSONType := func(args ...sql.Expression) (sql.Expression, error) {
+		return NewJSONType(args[0]), nil
+	}
+	testJSONFunction(t, newJSONType, []jsonTestCase{
+		{[]interface{}{`{"a": [10, true]}`}, "OBJECT", nil},
+		{[]interface{}{`[10, true]`}, "ARRAY", nil},
+		{[]interface{}{`true`}, "BOOLEAN", nil},
+		{[]interface{}{`null`}, "NULL", nil},
+		{[]interface{}{`"abc"`}, "STRING", nil},
+		{[]interface{}{`1`}, "INTEGER", nil},
+		{[]interface{}{`1.5`}, "DOUBLE", nil},
+		{[]interface{}{`18446744073709551615`}, "UNSIGNED INTEGER", nil},
+		{[]interface{}{nil}, nil, nil},
+		{[]interface{}{`abc`}, nil, sql.ErrInvalidJSONText},
+	})
+}
+
+func TestJSONValid(t *testing.T) {
+	newJSONValid := func(args ...sql.Expression) (sql.Expression, error) {
+		return NewJSONValid(args[0]), nil
+	}
+	testJSONFunction(t, newJSONValid, []jsonTestCase{
+		{[]interface{}{`{"a": 1}`}, true, nil},
+		{[]interface{}{`hello`}, false, nil},
+		{[]interface{}{`"hello"`}, true, nil},
+		{[]interface{}{``}, false, nil},
+		{[]interface{}{nil}, nil, nil},
+	})
+}
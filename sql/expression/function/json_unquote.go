@@ -55,6 +55,14 @@ func (js *JSONUnquote) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 		return json, err
 	}
 
+	// A JSON string is unquoted as is, any other JSON value becomes its text.
+	if doc, ok := json.(sql.JSONDocument); ok {
+		if str, ok := doc.Val.(string); ok {
+			return str, nil
+		}
+		return doc.String(), nil
+	}
+
 	ex, err := sql.LongText.Convert(json)
 	if err != nil {
 		return nil, err
@@ -78,27 +78,26 @@ func (l *Length) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, nil
 	}
 
-	var content string
-	switch l.Child.Type() {
-	case sql.TinyBlob, sql.Blob, sql.MediumBlob, sql.LongBlob:
-		val, err = sql.LongBlob.Convert(val)
-		if err != nil {
-			return nil, err
-		}
-
-		content = val.(string)
-	default:
-		val, err = sql.LongText.Convert(val)
-		if err != nil {
-			return nil, err
-		}
-
-		content = val.(string)
+	val, err = sql.LongText.Convert(val)
+	if err != nil {
+		return nil, err
 	}
+	content := val.(string)
 
-	if l.CountType == NumBytes {
+	// Strings are kept as UTF-8, so their length in bytes depends on the character set they're stored in. Binary
+	// strings are kept as the bytes they're made of, and each one of them counts as a character.
+	charset := sql.Collation_Default.CharacterSet()
+	if st, ok := l.Child.Type().(sql.StringType); ok {
+		charset = st.CharacterSet()
+	}
+
+	if charset == sql.CharacterSet_binary {
 		return int32(len(content)), nil
 	}
 
+	if l.CountType == NumBytes {
+		return int32(charset.ByteLength(content)), nil
+	}
+
 	return int32(utf8.RuneCountInString(content)), nil
 }
@@ -45,6 +45,20 @@ func TestLength(t *testing.T) {
 			NewLength,
 			int32(0),
 		},
+		{
+			"length latin1",
+			"fóo",
+			sql.CreateText(sql.Collation_latin1_swedish_ci),
+			NewLength,
+			int32(3),
+		},
+		{
+			"length utf16",
+			"fóo",
+			sql.CreateText(sql.Collation_utf16_general_ci),
+			NewLength,
+			int32(6),
+		},
 		{
 			"length nil",
 			nil,
@@ -64,6 +78,13 @@ func TestLength(t *testing.T) {
 			[]byte("fóo"),
 			sql.Blob,
 			NewCharLength,
+			int32(4),
+		},
+		{
+			"char_length latin1",
+			"fóo",
+			sql.CreateText(sql.Collation_latin1_swedish_ci),
+			NewCharLength,
 			int32(3),
 		},
 		{
@@ -0,0 +1,125 @@
+package function
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// Locate is a function that returns the position of the first occurrence of a substring in a string, starting the
+// search at an optional position. Positions are counted in characters, from 1, and 0 is returned if the substring
+// isn't found. POSITION(substr IN str) is a synonym of LOCATE(substr, str).
+type Locate struct {
+	substr sql.Expression
+	str    sql.Expression
+	pos    sql.Expression
+}
+
+var _ sql.FunctionExpression = (*Locate)(nil)
+
+// NewLocate creates a new Locate expression.
+func NewLocate(args ...sql.Expression) (sql.Expression, error) {
+	switch len(args) {
+	case 2:
+		return &Locate{args[0], args[1], nil}, nil
+	case 3:
+		return &Locate{args[0], args[1], args[2]}, nil
+	default:
+		return nil, sql.ErrInvalidArgumentNumber.New("LOCATE", "2 or 3", len(args))
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (l *Locate) FunctionName() string {
+	return "locate"
+}
+
+// Children implements the Expression interface.
+func (l *Locate) Children() []sql.Expression {
+	if l.pos == nil {
+		return []sql.Expression{l.substr, l.str}
+	}
+	return []sql.Expression{l.substr, l.str, l.pos}
+}
+
+// Resolved implements the Expression interface.
+func (l *Locate) Resolved() bool {
+	return l.substr.Resolved() && l.str.Resolved() && (l.pos == nil || l.pos.Resolved())
+}
+
+// IsNullable implements the Expression interface.
+func (l *Locate) IsNullable() bool {
+	return l.substr.IsNullable() || l.str.IsNullable() || (l.pos != nil && l.pos.IsNullable())
+}
+
+// Type implements the Expression interface.
+func (l *Locate) Type() sql.Type { return sql.Int64 }
+
+func (l *Locate) String() string {
+	if l.pos == nil {
+		return fmt.Sprintf("LOCATE(%s, %s)", l.substr, l.str)
+	}
+	return fmt.Sprintf("LOCATE(%s, %s, %s)", l.substr, l.str, l.pos)
+}
+
+// WithChildren implements the Expression interface.
+func (l *Locate) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewLocate(children...)
+}
+
+// Eval implements the Expression interface.
+func (l *Locate) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	substr, err := evalString(ctx, l.substr, row)
+	if substr == nil || err != nil {
+		return nil, err
+	}
+
+	str, err := evalString(ctx, l.str, row)
+	if str == nil || err != nil {
+		return nil, err
+	}
+
+	pos := int64(1)
+	if l.pos != nil {
+		p, err := l.pos.Eval(ctx, row)
+		if p == nil || err != nil {
+			return nil, err
+		}
+
+		p, err = sql.Int64.Convert(p)
+		if err != nil {
+			return nil, err
+		}
+		pos = p.(int64)
+	}
+
+	collation := argsCollation(l.substr, l.str)
+	binary := collation == sql.Collation_binary
+	text := characters(collation.Fold(str.(string)), binary)
+	subtext := characters(collation.Fold(substr.(string)), binary)
+
+	if pos < 1 || pos > int64(len(text))+1 {
+		return int64(0), nil
+	}
+
+	idx := indexOfCharacters(text[pos-1:], subtext)
+	if idx < 0 {
+		return int64(0), nil
+	}
+	return pos + int64(idx), nil
+}
+
+// indexOfCharacters returns the index of the first occurrence of the characters of subtext in text, or -1 if there's
+// none.
+func indexOfCharacters(text, subtext []string) int {
+	for i := 0; i <= len(text)-len(subtext); i++ {
+		j := 0
+		for j < len(subtext) && text[i+j] == subtext[j] {
+			j++
+		}
+		if j == len(subtext) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,52 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestLocate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		typ      sql.Type
+		row      sql.Row
+		expected interface{}
+	}{
+		{"null substr", sql.LongText, sql.NewRow(nil, "foobar", 1), nil},
+		{"null string", sql.LongText, sql.NewRow("bar", nil, 1), nil},
+		{"null position", sql.LongText, sql.NewRow("bar", "foobar", nil), nil},
+		{"match", sql.LongText, sql.NewRow("bar", "foobarbar", 1), int64(4)},
+		{"match after position", sql.LongText, sql.NewRow("bar", "foobarbar", 5), int64(7)},
+		{"no match", sql.LongText, sql.NewRow("xbar", "foobar", 1), int64(0)},
+		{"position 0", sql.LongText, sql.NewRow("bar", "foobar", 0), int64(0)},
+		{"position after string", sql.LongText, sql.NewRow("", "foo", 5), int64(0)},
+		{"empty substr", sql.LongText, sql.NewRow("", "foo", 4), int64(4)},
+		{"multibyte characters", sql.LongText, sql.NewRow("b", "ñañb", 1), int64(4)},
+		{"case insensitive", sql.LongText, sql.NewRow("BAR", "foobar", 1), int64(4)},
+		{"case sensitive", sql.CreateLongText(sql.Collation_utf8mb4_bin), sql.NewRow("BAR", "foobar", 1), int64(0)},
+		{"binary", sql.LongBlob, sql.NewRow("b", "ñañb", 1), int64(6)},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			f, err := NewLocate(
+				expression.NewGetField(0, tt.typ, "substr", true),
+				expression.NewGetField(1, tt.typ, "str", true),
+				expression.NewGetField(2, sql.Int64, "pos", true),
+			)
+			require.NoError(err)
+
+			v, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+
+	_, err := NewLocate(expression.NewLiteral("a", sql.LongText))
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+}
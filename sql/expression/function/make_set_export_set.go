@@ -0,0 +1,213 @@
+package function
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// MakeSet is a function that returns the comma separated list of the strings whose bit is set in its first argument,
+// the first string standing for the lowest bit. NULL strings are skipped.
+type MakeSet struct {
+	args []sql.Expression
+}
+
+var _ sql.FunctionExpression = (*MakeSet)(nil)
+
+// NewMakeSet creates a new MakeSet expression.
+func NewMakeSet(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New("MAKE_SET", "2 or more", len(args))
+	}
+	return &MakeSet{args}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (m *MakeSet) FunctionName() string {
+	return "make_set"
+}
+
+// Children implements the Expression interface.
+func (m *MakeSet) Children() []sql.Expression {
+	return m.args
+}
+
+// Resolved implements the Expression interface.
+func (m *MakeSet) Resolved() bool {
+	return argsResolved(m.args)
+}
+
+// IsNullable implements the Expression interface.
+func (m *MakeSet) IsNullable() bool {
+	return m.args[0].IsNullable()
+}
+
+// Type implements the Expression interface.
+func (m *MakeSet) Type() sql.Type { return sql.LongText }
+
+func (m *MakeSet) String() string {
+	return fmt.Sprintf("MAKE_SET(%s)", joinArgs(m.args))
+}
+
+// WithChildren implements the Expression interface.
+func (m *MakeSet) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewMakeSet(children...)
+}
+
+// Eval implements the Expression interface.
+func (m *MakeSet) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	bits, err := evalBits(ctx, m.args[0], row)
+	if bits == nil || err != nil {
+		return nil, err
+	}
+
+	var strs []string
+	for i, arg := range m.args[1:] {
+		if i >= 64 {
+			break
+		}
+		if *bits&(1<<uint(i)) == 0 {
+			continue
+		}
+
+		str, err := evalString(ctx, arg, row)
+		if err != nil {
+			return nil, err
+		}
+		if str != nil {
+			strs = append(strs, str.(string))
+		}
+	}
+
+	return strings.Join(strs, ","), nil
+}
+
+// ExportSet is a function that returns, for each bit of its first argument from the lowest one, the on string if
+// it's set or the off string otherwise, joined by a separator, which is a comma by default. The number of bits
+// examined is 64 unless a lower one is given.
+type ExportSet struct {
+	args []sql.Expression
+}
+
+var _ sql.FunctionExpression = (*ExportSet)(nil)
+
+// NewExportSet creates a new ExportSet expression.
+func NewExportSet(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 3 || len(args) > 5 {
+		return nil, sql.ErrInvalidArgumentNumber.New("EXPORT_SET", "3, 4 or 5", len(args))
+	}
+	return &ExportSet{args}, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (e *ExportSet) FunctionName() string {
+	return "export_set"
+}
+
+// Children implements the Expression interface.
+func (e *ExportSet) Children() []sql.Expression {
+	return e.args
+}
+
+// Resolved implements the Expression interface.
+func (e *ExportSet) Resolved() bool {
+	return argsResolved(e.args)
+}
+
+// IsNullable implements the Expression interface.
+func (e *ExportSet) IsNullable() bool {
+	for _, arg := range e.args {
+		if arg.IsNullable() {
+			return true
+		}
+	}
+	return false
+}
+
+// Type implements the Expression interface.
+func (e *ExportSet) Type() sql.Type { return sql.LongText }
+
+func (e *ExportSet) String() string {
+	return fmt.Sprintf("EXPORT_SET(%s)", joinArgs(e.args))
+}
+
+// WithChildren implements the Expression interface.
+func (e *ExportSet) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewExportSet(children...)
+}
+
+// Eval implements the Expression interface.
+func (e *ExportSet) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	bits, err := evalBits(ctx, e.args[0], row)
+	if bits == nil || err != nil {
+		return nil, err
+	}
+
+	on, err := evalString(ctx, e.args[1], row)
+	if on == nil || err != nil {
+		return nil, err
+	}
+
+	off, err := evalString(ctx, e.args[2], row)
+	if off == nil || err != nil {
+		return nil, err
+	}
+
+	separator := interface{}(",")
+	if len(e.args) > 3 {
+		separator, err = evalString(ctx, e.args[3], row)
+		if separator == nil || err != nil {
+			return nil, err
+		}
+	}
+
+	numBits := int64(64)
+	if len(e.args) > 4 {
+		n, err := e.args[4].Eval(ctx, row)
+		if n == nil || err != nil {
+			return nil, err
+		}
+
+		n, err = sql.Int64.Convert(n)
+		if err != nil {
+			return nil, err
+		}
+		if n := n.(int64); n >= 0 && n < 64 {
+			numBits = n
+		}
+	}
+
+	strs := make([]string, numBits)
+	for i := range strs {
+		if *bits&(1<<uint(i)) != 0 {
+			strs[i] = on.(string)
+		} else {
+			strs[i] = off.(string)
+		}
+	}
+
+	return strings.Join(strs, separator.(string)), nil
+}
+
+// evalBits evaluates the given expression as a set of bits, which is nil if its value is NULL. Negative numbers are
+// taken in two's complement.
+func evalBits(ctx *sql.Context, e sql.Expression, row sql.Row) (*uint64, error) {
+	val, err := e.Eval(ctx, row)
+	if val == nil || err != nil {
+		return nil, err
+	}
+
+	var bits uint64
+	if u, ok := val.(uint64); ok {
+		bits = u
+	} else {
+		n, err := sql.Int64.Convert(val)
+		if err != nil {
+			return nil, err
+		}
+		bits = uint64(n.(int64))
+	}
+
+	return &bits, nil
+}
@@ -0,0 +1,81 @@
+package function
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestMakeSet(t *testing.T) {
+	f, err := NewMakeSet(
+		expression.NewGetField(0, sql.Int64, "bits", true),
+		expression.NewGetField(1, sql.LongText, "str1", true),
+		expression.NewGetField(2, sql.LongText, "str2", true),
+		expression.NewGetField(3, sql.LongText, "str3", true),
+	)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"null bits", sql.NewRow(nil, "a", "b", "c"), nil},
+		{"one bit", sql.NewRow(1, "a", "b", "c"), "a"},
+		{"many bits", sql.NewRow(1|4, "a", "b", "c"), "a,c"},
+		{"null string", sql.NewRow(1|2|4, "a", nil, "c"), "a,c"},
+		{"no bits", sql.NewRow(0, "a", "b", "c"), ""},
+		{"negative", sql.NewRow(-1, "a", "b", "c"), "a,b,c"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, v)
+		})
+	}
+}
+
+func TestExportSet(t *testing.T) {
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"null bits", sql.NewRow(nil, "Y", "N"), nil},
+		{"null on", sql.NewRow(5, nil, "N"), nil},
+		{"default separator and bits", sql.NewRow(5, "1", "0"), "1,0,1,0" + strings.Repeat(",0", 60)},
+		{"separator", sql.NewRow(5, "Y", "N", "", 4), "YNYN"},
+		{"number of bits", sql.NewRow(6, "1", "0", ",", 10), "0,1,1,0,0,0,0,0,0,0"},
+		{"too many bits", sql.NewRow(1, "1", "0", "", 100), "1" + strings.Repeat("0", 63)},
+		{"null separator", sql.NewRow(5, "Y", "N", nil, 4), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			args := []sql.Expression{
+				expression.NewGetField(0, sql.Int64, "bits", true),
+				expression.NewGetField(1, sql.LongText, "on", true),
+				expression.NewGetField(2, sql.LongText, "off", true),
+			}
+			if len(tt.row) > 3 {
+				args = append(args,
+					expression.NewGetField(3, sql.LongText, "separator", true),
+					expression.NewGetField(4, sql.Int64, "number_of_bits", true),
+				)
+			}
+			f, err := NewExportSet(args...)
+			require.NoError(err)
+
+			v, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package function
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// maxTimeSeconds is the number of seconds of the largest TIME value,
+// 838:59:59. Larger values are clamped to it.
+const maxTimeSeconds = 838*3600 + 59*60 + 59
+
+// secondsToTime returns the TIME value of the given number of seconds, clamped
+// to the range of the type.
+func secondsToTime(seconds float64) (interface{}, error) {
+	seconds = math.Max(-maxTimeSeconds, math.Min(maxTimeSeconds, seconds))
+	micros := math.Round(seconds * float64(time.Second/time.Microsecond))
+	return sql.Time.Convert(time.Duration(micros) * time.Microsecond)
+}
+
+func evalFloat64(ctx *sql.Context, e sql.Expression, row sql.Row) (interface{}, error) {
+	val, err := e.Eval(ctx, row)
+	if val == nil || err != nil {
+		return nil, err
+	}
+	return sql.Float64.Convert(val)
+}
+
+// MakeTime returns the time of an hour, a minute and a second. The seconds
+// can have a fractional part. It returns NULL if the minute or the second are
+// not in the range 0-59.
+type MakeTime struct {
+	hour   sql.Expression
+	minute sql.Expression
+	second sql.Expression
+}
+
+var _ sql.FunctionExpression = (*MakeTime)(nil)
+
+// NewMakeTime creates a new MakeTime UDF.
+func NewMakeTime(hour, minute, second sql.Expression) sql.Expression {
+	return &MakeTime{hour, minute, second}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (m *MakeTime) FunctionName() string {
+	return "maketime"
+}
+
+// Children implements the Expression interface.
+func (m *MakeTime) Children() []sql.Expression {
+	return []sql.Expression{m.hour, m.minute, m.second}
+}
+
+// Resolved implements the Expression interface.
+func (m *MakeTime) Resolved() bool {
+	return m.hour.Resolved() && m.minute.Resolved() && m.second.Resolved()
+}
+
+// IsNullable implements the Expression interface.
+func (m *MakeTime) IsNullable() bool {
+	return true
+}
+
+func (m *MakeTime) String() string {
+	return fmt.Sprintf("MAKETIME(%s, %s, %s)", m.hour, m.minute, m.second)
+}
+
+// Type implements the Expression interface.
+func (m *MakeTime) Type() sql.Type {
+	return sql.Time
+}
+
+// WithChildren implements the Expression interface.
+func (m *MakeTime) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 3 {
+		return nil, sql.ErrInvalidChildrenNumber.New(m, len(children), 3)
+	}
+	return NewMakeTime(children[0], children[1], children[2]), nil
+}
+
+// Eval implements the Expression interface.
+func (m *MakeTime) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	hour, err := evalInt64(ctx, m.hour, row)
+	if hour == nil || err != nil {
+		return nil, err
+	}
+
+	minute, err := evalInt64(ctx, m.minute, row)
+	if minute == nil || err != nil {
+		return nil, err
+	}
+
+	second, err := evalFloat64(ctx, m.second, row)
+	if second == nil || err != nil {
+		return nil, err
+	}
+
+	h, min, s := hour.(int64), minute.(int64), second.(float64)
+	if min < 0 || min > 59 || s < 0 || s >= 60 {
+		return nil, nil
+	}
+
+	// The hour gives the sign of the time.
+	seconds := math.Abs(float64(h))*3600 + float64(min)*60 + s
+	if h < 0 {
+		seconds = -seconds
+	}
+	return secondsToTime(seconds)
+}
+
+// SecToTime returns the time of a number of seconds, which can have a
+// fractional part.
+type SecToTime struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*SecToTime)(nil)
+
+// NewSecToTime creates a new SecToTime UDF.
+func NewSecToTime(seconds sql.Expression) sql.Expression {
+	return &SecToTime{expression.UnaryExpression{Child: seconds}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *SecToTime) FunctionName() string {
+	return "sec_to_time"
+}
+
+func (s *SecToTime) String() string { return fmt.Sprintf("SEC_TO_TIME(%s)", s.Child) }
+
+// Type implements the Expression interface.
+func (s *SecToTime) Type() sql.Type { return sql.Time }
+
+// Eval implements the Expression interface.
+func (s *SecToTime) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	seconds, err := evalFloat64(ctx, s.Child, row)
+	if seconds == nil || err != nil {
+		return nil, err
+	}
+	return secondsToTime(seconds.(float64))
+}
+
+// WithChildren implements the Expression interface.
+func (s *SecToTime) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 1)
+	}
+	return NewSecToTime(children[0]), nil
+}
@@ -0,0 +1,67 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestMakeTime(t *testing.T) {
+	f := NewMakeTime(
+		expression.NewGetField(0, sql.Int64, "hour", true),
+		expression.NewGetField(1, sql.Int64, "minute", true),
+		expression.NewGetField(2, sql.Float64, "second", true),
+	)
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"time", sql.NewRow(12, 15, 30), "12:15:30"},
+		{"fractional seconds", sql.NewRow(12, 15, 30.25), "12:15:30.250000"},
+		{"negative", sql.NewRow(-1, 15, 30), "-01:15:30"},
+		{"more than a day", sql.NewRow(100, 0, 0), "100:00:00"},
+		{"clamped", sql.NewRow(900, 0, 0), "838:59:59"},
+		{"invalid minute", sql.NewRow(12, 60, 0), nil},
+		{"invalid second", sql.NewRow(12, 0, 60), nil},
+		{"null", sql.NewRow(12, nil, 0), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			v, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+}
+
+func TestSecToTime(t *testing.T) {
+	f := NewSecToTime(expression.NewGetField(0, sql.Float64, "seconds", true))
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"seconds", sql.NewRow(2378), "00:39:38"},
+		{"negative", sql.NewRow(-2378), "-00:39:38"},
+		{"fractional", sql.NewRow(1.5), "00:00:01.500000"},
+		{"clamped", sql.NewRow(99999999), "838:59:59"},
+		{"null", sql.NewRow(nil), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			v, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package function
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// Quote is a function that returns a string quoted with single quotes, and with the characters that must be escaped
+// in a string literal escaped, so it can be used as such in a statement. NULL is returned as the word NULL.
+type Quote struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*Quote)(nil)
+
+// NewQuote creates a new Quote expression.
+func NewQuote(e sql.Expression) sql.Expression {
+	return &Quote{expression.UnaryExpression{Child: e}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (q *Quote) FunctionName() string {
+	return "quote"
+}
+
+var quoteReplacer = strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\x00", `\0`, "\x1a", `\Z`)
+
+// Eval implements the Expression interface.
+func (q *Quote) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	str, err := evalString(ctx, q.Child, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if str == nil {
+		return "NULL", nil
+	}
+
+	return "'" + quoteReplacer.Replace(str.(string)) + "'", nil
+}
+
+// IsNullable implements the Expression interface.
+func (q *Quote) IsNullable() bool {
+	return false
+}
+
+func (q *Quote) String() string {
+	return fmt.Sprintf("QUOTE(%s)", q.Child)
+}
+
+// WithChildren implements the Expression interface.
+func (q *Quote) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(q, len(children), 1)
+	}
+	return NewQuote(children[0]), nil
+}
+
+// Type implements the Expression interface.
+func (q *Quote) Type() sql.Type {
+	return sql.LongText
+}
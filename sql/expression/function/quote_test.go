@@ -0,0 +1,34 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestQuote(t *testing.T) {
+	f := NewQuote(expression.NewGetField(0, sql.LongText, "str", true))
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"null", sql.NewRow(nil), "NULL"},
+		{"empty", sql.NewRow(""), "''"},
+		{"quote", sql.NewRow("Don't!"), `'Don\'t!'`},
+		{"backslash", sql.NewRow(`a\b`), `'a\\b'`},
+		{"control characters", sql.NewRow("a\x00b\x1a"), `'a\0b\Z'`},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, v)
+		})
+	}
+}
@@ -0,0 +1,567 @@
+package function
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	errors "gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/internal/regex"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// ErrRegexpIndexOutOfBounds is returned when the position of a regular
+// expression search is not in the text, or when a replacement refers to a
+// group that does not exist.
+var ErrRegexpIndexOutOfBounds = errors.NewKind("Index out of bounds in regular expression search")
+
+// regexpCache holds the matchers of a regular expression whose pattern and
+// match type don't depend on the row, so that the pattern is evaluated and
+// compiled only once. As matchers of some engines can't be used concurrently,
+// each evaluation takes one that isn't in use, or compiles a new one, and
+// gives it back once it's done. Matchers hold resources of the engine, so they
+// are disposed of along with the expression.
+type regexpCache struct {
+	mu        sync.Mutex
+	evaluated bool
+	pattern   *string
+	flags     regex.Flags
+	err       error
+	free      []regexpMatcher
+}
+
+type regexpMatcher struct {
+	matcher  regex.Matcher
+	disposer regex.Disposer
+}
+
+// regexpFunc is the base of the MySQL regular expression functions. Their
+// first two arguments are the text and the pattern, and their last optional
+// argument is the match type.
+type regexpFunc struct {
+	name      string
+	args      []sql.Expression
+	matchType int
+	cache     *regexpCache
+}
+
+func newRegexpFunc(name string, args []sql.Expression, min, max int) (regexpFunc, error) {
+	if len(args) < min || len(args) > max {
+		return regexpFunc{}, sql.ErrInvalidArgumentNumber.New(strings.ToUpper(name), fmt.Sprintf("%d to %d", min, max), len(args))
+	}
+
+	f := regexpFunc{name: name, args: args, matchType: max - 1}
+	if canBeCached(args[1]) && (len(args) < max || canBeCached(args[max-1])) {
+		f.cache = new(regexpCache)
+	}
+	return f, nil
+}
+
+// FunctionName implements sql.FunctionExpression
+func (f *regexpFunc) FunctionName() string {
+	return f.name
+}
+
+// Children implements the sql.Expression interface.
+func (f *regexpFunc) Children() []sql.Expression {
+	return f.args
+}
+
+// Resolved implements the sql.Expression interface.
+func (f *regexpFunc) Resolved() bool {
+	for _, arg := range f.args {
+		if !arg.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsNullable implements the sql.Expression interface.
+func (f *regexpFunc) IsNullable() bool {
+	return true
+}
+
+func (f *regexpFunc) String() string {
+	var args []string
+	for _, arg := range f.args {
+		args = append(args, arg.String())
+	}
+	return fmt.Sprintf("%s(%s)", strings.ToUpper(f.name), strings.Join(args, ", "))
+}
+
+// evalText returns the text argument as a string, or nil if it's NULL.
+func (f *regexpFunc) evalText(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return evalString(ctx, f.args[0], row)
+}
+
+// evalInt returns the integer argument at the given index, or the default
+// value if it was not given. It returns nil if the argument is NULL.
+func (f *regexpFunc) evalInt(ctx *sql.Context, i int, def int64, row sql.Row) (interface{}, error) {
+	if i >= len(f.args) {
+		return def, nil
+	}
+	return evalInt64(ctx, f.args[i], row)
+}
+
+// collation returns the collation of the text, which decides whether the
+// regular expression is case sensitive when the match type doesn't.
+func (f *regexpFunc) collation() sql.Collation {
+	for _, e := range f.args[:2] {
+		if st, ok := e.Type().(sql.StringType); ok {
+			return st.Collation()
+		}
+	}
+	return sql.Collation_Default
+}
+
+// evalPattern returns the pattern and the flags of the regular expression.
+// The pattern is nil if the pattern or the match type are NULL.
+func (f *regexpFunc) evalPattern(ctx *sql.Context, row sql.Row) (*string, regex.Flags, error) {
+	flags := regex.Flags{CaseInsensitive: !f.collation().IsCaseSensitive()}
+
+	pattern, err := evalString(ctx, f.args[1], row)
+	if pattern == nil || err != nil {
+		return nil, flags, err
+	}
+
+	if f.matchType < len(f.args) {
+		matchType, err := evalString(ctx, f.args[f.matchType], row)
+		if matchType == nil || err != nil {
+			return nil, flags, err
+		}
+
+		// When flags contradict each other, the last one wins.
+		for _, c := range matchType.(string) {
+			switch c {
+			case 'c':
+				flags.CaseInsensitive = false
+			case 'i':
+				flags.CaseInsensitive = true
+			case 'm':
+				flags.MultiLine = true
+			case 'n':
+				flags.DotAll = true
+			case 'u':
+				// Only \n is a line terminator in Go, which is what
+				// Unix-only line endings mean.
+			default:
+				return nil, flags, errInvalidRegexpFlag.New(string(c))
+			}
+		}
+	}
+
+	s := pattern.(string)
+	return &s, flags, nil
+}
+
+// matcher returns the matcher of the regular expression for the row and a
+// function to release it once it's no longer used. The matcher is nil if
+// the pattern or the match type are NULL.
+func (f *regexpFunc) matcher(ctx *sql.Context, row sql.Row) (regex.Matcher, func(), error) {
+	if f.cache == nil {
+		pattern, flags, err := f.evalPattern(ctx, row)
+		if pattern == nil || err != nil {
+			return nil, nil, err
+		}
+
+		m, d, err := regex.NewWithFlags(regex.Default(), *pattern, flags)
+		if err != nil {
+			return nil, nil, expression.ErrInvalidRegexp.New(err.Error())
+		}
+		return m, d.Dispose, nil
+	}
+
+	c := f.cache
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.evaluated {
+		c.pattern, c.flags, c.err = f.evalPattern(ctx, row)
+		c.evaluated = true
+	}
+	if c.pattern == nil || c.err != nil {
+		return nil, nil, c.err
+	}
+
+	var m regexpMatcher
+	if n := len(c.free); n > 0 {
+		m, c.free = c.free[n-1], c.free[:n-1]
+	} else {
+		matcher, disposer, err := regex.NewWithFlags(regex.Default(), *c.pattern, c.flags)
+		if err != nil {
+			return nil, nil, expression.ErrInvalidRegexp.New(err.Error())
+		}
+		m = regexpMatcher{matcher, disposer}
+	}
+
+	return m.matcher, func() {
+		c.mu.Lock()
+		c.free = append(c.free, m)
+		c.mu.Unlock()
+	}, nil
+}
+
+// Dispose implements the sql.Disposable interface.
+func (f *regexpFunc) Dispose() {
+	if f.cache == nil {
+		return
+	}
+
+	c := f.cache
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, m := range c.free {
+		m.disposer.Dispose()
+	}
+	c.free = nil
+}
+
+// regexpOffset returns the byte offset in the text of the 1-based character
+// position pos, which can be right after the end of the text.
+func regexpOffset(text string, pos int64) (int, error) {
+	if pos < 1 || pos > int64(utf8.RuneCountInString(text))+1 {
+		return 0, ErrRegexpIndexOutOfBounds.New()
+	}
+
+	offset := 0
+	for i := int64(1); i < pos; i++ {
+		_, size := utf8.DecodeRuneInString(text[offset:])
+		offset += size
+	}
+	return offset, nil
+}
+
+// regexpFind returns the indexes of the given occurrence of the regular
+// expression in the text, searching from the character position pos, in the
+// form returned by regex.Matcher.FindAllSubmatchIndex. It returns nil if
+// there is no such occurrence.
+func regexpFind(m regex.Matcher, text string, pos, occurrence int64) ([]int, error) {
+	offset, err := regexpOffset(text, pos)
+	if err != nil {
+		return nil, err
+	}
+
+	if occurrence < 1 {
+		occurrence = 1
+	}
+
+	matches := m.FindAllSubmatchIndex(text[offset:], int(occurrence))
+	if int64(len(matches)) < occurrence {
+		return nil, nil
+	}
+
+	match := matches[occurrence-1]
+	for i := range match {
+		if match[i] >= 0 {
+			match[i] += offset
+		}
+	}
+	return match, nil
+}
+
+// RegexpLike returns whether a text matches a regular expression.
+type RegexpLike struct {
+	regexpFunc
+}
+
+var _ sql.FunctionExpression = (*RegexpLike)(nil)
+
+// NewRegexpLike creates a new RegexpLike UDF.
+func NewRegexpLike(args ...sql.Expression) (sql.Expression, error) {
+	f, err := newRegexpFunc("regexp_like", args, 2, 3)
+	if err != nil {
+		return nil, err
+	}
+	return &RegexpLike{f}, nil
+}
+
+// Type implements the sql.Expression interface.
+func (r *RegexpLike) Type() sql.Type { return sql.Boolean }
+
+// WithChildren implements the sql.Expression interface.
+func (r *RegexpLike) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != len(r.args) {
+		return nil, sql.ErrInvalidChildrenNumber.New(r, len(children), len(r.args))
+	}
+	return NewRegexpLike(children...)
+}
+
+// Eval implements the sql.Expression interface.
+func (r *RegexpLike) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	text, err := r.evalText(ctx, row)
+	if text == nil || err != nil {
+		return nil, err
+	}
+
+	m, release, err := r.matcher(ctx, row)
+	if m == nil || err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return m.Match(text.(string)), nil
+}
+
+// RegexpInstr returns the character position of an occurrence of a regular
+// expression in a text, searching from a position, or 0 if there is no such
+// occurrence. If the return option is 1, it returns the position after the
+// end of the occurrence instead of its start.
+type RegexpInstr struct {
+	regexpFunc
+}
+
+var _ sql.FunctionExpression = (*RegexpInstr)(nil)
+
+// NewRegexpInstr creates a new RegexpInstr UDF.
+func NewRegexpInstr(args ...sql.Expression) (sql.Expression, error) {
+	f, err := newRegexpFunc("regexp_instr", args, 2, 6)
+	if err != nil {
+		return nil, err
+	}
+	return &RegexpInstr{f}, nil
+}
+
+// Type implements the sql.Expression interface.
+func (r *RegexpInstr) Type() sql.Type { return sql.Int64 }
+
+// WithChildren implements the sql.Expression interface.
+func (r *RegexpInstr) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != len(r.args) {
+		return nil, sql.ErrInvalidChildrenNumber.New(r, len(children), len(r.args))
+	}
+	return NewRegexpInstr(children...)
+}
+
+// Eval implements the sql.Expression interface.
+func (r *RegexpInstr) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	text, err := r.evalText(ctx, row)
+	if text == nil || err != nil {
+		return nil, err
+	}
+
+	pos, err := r.evalInt(ctx, 2, 1, row)
+	if pos == nil || err != nil {
+		return nil, err
+	}
+
+	occurrence, err := r.evalInt(ctx, 3, 1, row)
+	if occurrence == nil || err != nil {
+		return nil, err
+	}
+
+	returnOption, err := r.evalInt(ctx, 4, 0, row)
+	if returnOption == nil || err != nil {
+		return nil, err
+	}
+	if returnOption != int64(0) && returnOption != int64(1) {
+		return nil, ErrInvalidArgument.New(r.name, "return_option must be 0 or 1")
+	}
+
+	m, release, err := r.matcher(ctx, row)
+	if m == nil || err != nil {
+		return nil, err
+	}
+	defer release()
+
+	s := text.(string)
+	match, err := regexpFind(m, s, pos.(int64), occurrence.(int64))
+	if match == nil || err != nil {
+		return int64(0), err
+	}
+
+	end := match[returnOption.(int64)]
+	return int64(utf8.RuneCountInString(s[:end])) + 1, nil
+}
+
+// RegexpSubstr returns an occurrence of a regular expression in a text,
+// searching from a position, or NULL if there is no such occurrence.
+type RegexpSubstr struct {
+	regexpFunc
+}
+
+var _ sql.FunctionExpression = (*RegexpSubstr)(nil)
+
+// NewRegexpSubstr creates a new RegexpSubstr UDF.
+func NewRegexpSubstr(args ...sql.Expression) (sql.Expression, error) {
+	f, err := newRegexpFunc("regexp_substr", args, 2, 5)
+	if err != nil {
+		return nil, err
+	}
+	return &RegexpSubstr{f}, nil
+}
+
+// Type implements the sql.Expression interface.
+func (r *RegexpSubstr) Type() sql.Type { return sql.LongText }
+
+// WithChildren implements the sql.Expression interface.
+func (r *RegexpSubstr) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != len(r.args) {
+		return nil, sql.ErrInvalidChildrenNumber.New(r, len(children), len(r.args))
+	}
+	return NewRegexpSubstr(children...)
+}
+
+// Eval implements the sql.Expression interface.
+func (r *RegexpSubstr) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	text, err := r.evalText(ctx, row)
+	if text == nil || err != nil {
+		return nil, err
+	}
+
+	pos, err := r.evalInt(ctx, 2, 1, row)
+	if pos == nil || err != nil {
+		return nil, err
+	}
+
+	occurrence, err := r.evalInt(ctx, 3, 1, row)
+	if occurrence == nil || err != nil {
+		return nil, err
+	}
+
+	m, release, err := r.matcher(ctx, row)
+	if m == nil || err != nil {
+		return nil, err
+	}
+	defer release()
+
+	s := text.(string)
+	match, err := regexpFind(m, s, pos.(int64), occurrence.(int64))
+	if match == nil || err != nil {
+		return nil, err
+	}
+	return s[match[0]:match[1]], nil
+}
+
+// RegexpReplace replaces the occurrences of a regular expression in a text,
+// searching from a position, with a replacement. If the occurrence is 0, all
+// of them are replaced, otherwise only the given one is. In the replacement,
+// $n is the text matched by the n-th group and a backslash escapes the next
+// character.
+type RegexpReplace struct {
+	regexpFunc
+}
+
+var _ sql.FunctionExpression = (*RegexpReplace)(nil)
+
+// NewRegexpReplace creates a new RegexpReplace UDF.
+func NewRegexpReplace(args ...sql.Expression) (sql.Expression, error) {
+	f, err := newRegexpFunc("regexp_replace", args, 3, 6)
+	if err != nil {
+		return nil, err
+	}
+	return &RegexpReplace{f}, nil
+}
+
+// Type implements the sql.Expression interface.
+func (r *RegexpReplace) Type() sql.Type { return sql.LongText }
+
+// WithChildren implements the sql.Expression interface.
+func (r *RegexpReplace) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != len(r.args) {
+		return nil, sql.ErrInvalidChildrenNumber.New(r, len(children), len(r.args))
+	}
+	return NewRegexpReplace(children...)
+}
+
+// Eval implements the sql.Expression interface.
+func (r *RegexpReplace) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	text, err := r.evalText(ctx, row)
+	if text == nil || err != nil {
+		return nil, err
+	}
+
+	replacement, err := evalString(ctx, r.args[2], row)
+	if replacement == nil || err != nil {
+		return nil, err
+	}
+
+	pos, err := r.evalInt(ctx, 3, 1, row)
+	if pos == nil || err != nil {
+		return nil, err
+	}
+
+	occurrence, err := r.evalInt(ctx, 4, 0, row)
+	if occurrence == nil || err != nil {
+		return nil, err
+	}
+
+	m, release, err := r.matcher(ctx, row)
+	if m == nil || err != nil {
+		return nil, err
+	}
+	defer release()
+
+	s := text.(string)
+	offset, err := regexpOffset(s, pos.(int64))
+	if err != nil {
+		return nil, err
+	}
+
+	n := occurrence.(int64)
+	if n <= 0 {
+		n = -1
+	}
+
+	rest := s[offset:]
+	matches := m.FindAllSubmatchIndex(rest, int(n))
+	if n > 0 {
+		if int64(len(matches)) < n {
+			return s, nil
+		}
+		matches = matches[n-1:]
+	}
+
+	var sb strings.Builder
+	sb.WriteString(s[:offset])
+	last := 0
+	for _, match := range matches {
+		sb.WriteString(rest[last:match[0]])
+		if err := expandReplacement(&sb, replacement.(string), rest, match); err != nil {
+			return nil, err
+		}
+		last = match[1]
+	}
+	sb.WriteString(rest[last:])
+
+	return sb.String(), nil
+}
+
+// expandReplacement writes the replacement of a match of a regular
+// expression, where $n is the text matched by the n-th group, using as many
+// digits as form a valid group number, and a backslash escapes the next
+// character.
+func expandReplacement(sb *strings.Builder, replacement, text string, match []int) error {
+	groups := len(match)/2 - 1
+	for i := 0; i < len(replacement); i++ {
+		c := replacement[i]
+		switch {
+		case c == '\\' && i+1 < len(replacement):
+			i++
+			sb.WriteByte(replacement[i])
+		case c == '$' && i+1 < len(replacement) && isDigit(replacement[i+1]):
+			i++
+			group := int(replacement[i] - '0')
+			if group > groups {
+				return ErrRegexpIndexOutOfBounds.New()
+			}
+			for i+1 < len(replacement) && isDigit(replacement[i+1]) && group*10+int(replacement[i+1]-'0') <= groups {
+				i++
+				group = group*10 + int(replacement[i]-'0')
+			}
+			if match[2*group] >= 0 {
+				sb.WriteString(text[match[2*group]:match[2*group+1]])
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
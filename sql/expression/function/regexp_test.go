@@ -0,0 +1,187 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	errors "gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/internal/regex"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func regexpArgs(args []interface{}) []sql.Expression {
+	var exprs []sql.Expression
+	for _, arg := range args {
+		switch arg := arg.(type) {
+		case int:
+			exprs = append(exprs, expression.NewLiteral(int64(arg), sql.Int64))
+		case nil:
+			exprs = append(exprs, expression.NewLiteral(nil, sql.Null))
+		default:
+			exprs = append(exprs, expression.NewLiteral(arg, sql.LongText))
+		}
+	}
+	return exprs
+}
+
+func TestRegexpFunctions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fn       func(...sql.Expression) (sql.Expression, error)
+		args     []interface{}
+		expected interface{}
+		err      *errors.Kind
+	}{
+		{"like", NewRegexpLike, []interface{}{"Michael!", ".*"}, true, nil},
+		{"like no match", NewRegexpLike, []interface{}{"new*\n*line", "new\\*.\\*line"}, false, nil},
+		{"like case insensitive collation", NewRegexpLike, []interface{}{"CamelCase", "CAMELCASE"}, true, nil},
+		{"like case sensitive", NewRegexpLike, []interface{}{"CamelCase", "CAMELCASE", "c"}, false, nil},
+		{"like last flag wins", NewRegexpLike, []interface{}{"abc", "ABC", "ci"}, true, nil},
+		{"like single line", NewRegexpLike, []interface{}{"a\nb\nc", "^b$"}, false, nil},
+		{"like multiline", NewRegexpLike, []interface{}{"a\nb\nc", "^b$", "m"}, true, nil},
+		{"like dot all", NewRegexpLike, []interface{}{"a\nb", "a.b", "n"}, true, nil},
+		{"like invalid flag", NewRegexpLike, []interface{}{"abc", "abc", "x"}, nil, errInvalidRegexpFlag},
+		{"like invalid pattern", NewRegexpLike, []interface{}{"abc", "("}, nil, expression.ErrInvalidRegexp},
+		{"like null text", NewRegexpLike, []interface{}{nil, "abc"}, nil, nil},
+		{"like null pattern", NewRegexpLike, []interface{}{"abc", nil}, nil, nil},
+		{"like null match type", NewRegexpLike, []interface{}{"abc", "abc", nil}, nil, nil},
+
+		{"instr", NewRegexpInstr, []interface{}{"dog cat dog", "dog"}, int64(1), nil},
+		{"instr position", NewRegexpInstr, []interface{}{"dog cat dog", "dog", 2}, int64(9), nil},
+		{"instr occurrence", NewRegexpInstr, []interface{}{"aa aaa aaaa", "a{2}", 1, 3}, int64(8), nil},
+		{"instr end", NewRegexpInstr, []interface{}{"dog cat dog", "dog", 1, 2, 1}, int64(12), nil},
+		{"instr no match", NewRegexpInstr, []interface{}{"dog cat dog", "cow"}, int64(0), nil},
+		{"instr missing occurrence", NewRegexpInstr, []interface{}{"dog cat dog", "dog", 1, 3}, int64(0), nil},
+		{"instr multibyte", NewRegexpInstr, []interface{}{"añob", "b"}, int64(4), nil},
+		{"instr position after end", NewRegexpInstr, []interface{}{"abc", "x", 4}, int64(0), nil},
+		{"instr position out of bounds", NewRegexpInstr, []interface{}{"abc", "x", 5}, nil, ErrRegexpIndexOutOfBounds},
+		{"instr zero position", NewRegexpInstr, []interface{}{"abc", "x", 0}, nil, ErrRegexpIndexOutOfBounds},
+		{"instr invalid return option", NewRegexpInstr, []interface{}{"abc", "b", 1, 1, 2}, nil, ErrInvalidArgument},
+		{"instr match type", NewRegexpInstr, []interface{}{"aBc", "b", 1, 1, 0, "c"}, int64(0), nil},
+		{"instr null position", NewRegexpInstr, []interface{}{"abc", "b", nil}, nil, nil},
+
+		{"substr", NewRegexpSubstr, []interface{}{"abc def ghi", "[a-z]+"}, "abc", nil},
+		{"substr occurrence", NewRegexpSubstr, []interface{}{"abc def ghi", "[a-z]+", 1, 3}, "ghi", nil},
+		{"substr position", NewRegexpSubstr, []interface{}{"abc def ghi", "[a-z]+", 6}, "ef", nil},
+		{"substr no match", NewRegexpSubstr, []interface{}{"abc def ghi", "[0-9]+"}, nil, nil},
+		{"substr match type", NewRegexpSubstr, []interface{}{"abc DEF", "def", 1, 1, "i"}, "DEF", nil},
+
+		{"replace", NewRegexpReplace, []interface{}{"a b c", "b", "X"}, "a X c", nil},
+		{"replace all", NewRegexpReplace, []interface{}{"abc def ghi", "[a-z]+", "X"}, "X X X", nil},
+		{"replace position", NewRegexpReplace, []interface{}{"abc def ghi", "[a-z]+", "X", 5}, "abc X X", nil},
+		{"replace occurrence", NewRegexpReplace, []interface{}{"abc def ghi", "[a-z]+", "X", 1, 3}, "abc def X", nil},
+		{"replace missing occurrence", NewRegexpReplace, []interface{}{"abc def ghi", "[a-z]+", "X", 1, 4}, "abc def ghi", nil},
+		{"replace group", NewRegexpReplace, []interface{}{"John Smith", "(\\w+) (\\w+)", "$2, $1"}, "Smith, John", nil},
+		{"replace escape", NewRegexpReplace, []interface{}{"abc", "b", "\\$1"}, "a$1c", nil},
+		{"replace unmatched group", NewRegexpReplace, []interface{}{"ac", "a(b)?", "[$1]"}, "[]c", nil},
+		{"replace invalid group", NewRegexpReplace, []interface{}{"abc", "(b)", "$2"}, nil, ErrRegexpIndexOutOfBounds},
+		{"replace match type", NewRegexpReplace, []interface{}{"aBc abc", "b", "X", 1, 0, "c"}, "aBc aXc", nil},
+		{"replace null replacement", NewRegexpReplace, []interface{}{"abc", "b", nil}, nil, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			f, err := tt.fn(regexpArgs(tt.args)...)
+			require.NoError(err)
+
+			v, err := f.Eval(sql.NewEmptyContext(), nil)
+			if tt.err != nil {
+				require.Error(err)
+				require.True(tt.err.Is(err), "unexpected error %v", err)
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+}
+
+func TestRegexpFunctionsWithColumns(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	f, err := NewRegexpReplace(
+		expression.NewGetField(0, sql.LongText, "text", true),
+		expression.NewGetField(1, sql.LongText, "pattern", true),
+		expression.NewLiteral("_", sql.LongText),
+	)
+	require.NoError(err)
+	require.Nil(f.(*RegexpReplace).cache)
+	require.Equal("REGEXP_REPLACE(text, pattern, \"_\")", f.String())
+
+	rows := []struct {
+		row      sql.Row
+		expected interface{}
+	}{
+		{sql.NewRow("a-b-c", "-"), "a_b_c"},
+		{sql.NewRow("a-b-c", "[a-z]"), "_-_-_"},
+		{sql.NewRow("a-b-c", nil), nil},
+	}
+	for _, r := range rows {
+		v, err := f.Eval(ctx, r.row)
+		require.NoError(err)
+		require.Equal(r.expected, v)
+	}
+
+	f, err = NewRegexpLike(expression.NewGetField(0, sql.LongText, "text", true), expression.NewLiteral("^a", sql.LongText))
+	require.NoError(err)
+	require.NotNil(f.(*RegexpLike).cache)
+	for _, text := range []string{"abc", "bcd", "acd"} {
+		v, err := f.Eval(ctx, sql.NewRow(text))
+		require.NoError(err)
+		require.Equal(text[0] == 'a', v)
+	}
+}
+
+type countingDisposer struct {
+	disposed *int
+}
+
+func (d countingDisposer) Dispose() {
+	*d.disposed++
+}
+
+func TestRegexpFunctionsDispose(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	var compiled, disposed int
+	require.NoError(regex.Register("counting", func(re string, flags regex.Flags) (regex.Matcher, regex.Disposer, error) {
+		m, _, err := regex.NewGo(re, flags)
+		compiled++
+		return m, countingDisposer{&disposed}, err
+	}))
+
+	engine := regex.Default()
+	regex.SetDefault("counting")
+	defer regex.SetDefault(engine)
+
+	f, err := NewRegexpLike(expression.NewGetField(0, sql.LongText, "text", true), expression.NewLiteral("^a", sql.LongText))
+	require.NoError(err)
+	for _, text := range []string{"abc", "bcd", "acd"} {
+		_, err := f.Eval(ctx, sql.NewRow(text))
+		require.NoError(err)
+	}
+	require.Equal(1, compiled)
+	require.Equal(0, disposed)
+
+	f.(sql.Disposable).Dispose()
+	require.Equal(1, disposed)
+}
+
+func TestRegexpFunctionsArguments(t *testing.T) {
+	_, err := NewRegexpLike(regexpArgs([]interface{}{"a"})...)
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+
+	_, err = NewRegexpInstr(regexpArgs([]interface{}{"a", "b", 1, 1, 0, "c", "d"})...)
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+
+	_, err = NewRegexpSubstr(regexpArgs([]interface{}{"a", "b", 1, 1, "c", "d"})...)
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+
+	_, err = NewRegexpReplace(regexpArgs([]interface{}{"a", "b"})...)
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+}
@@ -4,29 +4,42 @@ import (
 	"math"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
 	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
 )
 
 // Defaults is the function map with all the default functions.
 var Defaults = []sql.Function{
-	// elt, find_in_set, insert, load_file, locate
+	// load_file
 	sql.Function1{Name: "abs", Fn: NewAbsVal},
 	NewUnaryFunc("acos", sql.Float64, ACosFunc),
+	sql.Function2{Name: "aes_decrypt", Fn: NewAESDecrypt},
+	sql.Function2{Name: "aes_encrypt", Fn: NewAESEncrypt},
+	sql.Function1{Name: "any_value", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewAnyValue(e) }},
 	sql.Function1{Name: "array_length", Fn: NewArrayLength},
 	NewUnaryFunc("ascii", sql.Uint8, AsciiFunc),
 	NewUnaryFunc("asin", sql.Float64, ASinFunc),
 	NewUnaryFunc("atan", sql.Float64, ATanFunc),
 	sql.Function1{Name: "avg", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewAvg(e) }},
 	NewUnaryFunc("bin", sql.Text, BinFunc),
+	sql.FunctionN{Name: "bin_to_uuid", Fn: NewBinToUUID},
+	sql.Function1{Name: "bit_and", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewBitAnd(e) }},
 	NewUnaryFunc("bit_length", sql.Int32, BinFunc),
+	sql.Function1{Name: "bit_or", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewBitOr(e) }},
+	sql.Function1{Name: "bit_xor", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewBitXor(e) }},
 	sql.Function1{Name: "ceil", Fn: NewCeil},
 	sql.Function1{Name: "ceiling", Fn: NewCeil},
+	sql.FunctionN{Name: "char", Fn: NewChar},
 	sql.Function1{Name: "char_length", Fn: NewCharLength},
 	sql.Function1{Name: "character_length", Fn: NewCharLength},
+	sql.Function1{Name: "charset", Fn: NewCharset},
 	sql.FunctionN{Name: "coalesce", Fn: NewCoalesce},
+	sql.Function1{Name: "collation", Fn: NewCollation},
 	sql.FunctionN{Name: "concat", Fn: NewConcat},
 	sql.FunctionN{Name: "concat_ws", Fn: NewConcatWithSeparator},
 	sql.NewFunction0("connection_id", NewConnectionID),
+	sql.Function3{Name: "conv", Fn: NewConv},
+	sql.Function3{Name: "convert_tz", Fn: NewConvertTz},
 	NewUnaryFunc("cos", sql.Float64, CosFunc),
 	NewUnaryFunc("cot", sql.Float64, CotFunc),
 	sql.Function1{Name: "count", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewCount(e) }},
@@ -41,6 +54,7 @@ var Defaults = []sql.Function{
 	sql.FunctionN{Name: "date_add", Fn: NewDateAdd},
 	sql.Function2{Name: "date_format", Fn: NewDateFormat},
 	sql.FunctionN{Name: "date_sub", Fn: NewDateSub},
+	sql.Function2{Name: "datediff", Fn: NewDateDiff},
 	sql.FunctionN{Name: "datetime", Fn: NewDatetime},
 	sql.Function1{Name: "day", Fn: NewDay},
 	NewUnaryDatetimeFunc("dayname", sql.LongText, dayNameFuncLogic),
@@ -48,58 +62,139 @@ var Defaults = []sql.Function{
 	sql.Function1{Name: "dayofweek", Fn: NewDayOfWeek},
 	sql.Function1{Name: "dayofyear", Fn: NewDayOfYear},
 	NewUnaryFunc("degrees", sql.Float64, DegreesFunc),
+	sql.FunctionN{Name: "elt", Fn: NewElt},
 	sql.Function1{Name: "explode", Fn: NewExplode},
+	sql.FunctionN{Name: "export_set", Fn: NewExportSet},
+	sql.Function2{Name: "extract", Fn: NewExtract},
+	sql.FunctionN{Name: "field", Fn: NewField},
+	sql.Function2{Name: "find_in_set", Fn: NewFindInSet},
 	sql.Function1{Name: "first", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewFirst(e) }},
 	sql.Function1{Name: "floor", Fn: NewFloor},
+	sql.FunctionN{Name: "format", Fn: NewFormat},
 	sql.Function1{Name: "from_base64", Fn: NewFromBase64},
+	sql.Function1{Name: "from_days", Fn: NewFromDays},
+	sql.FunctionN{Name: "from_unixtime", Fn: NewFromUnixtime},
+	sql.Function2{Name: "get_format", Fn: NewGetFormat},
 	sql.FunctionN{Name: "greatest", Fn: NewGreatest},
 	NewUnaryFunc("hex", sql.Text, HexFunc),
 	sql.Function1{Name: "hour", Fn: NewHour},
 	sql.Function3{Name: "if", Fn: NewIf},
 	sql.Function2{Name: "ifnull", Fn: NewIfNull},
+	sql.Function1{Name: "inet6_aton", Fn: NewInet6Aton},
+	sql.Function1{Name: "inet6_ntoa", Fn: NewInet6Ntoa},
+	sql.Function1{Name: "inet_aton", Fn: NewInetAton},
+	sql.Function1{Name: "inet_ntoa", Fn: NewInetNtoa},
+	sql.Function4{Name: "insert", Fn: NewInsert},
 	sql.Function2{Name: "instr", Fn: NewInstr},
 	sql.Function1{Name: "is_binary", Fn: NewIsBinary},
+	sql.FunctionN{Name: "json_array", Fn: NewJSONArray},
+	sql.Function1{Name: "json_arrayagg", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewJSONArrayAgg(e) }},
+	sql.FunctionN{Name: "json_array_append", Fn: NewJSONArrayAppend},
+	sql.FunctionN{Name: "json_array_insert", Fn: NewJSONArrayInsert},
+	sql.FunctionN{Name: "json_contains", Fn: NewJSONContains},
+	sql.FunctionN{Name: "json_contains_path", Fn: NewJSONContainsPath},
+	sql.Function1{Name: "json_depth", Fn: NewJSONDepth},
 	sql.FunctionN{Name: "json_extract", Fn: NewJSONExtract},
+	sql.FunctionN{Name: "json_insert", Fn: NewJSONInsert},
+	sql.FunctionN{Name: "json_keys", Fn: NewJSONKeys},
+	sql.FunctionN{Name: "json_length", Fn: NewJSONLength},
+	sql.FunctionN{Name: "json_merge", Fn: NewJSONMerge},
+	sql.FunctionN{Name: "json_merge_patch", Fn: NewJSONMergePatch},
+	sql.FunctionN{Name: "json_merge_preserve", Fn: NewJSONMergePreserve},
+	sql.FunctionN{Name: "json_object", Fn: NewJSONObject},
+	sql.Function2{Name: "json_objectagg", Fn: func(k, v sql.Expression) sql.Expression { return aggregation.NewJSONObjectAgg(k, v) }},
+	sql.Function1{Name: "json_quote", Fn: NewJSONQuote},
+	sql.FunctionN{Name: "json_remove", Fn: NewJSONRemove},
+	sql.FunctionN{Name: "json_replace", Fn: NewJSONReplace},
+	sql.FunctionN{Name: "json_search", Fn: NewJSONSearch},
+	sql.FunctionN{Name: "json_set", Fn: NewJSONSet},
+	sql.Function1{Name: "json_type", Fn: NewJSONType},
 	sql.Function1{Name: "json_unquote", Fn: NewJSONUnquote},
+	sql.Function1{Name: "json_valid", Fn: NewJSONValid},
 	sql.Function1{Name: "last", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewLast(e) }},
+	sql.Function1{Name: "last_day", Fn: NewLastDay},
 	sql.Function1{Name: "lcase", Fn: NewLower},
 	sql.FunctionN{Name: "least", Fn: NewLeast},
 	sql.Function2{Name: "left", Fn: NewLeft},
 	sql.Function1{Name: "length", Fn: NewLength},
 	sql.Function1{Name: "ln", Fn: NewLogBaseFunc(float64(math.E))},
+	sql.FunctionN{Name: "locate", Fn: NewLocate},
 	sql.FunctionN{Name: "log", Fn: NewLog},
 	sql.Function1{Name: "log10", Fn: NewLogBaseFunc(float64(10))},
 	sql.Function1{Name: "log2", Fn: NewLogBaseFunc(float64(2))},
 	sql.Function1{Name: "lower", Fn: NewLower},
 	sql.FunctionN{Name: "lpad", Fn: NewPadFunc(lPadType)},
 	sql.Function1{Name: "ltrim", Fn: NewTrimFunc(lTrimType)},
+	sql.FunctionN{Name: "make_set", Fn: NewMakeSet},
+	sql.Function2{Name: "makedate", Fn: NewMakeDate},
+	sql.Function3{Name: "maketime", Fn: NewMakeTime},
+	sql.Function1{Name: "md5", Fn: NewMD5},
 	sql.Function1{Name: "max", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewMax(e) }},
 	NewUnaryDatetimeFunc("microsecond", sql.Uint64, microsecondFuncLogic),
 	sql.FunctionN{Name: "mid", Fn: NewSubstring},
 	sql.Function1{Name: "min", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewMin(e) }},
 	sql.Function1{Name: "minute", Fn: NewMinute},
+	sql.Function2{Name: "mod", Fn: func(n, m sql.Expression) sql.Expression { return expression.NewMod(n, m) }},
 	sql.Function1{Name: "month", Fn: NewMonth},
 	NewUnaryDatetimeFunc("monthname", sql.LongText, monthNameFuncLogic),
 	sql.FunctionN{Name: "now", Fn: NewNow},
 	sql.Function2{Name: "nullif", Fn: NewNullIf},
+	sql.Function1{Name: "octet_length", Fn: NewLength},
+	sql.Function1{Name: "ord", Fn: NewOrd},
+	sql.Function2{Name: "period_add", Fn: NewPeriodAdd},
+	sql.Function2{Name: "period_diff", Fn: NewPeriodDiff},
+	sql.Function2{Name: "point", Fn: NewPoint},
+	sql.FunctionN{Name: "position", Fn: NewLocate},
 	sql.Function2{Name: "pow", Fn: NewPower},
 	sql.Function2{Name: "power", Fn: NewPower},
+	sql.Function1{Name: "quarter", Fn: NewQuarter},
+	sql.Function1{Name: "quote", Fn: NewQuote},
 	NewUnaryFunc("radians", sql.Float64, RadiansFunc),
 	sql.FunctionN{Name: "rand", Fn: NewRand},
+	sql.Function1{Name: "random_bytes", Fn: NewRandomBytes},
+	sql.FunctionN{Name: "regexp_instr", Fn: NewRegexpInstr},
+	sql.FunctionN{Name: "regexp_like", Fn: NewRegexpLike},
 	sql.FunctionN{Name: "regexp_matches", Fn: NewRegexpMatches},
+	sql.FunctionN{Name: "regexp_replace", Fn: NewRegexpReplace},
+	sql.FunctionN{Name: "regexp_substr", Fn: NewRegexpSubstr},
 	sql.Function2{Name: "repeat", Fn: NewRepeat},
 	sql.Function3{Name: "replace", Fn: NewReplace},
 	sql.Function1{Name: "reverse", Fn: NewReverse},
+	sql.Function2{Name: "right", Fn: NewRight},
 	sql.FunctionN{Name: "round", Fn: NewRound},
 	sql.FunctionN{Name: "rpad", Fn: NewPadFunc(rPadType)},
 	sql.Function1{Name: "rtrim", Fn: NewTrimFunc(rTrimType)},
+	sql.Function1{Name: "sec_to_time", Fn: NewSecToTime},
 	sql.Function1{Name: "second", Fn: NewSecond},
+	sql.Function1{Name: "sha", Fn: NewSHA1},
+	sql.Function1{Name: "sha1", Fn: NewSHA1},
+	sql.Function2{Name: "sha2", Fn: NewSHA2},
 	NewUnaryFunc("sign", sql.Int8, SignFunc),
 	NewUnaryFunc("sin", sql.Float64, SinFunc),
 	sql.Function1{Name: "sleep", Fn: NewSleep},
 	sql.Function1{Name: "soundex", Fn: NewSoundex},
+	sql.Function1{Name: "space", Fn: NewSpace},
 	sql.Function2{Name: "split", Fn: NewSplit},
 	sql.Function1{Name: "sqrt", Fn: NewSqrt},
+	sql.FunctionN{Name: "st_asgeojson", Fn: NewAsGeoJSON},
+	sql.Function1{Name: "st_astext", Fn: NewAsText},
+	sql.Function1{Name: "st_aswkt", Fn: NewAsText},
+	sql.Function2{Name: "st_contains", Fn: NewContains},
+	sql.Function2{Name: "st_distance", Fn: NewDistance},
+	sql.FunctionN{Name: "st_distance_sphere", Fn: NewDistanceSphere},
+	sql.FunctionN{Name: "st_geometryfromtext", Fn: NewGeomFromText},
+	sql.FunctionN{Name: "st_geomfromtext", Fn: NewGeomFromText},
+	sql.Function2{Name: "st_intersects", Fn: NewIntersects},
+	sql.Function1{Name: "st_srid", Fn: NewSRID},
+	sql.Function2{Name: "st_within", Fn: NewWithin},
+	sql.Function1{Name: "st_x", Fn: NewSTX},
+	sql.Function1{Name: "st_y", Fn: NewSTY},
+	sql.Function1{Name: "std", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewStdDevPop(e) }},
+	sql.Function1{Name: "stddev", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewStdDevPop(e) }},
+	sql.Function1{Name: "stddev_pop", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewStdDevPop(e) }},
+	sql.Function1{Name: "stddev_samp", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewStdDevSamp(e) }},
+	sql.Function2{Name: "str_to_date", Fn: NewStrToDate},
+	sql.Function2{Name: "strcmp", Fn: NewStrcmp},
 	sql.FunctionN{Name: "substr", Fn: NewSubstring},
 	sql.FunctionN{Name: "substring", Fn: NewSubstring},
 	sql.Function3{Name: "substring_index", Fn: NewSubstringIndex},
@@ -107,7 +202,10 @@ var Defaults = []sql.Function{
 	NewUnaryFunc("tan", sql.Float64, TanFunc),
 	NewUnaryDatetimeFunc("time_to_sec", sql.Uint64, timeToSecFuncLogic),
 	sql.FunctionN{Name: "timestamp", Fn: NewTimestamp},
+	sql.Function3{Name: "timestampadd", Fn: NewTimestampAdd},
+	sql.Function3{Name: "timestampdiff", Fn: NewTimestampDiff},
 	sql.Function1{Name: "to_base64", Fn: NewToBase64},
+	sql.Function1{Name: "to_days", Fn: NewToDays},
 	sql.Function1{Name: "trim", Fn: NewTrimFunc(bTrimType)},
 	sql.Function1{Name: "ucase", Fn: NewUpper},
 	NewUnaryFunc("unhex", sql.Text, UnhexFunc),
@@ -116,9 +214,16 @@ var Defaults = []sql.Function{
 	sql.Function2{Name: "timediff", Fn: NewTimeDiff},
 	sql.Function1{Name: "upper", Fn: NewUpper},
 	sql.NewFunction0("user", NewUser),
+	sql.NewFunction0("uuid", NewUUID),
+	sql.NewFunction0("uuid_short", NewUUIDShort),
+	sql.FunctionN{Name: "uuid_to_bin", Fn: NewUUIDToBin},
+	sql.Function1{Name: "var_pop", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewVarPop(e) }},
+	sql.Function1{Name: "var_samp", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewVarSamp(e) }},
+	sql.Function1{Name: "variance", Fn: func(e sql.Expression) sql.Expression { return aggregation.NewVarPop(e) }},
 	sql.FunctionN{Name: "week", Fn: NewWeek},
 	sql.Function1{Name: "weekday", Fn: NewWeekday},
 	NewUnaryDatetimeFunc("weekofyear", sql.Uint64, weekFuncLogic),
+	sql.FunctionN{Name: "weight_string", Fn: NewWeightString},
 	sql.Function1{Name: "year", Fn: NewYear},
 	sql.FunctionN{Name: "yearweek", Fn: NewYearWeek},
 }
@@ -0,0 +1,85 @@
+package function
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// ERWarnAllowedPacketOverflowed is the code of the warning added when the result of a string function is larger than
+// max_allowed_packet.
+const ERWarnAllowedPacketOverflowed = 1301
+
+// Space is a function that returns a string of the given number of spaces. NULL is returned, with a warning, if it
+// would be larger than max_allowed_packet.
+type Space struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*Space)(nil)
+
+// NewSpace creates a new Space expression.
+func NewSpace(e sql.Expression) sql.Expression {
+	return &Space{expression.UnaryExpression{Child: e}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *Space) FunctionName() string {
+	return "space"
+}
+
+// Eval implements the Expression interface.
+func (s *Space) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	n, err := evalInt64(ctx, s.Child, row)
+	if n == nil || err != nil {
+		return nil, err
+	}
+
+	count := n.(int64)
+	if count <= 0 {
+		return "", nil
+	}
+
+	if max := maxAllowedPacket(ctx); count > max {
+		ctx.Warn(ERWarnAllowedPacketOverflowed, "Result of space() was larger than max_allowed_packet (%d) - truncated", max)
+		return nil, nil
+	}
+
+	return strings.Repeat(" ", int(count)), nil
+}
+
+// maxAllowedPacket returns the max_allowed_packet of the session.
+func maxAllowedPacket(ctx *sql.Context) int64 {
+	if ctx != nil && ctx.Session != nil {
+		_, val := ctx.Get("max_allowed_packet")
+		if n, err := sql.Int64.Convert(val); err == nil && n != nil {
+			return n.(int64)
+		}
+	}
+	return math.MaxInt32
+}
+
+// IsNullable implements the Expression interface.
+func (s *Space) IsNullable() bool {
+	return true
+}
+
+func (s *Space) String() string {
+	return fmt.Sprintf("SPACE(%s)", s.Child)
+}
+
+// WithChildren implements the Expression interface.
+func (s *Space) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 1)
+	}
+	return NewSpace(children[0]), nil
+}
+
+// Type implements the Expression interface.
+func (s *Space) Type() sql.Type {
+	return sql.LongText
+}
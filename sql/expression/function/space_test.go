@@ -0,0 +1,38 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestSpace(t *testing.T) {
+	f := NewSpace(expression.NewGetField(0, sql.Int64, "n", true))
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+		warnings int
+	}{
+		{"null", sql.NewRow(nil), nil, 0},
+		{"spaces", sql.NewRow(3), "   ", 0},
+		{"zero", sql.NewRow(0), "", 0},
+		{"negative", sql.NewRow(-1), "", 0},
+		{"larger than max_allowed_packet", sql.NewRow(int64(3000000000)), nil, 1},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := sql.NewEmptyContext()
+			v, err := f.Eval(ctx, tt.row)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+			require.Len(ctx.Warnings(), tt.warnings)
+		})
+	}
+}
@@ -0,0 +1,488 @@
+package function
+
+import (
+	"fmt"
+	"math"
+
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+var (
+	// ErrInvalidGISData is returned when an argument of a spatial function
+	// is not a valid geometry.
+	ErrInvalidGISData = errors.NewKind("Invalid GIS data provided to function %s.")
+
+	// ErrUnexpectedGeometryType is returned when a geometry argument is not
+	// of the kind required by a function.
+	ErrUnexpectedGeometryType = errors.NewKind("%s value is a geometry of unexpected type %s in %s.")
+)
+
+// evalGeometry evaluates an argument of the function with the given name,
+// which must be a geometry or a value in the format of
+// sql.SerializeGeometry.
+func evalGeometry(ctx *sql.Context, name string, e sql.Expression, row sql.Row) (sql.GeometryValue, error) {
+	val, err := e.Eval(ctx, row)
+	if val == nil || err != nil {
+		return nil, err
+	}
+
+	g, err := sql.Geometry.Convert(val)
+	if err != nil {
+		return nil, ErrInvalidGISData.New(name)
+	}
+	return g.(sql.GeometryValue), nil
+}
+
+// evalPoint evaluates an argument of the function with the given name,
+// which must be a point.
+func evalPoint(ctx *sql.Context, name string, e sql.Expression, row sql.Row) (*sql.PointValue, error) {
+	g, err := evalGeometry(ctx, name, e, row)
+	if g == nil || err != nil {
+		return nil, err
+	}
+
+	p, ok := g.(sql.PointValue)
+	if !ok {
+		return nil, ErrUnexpectedGeometryType.New("POINT", g.Kind(), name)
+	}
+	return &p, nil
+}
+
+// evalSRID evaluates an SRID argument of the function with the given name.
+func evalSRID(ctx *sql.Context, name string, e sql.Expression, row sql.Row) (interface{}, error) {
+	srid, err := evalInt64(ctx, e, row)
+	if srid == nil || err != nil {
+		return nil, err
+	}
+
+	if srid.(int64) < 0 || srid.(int64) > math.MaxUint32 {
+		return nil, ErrInvalidArgument.New(name, fmt.Sprintf("SRID value %d is out of range", srid))
+	}
+	return uint32(srid.(int64)), nil
+}
+
+// Point returns the point with the given coordinates.
+type Point struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*Point)(nil)
+
+// NewPoint creates a new Point UDF.
+func NewPoint(x, y sql.Expression) sql.Expression {
+	return &Point{expression.BinaryExpression{Left: x, Right: y}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (p *Point) FunctionName() string {
+	return "point"
+}
+
+func (p *Point) String() string {
+	return fmt.Sprintf("POINT(%s, %s)", p.Left, p.Right)
+}
+
+// Type implements the Expression interface.
+func (p *Point) Type() sql.Type { return sql.Point }
+
+// Eval implements the Expression interface.
+func (p *Point) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	x, err := evalFloat64(ctx, p.Left, row)
+	if x == nil || err != nil {
+		return nil, err
+	}
+
+	y, err := evalFloat64(ctx, p.Right, row)
+	if y == nil || err != nil {
+		return nil, err
+	}
+
+	return sql.PointValue{X: x.(float64), Y: y.(float64)}, nil
+}
+
+// WithChildren implements the Expression interface.
+func (p *Point) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(p, len(children), 2)
+	}
+	return NewPoint(children[0], children[1]), nil
+}
+
+// GeomFromText returns the geometry of a well-known text representation,
+// with SRID 0 or the one given as second argument.
+type GeomFromText struct {
+	Text sql.Expression
+	SRID sql.Expression
+}
+
+var _ sql.FunctionExpression = (*GeomFromText)(nil)
+
+// NewGeomFromText creates a new GeomFromText UDF.
+func NewGeomFromText(args ...sql.Expression) (sql.Expression, error) {
+	switch len(args) {
+	case 1:
+		return &GeomFromText{args[0], nil}, nil
+	case 2:
+		return &GeomFromText{args[0], args[1]}, nil
+	default:
+		return nil, sql.ErrInvalidArgumentNumber.New("ST_GEOMFROMTEXT", "1 or 2", len(args))
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (g *GeomFromText) FunctionName() string {
+	return "st_geomfromtext"
+}
+
+// Children implements the Expression interface.
+func (g *GeomFromText) Children() []sql.Expression {
+	if g.SRID != nil {
+		return []sql.Expression{g.Text, g.SRID}
+	}
+	return []sql.Expression{g.Text}
+}
+
+// Resolved implements the Expression interface.
+func (g *GeomFromText) Resolved() bool {
+	return g.Text.Resolved() && (g.SRID == nil || g.SRID.Resolved())
+}
+
+// IsNullable implements the Expression interface.
+func (g *GeomFromText) IsNullable() bool {
+	return true
+}
+
+// Type implements the Expression interface.
+func (g *GeomFromText) Type() sql.Type {
+	return sql.Geometry
+}
+
+// WithChildren implements the Expression interface.
+func (g *GeomFromText) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewGeomFromText(children...)
+}
+
+// Eval implements the Expression interface.
+func (g *GeomFromText) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	text, err := evalString(ctx, g.Text, row)
+	if text == nil || err != nil {
+		return nil, err
+	}
+
+	var srid uint32
+	if g.SRID != nil {
+		val, err := evalSRID(ctx, g.FunctionName(), g.SRID, row)
+		if val == nil || err != nil {
+			return nil, err
+		}
+		srid = val.(uint32)
+	}
+
+	geometry, err := sql.ParseWKT(text.(string), srid)
+	if err != nil {
+		return nil, ErrInvalidGISData.New(g.FunctionName())
+	}
+	return geometry, nil
+}
+
+func (g *GeomFromText) String() string {
+	if g.SRID != nil {
+		return fmt.Sprintf("ST_GEOMFROMTEXT(%s, %s)", g.Text, g.SRID)
+	}
+	return fmt.Sprintf("ST_GEOMFROMTEXT(%s)", g.Text)
+}
+
+// AsText returns the well-known text representation of a geometry.
+type AsText struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*AsText)(nil)
+
+// NewAsText creates a new AsText UDF.
+func NewAsText(g sql.Expression) sql.Expression {
+	return &AsText{expression.UnaryExpression{Child: g}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (a *AsText) FunctionName() string {
+	return "st_astext"
+}
+
+func (a *AsText) String() string {
+	return fmt.Sprintf("ST_ASTEXT(%s)", a.Child)
+}
+
+// Type implements the Expression interface.
+func (a *AsText) Type() sql.Type { return sql.LongText }
+
+// IsNullable implements the Expression interface.
+func (a *AsText) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (a *AsText) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	g, err := evalGeometry(ctx, a.FunctionName(), a.Child, row)
+	if g == nil || err != nil {
+		return nil, err
+	}
+	return sql.WKT(g), nil
+}
+
+// WithChildren implements the Expression interface.
+func (a *AsText) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(a, len(children), 1)
+	}
+	return NewAsText(children[0]), nil
+}
+
+// AsGeoJSON returns the GeoJSON object of a geometry. The coordinates are
+// rounded to the number of decimal digits given as second argument, if any.
+type AsGeoJSON struct {
+	Geometry  sql.Expression
+	MaxDigits sql.Expression
+}
+
+var _ sql.FunctionExpression = (*AsGeoJSON)(nil)
+
+// NewAsGeoJSON creates a new AsGeoJSON UDF.
+func NewAsGeoJSON(args ...sql.Expression) (sql.Expression, error) {
+	switch len(args) {
+	case 1:
+		return &AsGeoJSON{args[0], nil}, nil
+	case 2:
+		return &AsGeoJSON{args[0], args[1]}, nil
+	default:
+		return nil, sql.ErrInvalidArgumentNumber.New("ST_ASGEOJSON", "1 or 2", len(args))
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (a *AsGeoJSON) FunctionName() string {
+	return "st_asgeojson"
+}
+
+// Children implements the Expression interface.
+func (a *AsGeoJSON) Children() []sql.Expression {
+	if a.MaxDigits != nil {
+		return []sql.Expression{a.Geometry, a.MaxDigits}
+	}
+	return []sql.Expression{a.Geometry}
+}
+
+// Resolved implements the Expression interface.
+func (a *AsGeoJSON) Resolved() bool {
+	return a.Geometry.Resolved() && (a.MaxDigits == nil || a.MaxDigits.Resolved())
+}
+
+// IsNullable implements the Expression interface.
+func (a *AsGeoJSON) IsNullable() bool {
+	return true
+}
+
+// Type implements the Expression interface.
+func (a *AsGeoJSON) Type() sql.Type {
+	return sql.JSON
+}
+
+// WithChildren implements the Expression interface.
+func (a *AsGeoJSON) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewAsGeoJSON(children...)
+}
+
+// Eval implements the Expression interface.
+func (a *AsGeoJSON) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	g, err := evalGeometry(ctx, a.FunctionName(), a.Geometry, row)
+	if g == nil || err != nil {
+		return nil, err
+	}
+
+	round := func(f float64) float64 { return f }
+	if a.MaxDigits != nil {
+		val, err := evalInt64(ctx, a.MaxDigits, row)
+		if val == nil || err != nil {
+			return nil, err
+		}
+		digits := val.(int64)
+		if digits < 0 {
+			return nil, ErrInvalidArgument.New(a.FunctionName(), "max_dec_digits must not be negative")
+		}
+		if digits < 17 {
+			scale := math.Pow10(int(digits))
+			round = func(f float64) float64 { return math.Round(f*scale) / scale }
+		}
+	}
+
+	point := func(p sql.PointValue) interface{} {
+		return []interface{}{round(p.X), round(p.Y)}
+	}
+	points := func(ps []sql.PointValue) interface{} {
+		coords := make([]interface{}, len(ps))
+		for i, p := range ps {
+			coords[i] = point(p)
+		}
+		return coords
+	}
+
+	var typ string
+	var coords interface{}
+	switch g := g.(type) {
+	case sql.PointValue:
+		typ, coords = "Point", point(g)
+	case sql.LineStringValue:
+		typ, coords = "LineString", points(g.Points)
+	case sql.PolygonValue:
+		rings := make([]interface{}, len(g.Rings))
+		for i, ring := range g.Rings {
+			rings[i] = points(ring.Points)
+		}
+		typ, coords = "Polygon", rings
+	}
+
+	return sql.JSONDocument{Val: map[string]interface{}{
+		"type":        typ,
+		"coordinates": coords,
+	}}, nil
+}
+
+func (a *AsGeoJSON) String() string {
+	if a.MaxDigits != nil {
+		return fmt.Sprintf("ST_ASGEOJSON(%s, %s)", a.Geometry, a.MaxDigits)
+	}
+	return fmt.Sprintf("ST_ASGEOJSON(%s)", a.Geometry)
+}
+
+// SRID returns the SRID of a geometry.
+type SRID struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*SRID)(nil)
+
+// NewSRID creates a new SRID UDF.
+func NewSRID(g sql.Expression) sql.Expression {
+	return &SRID{expression.UnaryExpression{Child: g}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *SRID) FunctionName() string {
+	return "st_srid"
+}
+
+func (s *SRID) String() string {
+	return fmt.Sprintf("ST_SRID(%s)", s.Child)
+}
+
+// Type implements the Expression interface.
+func (s *SRID) Type() sql.Type { return sql.Uint32 }
+
+// IsNullable implements the Expression interface.
+func (s *SRID) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (s *SRID) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	g, err := evalGeometry(ctx, s.FunctionName(), s.Child, row)
+	if g == nil || err != nil {
+		return nil, err
+	}
+	return g.GetSRID(), nil
+}
+
+// WithChildren implements the Expression interface.
+func (s *SRID) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 1)
+	}
+	return NewSRID(children[0]), nil
+}
+
+// STX returns the X coordinate of a point.
+type STX struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*STX)(nil)
+
+// NewSTX creates a new STX UDF.
+func NewSTX(p sql.Expression) sql.Expression {
+	return &STX{expression.UnaryExpression{Child: p}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *STX) FunctionName() string {
+	return "st_x"
+}
+
+func (s *STX) String() string {
+	return fmt.Sprintf("ST_X(%s)", s.Child)
+}
+
+// Type implements the Expression interface.
+func (s *STX) Type() sql.Type { return sql.Float64 }
+
+// IsNullable implements the Expression interface.
+func (s *STX) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (s *STX) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	p, err := evalPoint(ctx, s.FunctionName(), s.Child, row)
+	if p == nil || err != nil {
+		return nil, err
+	}
+	return p.X, nil
+}
+
+// WithChildren implements the Expression interface.
+func (s *STX) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 1)
+	}
+	return NewSTX(children[0]), nil
+}
+
+// STY returns the Y coordinate of a point.
+type STY struct {
+	expression.UnaryExpression
+}
+
+var _ sql.FunctionExpression = (*STY)(nil)
+
+// NewSTY creates a new STY UDF.
+func NewSTY(p sql.Expression) sql.Expression {
+	return &STY{expression.UnaryExpression{Child: p}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *STY) FunctionName() string {
+	return "st_y"
+}
+
+func (s *STY) String() string {
+	return fmt.Sprintf("ST_Y(%s)", s.Child)
+}
+
+// Type implements the Expression interface.
+func (s *STY) Type() sql.Type { return sql.Float64 }
+
+// IsNullable implements the Expression interface.
+func (s *STY) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (s *STY) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	p, err := evalPoint(ctx, s.FunctionName(), s.Child, row)
+	if p == nil || err != nil {
+		return nil, err
+	}
+	return p.Y, nil
+}
+
+// WithChildren implements the Expression interface.
+func (s *STY) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 1)
+	}
+	return NewSTY(children[0]), nil
+}
@@ -0,0 +1,628 @@
+package function
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+var (
+	// ErrSRIDMismatch is returned when the geometries given to a function
+	// have different SRIDs.
+	ErrSRIDMismatch = errors.NewKind("Binary geometry function %s given two geometries of different srids: %d and %d, which should have been identical.")
+
+	// ErrSpatialNotImplemented is returned when a function doesn't support
+	// the kinds of the geometries given to it.
+	ErrSpatialNotImplemented = errors.NewKind("%s(%s, %s) has not been implemented for Cartesian spatial reference systems.")
+
+	// ErrLongitudeOutOfRange is returned when the longitude of a point is
+	// not in the range (-180, 180].
+	ErrLongitudeOutOfRange = errors.NewKind("Longitude %v is out of range in function %s. It must be within (-180.000000, 180.000000].")
+
+	// ErrLatitudeOutOfRange is returned when the latitude of a point is not
+	// in the range [-90, 90].
+	ErrLatitudeOutOfRange = errors.NewKind("Latitude %v is out of range in function %s. It must be within [-90.000000, 90.000000].")
+
+	// ErrNonPositiveRadius is returned when the radius of a sphere is not
+	// positive.
+	ErrNonPositiveRadius = errors.NewKind("Invalid radius provided to function %s: Radius must be greater than zero.")
+)
+
+// earthRadius is the default radius of the sphere used by
+// ST_DISTANCE_SPHERE, in meters.
+const earthRadius = 6370986
+
+// evalGeometryPair evaluates the arguments of a function of two geometries,
+// which must have the same SRID. It returns nil geometries if any of them is
+// NULL.
+func evalGeometryPair(ctx *sql.Context, name string, left, right sql.Expression, row sql.Row) (sql.GeometryValue, sql.GeometryValue, error) {
+	a, err := evalGeometry(ctx, name, left, row)
+	if a == nil || err != nil {
+		return nil, nil, err
+	}
+
+	b, err := evalGeometry(ctx, name, right, row)
+	if b == nil || err != nil {
+		return nil, nil, err
+	}
+
+	if a.GetSRID() != b.GetSRID() {
+		return nil, nil, ErrSRIDMismatch.New(name, a.GetSRID(), b.GetSRID())
+	}
+	return a, b, nil
+}
+
+// Distance returns the distance between two geometries in the units of
+// their coordinates. Coordinates are taken as Cartesian, whatever their SRID.
+type Distance struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*Distance)(nil)
+
+// NewDistance creates a new Distance UDF.
+func NewDistance(g1, g2 sql.Expression) sql.Expression {
+	return &Distance{expression.BinaryExpression{Left: g1, Right: g2}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (d *Distance) FunctionName() string {
+	return "st_distance"
+}
+
+func (d *Distance) String() string {
+	return fmt.Sprintf("ST_DISTANCE(%s, %s)", d.Left, d.Right)
+}
+
+// Type implements the Expression interface.
+func (d *Distance) Type() sql.Type { return sql.Float64 }
+
+// IsNullable implements the Expression interface.
+func (d *Distance) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (d *Distance) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	a, b, err := evalGeometryPair(ctx, d.FunctionName(), d.Left, d.Right, row)
+	if a == nil || err != nil {
+		return nil, err
+	}
+	return geometryDistance(a, b), nil
+}
+
+// WithChildren implements the Expression interface.
+func (d *Distance) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(d, len(children), 2)
+	}
+	return NewDistance(children[0], children[1]), nil
+}
+
+// DistanceSphere returns the distance in meters between two points on a
+// sphere, whose X and Y coordinates are their longitude and latitude in
+// degrees. The radius of the sphere is that of the Earth unless it's given
+// as third argument.
+type DistanceSphere struct {
+	G1     sql.Expression
+	G2     sql.Expression
+	Radius sql.Expression
+}
+
+var _ sql.FunctionExpression = (*DistanceSphere)(nil)
+
+// NewDistanceSphere creates a new DistanceSphere UDF.
+func NewDistanceSphere(args ...sql.Expression) (sql.Expression, error) {
+	switch len(args) {
+	case 2:
+		return &DistanceSphere{args[0], args[1], nil}, nil
+	case 3:
+		return &DistanceSphere{args[0], args[1], args[2]}, nil
+	default:
+		return nil, sql.ErrInvalidArgumentNumber.New("ST_DISTANCE_SPHERE", "2 or 3", len(args))
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (d *DistanceSphere) FunctionName() string {
+	return "st_distance_sphere"
+}
+
+// Children implements the Expression interface.
+func (d *DistanceSphere) Children() []sql.Expression {
+	if d.Radius != nil {
+		return []sql.Expression{d.G1, d.G2, d.Radius}
+	}
+	return []sql.Expression{d.G1, d.G2}
+}
+
+// Resolved implements the Expression interface.
+func (d *DistanceSphere) Resolved() bool {
+	return d.G1.Resolved() && d.G2.Resolved() && (d.Radius == nil || d.Radius.Resolved())
+}
+
+// IsNullable implements the Expression interface.
+func (d *DistanceSphere) IsNullable() bool {
+	return true
+}
+
+// Type implements the Expression interface.
+func (d *DistanceSphere) Type() sql.Type {
+	return sql.Float64
+}
+
+// WithChildren implements the Expression interface.
+func (d *DistanceSphere) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewDistanceSphere(children...)
+}
+
+// Eval implements the Expression interface.
+func (d *DistanceSphere) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	a, b, err := evalGeometryPair(ctx, d.FunctionName(), d.G1, d.G2, row)
+	if a == nil || err != nil {
+		return nil, err
+	}
+
+	p1, ok1 := a.(sql.PointValue)
+	p2, ok2 := b.(sql.PointValue)
+	if !ok1 || !ok2 {
+		return nil, ErrSpatialNotImplemented.New(d.FunctionName(), a.Kind(), b.Kind())
+	}
+
+	radius := float64(earthRadius)
+	if d.Radius != nil {
+		val, err := evalFloat64(ctx, d.Radius, row)
+		if val == nil || err != nil {
+			return nil, err
+		}
+		radius = val.(float64)
+		if radius <= 0 {
+			return nil, ErrNonPositiveRadius.New(d.FunctionName())
+		}
+	}
+
+	for _, p := range []sql.PointValue{p1, p2} {
+		if p.X <= -180 || p.X > 180 {
+			return nil, ErrLongitudeOutOfRange.New(p.X, d.FunctionName())
+		}
+		if p.Y < -90 || p.Y > 90 {
+			return nil, ErrLatitudeOutOfRange.New(p.Y, d.FunctionName())
+		}
+	}
+
+	// Haversine formula.
+	toRadians := func(deg float64) float64 { return deg * math.Pi / 180 }
+	lat1, lat2 := toRadians(p1.Y), toRadians(p2.Y)
+	dLat := lat2 - lat1
+	dLon := toRadians(p2.X - p1.X)
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * radius * math.Asin(math.Min(1, math.Sqrt(h))), nil
+}
+
+func (d *DistanceSphere) String() string {
+	if d.Radius != nil {
+		return fmt.Sprintf("ST_DISTANCE_SPHERE(%s, %s, %s)", d.G1, d.G2, d.Radius)
+	}
+	return fmt.Sprintf("ST_DISTANCE_SPHERE(%s, %s)", d.G1, d.G2)
+}
+
+// Contains returns whether the first geometry contains the second one, that
+// is, whether no point of the second geometry is in the exterior of the
+// first one and their interiors have at least a point in common.
+type Contains struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*Contains)(nil)
+
+// NewContains creates a new Contains UDF.
+func NewContains(g1, g2 sql.Expression) sql.Expression {
+	return &Contains{expression.BinaryExpression{Left: g1, Right: g2}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (c *Contains) FunctionName() string {
+	return "st_contains"
+}
+
+func (c *Contains) String() string {
+	return fmt.Sprintf("ST_CONTAINS(%s, %s)", c.Left, c.Right)
+}
+
+// Type implements the Expression interface.
+func (c *Contains) Type() sql.Type { return sql.Boolean }
+
+// IsNullable implements the Expression interface.
+func (c *Contains) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (c *Contains) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	a, b, err := evalGeometryPair(ctx, c.FunctionName(), c.Left, c.Right, row)
+	if a == nil || err != nil {
+		return nil, err
+	}
+	return geometryContains(a, b), nil
+}
+
+// WithChildren implements the Expression interface.
+func (c *Contains) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(c, len(children), 2)
+	}
+	return NewContains(children[0], children[1]), nil
+}
+
+// Within returns whether the first geometry is within the second one, that
+// is, whether the second geometry contains the first one.
+type Within struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*Within)(nil)
+
+// NewWithin creates a new Within UDF.
+func NewWithin(g1, g2 sql.Expression) sql.Expression {
+	return &Within{expression.BinaryExpression{Left: g1, Right: g2}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (w *Within) FunctionName() string {
+	return "st_within"
+}
+
+func (w *Within) String() string {
+	return fmt.Sprintf("ST_WITHIN(%s, %s)", w.Left, w.Right)
+}
+
+// Type implements the Expression interface.
+func (w *Within) Type() sql.Type { return sql.Boolean }
+
+// IsNullable implements the Expression interface.
+func (w *Within) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (w *Within) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	a, b, err := evalGeometryPair(ctx, w.FunctionName(), w.Left, w.Right, row)
+	if a == nil || err != nil {
+		return nil, err
+	}
+	return geometryContains(b, a), nil
+}
+
+// WithChildren implements the Expression interface.
+func (w *Within) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(w, len(children), 2)
+	}
+	return NewWithin(children[0], children[1]), nil
+}
+
+// Intersects returns whether two geometries have at least a point in common.
+type Intersects struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*Intersects)(nil)
+
+// NewIntersects creates a new Intersects UDF.
+func NewIntersects(g1, g2 sql.Expression) sql.Expression {
+	return &Intersects{expression.BinaryExpression{Left: g1, Right: g2}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (i *Intersects) FunctionName() string {
+	return "st_intersects"
+}
+
+func (i *Intersects) String() string {
+	return fmt.Sprintf("ST_INTERSECTS(%s, %s)", i.Left, i.Right)
+}
+
+// Type implements the Expression interface.
+func (i *Intersects) Type() sql.Type { return sql.Boolean }
+
+// IsNullable implements the Expression interface.
+func (i *Intersects) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (i *Intersects) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	a, b, err := evalGeometryPair(ctx, i.FunctionName(), i.Left, i.Right, row)
+	if a == nil || err != nil {
+		return nil, err
+	}
+	return geometryIntersects(a, b), nil
+}
+
+// WithChildren implements the Expression interface.
+func (i *Intersects) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(i, len(children), 2)
+	}
+	return NewIntersects(children[0], children[1]), nil
+}
+
+// segment is the segment between two points, which may be the same point.
+type segment struct {
+	a, b sql.PointValue
+}
+
+// segments returns the segments of a geometry. A point is a single
+// degenerate segment.
+func segments(g sql.GeometryValue) []segment {
+	lineSegments := func(points []sql.PointValue) []segment {
+		segs := make([]segment, 0, len(points)-1)
+		for i := 1; i < len(points); i++ {
+			segs = append(segs, segment{points[i-1], points[i]})
+		}
+		return segs
+	}
+
+	switch g := g.(type) {
+	case sql.PointValue:
+		return []segment{{g, g}}
+	case sql.LineStringValue:
+		return lineSegments(g.Points)
+	case sql.PolygonValue:
+		var segs []segment
+		for _, ring := range g.Rings {
+			segs = append(segs, lineSegments(ring.Points)...)
+		}
+		return segs
+	default:
+		return nil
+	}
+}
+
+// vertices returns the points that define a geometry.
+func vertices(g sql.GeometryValue) []sql.PointValue {
+	switch g := g.(type) {
+	case sql.PointValue:
+		return []sql.PointValue{g}
+	case sql.LineStringValue:
+		return g.Points
+	case sql.PolygonValue:
+		var points []sql.PointValue
+		for _, ring := range g.Rings {
+			points = append(points, ring.Points...)
+		}
+		return points
+	default:
+		return nil
+	}
+}
+
+// orientation returns a positive number if c is to the left of the line
+// from a to b, a negative one if it's to the right and 0 if it's on it.
+func orientation(a, b, c sql.PointValue) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
+
+// inBoundingBox returns whether p is in the bounding box of s.
+func inBoundingBox(p sql.PointValue, s segment) bool {
+	return p.X >= math.Min(s.a.X, s.b.X) && p.X <= math.Max(s.a.X, s.b.X) &&
+		p.Y >= math.Min(s.a.Y, s.b.Y) && p.Y <= math.Max(s.a.Y, s.b.Y)
+}
+
+func onSegment(p sql.PointValue, s segment) bool {
+	return orientation(s.a, s.b, p) == 0 && inBoundingBox(p, s)
+}
+
+func segmentsIntersect(s, t segment) bool {
+	d1 := orientation(t.a, t.b, s.a)
+	d2 := orientation(t.a, t.b, s.b)
+	d3 := orientation(s.a, s.b, t.a)
+	d4 := orientation(s.a, s.b, t.b)
+
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+
+	return (d1 == 0 && inBoundingBox(s.a, t)) ||
+		(d2 == 0 && inBoundingBox(s.b, t)) ||
+		(d3 == 0 && inBoundingBox(t.a, s)) ||
+		(d4 == 0 && inBoundingBox(t.b, s))
+}
+
+func pointDistance(p, q sql.PointValue) float64 {
+	return math.Hypot(p.X-q.X, p.Y-q.Y)
+}
+
+func pointSegmentDistance(p sql.PointValue, s segment) float64 {
+	dx, dy := s.b.X-s.a.X, s.b.Y-s.a.Y
+	if dx == 0 && dy == 0 {
+		return pointDistance(p, s.a)
+	}
+
+	t := ((p.X-s.a.X)*dx + (p.Y-s.a.Y)*dy) / (dx*dx + dy*dy)
+	t = math.Max(0, math.Min(1, t))
+	return pointDistance(p, sql.PointValue{X: s.a.X + t*dx, Y: s.a.Y + t*dy})
+}
+
+func segmentDistance(s, t segment) float64 {
+	if segmentsIntersect(s, t) {
+		return 0
+	}
+	return math.Min(
+		math.Min(pointSegmentDistance(s.a, t), pointSegmentDistance(s.b, t)),
+		math.Min(pointSegmentDistance(t.a, s), pointSegmentDistance(t.b, s)),
+	)
+}
+
+// The locations of a point relative to a geometry.
+const (
+	exterior = iota
+	boundary
+	interior
+)
+
+// ringContains returns whether a point not on a ring is inside it.
+func ringContains(ring []sql.PointValue, p sql.PointValue) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// locate returns whether a point is in the exterior, the boundary or the
+// interior of a geometry. The boundary of a line string is made of its
+// endpoints, unless it's closed, and that of a polygon of its rings.
+func locate(g sql.GeometryValue, p sql.PointValue) int {
+	switch g := g.(type) {
+	case sql.PointValue:
+		if g.X == p.X && g.Y == p.Y {
+			return interior
+		}
+	case sql.LineStringValue:
+		first, last := g.Points[0], g.Points[len(g.Points)-1]
+		if first != last && (p == first || p == last) {
+			return boundary
+		}
+		for _, s := range segments(g) {
+			if onSegment(p, s) {
+				return interior
+			}
+		}
+	case sql.PolygonValue:
+		for _, s := range segments(g) {
+			if onSegment(p, s) {
+				return boundary
+			}
+		}
+		if !ringContains(g.Rings[0].Points, p) {
+			return exterior
+		}
+		for _, hole := range g.Rings[1:] {
+			if ringContains(hole.Points, p) {
+				return exterior
+			}
+		}
+		return interior
+	}
+	return exterior
+}
+
+func geometryIntersects(a, b sql.GeometryValue) bool {
+	for _, s := range segments(a) {
+		for _, t := range segments(b) {
+			if segmentsIntersect(s, t) {
+				return true
+			}
+		}
+	}
+
+	// With no crossing boundaries, a geometry can only intersect a polygon
+	// by being inside it, and the other way around.
+	if _, ok := a.(sql.PolygonValue); ok && locate(a, vertices(b)[0]) != exterior {
+		return true
+	}
+	if _, ok := b.(sql.PolygonValue); ok && locate(b, vertices(a)[0]) != exterior {
+		return true
+	}
+	return false
+}
+
+func geometryDistance(a, b sql.GeometryValue) float64 {
+	if geometryIntersects(a, b) {
+		return 0
+	}
+
+	dist := math.Inf(1)
+	for _, s := range segments(a) {
+		for _, t := range segments(b) {
+			dist = math.Min(dist, segmentDistance(s, t))
+		}
+	}
+	return dist
+}
+
+// splitSegment returns the points at which the segments of g cross or touch
+// s, along with the endpoints of s, sorted from s.a to s.b. Each part of s
+// between two consecutive points is entirely in the exterior, the boundary or
+// the interior of g.
+func splitSegment(s segment, g sql.GeometryValue) []sql.PointValue {
+	dx, dy := s.b.X-s.a.X, s.b.Y-s.a.Y
+	param := func(p sql.PointValue) float64 {
+		if math.Abs(dx) > math.Abs(dy) {
+			return (p.X - s.a.X) / dx
+		}
+		return (p.Y - s.a.Y) / dy
+	}
+
+	params := []float64{0, 1}
+	for _, v := range vertices(g) {
+		if onSegment(v, s) {
+			params = append(params, param(v))
+		}
+	}
+	for _, t := range segments(g) {
+		tdx, tdy := t.b.X-t.a.X, t.b.Y-t.a.Y
+		denom := dx*tdy - dy*tdx
+		if denom != 0 && segmentsIntersect(s, t) {
+			params = append(params, ((t.a.X-s.a.X)*tdy-(t.a.Y-s.a.Y)*tdx)/denom)
+		}
+	}
+	sort.Float64s(params)
+
+	points := make([]sql.PointValue, 0, len(params))
+	for i, t := range params {
+		if i > 0 && t == params[i-1] {
+			continue
+		}
+		points = append(points, sql.PointValue{X: s.a.X + t*dx, Y: s.a.Y + t*dy})
+	}
+	return points
+}
+
+func geometryContains(a, b sql.GeometryValue) bool {
+	switch b := b.(type) {
+	case sql.PointValue:
+		return locate(a, b) == interior
+	case sql.PolygonValue:
+		if _, ok := a.(sql.PolygonValue); !ok {
+			return false
+		}
+	}
+
+	// No point of b may be in the exterior of a, which is checked at the
+	// vertices of b and at the midpoints of the parts its segments are split
+	// into by the segments of a. The midpoints are all in the interior of b.
+	interiorsIntersect := false
+	for _, v := range vertices(b) {
+		if locate(a, v) == exterior {
+			return false
+		}
+	}
+	for _, s := range segments(b) {
+		points := splitSegment(s, a)
+		for i := 1; i < len(points); i++ {
+			mid := sql.PointValue{X: (points[i-1].X + points[i].X) / 2, Y: (points[i-1].Y + points[i].Y) / 2}
+			switch locate(a, mid) {
+			case exterior:
+				return false
+			case interior:
+				interiorsIntersect = true
+			}
+		}
+	}
+
+	if b, ok := b.(sql.PolygonValue); ok {
+		// The boundary of b is in a, so the interior of b is too unless it
+		// surrounds a hole of a.
+		for _, hole := range a.(sql.PolygonValue).Rings[1:] {
+			for _, v := range hole.Points {
+				if locate(b, v) == interior {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	return interiorsIntersect
+}
@@ -0,0 +1,228 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func geometryLiteral(t *testing.T, wkt string) sql.Expression {
+	g, err := sql.ParseWKT(wkt, 0)
+	require.NoError(t, err)
+	return expression.NewLiteral(g, sql.Geometry)
+}
+
+func TestGeomFromText(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	f, err := NewGeomFromText(expression.NewLiteral("POINT(1 2)", sql.LongText))
+	require.NoError(err)
+	v, err := f.Eval(ctx, nil)
+	require.NoError(err)
+	require.Equal(sql.PointValue{X: 1, Y: 2}, v)
+
+	f, err = NewGeomFromText(expression.NewLiteral("LINESTRING(0 0,1 1)", sql.LongText), expression.NewLiteral(int64(4326), sql.Int64))
+	require.NoError(err)
+	v, err = f.Eval(ctx, nil)
+	require.NoError(err)
+	require.Equal(sql.LineStringValue{SRID: 4326, Points: []sql.PointValue{{X: 0, Y: 0}, {X: 1, Y: 1}}}, v)
+
+	f, err = NewGeomFromText(expression.NewLiteral("POINT(1)", sql.LongText))
+	require.NoError(err)
+	_, err = f.Eval(ctx, nil)
+	require.True(ErrInvalidGISData.Is(err))
+
+	f, err = NewGeomFromText(expression.NewLiteral("POINT(1 2)", sql.LongText), expression.NewLiteral(int64(-1), sql.Int64))
+	require.NoError(err)
+	_, err = f.Eval(ctx, nil)
+	require.True(ErrInvalidArgument.Is(err))
+
+	f, err = NewGeomFromText(expression.NewLiteral(nil, sql.Null))
+	require.NoError(err)
+	v, err = f.Eval(ctx, nil)
+	require.NoError(err)
+	require.Nil(v)
+
+	_, err = NewGeomFromText()
+	require.True(sql.ErrInvalidArgumentNumber.Is(err))
+}
+
+func TestSpatialAccessors(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	point := expression.NewLiteral(sql.PointValue{SRID: 4326, X: 1.5, Y: -2}, sql.Point)
+	line := geometryLiteral(t, "LINESTRING(0 0,1.123456 1)")
+	polygon := geometryLiteral(t, "POLYGON((0 0,1 0,1 1,0 0))")
+
+	testCases := []struct {
+		name     string
+		f        sql.Expression
+		expected interface{}
+	}{
+		{"point", NewPoint(expression.NewLiteral(int64(3), sql.Int64), expression.NewLiteral(4.5, sql.Float64)), sql.PointValue{X: 3, Y: 4.5}},
+		{"astext point", NewAsText(point), "POINT(1.5 -2)"},
+		{"astext polygon", NewAsText(polygon), "POLYGON((0 0,1 0,1 1,0 0))"},
+		{"astext serialized", NewAsText(expression.NewLiteral(string(sql.SerializeGeometry(sql.PointValue{X: 1, Y: 2})), sql.LongBlob)), "POINT(1 2)"},
+		{"srid", NewSRID(point), uint32(4326)},
+		{"x", NewSTX(point), 1.5},
+		{"y", NewSTY(point), -2.0},
+		{"x null", NewSTX(expression.NewLiteral(nil, sql.Null)), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := tt.f.Eval(ctx, nil)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+
+	_, err := NewSTX(line).Eval(ctx, nil)
+	require.True(ErrUnexpectedGeometryType.Is(err))
+
+	_, err = NewAsText(expression.NewLiteral("POINT(1 2)", sql.LongText)).Eval(ctx, nil)
+	require.True(ErrInvalidGISData.Is(err))
+
+	f, err := NewAsGeoJSON(point)
+	require.NoError(err)
+	v, err := f.Eval(ctx, nil)
+	require.NoError(err)
+	require.Equal(`{"type": "Point", "coordinates": [1.5, -2.0]}`, v.(sql.JSONDocument).String())
+
+	f, err = NewAsGeoJSON(line, expression.NewLiteral(int64(2), sql.Int64))
+	require.NoError(err)
+	v, err = f.Eval(ctx, nil)
+	require.NoError(err)
+	require.Equal(`{"type": "LineString", "coordinates": [[0.0, 0.0], [1.12, 1.0]]}`, v.(sql.JSONDocument).String())
+
+	f, err = NewAsGeoJSON(polygon)
+	require.NoError(err)
+	v, err = f.Eval(ctx, nil)
+	require.NoError(err)
+	require.Equal(`{"type": "Polygon", "coordinates": [[[0.0, 0.0], [1.0, 0.0], [1.0, 1.0], [0.0, 0.0]]]}`, v.(sql.JSONDocument).String())
+}
+
+func TestSpatialRelations(t *testing.T) {
+	square := "POLYGON((0 0,4 0,4 4,0 4,0 0))"
+	squareWithHole := "POLYGON((0 0,4 0,4 4,0 4,0 0),(1 1,3 1,3 3,1 3,1 1))"
+
+	testCases := []struct {
+		g1, g2     string
+		distance   float64
+		contains   bool
+		within     bool
+		intersects bool
+	}{
+		{"POINT(0 0)", "POINT(3 4)", 5, false, false, false},
+		{"POINT(1 1)", "POINT(1 1)", 0, true, true, true},
+		{"LINESTRING(0 0,2 0)", "POINT(1 1)", 1, false, false, false},
+		{"LINESTRING(0 0,2 0)", "POINT(1 0)", 0, true, false, true},
+		{"LINESTRING(0 0,2 0)", "POINT(2 0)", 0, false, false, true},
+		{"LINESTRING(0 0,4 0)", "LINESTRING(1 0,3 0)", 0, true, false, true},
+		{"LINESTRING(0 0,2 2)", "LINESTRING(0 2,2 0)", 0, false, false, true},
+		{"LINESTRING(0 0,1 0)", "LINESTRING(0 3,1 3)", 3, false, false, false},
+		{square, "POINT(2 2)", 0, true, false, true},
+		{square, "POINT(4 2)", 0, false, false, true},
+		{square, "POINT(7 8)", 5, false, false, false},
+		{square, "LINESTRING(1 1,3 3)", 0, true, false, true},
+		{square, "LINESTRING(0 0,4 0)", 0, false, false, true},
+		{square, "LINESTRING(2 2,6 2)", 0, false, false, true},
+		{square, "POLYGON((1 1,2 1,2 2,1 1))", 0, true, false, true},
+		{square, square, 0, true, true, true},
+		{square, "POLYGON((5 0,6 0,6 1,5 0))", 1, false, false, false},
+		{squareWithHole, "POINT(2 2)", 1, false, false, false},
+		{squareWithHole, "POINT(0.5 0.5)", 0, true, false, true},
+		{squareWithHole, "LINESTRING(0.5 2,3.5 2)", 0, false, false, true},
+		{squareWithHole, "POLYGON((0.5 0.5,3.5 0.5,3.5 3.5,0.5 3.5,0.5 0.5))", 0, false, false, true},
+		{squareWithHole, "POLYGON((1.5 1.5,2.5 1.5,2.5 2.5,1.5 2.5,1.5 1.5))", 0.5, false, false, false},
+		{"POLYGON((1.5 1.5,2.5 1.5,2.5 2.5,1.5 2.5,1.5 1.5))", square, 0, false, true, true},
+	}
+
+	ctx := sql.NewEmptyContext()
+	for _, tt := range testCases {
+		t.Run(tt.g1+" "+tt.g2, func(t *testing.T) {
+			require := require.New(t)
+			g1, g2 := geometryLiteral(t, tt.g1), geometryLiteral(t, tt.g2)
+
+			v, err := NewDistance(g1, g2).Eval(ctx, nil)
+			require.NoError(err)
+			require.InDelta(tt.distance, v, 1e-9)
+
+			v, err = NewContains(g1, g2).Eval(ctx, nil)
+			require.NoError(err)
+			require.Equal(tt.contains, v, "contains")
+
+			v, err = NewWithin(g1, g2).Eval(ctx, nil)
+			require.NoError(err)
+			require.Equal(tt.within, v, "within")
+
+			v, err = NewIntersects(g1, g2).Eval(ctx, nil)
+			require.NoError(err)
+			require.Equal(tt.intersects, v, "intersects")
+
+			v, err = NewIntersects(g2, g1).Eval(ctx, nil)
+			require.NoError(err)
+			require.Equal(tt.intersects, v, "intersects reversed")
+		})
+	}
+
+	_, err := NewContains(
+		expression.NewLiteral(sql.PointValue{SRID: 4326}, sql.Point),
+		expression.NewLiteral(sql.PointValue{}, sql.Point),
+	).Eval(ctx, nil)
+	require.True(t, ErrSRIDMismatch.Is(err))
+
+	v, err := NewIntersects(geometryLiteral(t, square), expression.NewLiteral(nil, sql.Null)).Eval(ctx, nil)
+	require.NoError(t, err)
+	require.Nil(t, v)
+}
+
+func TestDistanceSphere(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	point := func(lon, lat float64) sql.Expression {
+		return expression.NewLiteral(sql.PointValue{X: lon, Y: lat}, sql.Point)
+	}
+
+	// From Paris to London.
+	f, err := NewDistanceSphere(point(2.3522, 48.8566), point(-0.1276, 51.5072))
+	require.NoError(err)
+	v, err := f.Eval(ctx, nil)
+	require.NoError(err)
+	require.InDelta(343529.11, v, 0.01)
+
+	f, err = NewDistanceSphere(point(0, 0), point(180, 0), expression.NewLiteral(int64(1), sql.Int64))
+	require.NoError(err)
+	v, err = f.Eval(ctx, nil)
+	require.NoError(err)
+	require.InDelta(3.141592653589793, v, 1e-12)
+
+	f, err = NewDistanceSphere(point(0, 0), point(0, 0), expression.NewLiteral(int64(0), sql.Int64))
+	require.NoError(err)
+	_, err = f.Eval(ctx, nil)
+	require.True(ErrNonPositiveRadius.Is(err))
+
+	f, err = NewDistanceSphere(point(-180, 0), point(0, 0))
+	require.NoError(err)
+	_, err = f.Eval(ctx, nil)
+	require.True(ErrLongitudeOutOfRange.Is(err))
+
+	f, err = NewDistanceSphere(point(0, 91), point(0, 0))
+	require.NoError(err)
+	_, err = f.Eval(ctx, nil)
+	require.True(ErrLatitudeOutOfRange.Is(err))
+
+	f, err = NewDistanceSphere(geometryLiteral(t, "LINESTRING(0 0,1 1)"), point(0, 0))
+	require.NoError(err)
+	_, err = f.Eval(ctx, nil)
+	require.True(ErrSpatialNotImplemented.Is(err))
+
+	_, err = NewDistanceSphere(point(0, 0))
+	require.True(sql.ErrInvalidArgumentNumber.Is(err))
+}
@@ -0,0 +1,560 @@
+package function
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// parsedDate holds the parts of a date parsed by STR_TO_DATE. The parts not
+// given in the parsed string are zero.
+type parsedDate struct {
+	year, month, day                  int
+	hour, minute, second, microsecond int
+	yearDay                           int
+
+	// usaTime is set when the hour is given in the 12-hour format, with pm
+	// telling whether it's after noon.
+	usaTime bool
+	pm      bool
+
+	// weekday is the day of the week, from 1 for Monday to 7 for Sunday, and
+	// zero when not given. It's used, with the week number and its year, to
+	// compute the date.
+	weekday          int
+	weekNumber       int
+	sundayFirst      bool
+	strictWeek       bool
+	weekYear         int
+	weekYearSunFirst bool
+}
+
+// dateParser parses the value of a date format specifier at the start of the
+// given string into the given date, returning the rest of the string.
+type dateParser func(d *parsedDate, s string) (string, bool)
+
+var (
+	monthNames            = []string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}
+	abbreviatedMonthNames = []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
+	dayNames              = []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+	abbreviatedDayNames   = []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+)
+
+// specifierToParser has the parsers of the values of the specifiers in
+// specifierToFunc, so STR_TO_DATE accepts the same formats as DATE_FORMAT.
+var specifierToParser = map[byte]dateParser{
+	'a': nameParser(abbreviatedDayNames, func(d *parsedDate, i int) { d.weekday = i + 1 }),
+	'b': nameParser(abbreviatedMonthNames, func(d *parsedDate, i int) { d.month = i + 1 }),
+	'c': numberParser(2, func(d *parsedDate, n int) bool { d.month = n; return true }),
+	'D': parseDayWithSuffix,
+	'd': numberParser(2, func(d *parsedDate, n int) bool { d.day = n; return true }),
+	'e': numberParser(2, func(d *parsedDate, n int) bool { d.day = n; return true }),
+	'f': parseMicroseconds,
+	'H': numberParser(2, func(d *parsedDate, n int) bool { d.hour = n; return true }),
+	'h': numberParser(2, setTwelveHour),
+	'I': numberParser(2, setTwelveHour),
+	'i': numberParser(2, func(d *parsedDate, n int) bool { d.minute = n; return true }),
+	'j': numberParser(3, func(d *parsedDate, n int) bool { d.yearDay = n; return n > 0 }),
+	'k': numberParser(2, func(d *parsedDate, n int) bool { d.hour = n; return true }),
+	'l': numberParser(2, setTwelveHour),
+	'M': nameParser(monthNames, func(d *parsedDate, i int) { d.month = i + 1 }),
+	'm': numberParser(2, func(d *parsedDate, n int) bool { d.month = n; return true }),
+	'p': parseAMPM,
+	'S': numberParser(2, func(d *parsedDate, n int) bool { d.second = n; return true }),
+	's': numberParser(2, func(d *parsedDate, n int) bool { d.second = n; return true }),
+	'U': weekParser(true, false),
+	'u': weekParser(false, false),
+	'V': weekParser(true, true),
+	'v': weekParser(false, true),
+	'W': nameParser(dayNames, func(d *parsedDate, i int) { d.weekday = i + 1 }),
+	'w': numberParser(1, setWeekday),
+	'X': weekYearParser(true),
+	'x': weekYearParser(false),
+	'Y': parseYear,
+	'y': numberParser(2, func(d *parsedDate, n int) bool { d.year = twoDigitYear(n); return true }),
+}
+
+func init() {
+	// %r and %T are parsed as the formats they are shorthands for.
+	specifierToParser['r'] = formatParser("%I:%i:%S %p")
+	specifierToParser['T'] = formatParser("%H:%i:%S")
+}
+
+// parseNumber returns the number made of at most the given digits at the
+// start of the string and the rest of it.
+func parseNumber(s string, maxDigits int) (int, string, bool) {
+	n, i := 0, 0
+	for ; i < len(s) && i < maxDigits && s[i] >= '0' && s[i] <= '9'; i++ {
+		n = n*10 + int(s[i]-'0')
+	}
+	return n, s[i:], i > 0
+}
+
+func numberParser(maxDigits int, set func(d *parsedDate, n int) bool) dateParser {
+	return func(d *parsedDate, s string) (string, bool) {
+		n, rest, ok := parseNumber(s, maxDigits)
+		if !ok || !set(d, n) {
+			return "", false
+		}
+		return rest, true
+	}
+}
+
+// nameParser returns a parser of the given names, which are matched with the
+// word at the start of the string ignoring the case.
+func nameParser(names []string, set func(d *parsedDate, i int)) dateParser {
+	return func(d *parsedDate, s string) (string, bool) {
+		end := strings.IndexFunc(s, func(r rune) bool { return !unicode.IsLetter(r) })
+		if end < 0 {
+			end = len(s)
+		}
+		for i, name := range names {
+			if strings.EqualFold(s[:end], name) {
+				set(d, i)
+				return s[end:], true
+			}
+		}
+		return "", false
+	}
+}
+
+func formatParser(format string) dateParser {
+	return func(d *parsedDate, s string) (string, bool) {
+		return d.parse(s, format)
+	}
+}
+
+func weekParser(sundayFirst, strict bool) dateParser {
+	return numberParser(2, func(d *parsedDate, n int) bool {
+		d.weekNumber = n
+		d.sundayFirst = sundayFirst
+		d.strictWeek = strict
+		return n <= 53 && (!strict || n > 0)
+	})
+}
+
+func weekYearParser(sundayFirst bool) dateParser {
+	return numberParser(4, func(d *parsedDate, n int) bool {
+		d.weekYear = n
+		d.weekYearSunFirst = sundayFirst
+		return true
+	})
+}
+
+func setTwelveHour(d *parsedDate, n int) bool {
+	d.hour = n
+	d.usaTime = true
+	return true
+}
+
+func setWeekday(d *parsedDate, n int) bool {
+	if n > 6 {
+		return false
+	}
+	if n == 0 {
+		n = 7
+	}
+	d.weekday = n
+	return true
+}
+
+// twoDigitYear returns the year given with two digits, which is in the range
+// 1970-2069.
+func twoDigitYear(year int) int {
+	if year < 70 {
+		return year + 2000
+	}
+	return year + 1900
+}
+
+func parseYear(d *parsedDate, s string) (string, bool) {
+	n, rest, ok := parseNumber(s, 4)
+	if !ok {
+		return "", false
+	}
+	d.year = n
+	if len(s)-len(rest) <= 2 {
+		d.year = twoDigitYear(n)
+	}
+	return rest, true
+}
+
+func parseDayWithSuffix(d *parsedDate, s string) (string, bool) {
+	n, rest, ok := parseNumber(s, 2)
+	if !ok {
+		return "", false
+	}
+	d.day = n
+	// skip the suffix, such as "st" or "th"
+	if len(rest) >= 2 {
+		return rest[2:], true
+	}
+	return "", true
+}
+
+func parseMicroseconds(d *parsedDate, s string) (string, bool) {
+	n, rest, ok := parseNumber(s, 6)
+	if !ok {
+		return "", false
+	}
+	for i := len(s) - len(rest); i < 6; i++ {
+		n *= 10
+	}
+	d.microsecond = n
+	return rest, true
+}
+
+func parseAMPM(d *parsedDate, s string) (string, bool) {
+	if !d.usaTime || len(s) < 2 {
+		return "", false
+	}
+	switch strings.ToUpper(s[:2]) {
+	case "AM":
+		d.pm = false
+	case "PM":
+		d.pm = true
+	default:
+		return "", false
+	}
+	return s[2:], true
+}
+
+// parse parses the given string with the given format into the date, and
+// returns the rest of the string. As MySQL does, spaces are skipped before
+// each part of the format, and the parsing stops at the end of either the
+// string or the format.
+func (d *parsedDate) parse(s, format string) (string, bool) {
+	for i := 0; i < len(format); i++ {
+		s = strings.TrimLeftFunc(s, unicode.IsSpace)
+		if s == "" {
+			break
+		}
+
+		c := format[i]
+		switch {
+		case c == '%' && i+1 < len(format):
+			i++
+			if format[i] == '%' {
+				if s[0] != '%' {
+					return "", false
+				}
+				s = s[1:]
+				continue
+			}
+
+			parser, ok := specifierToParser[format[i]]
+			if !ok {
+				return "", false
+			}
+			if s, ok = parser(d, s); !ok {
+				return "", false
+			}
+		case unicode.IsSpace(rune(c)):
+		default:
+			if s[0] != c {
+				return "", false
+			}
+			s = s[1:]
+		}
+	}
+	return s, true
+}
+
+// maxDaynr is the day number of 9999-12-31.
+const maxDaynr = 3652424
+
+// resolve computes the date from the day of the year or the week, and
+// checks that the parts of the date are in range.
+func (d *parsedDate) resolve() bool {
+	if d.usaTime {
+		if d.hour < 1 || d.hour > 12 {
+			return false
+		}
+		d.hour %= 12
+		if d.pm {
+			d.hour += 12
+		}
+	}
+
+	if d.yearDay > 0 {
+		days := int(calcDaynr(int32(d.year), 1, 1)) + d.yearDay - 1
+		if days <= 0 || days > maxDaynr {
+			return false
+		}
+		d.setDaynr(days)
+	}
+
+	if d.weekNumber >= 0 && d.weekday > 0 {
+		// %V and %v need the year of %X and %x respectively, and %U and %u
+		// can't be used with them.
+		if d.strictWeek && (d.weekYear < 0 || d.weekYearSunFirst != d.sundayFirst) {
+			return false
+		}
+		if !d.strictWeek && d.weekYear >= 0 {
+			return false
+		}
+
+		year := d.year
+		if d.strictWeek {
+			year = d.weekYear
+		}
+
+		days := int(calcDaynr(int32(year), 1, 1))
+		firstWeekday := int(calcWeekday(int32(days), d.sundayFirst))
+		if d.sundayFirst {
+			if firstWeekday != 0 {
+				days += 7 - firstWeekday
+			}
+			days += (d.weekNumber-1)*7 + d.weekday%7
+		} else {
+			if firstWeekday > 3 {
+				days += 7
+			}
+			days += (d.weekNumber-1)*7 + d.weekday - 1 - firstWeekday
+		}
+
+		if days <= 0 || days > maxDaynr {
+			return false
+		}
+		d.setDaynr(days)
+	}
+
+	return d.month <= 12 && d.day <= 31 && d.hour <= 23 && d.minute <= 59 && d.second <= 59
+}
+
+func (d *parsedDate) setDaynr(days int) {
+	t := dateFromDaynr(int64(days))
+	d.year, d.month, d.day = t.Year(), int(t.Month()), t.Day()
+}
+
+// datetime returns the date as a datetime, or false if it has zero parts or
+// it's not a valid date, such as February 30.
+func (d *parsedDate) datetime() (time.Time, bool) {
+	if d.month == 0 || d.day == 0 {
+		return time.Time{}, false
+	}
+	t := time.Date(d.year, time.Month(d.month), d.day, d.hour, d.minute, d.second, d.microsecond*int(time.Microsecond), time.UTC)
+	if t.Day() != d.day {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+func (d *parsedDate) duration() time.Duration {
+	return time.Duration(d.hour)*time.Hour +
+		time.Duration(d.minute)*time.Minute +
+		time.Duration(d.second)*time.Second +
+		time.Duration(d.microsecond)*time.Microsecond
+}
+
+// strToDateType returns the type of the result of STR_TO_DATE with the given
+// format, which is DATE or TIME when the format only has date or time parts,
+// and DATETIME otherwise.
+func strToDateType(format string) sql.Type {
+	var hasDate, hasTime bool
+	for i := 0; i+1 < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		i++
+		switch {
+		case strings.IndexByte("abcDdejMmUuVvWwXxYy", format[i]) >= 0:
+			hasDate = true
+		case strings.IndexByte("fHhIiklpSsTr", format[i]) >= 0:
+			hasTime = true
+		}
+	}
+
+	switch {
+	case hasDate && !hasTime:
+		return sql.Date
+	case hasTime && !hasDate:
+		return sql.Time
+	default:
+		return sql.Datetime
+	}
+}
+
+// StrToDate parses a string into a date, a time or a datetime with a format
+// made of the specifiers of DATE_FORMAT. It returns NULL if the string cannot
+// be parsed or it's not a valid date.
+type StrToDate struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*StrToDate)(nil)
+
+// NewStrToDate returns a new StrToDate UDF.
+func NewStrToDate(str, format sql.Expression) sql.Expression {
+	return &StrToDate{expression.BinaryExpression{Left: str, Right: format}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *StrToDate) FunctionName() string {
+	return "str_to_date"
+}
+
+func (s *StrToDate) String() string {
+	return fmt.Sprintf("STR_TO_DATE(%s, %s)", s.Left, s.Right)
+}
+
+// Type implements the Expression interface. As the type of the result depends
+// on the format, it's only known when the format is a literal.
+func (s *StrToDate) Type() sql.Type {
+	if lit, ok := s.Right.(*expression.Literal); ok {
+		if format, ok := lit.Value().(string); ok {
+			return strToDateType(format)
+		}
+	}
+	return sql.Datetime
+}
+
+// IsNullable implements the Expression interface.
+func (s *StrToDate) IsNullable() bool {
+	return true
+}
+
+// WithChildren implements the Expression interface.
+func (s *StrToDate) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 2)
+	}
+	return NewStrToDate(children[0], children[1]), nil
+}
+
+// Eval implements the Expression interface.
+func (s *StrToDate) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	str, err := evalString(ctx, s.Left, row)
+	if str == nil || err != nil {
+		return nil, err
+	}
+
+	format, err := evalString(ctx, s.Right, row)
+	if format == nil || err != nil {
+		return nil, err
+	}
+
+	d := parsedDate{weekNumber: -1, weekYear: -1}
+	if _, ok := d.parse(str.(string), format.(string)); !ok || !d.resolve() {
+		return nil, nil
+	}
+
+	switch s.Type() {
+	case sql.Time:
+		return sql.Time.Convert(d.duration())
+	case sql.Date:
+		t, ok := d.datetime()
+		if !ok {
+			return nil, nil
+		}
+		return t.Truncate(24 * time.Hour), nil
+	default:
+		t, ok := d.datetime()
+		if !ok {
+			return nil, nil
+		}
+		return t, nil
+	}
+}
+
+func evalString(ctx *sql.Context, e sql.Expression, row sql.Row) (interface{}, error) {
+	val, err := e.Eval(ctx, row)
+	if val == nil || err != nil {
+		return nil, err
+	}
+	return sql.LongText.Convert(val)
+}
+
+// dateFormats has the formats returned by GET_FORMAT for each type and
+// standard.
+var dateFormats = map[string]map[string]string{
+	"DATE": {
+		"USA":      "%m.%d.%Y",
+		"JIS":      "%Y-%m-%d",
+		"ISO":      "%Y-%m-%d",
+		"EUR":      "%d.%m.%Y",
+		"INTERNAL": "%Y%m%d",
+	},
+	"DATETIME": {
+		"USA":      "%Y-%m-%d %H.%i.%s",
+		"JIS":      "%Y-%m-%d %H:%i:%s",
+		"ISO":      "%Y-%m-%d %H:%i:%s",
+		"EUR":      "%Y-%m-%d %H.%i.%s",
+		"INTERNAL": "%Y%m%d%H%i%s",
+	},
+	"TIME": {
+		"USA":      "%h:%i:%s %p",
+		"JIS":      "%H:%i:%s",
+		"ISO":      "%H:%i:%s",
+		"EUR":      "%H.%i.%s",
+		"INTERNAL": "%H%i%s",
+	},
+}
+
+func init() {
+	dateFormats["TIMESTAMP"] = dateFormats["DATETIME"]
+}
+
+// GetFormat returns the format of DATE_FORMAT and STR_TO_DATE for a type,
+// which is DATE, TIME, DATETIME or TIMESTAMP, and a standard, which is 'EUR',
+// 'USA', 'JIS', 'ISO' or 'INTERNAL'. It returns NULL for an unknown standard.
+type GetFormat struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*GetFormat)(nil)
+
+// NewGetFormat returns a new GetFormat UDF.
+func NewGetFormat(typ, standard sql.Expression) sql.Expression {
+	return &GetFormat{expression.BinaryExpression{Left: typ, Right: standard}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (g *GetFormat) FunctionName() string {
+	return "get_format"
+}
+
+func (g *GetFormat) String() string {
+	return fmt.Sprintf("GET_FORMAT(%s, %s)", g.Left, g.Right)
+}
+
+// Type implements the Expression interface.
+func (g *GetFormat) Type() sql.Type {
+	return sql.LongText
+}
+
+// IsNullable implements the Expression interface.
+func (g *GetFormat) IsNullable() bool {
+	return true
+}
+
+// WithChildren implements the Expression interface.
+func (g *GetFormat) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(g, len(children), 2)
+	}
+	return NewGetFormat(children[0], children[1]), nil
+}
+
+// Eval implements the Expression interface.
+func (g *GetFormat) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	typ, err := evalString(ctx, g.Left, row)
+	if typ == nil || err != nil {
+		return nil, err
+	}
+
+	standard, err := evalString(ctx, g.Right, row)
+	if standard == nil || err != nil {
+		return nil, err
+	}
+
+	format, ok := dateFormats[strings.ToUpper(typ.(string))][strings.ToUpper(standard.(string))]
+	if !ok {
+		return nil, nil
+	}
+	return format, nil
+}
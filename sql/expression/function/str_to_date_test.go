@@ -0,0 +1,106 @@
+package function
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestStrToDate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		str      string
+		format   string
+		expected interface{}
+	}{
+		{"date", "01,5,2013", "%d,%m,%Y", time.Date(2013, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{"month name", "May 1, 2013", "%M %d,%Y", time.Date(2013, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{"abbreviated names", "Wed, 01 may 13", "%a, %d %b %y", time.Date(2013, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{"two digit year", "01/05/75", "%d/%m/%Y", time.Date(1975, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{"time", "a09:30:17", "a%h:%i:%s", "09:30:17"},
+		{"trailing characters", "09:30:17a", "%h:%i:%s", "09:30:17"},
+		{"literal mismatch", "a09:30:17", "%h:%i:%s", nil},
+		{"datetime", "2013-05-01 10:11:12.5 PM", "%Y-%m-%d %h:%i:%s.%f %p", time.Date(2013, 5, 1, 22, 11, 12, 500000000, time.UTC)},
+		{"shorthands", "Friday 13th of October 2006 11:01:02 PM", "%W %D of %M %Y %r", time.Date(2006, 10, 13, 23, 1, 2, 0, time.UTC)},
+		{"24 hour time", "2006-10-13 23:01:02", "%Y-%m-%d %T", time.Date(2006, 10, 13, 23, 1, 2, 0, time.UTC)},
+		{"day of year", "2006 100", "%Y %j", time.Date(2006, 4, 10, 0, 0, 0, 0, time.UTC)},
+		{"week", "200442 Monday", "%X%V %W", time.Date(2004, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{"week with %U", "2021 01 0", "%Y %U %w", time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{"week with %v and %X", "200442 Monday", "%X%v %W", nil},
+		{"percent", "100% 2020-01-02", "100%% %Y-%m-%d", time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"zero date", "00/00/0000", "%m/%d/%Y", nil},
+		{"zero day", "9", "%m", nil},
+		{"invalid date", "04/31/2004", "%m/%d/%Y", nil},
+		{"invalid hour", "13:00 PM", "%h:%i %p", nil},
+		{"am without hour", "10 AM", "%H %p", nil},
+		{"invalid month name", "Mayo 1, 2013", "%M %d,%Y", nil},
+		{"unknown specifier", "1", "%Q", nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			f := NewStrToDate(expression.NewLiteral(tt.str, sql.LongText), expression.NewLiteral(tt.format, sql.LongText))
+			v, err := f.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+
+	f := NewStrToDate(expression.NewGetField(0, sql.LongText, "s", true), expression.NewGetField(1, sql.LongText, "f", true))
+	require.Equal(t, "STR_TO_DATE(s, f)", f.String())
+	require.Equal(t, sql.Datetime, f.Type())
+
+	v, err := f.Eval(sql.NewEmptyContext(), sql.NewRow("2013 5 1", "%Y %m %d"))
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2013, 5, 1, 0, 0, 0, 0, time.UTC), v)
+
+	v, err = f.Eval(sql.NewEmptyContext(), sql.NewRow(nil, "%Y %m %d"))
+	require.NoError(t, err)
+	require.Nil(t, v)
+}
+
+func TestStrToDateType(t *testing.T) {
+	require.Equal(t, sql.Date, strToDateType("%d/%m/%Y"))
+	require.Equal(t, sql.Time, strToDateType("%H:%i:%s.%f"))
+	require.Equal(t, sql.Datetime, strToDateType("%Y-%m-%d %T"))
+	require.Equal(t, sql.Datetime, strToDateType("abc"))
+}
+
+func TestStrToDateSpecifiers(t *testing.T) {
+	for specifier := range specifierToFunc {
+		require.Contains(t, specifierToParser, specifier, "no parser for %%%c", specifier)
+	}
+}
+
+func TestGetFormat(t *testing.T) {
+	testCases := []struct {
+		typ      interface{}
+		standard interface{}
+		expected interface{}
+	}{
+		{"DATE", "USA", "%m.%d.%Y"},
+		{"date", "eur", "%d.%m.%Y"},
+		{"DATETIME", "JIS", "%Y-%m-%d %H:%i:%s"},
+		{"TIMESTAMP", "INTERNAL", "%Y%m%d%H%i%s"},
+		{"TIME", "USA", "%h:%i:%s %p"},
+		{"TIME", "XYZ", nil},
+		{"YEAR", "ISO", nil},
+		{"DATE", nil, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("%v %v", tt.typ, tt.standard), func(t *testing.T) {
+			require := require.New(t)
+			f := NewGetFormat(expression.NewLiteral(tt.typ, sql.LongText), expression.NewLiteral(tt.standard, sql.LongText))
+			v, err := f.Eval(sql.NewEmptyContext(), nil)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package function
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// Strcmp is a function that compares two strings with their collation. It returns 0 if they're equal, -1 if the first
+// one sorts before the second one and 1 otherwise.
+type Strcmp struct {
+	expression.BinaryExpression
+}
+
+var _ sql.FunctionExpression = (*Strcmp)(nil)
+
+// NewStrcmp creates a new Strcmp expression.
+func NewStrcmp(left, right sql.Expression) sql.Expression {
+	return &Strcmp{expression.BinaryExpression{Left: left, Right: right}}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (s *Strcmp) FunctionName() string {
+	return "strcmp"
+}
+
+// Type implements the Expression interface.
+func (s *Strcmp) Type() sql.Type { return sql.Int64 }
+
+func (s *Strcmp) String() string {
+	return fmt.Sprintf("STRCMP(%s, %s)", s.Left, s.Right)
+}
+
+// WithChildren implements the Expression interface.
+func (s *Strcmp) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 2)
+	}
+	return NewStrcmp(children[0], children[1]), nil
+}
+
+// Eval implements the Expression interface.
+func (s *Strcmp) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	left, err := evalString(ctx, s.Left, row)
+	if left == nil || err != nil {
+		return nil, err
+	}
+
+	right, err := evalString(ctx, s.Right, row)
+	if right == nil || err != nil {
+		return nil, err
+	}
+
+	return int64(argsCollation(s.Left, s.Right).Compare(left.(string), right.(string))), nil
+}
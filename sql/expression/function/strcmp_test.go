@@ -0,0 +1,40 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestStrcmp(t *testing.T) {
+	testCases := []struct {
+		name     string
+		typ      sql.Type
+		row      sql.Row
+		expected interface{}
+	}{
+		{"null left", sql.LongText, sql.NewRow(nil, "a"), nil},
+		{"null right", sql.LongText, sql.NewRow("a", nil), nil},
+		{"less", sql.LongText, sql.NewRow("text", "text2"), int64(-1)},
+		{"greater", sql.LongText, sql.NewRow("text2", "text"), int64(1)},
+		{"equal", sql.LongText, sql.NewRow("text", "text"), int64(0)},
+		{"case insensitive", sql.LongText, sql.NewRow("Text", "text"), int64(0)},
+		{"case sensitive", sql.CreateLongText(sql.Collation_utf8mb4_bin), sql.NewRow("Text", "text"), int64(-1)},
+		{"binary", sql.LongBlob, sql.NewRow("Text", "text"), int64(-1)},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewStrcmp(
+				expression.NewGetField(0, tt.typ, "expr1", true),
+				expression.NewGetField(1, tt.typ, "expr2", true),
+			)
+			v, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, v)
+		})
+	}
+}
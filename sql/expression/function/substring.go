@@ -367,6 +367,83 @@ func (l Left) WithChildren(children ...sql.Expression) (sql.Expression, error) {
 	return NewLeft(children[0], children[1]), nil
 }
 
+// Right is a function that returns the last N characters of a string expression.
+type Right struct {
+	str sql.Expression
+	len sql.Expression
+}
+
+var _ sql.FunctionExpression = Right{}
+
+// NewRight creates a new RIGHT function.
+func NewRight(str, len sql.Expression) sql.Expression {
+	return Right{str, len}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (r Right) FunctionName() string {
+	return "right"
+}
+
+// Children implements the Expression interface.
+func (r Right) Children() []sql.Expression {
+	return []sql.Expression{r.str, r.len}
+}
+
+// Eval implements the Expression interface.
+func (r Right) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	str, err := evalString(ctx, r.str, row)
+	if str == nil || err != nil {
+		return nil, err
+	}
+
+	n, err := evalInt64(ctx, r.len, row)
+	if n == nil || err != nil {
+		return nil, err
+	}
+
+	chars := characters(str.(string), isBinaryString(r.str))
+	length := n.(int64)
+	if length > int64(len(chars)) {
+		length = int64(len(chars))
+	}
+	if length <= 0 {
+		return "", nil
+	}
+
+	return strings.Join(chars[int64(len(chars))-length:], ""), nil
+}
+
+// IsNullable implements the Expression interface.
+func (r Right) IsNullable() bool {
+	return r.str.IsNullable() || r.len.IsNullable()
+}
+
+func (r Right) String() string {
+	return fmt.Sprintf("RIGHT(%s, %s)", r.str, r.len)
+}
+
+// Resolved implements the Expression interface.
+func (r Right) Resolved() bool {
+	return r.str.Resolved() && r.len.Resolved()
+}
+
+// Type implements the Expression interface.
+func (r Right) Type() sql.Type {
+	if isBinaryString(r.str) {
+		return sql.LongBlob
+	}
+	return sql.LongText
+}
+
+// WithChildren implements the Expression interface.
+func (r Right) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(r, len(children), 2)
+	}
+	return NewRight(children[0], children[1]), nil
+}
+
 type Instr struct {
 	str    sql.Expression
 	substr sql.Expression
@@ -178,3 +178,43 @@ func TestLeft(t *testing.T) {
 		})
 	}
 }
+
+func TestRight(t *testing.T) {
+	testCases := []struct {
+		name     string
+		typ      sql.Type
+		row      sql.Row
+		expected interface{}
+		err      bool
+	}{
+		{"both null", sql.LongText, sql.NewRow(nil, nil), nil, false},
+		{"null string", sql.LongText, sql.NewRow(nil, 1), nil, false},
+		{"null len", sql.LongText, sql.NewRow("foo", nil), nil, false},
+		{"len == string.len", sql.LongText, sql.NewRow("foo", 3), "foo", false},
+		{"len > string.len", sql.LongText, sql.NewRow("foo", 10), "foo", false},
+		{"len == 0", sql.LongText, sql.NewRow("foo", 0), "", false},
+		{"len < 0", sql.LongText, sql.NewRow("foo", -1), "", false},
+		{"len < string.len", sql.LongText, sql.NewRow("foobar", 4), "obar", false},
+		{"multibyte characters", sql.LongText, sql.NewRow("añb", 2), "ñb", false},
+		{"binary", sql.LongBlob, sql.NewRow("añb", 2), "\xb1b", false},
+		{"bad len type", sql.LongText, sql.NewRow("hello", "hello"), "", true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			f := NewRight(
+				expression.NewGetField(0, tt.typ, "str", true),
+				expression.NewGetField(1, sql.Int64, "len", false),
+			)
+
+			v, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			if tt.err {
+				require.Error(err)
+			} else {
+				require.NoError(err)
+				require.Equal(tt.expected, v)
+			}
+		})
+	}
+}
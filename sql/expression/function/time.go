@@ -777,7 +777,7 @@ func (n *Now) Children() []sql.Expression { return nil }
 
 // Eval implements the sql.Expression interface.
 func (n *Now) Eval(ctx *sql.Context, _ sql.Row) (interface{}, error) {
-	t := ctx.QueryTime()
+	t := sql.InTimeZone(ctx.QueryTime(), sql.SessionTimeZone(ctx))
 	// TODO: Now should return a string formatted depending on context.  This code handles string formatting
 	// and should be enabled at the time we fix the return type
 	/*s, err := formatDate("%Y-%m-%d %H:%i:%s", t)
@@ -1105,7 +1105,7 @@ func NewCurrentTime() sql.Expression {
 }
 
 func currTimeLogic(ctx *sql.Context, _ sql.Row) (interface{}, error) {
-	t := ctx.QueryTime()
+	t := sql.InTimeZone(ctx.QueryTime(), sql.SessionTimeZone(ctx))
 	return fmt.Sprintf("%02d:%02d:%02d", t.Hour(), t.Minute(), t.Second()), nil
 }
 
@@ -1132,7 +1132,7 @@ func NewCurrTimestamp() sql.Expression {
 }
 
 func currDatetimeLogic(ctx *sql.Context, _ sql.Row) (interface{}, error) {
-	return ctx.QueryTime(), nil
+	return sql.InTimeZone(ctx.QueryTime(), sql.SessionTimeZone(ctx)), nil
 }
 
 // Eval implements sql.Expression
@@ -355,22 +355,22 @@ func TestNow(t *testing.T) {
 	}{
 		{
 			args:      nil,
-			result:    date,
+			result:    date.UTC(),
 			expectErr: false,
 		},
 		{
 			args:      []sql.Expression{expression.NewLiteral(0, sql.Int8)},
-			result:    date,
+			result:    date.UTC(),
 			expectErr: false,
 		},
 		{
 			args:      []sql.Expression{expression.NewLiteral(0, sql.Int64)},
-			result:    date,
+			result:    date.UTC(),
 			expectErr: false,
 		},
 		{
 			args:      []sql.Expression{expression.NewLiteral(6, sql.Uint8)},
-			result:    date,
+			result:    date.UTC(),
 			expectErr: false,
 		},
 		{
@@ -403,6 +403,13 @@ func TestNow(t *testing.T) {
 			}
 		})
 	}
+
+	require.NoError(t, ctx.Set(ctx, "time_zone", sql.LongText, "+01:30"))
+	now, err := NewNow()
+	require.NoError(t, err)
+	val, err := now.Eval(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, date.UTC().Add(90*time.Minute), val)
 }
 
 func TestUTCTimestamp(t *testing.T) {
@@ -566,8 +573,8 @@ func TestTimeDiff(t *testing.T) {
 		},
 		{
 			"time types 1",
-			expression.NewConvert(expression.NewLiteral("00:00:00.1", sql.Text), expression.ConvertToTime),
-			expression.NewConvert(expression.NewLiteral("00:00:00.2", sql.Text), expression.ConvertToTime),
+			expression.NewConvertWithLengthAndScale(expression.NewLiteral("00:00:00.1", sql.Text), expression.ConvertToTime, 1, 0),
+			expression.NewConvertWithLengthAndScale(expression.NewLiteral("00:00:00.2", sql.Text), expression.ConvertToTime, 1, 0),
 			"-00:00:00.100000",
 			false,
 		},
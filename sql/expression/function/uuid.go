@@ -0,0 +1,366 @@
+package function
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// ErrIncorrectStringValue is returned when a string is not a valid argument
+// of a function.
+var ErrIncorrectStringValue = errors.NewKind("Incorrect string value: '%s' for function %s")
+
+// uuidEpoch is the number of 100-nanosecond intervals from the start of the
+// Gregorian calendar, 1582-10-15, to the Unix epoch.
+const uuidEpoch = 0x01B21DD213814000
+
+// uuidGenerator generates version 1 UUIDs. As there is no MAC address to use,
+// the node is a random number with the multicast bit set, as RFC 4122
+// recommends.
+type uuidGenerator struct {
+	mu        sync.Mutex
+	node      [6]byte
+	clockSeq  uint16
+	lastTime  uint64
+	initiated bool
+}
+
+var uuidGen uuidGenerator
+
+func (g *uuidGenerator) next() (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.initiated {
+		var seed [8]byte
+		if _, err := rand.Read(seed[:]); err != nil {
+			return "", err
+		}
+		copy(g.node[:], seed[:6])
+		g.node[0] |= 0x01
+		g.clockSeq = binary.BigEndian.Uint16(seed[6:])
+		g.initiated = true
+	}
+
+	// Two UUIDs generated in the same interval would be equal, so the time
+	// is moved forward instead.
+	now := uint64(time.Now().UnixNano()/100) + uuidEpoch
+	if now <= g.lastTime {
+		now = g.lastTime + 1
+	}
+	g.lastTime = now
+
+	var u [16]byte
+	binary.BigEndian.PutUint32(u[0:], uint32(now))
+	binary.BigEndian.PutUint16(u[4:], uint16(now>>32))
+	binary.BigEndian.PutUint16(u[6:], uint16(now>>48)&0x0fff|0x1000)
+	binary.BigEndian.PutUint16(u[8:], g.clockSeq&0x3fff|0x8000)
+	copy(u[10:], g.node[:])
+
+	return formatUUID(u[:]), nil
+}
+
+// formatUUID returns the textual form of a binary UUID.
+func formatUUID(u []byte) string {
+	s := hex.EncodeToString(u)
+	return s[0:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:32]
+}
+
+// parseUUID returns the binary form of a UUID, which can be given as 32
+// hexadecimal digits, optionally separated by dashes in the standard form and
+// enclosed in braces.
+func parseUUID(s string) ([]byte, bool) {
+	switch len(s) {
+	case 38:
+		if s[0] != '{' || s[37] != '}' {
+			return nil, false
+		}
+		s = s[1:37]
+		fallthrough
+	case 36:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return nil, false
+		}
+		s = s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:36]
+	case 32:
+	default:
+		return nil, false
+	}
+
+	u, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, false
+	}
+	return u, true
+}
+
+// swapUUID moves the time-high part of a binary UUID to the start and the
+// time-low part to the end of the time, so that version 1 UUIDs sort in the
+// order they were generated.
+func swapUUID(u []byte) []byte {
+	var s []byte
+	s = append(s, u[6:8]...)
+	s = append(s, u[4:6]...)
+	s = append(s, u[0:4]...)
+	return append(s, u[8:]...)
+}
+
+// unswapUUID reverts swapUUID.
+func unswapUUID(s []byte) []byte {
+	var u []byte
+	u = append(u, s[4:8]...)
+	u = append(u, s[2:4]...)
+	u = append(u, s[0:2]...)
+	return append(u, s[8:]...)
+}
+
+// UUID returns a version 1 UUID, which is unique in time and space.
+type UUID struct {
+	NoArgFunc
+}
+
+var _ sql.FunctionExpression = UUID{}
+var _ sql.NonDeterministicExpression = UUID{}
+
+// NewUUID creates a new UUID UDF.
+func NewUUID() sql.Expression {
+	return UUID{
+		NoArgFunc: NoArgFunc{"uuid", sql.LongText},
+	}
+}
+
+// IsNonDeterministic implements sql.NonDeterministicExpression
+func (u UUID) IsNonDeterministic() bool { return true }
+
+// Eval implements sql.Expression
+func (u UUID) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return uuidGen.next()
+}
+
+// WithChildren implements sql.Expression
+func (u UUID) WithChildren(expressions ...sql.Expression) (sql.Expression, error) {
+	return NoArgFuncWithChildren(u, expressions)
+}
+
+// uuidShort is the last value returned by UUID_SHORT. It starts at the time
+// the server started shifted 24 bits to the left, as the server id is 0.
+var uuidShort = uint64(time.Now().Unix()) << 24
+
+// UUIDShort returns a unique integer, which increases with each call.
+type UUIDShort struct {
+	NoArgFunc
+}
+
+var _ sql.FunctionExpression = UUIDShort{}
+var _ sql.NonDeterministicExpression = UUIDShort{}
+
+// NewUUIDShort creates a new UUIDShort UDF.
+func NewUUIDShort() sql.Expression {
+	return UUIDShort{
+		NoArgFunc: NoArgFunc{"uuid_short", sql.Uint64},
+	}
+}
+
+// IsNonDeterministic implements sql.NonDeterministicExpression
+func (u UUIDShort) IsNonDeterministic() bool { return true }
+
+// Eval implements sql.Expression
+func (u UUIDShort) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return atomic.AddUint64(&uuidShort, 1), nil
+}
+
+// WithChildren implements sql.Expression
+func (u UUIDShort) WithChildren(expressions ...sql.Expression) (sql.Expression, error) {
+	return NoArgFuncWithChildren(u, expressions)
+}
+
+func evalSwapFlag(ctx *sql.Context, e sql.Expression, row sql.Row) (bool, error) {
+	if e == nil {
+		return false, nil
+	}
+
+	swap, err := evalInt64(ctx, e, row)
+	if swap == nil || err != nil {
+		return false, err
+	}
+	return swap.(int64) != 0, nil
+}
+
+// UUIDToBin returns the 16-byte binary form of a UUID. If the swap flag is
+// true, the time-low and the time-high parts of the UUID are swapped, which
+// makes version 1 UUIDs better suited as index keys.
+type UUIDToBin struct {
+	UUID sql.Expression
+	Swap sql.Expression
+}
+
+var _ sql.FunctionExpression = (*UUIDToBin)(nil)
+
+// NewUUIDToBin creates a new UUIDToBin UDF.
+func NewUUIDToBin(args ...sql.Expression) (sql.Expression, error) {
+	switch len(args) {
+	case 1:
+		return &UUIDToBin{args[0], nil}, nil
+	case 2:
+		return &UUIDToBin{args[0], args[1]}, nil
+	default:
+		return nil, sql.ErrInvalidArgumentNumber.New("UUID_TO_BIN", "1 or 2", len(args))
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (u *UUIDToBin) FunctionName() string {
+	return "uuid_to_bin"
+}
+
+// Children implements the Expression interface.
+func (u *UUIDToBin) Children() []sql.Expression {
+	if u.Swap != nil {
+		return []sql.Expression{u.UUID, u.Swap}
+	}
+	return []sql.Expression{u.UUID}
+}
+
+// Resolved implements the Expression interface.
+func (u *UUIDToBin) Resolved() bool {
+	return u.UUID.Resolved() && (u.Swap == nil || u.Swap.Resolved())
+}
+
+// IsNullable implements the Expression interface.
+func (u *UUIDToBin) IsNullable() bool {
+	return u.UUID.IsNullable()
+}
+
+func (u *UUIDToBin) String() string {
+	if u.Swap != nil {
+		return fmt.Sprintf("UUID_TO_BIN(%s, %s)", u.UUID, u.Swap)
+	}
+	return fmt.Sprintf("UUID_TO_BIN(%s)", u.UUID)
+}
+
+// Type implements the Expression interface.
+func (u *UUIDToBin) Type() sql.Type {
+	return sql.LongBlob
+}
+
+// WithChildren implements the Expression interface.
+func (u *UUIDToBin) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewUUIDToBin(children...)
+}
+
+// Eval implements the Expression interface.
+func (u *UUIDToBin) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	str, err := evalString(ctx, u.UUID, row)
+	if str == nil || err != nil {
+		return nil, err
+	}
+
+	bin, ok := parseUUID(str.(string))
+	if !ok {
+		return nil, ErrIncorrectStringValue.New(str, "uuid_to_bin")
+	}
+
+	swap, err := evalSwapFlag(ctx, u.Swap, row)
+	if err != nil {
+		return nil, err
+	}
+	if swap {
+		bin = swapUUID(bin)
+	}
+
+	return string(bin), nil
+}
+
+// BinToUUID returns the textual form of a 16-byte binary UUID. If the swap
+// flag is true, the binary UUID is expected to have been converted by
+// UUID_TO_BIN with the swap flag.
+type BinToUUID struct {
+	Bin  sql.Expression
+	Swap sql.Expression
+}
+
+var _ sql.FunctionExpression = (*BinToUUID)(nil)
+
+// NewBinToUUID creates a new BinToUUID UDF.
+func NewBinToUUID(args ...sql.Expression) (sql.Expression, error) {
+	switch len(args) {
+	case 1:
+		return &BinToUUID{args[0], nil}, nil
+	case 2:
+		return &BinToUUID{args[0], args[1]}, nil
+	default:
+		return nil, sql.ErrInvalidArgumentNumber.New("BIN_TO_UUID", "1 or 2", len(args))
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (b *BinToUUID) FunctionName() string {
+	return "bin_to_uuid"
+}
+
+// Children implements the Expression interface.
+func (b *BinToUUID) Children() []sql.Expression {
+	if b.Swap != nil {
+		return []sql.Expression{b.Bin, b.Swap}
+	}
+	return []sql.Expression{b.Bin}
+}
+
+// Resolved implements the Expression interface.
+func (b *BinToUUID) Resolved() bool {
+	return b.Bin.Resolved() && (b.Swap == nil || b.Swap.Resolved())
+}
+
+// IsNullable implements the Expression interface.
+func (b *BinToUUID) IsNullable() bool {
+	return b.Bin.IsNullable()
+}
+
+func (b *BinToUUID) String() string {
+	if b.Swap != nil {
+		return fmt.Sprintf("BIN_TO_UUID(%s, %s)", b.Bin, b.Swap)
+	}
+	return fmt.Sprintf("BIN_TO_UUID(%s)", b.Bin)
+}
+
+// Type implements the Expression interface.
+func (b *BinToUUID) Type() sql.Type {
+	return sql.LongText
+}
+
+// WithChildren implements the Expression interface.
+func (b *BinToUUID) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewBinToUUID(children...)
+}
+
+// Eval implements the Expression interface.
+func (b *BinToUUID) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	str, err := evalString(ctx, b.Bin, row)
+	if str == nil || err != nil {
+		return nil, err
+	}
+
+	bin := []byte(str.(string))
+	if len(bin) != 16 {
+		return nil, ErrIncorrectStringValue.New(strings.ToUpper(hex.EncodeToString(bin)), "bin_to_uuid")
+	}
+
+	swap, err := evalSwapFlag(ctx, b.Swap, row)
+	if err != nil {
+		return nil, err
+	}
+	if swap {
+		bin = unswapUUID(bin)
+	}
+
+	return formatUUID(bin), nil
+}
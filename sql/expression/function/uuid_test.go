@@ -0,0 +1,122 @@
+package function
+
+import (
+	"encoding/hex"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestUUID(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	f := NewUUID()
+	require.True(f.(sql.NonDeterministicExpression).IsNonDeterministic())
+	require.Equal("UUID()", f.String())
+
+	format := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-1[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		v, err := f.Eval(ctx, nil)
+		require.NoError(err)
+		require.Regexp(format, v)
+		require.False(seen[v.(string)], "duplicate UUID %s", v)
+		seen[v.(string)] = true
+	}
+}
+
+func TestUUIDShort(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	f := NewUUIDShort()
+	require.True(f.(sql.NonDeterministicExpression).IsNonDeterministic())
+
+	v1, err := f.Eval(ctx, nil)
+	require.NoError(err)
+	v2, err := f.Eval(ctx, nil)
+	require.NoError(err)
+	require.Equal(v1.(uint64)+1, v2)
+}
+
+func TestUUIDToBin(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []interface{}
+		expected interface{}
+		err      bool
+	}{
+		{"dashes", []interface{}{"6ccd780c-baba-1026-9564-5b8c656024db"}, "6ccd780cbaba102695645b8c656024db", false},
+		{"no dashes", []interface{}{"6CCD780CBABA102695645B8C656024DB"}, "6ccd780cbaba102695645b8c656024db", false},
+		{"braces", []interface{}{"{6ccd780c-baba-1026-9564-5b8c656024db}"}, "6ccd780cbaba102695645b8c656024db", false},
+		{"swap", []interface{}{"6ccd780c-baba-1026-9564-5b8c656024db", int64(1)}, "1026baba6ccd780c95645b8c656024db", false},
+		{"no swap", []interface{}{"6ccd780c-baba-1026-9564-5b8c656024db", int64(0)}, "6ccd780cbaba102695645b8c656024db", false},
+		{"null", []interface{}{nil}, nil, false},
+		{"too short", []interface{}{"6ccd780c-baba-1026-9564-5b8c656024d"}, nil, true},
+		{"misplaced dash", []interface{}{"6ccd780cb-aba-1026-9564-5b8c656024db"}, nil, true},
+		{"not hexadecimal", []interface{}{"6ccd780c-baba-1026-9564-5b8c656024dx"}, nil, true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			var args []sql.Expression
+			for _, arg := range tt.args {
+				args = append(args, expression.NewLiteral(arg, sql.LongText))
+			}
+
+			f, err := NewUUIDToBin(args...)
+			require.NoError(err)
+
+			v, err := f.Eval(sql.NewEmptyContext(), nil)
+			if tt.err {
+				require.True(ErrIncorrectStringValue.Is(err))
+				return
+			}
+			require.NoError(err)
+			if v != nil {
+				v = hex.EncodeToString([]byte(v.(string)))
+			}
+			require.Equal(tt.expected, v)
+		})
+	}
+
+	_, err := NewUUIDToBin()
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+}
+
+func TestBinToUUID(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	bin, err := hex.DecodeString("1026baba6ccd780c95645b8c656024db")
+	require.NoError(err)
+
+	f, err := NewBinToUUID(expression.NewLiteral(string(bin), sql.LongBlob))
+	require.NoError(err)
+	v, err := f.Eval(ctx, nil)
+	require.NoError(err)
+	require.Equal("1026baba-6ccd-780c-9564-5b8c656024db", v)
+
+	f, err = NewBinToUUID(expression.NewLiteral(string(bin), sql.LongBlob), expression.NewLiteral(int64(1), sql.Int64))
+	require.NoError(err)
+	v, err = f.Eval(ctx, nil)
+	require.NoError(err)
+	require.Equal("6ccd780c-baba-1026-9564-5b8c656024db", v)
+
+	f, err = NewBinToUUID(expression.NewLiteral("abc", sql.LongBlob))
+	require.NoError(err)
+	_, err = f.Eval(ctx, nil)
+	require.True(ErrIncorrectStringValue.Is(err))
+
+	f, err = NewBinToUUID(expression.NewLiteral(nil, sql.Null))
+	require.NoError(err)
+	v, err = f.Eval(ctx, nil)
+	require.NoError(err)
+	require.Nil(v)
+}
@@ -0,0 +1,115 @@
+package function
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// WeightString is a function that returns the sort key of a string in its collation, which is the string itself for
+// binary strings. WEIGHT_STRING(str AS CHAR(n)) and WEIGHT_STRING(str AS BINARY(n)) are parsed as
+// WEIGHT_STRING(str, 'CHAR', n) and WEIGHT_STRING(str, 'BINARY', n), and take the string cast to that type, padded
+// with spaces or zero bytes up to its length.
+type WeightString struct {
+	str      sql.Expression
+	castType sql.Expression
+	length   sql.Expression
+}
+
+var _ sql.FunctionExpression = (*WeightString)(nil)
+
+// NewWeightString creates a new WeightString expression.
+func NewWeightString(args ...sql.Expression) (sql.Expression, error) {
+	switch len(args) {
+	case 1:
+		return &WeightString{args[0], nil, nil}, nil
+	case 3:
+		return &WeightString{args[0], args[1], args[2]}, nil
+	default:
+		return nil, sql.ErrInvalidArgumentNumber.New("WEIGHT_STRING", "1 or 3", len(args))
+	}
+}
+
+// FunctionName implements sql.FunctionExpression
+func (w *WeightString) FunctionName() string {
+	return "weight_string"
+}
+
+// Children implements the Expression interface.
+func (w *WeightString) Children() []sql.Expression {
+	if w.castType == nil {
+		return []sql.Expression{w.str}
+	}
+	return []sql.Expression{w.str, w.castType, w.length}
+}
+
+// Resolved implements the Expression interface.
+func (w *WeightString) Resolved() bool {
+	return argsResolved(w.Children())
+}
+
+// IsNullable implements the Expression interface.
+func (w *WeightString) IsNullable() bool {
+	return w.str.IsNullable()
+}
+
+// Type implements the Expression interface.
+func (w *WeightString) Type() sql.Type { return sql.LongBlob }
+
+func (w *WeightString) String() string {
+	if w.castType == nil {
+		return fmt.Sprintf("WEIGHT_STRING(%s)", w.str)
+	}
+	return fmt.Sprintf("WEIGHT_STRING(%s, %s, %s)", w.str, w.castType, w.length)
+}
+
+// WithChildren implements the Expression interface.
+func (w *WeightString) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewWeightString(children...)
+}
+
+// Eval implements the Expression interface.
+func (w *WeightString) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	str, err := evalString(ctx, w.str, row)
+	if str == nil || err != nil {
+		return nil, err
+	}
+
+	s := str.(string)
+	collation := argsCollation(w.str)
+	if w.castType == nil {
+		return collation.WeightString(s), nil
+	}
+
+	castType, err := evalString(ctx, w.castType, row)
+	if castType == nil || err != nil {
+		return nil, err
+	}
+
+	n, err := evalInt64(ctx, w.length, row)
+	if n == nil || err != nil {
+		return nil, err
+	}
+	length := int(n.(int64))
+	if length < 0 {
+		length = 0
+	}
+
+	switch strings.ToUpper(castType.(string)) {
+	case "CHAR":
+		chars := characters(s, collation == sql.Collation_binary)
+		if len(chars) > length {
+			chars = chars[:length]
+		}
+		s = strings.Join(chars, "") + strings.Repeat(" ", length-len(chars))
+		return collation.WeightString(s), nil
+	case "BINARY":
+		if len(s) > length {
+			s = s[:length]
+		}
+		return s + strings.Repeat("\x00", length-len(s)), nil
+	default:
+		return nil, sql.ErrInvalidType.New(castType)
+	}
+}
@@ -0,0 +1,50 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func TestWeightString(t *testing.T) {
+	testCases := []struct {
+		name     string
+		typ      sql.Type
+		args     []interface{}
+		expected interface{}
+	}{
+		{"null", sql.LongText, []interface{}{nil}, nil},
+		{"case insensitive", sql.LongText, []interface{}{"AB"}, sql.Collation_Default.WeightString("ab")},
+		{"binary", sql.LongBlob, []interface{}{"AB"}, "AB"},
+		{"as char", sql.LongText, []interface{}{"ab", "CHAR", 4}, sql.Collation_Default.WeightString("ab  ")},
+		{"as char truncated", sql.LongText, []interface{}{"abc", "CHAR", 2}, sql.Collation_Default.WeightString("ab")},
+		{"as binary", sql.LongText, []interface{}{"ab", "BINARY", 4}, "ab\x00\x00"},
+		{"as binary truncated", sql.LongText, []interface{}{"abc", "BINARY", 2}, "ab"},
+		{"null length", sql.LongText, []interface{}{"ab", "BINARY", nil}, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			args := []sql.Expression{expression.NewGetField(0, tt.typ, "str", true)}
+			if len(tt.args) > 1 {
+				args = append(args,
+					expression.NewGetField(1, sql.LongText, "type", false),
+					expression.NewGetField(2, sql.Int64, "length", true),
+				)
+			}
+			f, err := NewWeightString(args...)
+			require.NoError(err)
+
+			v, err := f.Eval(sql.NewEmptyContext(), sql.NewRow(tt.args...))
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+
+	_, err := NewWeightString(expression.NewLiteral("a", sql.LongText), expression.NewLiteral("CHAR", sql.LongText))
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+}
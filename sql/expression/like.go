@@ -4,16 +4,24 @@ import (
 	"bytes"
 	"fmt"
 	"regexp"
-	"strings"
 	"sync"
 
+	"gopkg.in/src-d/go-errors.v1"
+
 	"github.com/dolthub/go-mysql-server/internal/regex"
 	"github.com/dolthub/go-mysql-server/sql"
 )
 
-// Like performs pattern matching against two strings.
+// ErrIncorrectEscape is returned when the escape character of a LIKE
+// expression isn't a single character.
+var ErrIncorrectEscape = errors.NewKind("Incorrect arguments to ESCAPE")
+
+// Like performs pattern matching against two strings. The escape character of
+// the pattern is a backslash unless another one is given, and there is none
+// if it's empty.
 type Like struct {
 	BinaryExpression
+	Escape sql.Expression
 	pool   *sync.Pool
 	once   sync.Once
 	cached bool
@@ -21,22 +29,47 @@ type Like struct {
 
 // NewLike creates a new LIKE expression.
 func NewLike(left, right sql.Expression) sql.Expression {
+	return NewLikeWithEscape(left, right, nil)
+}
+
+// NewLikeWithEscape creates a new LIKE expression with the given escape
+// character, which may be nil to use the default one.
+func NewLikeWithEscape(left, right, escape sql.Expression) sql.Expression {
 	var cached = true
-	sql.Inspect(right, func(e sql.Expression) bool {
-		if _, ok := e.(*GetField); ok {
-			cached = false
+	for _, e := range []sql.Expression{right, escape} {
+		if e == nil {
+			continue
 		}
-		return true
-	})
+		sql.Inspect(e, func(e sql.Expression) bool {
+			if _, ok := e.(*GetField); ok {
+				cached = false
+			}
+			return true
+		})
+	}
 
 	return &Like{
 		BinaryExpression: BinaryExpression{left, right},
+		Escape:           escape,
 		pool:             nil,
 		once:             sync.Once{},
 		cached:           cached,
 	}
 }
 
+// Children implements the sql.Expression interface.
+func (l *Like) Children() []sql.Expression {
+	if l.Escape == nil {
+		return l.BinaryExpression.Children()
+	}
+	return []sql.Expression{l.Left, l.Right, l.Escape}
+}
+
+// Resolved implements the sql.Expression interface.
+func (l *Like) Resolved() bool {
+	return l.BinaryExpression.Resolved() && (l.Escape == nil || l.Escape.Resolved())
+}
+
 // Type implements the sql.Expression interface.
 func (l *Like) Type() sql.Type { return sql.Boolean }
 
@@ -53,6 +86,7 @@ func (l *Like) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	collation := l.collation()
 
 	var (
 		matcher  regex.Matcher
@@ -61,14 +95,14 @@ func (l *Like) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 
 	if !l.cached {
 		// for non-cached regex every time create a new matcher
-		right, rerr := l.evalRight(ctx, row)
+		right, rerr := l.evalRight(ctx, row, collation)
 		if rerr != nil {
 			return nil, rerr
 		}
 		matcher, disposer, err = regex.New("go", *right)
 	} else {
 		l.once.Do(func() {
-			right, err := l.evalRight(ctx, row)
+			right, err := l.evalRight(ctx, row, collation)
 			l.pool = &sync.Pool{
 				New: func() interface{} {
 					if err != nil || right == nil {
@@ -87,7 +121,7 @@ func (l *Like) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, err
 	}
 
-	ok := matcher.Match(left.(string))
+	ok := matcher.Match(collation.Fold(left.(string)))
 	if !l.cached {
 		disposer.Dispose()
 	} else {
@@ -98,7 +132,19 @@ func (l *Like) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	return ok, nil
 }
 
-func (l *Like) evalRight(ctx *sql.Context, row sql.Row) (*string, error) {
+// collation returns the collation used to match the strings, which is the one
+// of the left side if it's a string, or the one of the pattern otherwise.
+func (l *Like) collation() sql.Collation {
+	if st, ok := l.Left.Type().(sql.StringType); ok {
+		return st.Collation()
+	}
+	if st, ok := l.Right.Type().(sql.StringType); ok {
+		return st.Collation()
+	}
+	return sql.Collation_Default
+}
+
+func (l *Like) evalRight(ctx *sql.Context, row sql.Row, collation sql.Collation) (*string, error) {
 	v, err := l.Right.Eval(ctx, row)
 	if err != nil {
 		return nil, err
@@ -110,58 +156,82 @@ func (l *Like) evalRight(ctx *sql.Context, row sql.Row) (*string, error) {
 	if err != nil {
 		return nil, err
 	}
-	s := patternToGoRegex(v.(string))
+
+	escape := '\\'
+	if l.Escape != nil {
+		e, err := l.Escape.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		e, err = sql.LongText.Convert(e)
+		if err != nil {
+			return nil, err
+		}
+
+		chars := []rune(collation.Fold(e.(string)))
+		switch len(chars) {
+		case 0:
+			escape = 0
+		case 1:
+			escape = chars[0]
+		default:
+			return nil, ErrIncorrectEscape.New()
+		}
+	}
+
+	s := patternToGoRegexWithEscape(collation.Fold(v.(string)), escape)
 	return &s, nil
 }
 
 func (l *Like) String() string {
+	if l.Escape != nil {
+		return fmt.Sprintf("%s LIKE %s ESCAPE %s", l.Left, l.Right, l.Escape)
+	}
 	return fmt.Sprintf("%s LIKE %s", l.Left, l.Right)
 }
 
 // WithChildren implements the Expression interface.
 func (l *Like) WithChildren(children ...sql.Expression) (sql.Expression, error) {
-	if len(children) != 2 {
+	switch len(children) {
+	case 2:
+		return NewLike(children[0], children[1]), nil
+	case 3:
+		return NewLikeWithEscape(children[0], children[1], children[2]), nil
+	default:
 		return nil, sql.ErrInvalidChildrenNumber.New(l, len(children), 2)
 	}
-	return NewLike(children[0], children[1]), nil
 }
 
 func patternToGoRegex(pattern string) string {
+	return patternToGoRegexWithEscape(pattern, '\\')
+}
+
+// patternToGoRegexWithEscape converts a LIKE pattern to a regular expression.
+// The character following the escape one is matched literally, as is the
+// escape character when it's the last one. An escape of 0 means there's none.
+func patternToGoRegexWithEscape(pattern string, escape rune) string {
 	var buf bytes.Buffer
 	buf.WriteString("(?s)")
 	buf.WriteRune('^')
 	var escaped bool
-	for _, r := range strings.Replace(regexp.QuoteMeta(pattern), `\\`, `\`, -1) {
-		switch r {
-		case '_':
-			if escaped {
-				buf.WriteRune(r)
-			} else {
-				buf.WriteRune('.')
-			}
-		case '%':
-			if !escaped {
-				buf.WriteString(".*")
-			} else {
-				buf.WriteRune(r)
-			}
-		case '\\':
-			if escaped {
-				buf.WriteString(`\\`)
-			} else {
-				escaped = true
-				continue
-			}
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			buf.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == escape && escape != 0:
+			escaped = true
+		case r == '_':
+			buf.WriteRune('.')
+		case r == '%':
+			buf.WriteString(".*")
 		default:
-			if escaped {
-				buf.WriteString(`\`)
-			}
-			buf.WriteRune(r)
+			buf.WriteString(regexp.QuoteMeta(string(r)))
 		}
+	}
 
-		if escaped {
-			escaped = false
-		}
+	if escaped {
+		buf.WriteString(regexp.QuoteMeta(string(escape)))
 	}
 
 	buf.WriteRune('$')
@@ -64,3 +64,87 @@ func TestLike(t *testing.T) {
 		})
 	}
 }
+
+func TestLikeCollation(t *testing.T) {
+	testCases := []struct {
+		typ            sql.Type
+		value, pattern string
+		ok             bool
+	}{
+		{sql.Text, "ABC", "a%", true},
+		{sql.Text, "Résumé", "resume", true},
+		{sql.Text, "Résumé", "r_sum_", true},
+		{sql.Text, "Straße", "strasse", false},
+		{sql.CreateText(sql.Collation_utf8mb4_bin), "ABC", "a%", false},
+		{sql.CreateText(sql.Collation_utf8mb4_0900_as_cs), "résumé", "resume", false},
+		{sql.CreateText(sql.Collation_utf8mb4_0900_as_ci), "RÉSUMÉ", "résumé", true},
+		{sql.CreateText(sql.Collation_utf8mb4_0900_as_ci), "RÉSUMÉ", "resume", false},
+		{sql.CreateText(sql.Collation_utf8mb4_general_ci), "ABC", "a_c", true},
+		{sql.LongBlob, "ABC", "a%", false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("%q LIKE %q (%s)", tt.value, tt.pattern, tt.typ), func(t *testing.T) {
+			f := NewLike(
+				NewGetField(0, tt.typ, "", false),
+				NewLiteral(tt.pattern, sql.LongText),
+			)
+			value, err := f.Eval(sql.NewEmptyContext(), sql.NewRow(tt.value))
+			require.NoError(t, err)
+			require.Equal(t, tt.ok, value)
+		})
+	}
+}
+
+func TestPatternToRegexWithEscape(t *testing.T) {
+	testCases := []struct {
+		in     string
+		escape rune
+		out    string
+	}{
+		{`a|%b`, '|', `(?s)^a%b$`},
+		{`a|_b`, '|', `(?s)^a_b$`},
+		{`a||b`, '|', `(?s)^a\|b$`},
+		{`a\%b`, '|', `(?s)^a\\.*b$`},
+		{`a%b|`, '|', `(?s)^a.*b\|$`},
+		{`a\%b`, 0, `(?s)^a\\.*b$`},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.in, func(t *testing.T) {
+			require.Equal(t, tt.out, patternToGoRegexWithEscape(tt.in, tt.escape))
+		})
+	}
+}
+
+func TestLikeEscape(t *testing.T) {
+	testCases := []struct {
+		pattern, escape, value string
+		ok                     interface{}
+		err                    bool
+	}{
+		{"a|%", "|", "a%", true, false},
+		{"a|%", "|", "ab", false, false},
+		{"a\\%", "|", "a\\bc", true, false},
+		{"a\\%", "", "a\\bc", true, false},
+		{"a\\%", "", "a%", false, false},
+		{"a%", "||", "ab", nil, true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("%q LIKE %q ESCAPE %q", tt.value, tt.pattern, tt.escape), func(t *testing.T) {
+			f := NewLikeWithEscape(
+				NewGetField(0, sql.Text, "", false),
+				NewLiteral(tt.pattern, sql.LongText),
+				NewLiteral(tt.escape, sql.LongText),
+			)
+			value, err := f.Eval(sql.NewEmptyContext(), sql.NewRow(tt.value))
+			if tt.err {
+				require.True(t, ErrIncorrectEscape.Is(err))
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.ok, value)
+			}
+		})
+	}
+}
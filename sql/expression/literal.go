@@ -45,6 +45,9 @@ func (p *Literal) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 func (p *Literal) String() string {
 	switch v := p.value.(type) {
 	case string:
+		if sql.IsDecimal(p.fieldType) {
+			return v
+		}
 		return fmt.Sprintf("%q", v)
 	case []byte:
 		return "BLOB"
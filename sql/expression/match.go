@@ -0,0 +1,151 @@
+package expression
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// Match is a MATCH (columns) AGAINST (expr) full-text search expression. It evaluates to the relevance of each row
+// for the search, using the FULLTEXT index bound to it during analysis.
+type Match struct {
+	Columns []sql.Expression
+	Against sql.Expression
+	Mode    sql.FullTextSearchMode
+	// Index is the FULLTEXT index covering Columns. It's nil until the analyzer binds it.
+	Index sql.FullTextIndex
+	cache *matchCache
+}
+
+// matchCache holds the search of a Match expression, which is computed only once per query.
+type matchCache struct {
+	sync.Mutex
+	lookup sql.FullTextLookup
+}
+
+var _ sql.Expression = (*Match)(nil)
+
+// NewMatch creates a new Match expression.
+func NewMatch(columns []sql.Expression, against sql.Expression, mode sql.FullTextSearchMode) *Match {
+	return &Match{
+		Columns: columns,
+		Against: against,
+		Mode:    mode,
+		cache:   new(matchCache),
+	}
+}
+
+// WithIndex returns a copy of this expression bound to the index given.
+func (m *Match) WithIndex(idx sql.FullTextIndex) *Match {
+	nm := *m
+	nm.Index = idx
+	nm.cache = new(matchCache)
+	return &nm
+}
+
+// Lookup returns the search of this expression, computing it the first time it's called.
+func (m *Match) Lookup(ctx *sql.Context, row sql.Row) (sql.FullTextLookup, error) {
+	if m.Index == nil {
+		return nil, sql.ErrNoFullTextIndex.New()
+	}
+
+	m.cache.Lock()
+	defer m.cache.Unlock()
+	if m.cache.lookup != nil {
+		return m.cache.lookup, nil
+	}
+
+	against, err := m.Against.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	var query string
+	if against != nil {
+		against, err = sql.LongText.Convert(against)
+		if err != nil {
+			return nil, err
+		}
+		query = against.(string)
+	}
+
+	lookup, err := m.Index.Search(ctx, query, m.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	m.cache.lookup = lookup
+	return lookup, nil
+}
+
+// Resolved implements the Expression interface.
+func (m *Match) Resolved() bool {
+	for _, c := range m.Columns {
+		if !c.Resolved() {
+			return false
+		}
+	}
+	return m.Against.Resolved()
+}
+
+// IsNullable implements the Expression interface.
+func (m *Match) IsNullable() bool {
+	return false
+}
+
+// Type implements the Expression interface.
+func (m *Match) Type() sql.Type {
+	return sql.Float64
+}
+
+// Eval implements the Expression interface.
+func (m *Match) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	lookup, err := m.Lookup(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(m.Columns))
+	for i, c := range m.Columns {
+		values[i], err = c.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return lookup.Relevance(values...)
+}
+
+// Children implements the Expression interface.
+func (m *Match) Children() []sql.Expression {
+	return append(append([]sql.Expression{}, m.Columns...), m.Against)
+}
+
+// WithChildren implements the Expression interface.
+func (m *Match) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != len(m.Columns)+1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(m, len(children), len(m.Columns)+1)
+	}
+
+	nm := *m
+	nm.Columns = children[:len(m.Columns)]
+	nm.Against = children[len(m.Columns)]
+	nm.cache = new(matchCache)
+	return &nm, nil
+}
+
+func (m *Match) String() string {
+	columns := make([]string, len(m.Columns))
+	for i, c := range m.Columns {
+		columns[i] = c.String()
+	}
+
+	against := m.Against.String()
+	if m.Mode == sql.FullTextSearchMode_Boolean {
+		against += " " + m.Mode.String()
+	}
+
+	return fmt.Sprintf("MATCH (%s) AGAINST (%s)", strings.Join(columns, ", "), against)
+}
@@ -48,11 +48,18 @@ func (s *SetField) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	// Some expressions, such as a division by zero, evaluate to the NULL type rather than nil
+	if val == sql.Null {
+		val = nil
+	}
 	if val != nil {
-		val, err = getField.fieldType.Convert(val)
-		if err != nil {
+		converted, err := getField.fieldType.Convert(val)
+		if err == nil {
+			val = converted
+		} else if sql.LoadSqlMode(ctx).Strict() {
 			return nil, err
 		}
+		// Otherwise the value is adjusted to fit the column when the row is written, see sql.ConvertToColumn.
 	}
 	updatedRow := row.Copy()
 	updatedRow[getField.fieldIndex] = val
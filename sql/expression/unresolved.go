@@ -79,6 +79,9 @@ type UnresolvedFunction struct {
 	name string
 	// IsAggregate or not.
 	IsAggregate bool
+	// Distinct is whether the aggregation only takes into account distinct
+	// values of its arguments.
+	Distinct bool
 	// Children of the expression.
 	Arguments []sql.Expression
 }
@@ -89,7 +92,7 @@ func NewUnresolvedFunction(
 	agg bool,
 	arguments ...sql.Expression,
 ) *UnresolvedFunction {
-	return &UnresolvedFunction{name, agg, arguments}
+	return &UnresolvedFunction{name: name, IsAggregate: agg, Arguments: arguments}
 }
 
 // Children implements the Expression interface.
@@ -120,7 +123,11 @@ func (uf *UnresolvedFunction) String() string {
 	for i, e := range uf.Arguments {
 		exprs[i] = e.String()
 	}
-	return fmt.Sprintf("%s(%s)", uf.name, strings.Join(exprs, ", "))
+	var distinct string
+	if uf.Distinct {
+		distinct = "DISTINCT "
+	}
+	return fmt.Sprintf("%s(%s%s)", uf.name, distinct, strings.Join(exprs, ", "))
 }
 
 // Eval implements the Expression interface.
@@ -133,5 +140,7 @@ func (uf *UnresolvedFunction) WithChildren(children ...sql.Expression) (sql.Expr
 	if len(children) != len(uf.Arguments) {
 		return nil, sql.ErrInvalidChildrenNumber.New(uf, len(children), len(uf.Arguments))
 	}
-	return NewUnresolvedFunction(uf.name, uf.IsAggregate, children...), nil
+	nf := NewUnresolvedFunction(uf.name, uf.IsAggregate, children...)
+	nf.Distinct = uf.Distinct
+	return nf, nil
 }
@@ -24,12 +24,19 @@ import (
 // hand side of a SET statement for a system variable.
 type SystemVar struct {
 	Name string
-	typ  sql.Type
+	// Global is whether the expression refers to the global value of the variable instead of the session one.
+	Global bool
+	typ    sql.Type
 }
 
 // NewSystemVar creates a new SystemVar expression.
 func NewSystemVar(name string, typ sql.Type) *SystemVar {
-	return &SystemVar{name, typ}
+	return &SystemVar{Name: name, typ: typ}
+}
+
+// NewGlobalSystemVar creates a new SystemVar expression for the global value of a system variable.
+func NewGlobalSystemVar(name string, typ sql.Type) *SystemVar {
+	return &SystemVar{Name: name, Global: true, typ: typ}
 }
 
 // Children implements the sql.Expression interface.
@@ -37,6 +44,11 @@ func (v *SystemVar) Children() []sql.Expression { return nil }
 
 // Eval implements the sql.Expression interface.
 func (v *SystemVar) Eval(ctx *sql.Context, _ sql.Row) (interface{}, error) {
+	if v.Global {
+		_, val, err := sql.GetGlobal(v.Name)
+		return val, err
+	}
+
 	_, val := ctx.Get(v.Name)
 	return val, nil
 }
@@ -51,10 +63,15 @@ func (v *SystemVar) IsNullable() bool { return false }
 func (v *SystemVar) Resolved() bool { return true }
 
 // String implements the sql.Expression interface.
-func (v *SystemVar) String() string { return "@@" + v.Name }
+func (v *SystemVar) String() string {
+	if v.Global {
+		return "@@global." + v.Name
+	}
+	return "@@" + v.Name
+}
 
 func (v *SystemVar) DebugString() string {
-	return fmt.Sprintf("@@%s (%s)", v.Name, v.typ)
+	return fmt.Sprintf("%s (%s)", v, v.typ)
 }
 
 // WithChildren implements the Expression interface.
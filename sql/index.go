@@ -1,6 +1,16 @@
 package sql
 
-import "fmt"
+import (
+	"fmt"
+
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+// ErrNoFullTextIndex is returned when a MATCH expression names columns that are not covered by a FULLTEXT index.
+var ErrNoFullTextIndex = errors.NewKind("Can't find FULLTEXT index matching the column list")
+
+// ErrBadFullTextColumn is returned when a FULLTEXT index is created on a column that isn't a text column.
+var ErrBadFullTextColumn = errors.NewKind("Column '%s' cannot be part of FULLTEXT index")
 
 // Index is the basic representation of an index. It can be extended with
 // more functionality by implementing more specific interfaces.
@@ -60,6 +70,39 @@ type NegateIndex interface {
 	Not(keys ...interface{}) (IndexLookup, error)
 }
 
+// FullTextSearchMode is the search modifier of a MATCH ... AGAINST expression.
+type FullTextSearchMode byte
+
+const (
+	FullTextSearchMode_NaturalLanguage FullTextSearchMode = iota
+	FullTextSearchMode_Boolean
+)
+
+func (m FullTextSearchMode) String() string {
+	switch m {
+	case FullTextSearchMode_Boolean:
+		return "IN BOOLEAN MODE"
+	default:
+		return "IN NATURAL LANGUAGE MODE"
+	}
+}
+
+// FullTextIndex is an index over one or more text columns that can be searched with MATCH ... AGAINST.
+type FullTextIndex interface {
+	Index
+	// Search returns a lookup for the rows matching the query given. Rows should be returned in descending order of
+	// relevance.
+	Search(ctx *Context, query string, mode FullTextSearchMode) (FullTextLookup, error)
+}
+
+// FullTextLookup is the IndexLookup of a full-text search.
+type FullTextLookup interface {
+	IndexLookup
+	// Relevance returns the relevance score of a row for this search, given the values of its indexed columns. The
+	// values may be in any order. Rows that don't match the search have a relevance of 0.
+	Relevance(values ...interface{}) (float64, error)
+}
+
 // IndexLookup is the implementation-specific definition of an index lookup, created by calls to Index.Get(). The
 // IndexLookup must contain all necessary information to retrieve exactly the rows in the table specified by key(s)
 // specified in Index.Get(). Implementors are responsible for all semantics of correctly returning rows that match an
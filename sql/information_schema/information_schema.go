@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 	"time"
 
@@ -48,6 +49,10 @@ const (
 	ViewsTableName = "views"
 	// UserPrivilegesTableName is the name of the user_privileges table
 	UserPrivilegesTableName = "user_privileges"
+	// GlobalStatusTableName is the name of the global_status table.
+	GlobalStatusTableName = "global_status"
+	// SessionStatusTableName is the name of the session_status table.
+	SessionStatusTableName = "session_status"
 )
 
 var _ Database = (*informationSchemaDatabase)(nil)
@@ -62,6 +67,9 @@ type informationSchemaTable struct {
 	schema  Schema
 	catalog *Catalog
 	rowIter func(*Context, *Catalog) (RowIter, error)
+	// keys are the columns that identify the rows of the table, which MySQL takes from the unique keys of the data
+	// dictionary tables the information schema is a view of.
+	keys [][]string
 }
 
 type informationSchemaPartition struct {
@@ -74,10 +82,11 @@ type informationSchemaPartitionIter struct {
 }
 
 var (
-	_ Database      = (*informationSchemaDatabase)(nil)
-	_ Table         = (*informationSchemaTable)(nil)
-	_ Partition     = (*informationSchemaPartition)(nil)
-	_ PartitionIter = (*informationSchemaPartitionIter)(nil)
+	_ Database        = (*informationSchemaDatabase)(nil)
+	_ Table           = (*informationSchemaTable)(nil)
+	_ UniqueKeysTable = (*informationSchemaTable)(nil)
+	_ Partition       = (*informationSchemaPartition)(nil)
+	_ PartitionIter   = (*informationSchemaPartitionIter)(nil)
 )
 
 var filesSchema = Schema{
@@ -360,6 +369,16 @@ var userPrivilegesSchema = Schema{
 	{Name: "is_grantable", Type: LongText, Default: nil, Nullable: false, Source: UserPrivilegesTableName},
 }
 
+var globalStatusSchema = Schema{
+	{Name: "variable_name", Type: LongText, Default: nil, Nullable: false, Source: GlobalStatusTableName},
+	{Name: "variable_value", Type: LongText, Default: nil, Nullable: true, Source: GlobalStatusTableName},
+}
+
+var sessionStatusSchema = Schema{
+	{Name: "variable_name", Type: LongText, Default: nil, Nullable: false, Source: SessionStatusTableName},
+	{Name: "variable_value", Type: LongText, Default: nil, Nullable: true, Source: SessionStatusTableName},
+}
+
 func tablesRowIter(ctx *Context, cat *Catalog) (RowIter, error) {
 	var rows []Row
 	for _, db := range cat.AllDatabases() {
@@ -456,6 +475,13 @@ func columnsRowIter(ctx *Context, cat *Catalog) (RowIter, error) {
 					charName = Collation_Default.CharacterSet().String()
 					collName = Collation_Default.String()
 				}
+				extra, generation := c.Extra, ""
+				if c.Generated != nil {
+					extra, generation = "STORED GENERATED", c.Generated.Expression.String()
+					if c.Virtual {
+						extra = "VIRTUAL GENERATED"
+					}
+				}
 				rows = append(rows, Row{
 					"def",                            // table_catalog
 					db.Name(),                        // table_schema
@@ -474,10 +500,10 @@ func columnsRowIter(ctx *Context, cat *Catalog) (RowIter, error) {
 					collName,                         // collation_name
 					strings.ToLower(c.Type.String()), // column_type
 					"",                               // column_key
-					c.Extra,                          // extra
+					extra,                            // extra
 					"select",                         // privileges
 					c.Comment,                        // column_comment
-					"",                               // generation_expression
+					generation,                       // generation_expression
 				})
 			}
 			return true, nil
@@ -626,41 +652,48 @@ func NewInformationSchemaDatabase(cat *Catalog) Database {
 				name:    FilesTableName,
 				schema:  filesSchema,
 				catalog: cat,
+				keys:    [][]string{{"file_name"}},
 			},
 			ColumnStatisticsTableName: &informationSchemaTable{
 				name:    ColumnStatisticsTableName,
 				schema:  columnStatisticsSchema,
 				catalog: cat,
+				keys:    [][]string{{"schema_name", "table_name", "column_name"}},
 			},
 			TablesTableName: &informationSchemaTable{
 				name:    TablesTableName,
 				schema:  tablesSchema,
 				catalog: cat,
 				rowIter: tablesRowIter,
+				keys:    [][]string{{"table_schema", "table_name"}},
 			},
 			ColumnsTableName: &informationSchemaTable{
 				name:    ColumnsTableName,
 				schema:  columnsSchema,
 				catalog: cat,
 				rowIter: columnsRowIter,
+				keys:    [][]string{{"table_schema", "table_name", "column_name"}},
 			},
 			SchemataTableName: &informationSchemaTable{
 				name:    SchemataTableName,
 				schema:  schemataSchema,
 				catalog: cat,
 				rowIter: schemataRowIter,
+				keys:    [][]string{{"schema_name"}},
 			},
 			CollationsTableName: &informationSchemaTable{
 				name:    CollationsTableName,
 				schema:  collationsSchema,
 				catalog: cat,
 				rowIter: collationsRowIter,
+				keys:    [][]string{{"collation_name"}, {"id"}},
 			},
 			StatisticsTableName: &informationSchemaTable{
 				name:    StatisticsTableName,
 				schema:  statisticsSchema,
 				catalog: cat,
 				rowIter: emptyRowIter,
+				keys:    [][]string{{"table_schema", "table_name", "index_name", "seq_in_index"}},
 			},
 			TableConstraintsTableName: &informationSchemaTable{
 				name:    TableConstraintsTableName,
@@ -673,6 +706,7 @@ func NewInformationSchemaDatabase(cat *Catalog) Database {
 				schema:  referentialConstraintsSchema,
 				catalog: cat,
 				rowIter: emptyRowIter,
+				keys:    [][]string{{"constraint_schema", "constraint_name"}},
 			},
 			KeyColumnUsageTableName: &informationSchemaTable{
 				name:    KeyColumnUsageTableName,
@@ -685,24 +719,28 @@ func NewInformationSchemaDatabase(cat *Catalog) Database {
 				schema:  triggersSchema,
 				catalog: cat,
 				rowIter: triggersRowIter,
+				keys:    [][]string{{"trigger_schema", "trigger_name"}},
 			},
 			EventsTableName: &informationSchemaTable{
 				name:    EventsTableName,
 				schema:  eventsSchema,
 				catalog: cat,
 				rowIter: emptyRowIter,
+				keys:    [][]string{{"event_schema", "event_name"}},
 			},
 			RoutinesTableName: &informationSchemaTable{
 				name:    RoutinesTableName,
 				schema:  routinesSchema,
 				catalog: cat,
 				rowIter: emptyRowIter,
+				keys:    [][]string{{"routine_schema", "routine_name", "routine_type"}},
 			},
 			ViewsTableName: &informationSchemaTable{
 				name:    ViewsTableName,
 				schema:  viewsSchema,
 				catalog: cat,
 				rowIter: viewRowIter,
+				keys:    [][]string{{"table_schema", "table_name"}},
 			},
 			UserPrivilegesTableName: &informationSchemaTable{
 				name:    UserPrivilegesTableName,
@@ -710,6 +748,20 @@ func NewInformationSchemaDatabase(cat *Catalog) Database {
 				catalog: cat,
 				rowIter: emptyRowIter,
 			},
+			GlobalStatusTableName: &informationSchemaTable{
+				name:    GlobalStatusTableName,
+				schema:  globalStatusSchema,
+				catalog: cat,
+				rowIter: globalStatusRowIter,
+				keys:    [][]string{{"variable_name"}},
+			},
+			SessionStatusTableName: &informationSchemaTable{
+				name:    SessionStatusTableName,
+				schema:  sessionStatusSchema,
+				catalog: cat,
+				rowIter: sessionStatusRowIter,
+				keys:    [][]string{{"variable_name"}},
+			},
 		},
 	}
 }
@@ -736,6 +788,25 @@ func viewRowIter(context *Context, catalog *Catalog) (RowIter, error) {
 	return RowsToRowIter(rows...), nil
 }
 
+func globalStatusRowIter(ctx *Context, cat *Catalog) (RowIter, error) {
+	return statusRowIter(cat.GlobalStatus()), nil
+}
+
+func sessionStatusRowIter(ctx *Context, cat *Catalog) (RowIter, error) {
+	return statusRowIter(cat.SessionStatus(ctx.ID())), nil
+}
+
+func statusRowIter(status map[string]int64) RowIter {
+	var rows []Row
+	for name, value := range status {
+		rows = append(rows, Row{name, fmt.Sprint(value)})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i][0].(string) < rows[j][0].(string)
+	})
+	return RowsToRowIter(rows...)
+}
+
 // Name implements the sql.Database interface.
 func (db *informationSchemaDatabase) Name() string { return db.name }
 
@@ -767,6 +838,11 @@ func (t *informationSchemaTable) Schema() Schema {
 }
 
 // Partitions implements the sql.Table interface.
+// UniqueKeys implements the sql.UniqueKeysTable interface.
+func (t *informationSchemaTable) UniqueKeys() [][]string {
+	return t.keys
+}
+
 func (t *informationSchemaTable) Partitions(ctx *Context) (PartitionIter, error) {
 	return &informationSchemaPartitionIter{informationSchemaPartition: informationSchemaPartition{partitionKey(t.Name())}}, nil
 }
@@ -3,11 +3,35 @@ package sql
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/dolthub/vitess/go/sqltypes"
 	"github.com/dolthub/vitess/go/vt/proto/query"
+	"gopkg.in/src-d/go-errors.v1"
 )
 
+var (
+	// ErrInvalidJSONText is returned when a value is not a valid JSON text.
+	ErrInvalidJSONText = errors.NewKind("Invalid JSON text: %s")
+
+	// ErrInvalidJSONPath is returned when a JSON path expression is not valid.
+	ErrInvalidJSONPath = errors.NewKind("Invalid JSON path expression: %s")
+
+	// ErrJSONPathWildcard is returned when a JSON path expression with
+	// wildcards is used where a single location is required.
+	ErrJSONPathWildcard = errors.NewKind("In this situation, path expressions may not contain the * and ** tokens or an array range: %s")
+
+	// ErrJSONNullKey is returned when the key of a JSON object member is NULL.
+	ErrJSONNullKey = errors.NewKind("JSON documents may not contain NULL member names")
+)
+
+// JSON is the type of JSON documents. Its values are JSONDocument.
 var JSON JsonType = jsonType{}
 
 type JsonType interface {
@@ -16,33 +40,482 @@ type JsonType interface {
 
 type jsonType struct{}
 
-// Compare implements Type interface.
+// JSONDocument is a JSON value. Val holds the decoded value, which is nil for
+// the JSON null literal, a bool, an int64, a uint64, a float64, a string, a
+// []interface{} with the elements of an array or a map[string]interface{}
+// with the members of an object.
+type JSONDocument struct {
+	Val interface{}
+}
+
+// MustJSON parses the given JSON text, panicking if it's not valid.
+func MustJSON(text string) JSONDocument {
+	doc, err := JSON.Convert(text)
+	if err != nil {
+		panic(err)
+	}
+	return doc.(JSONDocument)
+}
+
+// String returns the JSON text of the document, normalized as MySQL does:
+// object members are sorted by the length of their keys and then by the
+// keys themselves, and items are separated by a comma and a space.
+func (doc JSONDocument) String() string {
+	var buf bytes.Buffer
+	writeJSON(&buf, doc.Val)
+	return buf.String()
+}
+
+// Compare implements Type interface. JSON values are compared as MySQL does:
+// values of different JSON types are ordered by their type, numbers are
+// compared by their numeric value, strings byte by byte, and arrays element
+// by element.
 func (t jsonType) Compare(a interface{}, b interface{}) (int, error) {
 	if hasNulls, res := compareNulls(a, b); hasNulls {
 		return res, nil
 	}
-	//TODO: this won't work if a JSON has two fields in a different order
-	return bytes.Compare(a.([]byte), b.([]byte)), nil
+
+	ad, err := t.Convert(a)
+	if err != nil {
+		return 0, err
+	}
+	bd, err := t.Convert(b)
+	if err != nil {
+		return 0, err
+	}
+
+	return CompareJSON(ad.(JSONDocument).Val, bd.(JSONDocument).Val), nil
 }
 
-// Convert implements Type interface.
+// Convert implements Type interface. Strings and byte slices are parsed as
+// JSON text, other values are converted to the JSON value that represents
+// them.
 func (t jsonType) Convert(v interface{}) (interface{}, error) {
 	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case JSONDocument:
+		return v, nil
 	case string:
-		var doc interface{}
-		if err := json.Unmarshal([]byte(v), &doc); err != nil {
-			return json.Marshal(v)
+		return parseJSON([]byte(v))
+	case []byte:
+		return parseJSON(v)
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
 		}
-		return json.Marshal(doc)
+		return parseJSON(b)
+	}
+}
+
+func parseJSON(text []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(text))
+	dec.UseNumber()
+
+	var val interface{}
+	if err := dec.Decode(&val); err != nil {
+		return nil, ErrInvalidJSONText.New(string(text))
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, ErrInvalidJSONText.New(string(text))
+	}
+
+	return JSONDocument{Val: normalizeJSONNumbers(val)}, nil
+}
+
+// normalizeJSONNumbers replaces the numbers of a decoded JSON value with
+// int64, uint64 or float64 values.
+func normalizeJSONNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		s := string(v)
+		if !strings.ContainsAny(s, ".eE") {
+			if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+				return i
+			}
+			if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+				return u
+			}
+		}
+		f, _ := strconv.ParseFloat(s, 64)
+		return f
+	case []interface{}:
+		for i := range v {
+			v[i] = normalizeJSONNumbers(v[i])
+		}
+		return v
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = normalizeJSONNumbers(v[k])
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// JSONValueOf returns the JSON value of an SQL value of the given type, as used
+// to build a document out of SQL values. JSON values are used as they are,
+// strings become JSON strings instead of being parsed, booleans become JSON
+// booleans, dates become strings and NULL becomes the JSON null literal.
+func JSONValueOf(t Type, v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case JSONDocument:
+		return v.Val, nil
+	case bool:
+		return v, nil
+	case string:
+		if t == JSON {
+			doc, err := JSON.Convert(v)
+			if err != nil {
+				return nil, err
+			}
+			return doc.(JSONDocument).Val, nil
+		}
+		return v, nil
 	case []byte:
-		var doc interface{}
-		if err := json.Unmarshal(v, &doc); err != nil {
-			return json.Marshal(v)
+		return string(v), nil
+	case time.Time:
+		if t == Date {
+			return v.Format(DateLayout), nil
+		}
+		return v.Format("2006-01-02 15:04:05.000000"), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case uint8:
+		return uint64(v), nil
+	case uint16:
+		return uint64(v), nil
+	case uint32:
+		return uint64(v), nil
+	case uint64:
+		return v, nil
+	case uint:
+		return uint64(v), nil
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	default:
+		doc, err := JSON.Convert(v)
+		if err != nil {
+			return nil, err
+		}
+		return doc.(JSONDocument).Val, nil
+	}
+}
+
+// JSONTypeName returns the name of the JSON type of the given value, as
+// returned by JSON_TYPE.
+func JSONTypeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		return "BOOLEAN"
+	case int64:
+		return "INTEGER"
+	case uint64:
+		return "UNSIGNED INTEGER"
+	case float64:
+		return "DOUBLE"
+	case string:
+		return "STRING"
+	case []interface{}:
+		return "ARRAY"
+	case map[string]interface{}:
+		return "OBJECT"
+	default:
+		return "OPAQUE"
+	}
+}
+
+// jsonTypeRank returns the position of the JSON type of a value in the order
+// used to compare values of different types.
+func jsonTypeRank(v interface{}) int {
+	switch v.(type) {
+	case nil:
+		return 0
+	case int64, uint64, float64:
+		return 1
+	case string:
+		return 2
+	case map[string]interface{}:
+		return 3
+	case []interface{}:
+		return 4
+	case bool:
+		return 5
+	default:
+		return 6
+	}
+}
+
+// CompareJSON compares two decoded JSON values as MySQL does.
+func CompareJSON(a, b interface{}) int {
+	ra, rb := jsonTypeRank(a), jsonTypeRank(b)
+	if ra != rb {
+		if ra < rb {
+			return -1
+		}
+		return 1
+	}
+
+	switch a := a.(type) {
+	case nil:
+		return 0
+	case bool:
+		bb := b.(bool)
+		switch {
+		case a == bb:
+			return 0
+		case !a:
+			return -1
+		default:
+			return 1
+		}
+	case string:
+		return strings.Compare(a, b.(string))
+	case []interface{}:
+		bb := b.([]interface{})
+		for i := 0; i < len(a) && i < len(bb); i++ {
+			if cmp := CompareJSON(a[i], bb[i]); cmp != 0 {
+				return cmp
+			}
+		}
+		return compareInts(len(a), len(bb))
+	case map[string]interface{}:
+		bb := b.(map[string]interface{})
+		if cmp := compareInts(len(a), len(bb)); cmp != 0 {
+			return cmp
+		}
+		ak, bk := JSONObjectKeys(a), JSONObjectKeys(bb)
+		for i := range ak {
+			if cmp := strings.Compare(ak[i], bk[i]); cmp != 0 {
+				return cmp
+			}
+		}
+		for _, k := range ak {
+			if cmp := CompareJSON(a[k], bb[k]); cmp != 0 {
+				return cmp
+			}
+		}
+		return 0
+	case int64, uint64, float64:
+		return compareJSONNumbers(a, b)
+	default:
+		return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareJSONNumbers(a, b interface{}) int {
+	switch a := a.(type) {
+	case int64:
+		switch b := b.(type) {
+		case int64:
+			return compareInt64s(a, b)
+		case uint64:
+			if a < 0 {
+				return -1
+			}
+			return compareUint64s(uint64(a), b)
+		}
+	case uint64:
+		switch b := b.(type) {
+		case uint64:
+			return compareUint64s(a, b)
+		case int64:
+			if b < 0 {
+				return 1
+			}
+			return compareUint64s(a, uint64(b))
 		}
-		return json.Marshal(doc)
+	}
+
+	fa, fb := jsonNumberToFloat(a), jsonNumberToFloat(b)
+	switch {
+	case fa < fb:
+		return -1
+	case fa > fb:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareInt64s(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
 	default:
-		return json.Marshal(v)
+		return 0
+	}
+}
+
+func compareUint64s(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func jsonNumberToFloat(v interface{}) float64 {
+	switch v := v.(type) {
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float64:
+		return v
+	default:
+		return math.NaN()
+	}
+}
+
+// JSONObjectKeys returns the keys of a JSON object in the order MySQL uses,
+// that is, sorted by their length and then by their bytes.
+func JSONObjectKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) < len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// CopyJSON returns a deep copy of a decoded JSON value, so it can be modified
+// without changing the original one.
+func CopyJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		arr := make([]interface{}, len(v))
+		for i := range v {
+			arr[i] = CopyJSON(v[i])
+		}
+		return arr
+	case map[string]interface{}:
+		obj := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			obj[k] = CopyJSON(val)
+		}
+		return obj
+	default:
+		return v
+	}
+}
+
+func writeJSON(buf *bytes.Buffer, v interface{}) {
+	switch v := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case int64:
+		buf.WriteString(strconv.FormatInt(v, 10))
+	case uint64:
+		buf.WriteString(strconv.FormatUint(v, 10))
+	case float64:
+		buf.WriteString(formatJSONFloat(v))
+	case string:
+		writeJSONString(buf, v)
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, elem := range v {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			writeJSON(buf, elem)
+		}
+		buf.WriteByte(']')
+	case map[string]interface{}:
+		buf.WriteByte('{')
+		for i, k := range JSONObjectKeys(v) {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			writeJSONString(buf, k)
+			buf.WriteString(": ")
+			writeJSON(buf, v[k])
+		}
+		buf.WriteByte('}')
+	default:
+		writeJSONString(buf, fmt.Sprint(v))
+	}
+}
+
+// formatJSONFloat formats a double as MySQL does, keeping a decimal point for
+// integral values so they can be told apart from integers.
+func formatJSONFloat(f float64) string {
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if strings.Contains(s, "e") {
+		return strings.Replace(s, "e+", "e", 1)
+	}
+	if !strings.Contains(s, ".") && !math.IsInf(f, 0) && !math.IsNaN(f) {
+		s += ".0"
+	}
+	return s
+}
+
+func writeJSONString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(buf, `\u%04x`, r)
+			} else {
+				buf.WriteRune(r)
+			}
+		}
 	}
+	buf.WriteByte('"')
 }
 
 // MustConvert implements the Type interface.
@@ -70,7 +543,7 @@ func (t jsonType) SQL(v interface{}) (sqltypes.Value, error) {
 		return sqltypes.Value{}, err
 	}
 
-	return sqltypes.MakeTrusted(sqltypes.TypeJSON, v.([]byte)), nil
+	return sqltypes.MakeTrusted(sqltypes.TypeJSON, []byte(v.(JSONDocument).String())), nil
 }
 
 // String implements Type interface.
@@ -85,5 +558,5 @@ func (t jsonType) Type() query.Type {
 
 // Zero implements Type interface.
 func (t jsonType) Zero() interface{} {
-	return []byte(`""`)
+	return JSONDocument{Val: ""}
 }
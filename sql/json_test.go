@@ -17,9 +17,20 @@ func TestJsonCompare(t *testing.T) {
 		{nil, 0, -1},
 		{0, nil, 1},
 		{nil, nil, 0},
-		{[]byte("A"), []byte("B"), -1},
-		{[]byte("A"), []byte("A"), 0},
-		{[]byte("C"), []byte("B"), 1},
+		{`"A"`, `"B"`, -1},
+		{`"A"`, `"A"`, 0},
+		{`"C"`, `"B"`, 1},
+		{`1`, `1.0`, 0},
+		{`2`, `10`, -1},
+		{`{"a": 1, "b": 2}`, `{"b":2,"a":1}`, 0},
+		{`[1, 2]`, `[1, 3]`, -1},
+		{`[1, 2]`, `[1, 2, 3]`, -1},
+		{`null`, `1`, -1},
+		{`1`, `"1"`, -1},
+		{`"a"`, `{}`, -1},
+		{`{}`, `[]`, -1},
+		{`[]`, `true`, -1},
+		{`false`, `true`, -1},
 	}
 
 	for _, test := range tests {
@@ -37,9 +48,15 @@ func TestJsonConvert(t *testing.T) {
 		expectedVal interface{}
 		expectedErr bool
 	}{
-		{"", []byte(`""`), false},
-		{[]int{1, 2}, []byte("[1,2]"), false},
-		{`{"a": true, "b": 3}`, []byte(`{"a":true,"b":3}`), false},
+		{`""`, JSONDocument{Val: ""}, false},
+		{[]int{1, 2}, JSONDocument{Val: []interface{}{int64(1), int64(2)}}, false},
+		{`{"a": true, "b": 3}`, JSONDocument{Val: map[string]interface{}{"a": true, "b": int64(3)}}, false},
+		{`{"a": 1.5, "b": 18446744073709551615}`, JSONDocument{Val: map[string]interface{}{"a": 1.5, "b": uint64(18446744073709551615)}}, false},
+		{[]byte(`[null]`), JSONDocument{Val: []interface{}{nil}}, false},
+		{MustJSON(`[1]`), MustJSON(`[1]`), false},
+		{"", nil, true},
+		{`{"a": 1`, nil, true},
+		{`[1] [2]`, nil, true},
 	}
 
 	for _, test := range tests {
@@ -55,6 +72,26 @@ func TestJsonConvert(t *testing.T) {
 	}
 }
 
+func TestJSONDocumentString(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{`null`, `null`},
+		{`"a\"b\n"`, `"a\"b\n"`},
+		{`1.0`, `1.0`},
+		{`1e3`, `1000.0`},
+		{`[1,"a",[]]`, `[1, "a", []]`},
+		{`{"bb": 1, "a": {"c": true}, "ab": null}`, `{"a": {"c": true}, "ab": null, "bb": 1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			require.Equal(t, tt.expected, MustJSON(tt.text).String())
+		})
+	}
+}
+
 func TestJsonString(t *testing.T) {
 	require.Equal(t, "JSON", JSON.String())
 }
@@ -0,0 +1,497 @@
+package sql
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type jsonPathLegKind byte
+
+const (
+	// jsonMember is a .key leg.
+	jsonMember jsonPathLegKind = iota
+	// jsonMemberWildcard is a .* leg.
+	jsonMemberWildcard
+	// jsonArrayCell is a [n] leg.
+	jsonArrayCell
+	// jsonArrayRange is a [m to n] leg.
+	jsonArrayRange
+	// jsonArrayWildcard is a [*] leg.
+	jsonArrayWildcard
+	// jsonDoubleWildcard is a ** leg.
+	jsonDoubleWildcard
+)
+
+// jsonArrayIndex is an array index in a JSON path, which is either a position
+// or, with last set, a position from the end of the array, as in [last-n].
+type jsonArrayIndex struct {
+	n    int
+	last bool
+}
+
+// resolve returns the position the index refers to in an array of the given
+// length. The position may be out of bounds.
+func (i jsonArrayIndex) resolve(length int) int {
+	if i.last {
+		return length - 1 - i.n
+	}
+	return i.n
+}
+
+type jsonPathLeg struct {
+	kind     jsonPathLegKind
+	key      string
+	from, to jsonArrayIndex
+}
+
+// JSONPath is a JSON path expression, as used by the JSON functions to refer
+// to the values in a document, such as $.a[0] or $**.b.
+type JSONPath struct {
+	text string
+	legs []jsonPathLeg
+}
+
+// ParseJSONPath parses the given MySQL JSON path expression.
+func ParseJSONPath(path string) (*JSONPath, error) {
+	p := &jsonPathParser{s: path}
+	legs, err := p.parse()
+	if err != nil {
+		return nil, ErrInvalidJSONPath.New(path)
+	}
+	return &JSONPath{text: path, legs: legs}, nil
+}
+
+// String returns the text of the path.
+func (p *JSONPath) String() string {
+	return p.text
+}
+
+// HasWildcards returns whether the path can refer to more than one value,
+// because it has wildcards or array ranges.
+func (p *JSONPath) HasWildcards() bool {
+	for _, leg := range p.legs {
+		switch leg.kind {
+		case jsonMemberWildcard, jsonArrayRange, jsonArrayWildcard, jsonDoubleWildcard:
+			return true
+		}
+	}
+	return false
+}
+
+// IsArrayCell returns whether the last leg of the path is an array position.
+func (p *JSONPath) IsArrayCell() bool {
+	return len(p.legs) > 0 && p.legs[len(p.legs)-1].kind == jsonArrayCell
+}
+
+// IsRoot returns whether the path refers to the whole document.
+func (p *JSONPath) IsRoot() bool {
+	return len(p.legs) == 0
+}
+
+// Extract returns the values the path refers to in the given decoded JSON
+// value, in document order.
+func (p *JSONPath) Extract(v interface{}) []interface{} {
+	var result []interface{}
+	walkJSONPath(v, p.legs, "$", func(_ string, v interface{}) {
+		result = append(result, v)
+	})
+	return result
+}
+
+// Walk calls fn with every value the path refers to in the given decoded JSON
+// value, along with the path to that value without wildcards.
+func (p *JSONPath) Walk(v interface{}, fn func(path string, v interface{})) {
+	walkJSONPath(v, p.legs, "$", fn)
+}
+
+// WalkAll is like Walk, but fn is also called with all the values nested in
+// the ones the path refers to.
+func (p *JSONPath) WalkAll(v interface{}, fn func(path string, v interface{})) {
+	walkJSONPath(v, p.legs, "$", func(path string, v interface{}) {
+		walkNestedJSON(v, path, fn)
+	})
+}
+
+func walkNestedJSON(v interface{}, path string, fn func(string, interface{})) {
+	fn(path, v)
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for _, k := range JSONObjectKeys(v) {
+			walkNestedJSON(v[k], path+"."+quoteJSONKey(k), fn)
+		}
+	case []interface{}:
+		for i := range v {
+			walkNestedJSON(v[i], path+"["+strconv.Itoa(i)+"]", fn)
+		}
+	}
+}
+
+func walkJSONPath(v interface{}, legs []jsonPathLeg, path string, fn func(string, interface{})) {
+	if len(legs) == 0 {
+		fn(path, v)
+		return
+	}
+
+	leg, rest := legs[0], legs[1:]
+	switch leg.kind {
+	case jsonMember:
+		if obj, ok := v.(map[string]interface{}); ok {
+			if val, ok := obj[leg.key]; ok {
+				walkJSONPath(val, rest, path+"."+quoteJSONKey(leg.key), fn)
+			}
+		}
+	case jsonMemberWildcard:
+		if obj, ok := v.(map[string]interface{}); ok {
+			for _, k := range JSONObjectKeys(obj) {
+				walkJSONPath(obj[k], rest, path+"."+quoteJSONKey(k), fn)
+			}
+		}
+	case jsonArrayCell, jsonArrayRange, jsonArrayWildcard:
+		arr, ok := v.([]interface{})
+		if !ok {
+			// Values that are not arrays are treated as an array with a
+			// single element, except for the [*] wildcard.
+			if leg.kind == jsonArrayWildcard {
+				return
+			}
+			from, to := leg.bounds(1)
+			if from <= 0 && to >= 0 {
+				walkJSONPath(v, rest, path, fn)
+			}
+			return
+		}
+
+		from, to := leg.bounds(len(arr))
+		if from < 0 {
+			from = 0
+		}
+		for i := from; i <= to && i < len(arr); i++ {
+			walkJSONPath(arr[i], rest, path+"["+strconv.Itoa(i)+"]", fn)
+		}
+	case jsonDoubleWildcard:
+		walkJSONPath(v, rest, path, fn)
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for _, k := range JSONObjectKeys(v) {
+				walkJSONPath(v[k], legs, path+"."+quoteJSONKey(k), fn)
+			}
+		case []interface{}:
+			for i := range v {
+				walkJSONPath(v[i], legs, path+"["+strconv.Itoa(i)+"]", fn)
+			}
+		}
+	}
+}
+
+// bounds returns the first and last positions an array leg refers to in an
+// array of the given length.
+func (leg jsonPathLeg) bounds(length int) (int, int) {
+	switch leg.kind {
+	case jsonArrayCell:
+		i := leg.from.resolve(length)
+		return i, i
+	case jsonArrayRange:
+		return leg.from.resolve(length), leg.to.resolve(length)
+	default:
+		return 0, length - 1
+	}
+}
+
+// Update returns the given decoded JSON value with the value the path refers
+// to replaced by the result of fn, which receives the current value and
+// whether it exists. A value that doesn't exist can only be added when its
+// parent exists, as a new member of an object or at the end of an array. If
+// fn returns false, nothing is changed. The path must not have wildcards, and
+// the given value may be modified.
+func (p *JSONPath) Update(v interface{}, fn func(cur interface{}, exists bool) (interface{}, bool)) interface{} {
+	return updateJSONPath(v, p.legs, fn)
+}
+
+// UpdateParent is like Update, but fn receives the parent of the value the
+// path refers to. It's not called if the parent doesn't exist.
+func (p *JSONPath) UpdateParent(v interface{}, fn func(parent interface{}) (interface{}, bool)) interface{} {
+	if len(p.legs) == 0 {
+		return v
+	}
+	return updateJSONPath(v, p.legs[:len(p.legs)-1], func(cur interface{}, exists bool) (interface{}, bool) {
+		if !exists {
+			return nil, false
+		}
+		return fn(cur)
+	})
+}
+
+// LastMember returns the key of the last leg of the path if it's a member.
+func (p *JSONPath) LastMember() (string, bool) {
+	if len(p.legs) == 0 || p.legs[len(p.legs)-1].kind != jsonMember {
+		return "", false
+	}
+	return p.legs[len(p.legs)-1].key, true
+}
+
+// LastArrayCell returns the position the last leg of the path refers to in an
+// array of the given length, if it's an array position.
+func (p *JSONPath) LastArrayCell(length int) (int, bool) {
+	if !p.IsArrayCell() {
+		return 0, false
+	}
+	return p.legs[len(p.legs)-1].from.resolve(length), true
+}
+
+func updateJSONPath(v interface{}, legs []jsonPathLeg, fn func(interface{}, bool) (interface{}, bool)) interface{} {
+	if len(legs) == 0 {
+		if nv, ok := fn(v, true); ok {
+			return nv
+		}
+		return v
+	}
+
+	leg, rest := legs[0], legs[1:]
+	switch leg.kind {
+	case jsonMember:
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return v
+		}
+		cur, exists := obj[leg.key]
+		if exists {
+			obj[leg.key] = updateJSONPath(cur, rest, fn)
+		} else if len(rest) == 0 {
+			if nv, ok := fn(nil, false); ok {
+				obj[leg.key] = nv
+			}
+		}
+		return obj
+	case jsonArrayCell:
+		arr, ok := v.([]interface{})
+		if !ok {
+			// A value that is not an array is treated as an array with a
+			// single element, so adding a value turns it into an array.
+			i := leg.from.resolve(1)
+			switch {
+			case i == 0:
+				return updateJSONPath(v, rest, fn)
+			case i > 0 && len(rest) == 0:
+				if nv, ok := fn(nil, false); ok {
+					return []interface{}{v, nv}
+				}
+			}
+			return v
+		}
+
+		i := leg.from.resolve(len(arr))
+		switch {
+		case i < 0:
+		case i < len(arr):
+			arr[i] = updateJSONPath(arr[i], rest, fn)
+		case len(rest) == 0:
+			if nv, ok := fn(nil, false); ok {
+				arr = append(arr, nv)
+			}
+		}
+		return arr
+	default:
+		return v
+	}
+}
+
+func quoteJSONKey(key string) string {
+	if isJSONIdentifier(key) {
+		return key
+	}
+	var buf bytes.Buffer
+	writeJSONString(&buf, key)
+	return buf.String()
+}
+
+func isJSONIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		if r == '_' || r == '$' || unicode.IsLetter(r) {
+			continue
+		}
+		if i > 0 && unicode.IsDigit(r) {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+type jsonPathParser struct {
+	s   string
+	pos int
+}
+
+func (p *jsonPathParser) parse() ([]jsonPathLeg, error) {
+	p.skipSpaces()
+	if !p.consume("$") {
+		return nil, errInvalidPath
+	}
+
+	var legs []jsonPathLeg
+	for {
+		p.skipSpaces()
+		if p.pos >= len(p.s) {
+			break
+		}
+
+		var leg jsonPathLeg
+		var err error
+		switch {
+		case p.consume("**"):
+			leg = jsonPathLeg{kind: jsonDoubleWildcard}
+		case p.consume("."):
+			leg, err = p.parseMember()
+		case p.consume("["):
+			leg, err = p.parseArrayLeg()
+		default:
+			return nil, errInvalidPath
+		}
+		if err != nil {
+			return nil, err
+		}
+		legs = append(legs, leg)
+	}
+
+	// A path can't end with **
+	if len(legs) > 0 && legs[len(legs)-1].kind == jsonDoubleWildcard {
+		return nil, errInvalidPath
+	}
+
+	return legs, nil
+}
+
+// errInvalidPath is returned by the parser, and replaced by an
+// ErrInvalidJSONPath with the whole path by ParseJSONPath.
+var errInvalidPath = errors.New("invalid JSON path")
+
+func (p *jsonPathParser) skipSpaces() {
+	for p.pos < len(p.s) && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t' || p.s[p.pos] == '\n') {
+		p.pos++
+	}
+}
+
+func (p *jsonPathParser) consume(s string) bool {
+	if strings.HasPrefix(p.s[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *jsonPathParser) parseMember() (jsonPathLeg, error) {
+	p.skipSpaces()
+	if p.consume("*") {
+		return jsonPathLeg{kind: jsonMemberWildcard}, nil
+	}
+
+	if p.pos < len(p.s) && p.s[p.pos] == '"' {
+		end := p.pos + 1
+		for end < len(p.s) && p.s[end] != '"' {
+			if p.s[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(p.s) {
+			return jsonPathLeg{}, errInvalidPath
+		}
+
+		doc, err := parseJSON([]byte(p.s[p.pos : end+1]))
+		if err != nil {
+			return jsonPathLeg{}, errInvalidPath
+		}
+		p.pos = end + 1
+		return jsonPathLeg{kind: jsonMember, key: doc.(JSONDocument).Val.(string)}, nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.s) {
+		r, size := utf8.DecodeRuneInString(p.s[p.pos:])
+		if r != '_' && r != '$' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			break
+		}
+		p.pos += size
+	}
+
+	key := p.s[start:p.pos]
+	if !isJSONIdentifier(key) {
+		return jsonPathLeg{}, errInvalidPath
+	}
+	return jsonPathLeg{kind: jsonMember, key: key}, nil
+}
+
+func (p *jsonPathParser) parseArrayLeg() (jsonPathLeg, error) {
+	p.skipSpaces()
+	if p.consume("*") {
+		p.skipSpaces()
+		if !p.consume("]") {
+			return jsonPathLeg{}, errInvalidPath
+		}
+		return jsonPathLeg{kind: jsonArrayWildcard}, nil
+	}
+
+	from, err := p.parseArrayIndex()
+	if err != nil {
+		return jsonPathLeg{}, err
+	}
+
+	p.skipSpaces()
+	if p.consume("]") {
+		return jsonPathLeg{kind: jsonArrayCell, from: from}, nil
+	}
+
+	if !p.consume("to") {
+		return jsonPathLeg{}, errInvalidPath
+	}
+
+	to, err := p.parseArrayIndex()
+	if err != nil {
+		return jsonPathLeg{}, err
+	}
+
+	p.skipSpaces()
+	if !p.consume("]") {
+		return jsonPathLeg{}, errInvalidPath
+	}
+
+	if from.last == to.last && ((!from.last && from.n > to.n) || (from.last && from.n < to.n)) {
+		return jsonPathLeg{}, errInvalidPath
+	}
+
+	return jsonPathLeg{kind: jsonArrayRange, from: from, to: to}, nil
+}
+
+func (p *jsonPathParser) parseArrayIndex() (jsonArrayIndex, error) {
+	p.skipSpaces()
+	if p.consume("last") {
+		p.skipSpaces()
+		if !p.consume("-") {
+			return jsonArrayIndex{last: true}, nil
+		}
+		p.skipSpaces()
+		n, err := p.parseNumber()
+		return jsonArrayIndex{n: n, last: true}, err
+	}
+
+	n, err := p.parseNumber()
+	return jsonArrayIndex{n: n}, err
+}
+
+func (p *jsonPathParser) parseNumber() (int, error) {
+	start := p.pos
+	for p.pos < len(p.s) && '0' <= p.s[p.pos] && p.s[p.pos] <= '9' {
+		p.pos++
+	}
+	if start == p.pos {
+		return 0, errInvalidPath
+	}
+	return strconv.Atoi(p.s[start:p.pos])
+}
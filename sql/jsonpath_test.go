@@ -0,0 +1,117 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJSONPath(t *testing.T) {
+	valid := []string{
+		"$",
+		"$.a",
+		"$ . a",
+		`$."a b"`,
+		"$.*",
+		"$[0]",
+		"$[ 1 ]",
+		"$[*]",
+		"$[last]",
+		"$[last - 1]",
+		"$[1 to 3]",
+		"$[0 to last]",
+		"$**.a",
+		"$.a[0].b",
+	}
+
+	for _, path := range valid {
+		t.Run(path, func(t *testing.T) {
+			p, err := ParseJSONPath(path)
+			require.NoError(t, err)
+			require.Equal(t, path, p.String())
+		})
+	}
+
+	invalid := []string{
+		"",
+		"a",
+		"$.",
+		"$.[0]",
+		"$[a]",
+		"$[-1]",
+		"$[0",
+		"$**",
+		`$."a`,
+		"$.a b",
+	}
+
+	for _, path := range invalid {
+		t.Run(path, func(t *testing.T) {
+			_, err := ParseJSONPath(path)
+			require.Error(t, err)
+			require.True(t, ErrInvalidJSONPath.Is(err))
+		})
+	}
+}
+
+func TestJSONPathExtract(t *testing.T) {
+	doc := MustJSON(`{"a": [1, 2, {"b": 3}], "c": {"b": 4}, "d e": 5}`).Val
+
+	tests := []struct {
+		path     string
+		expected []interface{}
+	}{
+		{"$", []interface{}{doc}},
+		{"$.d", nil},
+		{`$."d e"`, []interface{}{int64(5)}},
+		{"$.a[1]", []interface{}{int64(2)}},
+		{"$.a[last]", []interface{}{MustJSON(`{"b": 3}`).Val}},
+		{"$.a[last-2]", []interface{}{int64(1)}},
+		{"$.a[5]", nil},
+		{"$.a[0 to 1]", []interface{}{int64(1), int64(2)}},
+		{"$.a[*]", []interface{}{int64(1), int64(2), MustJSON(`{"b": 3}`).Val}},
+		{"$.c[0].b", []interface{}{int64(4)}},
+		{"$.c[*]", nil},
+		{"$**.b", []interface{}{int64(3), int64(4)}},
+		{"$.*.b", []interface{}{int64(4)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			p, err := ParseJSONPath(tt.path)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, p.Extract(doc))
+		})
+	}
+}
+
+func TestJSONPathUpdate(t *testing.T) {
+	tests := []struct {
+		doc      string
+		path     string
+		expected string
+	}{
+		{`{"a": 1}`, "$.a", `{"a": 10}`},
+		{`{"a": 1}`, "$.b", `{"a": 1, "b": 10}`},
+		{`{"a": 1}`, "$.b.c", `{"a": 1}`},
+		{`[1, 2]`, "$[0]", `[10, 2]`},
+		{`[1, 2]`, "$[5]", `[1, 2, 10]`},
+		{`[1, 2]`, "$[last]", `[1, 10]`},
+		{`1`, "$[0]", `10`},
+		{`1`, "$[1]", `[1, 10]`},
+		{`{"a": [1]}`, "$.a[0]", `{"a": [10]}`},
+		{`1`, "$", `10`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.doc+" "+tt.path, func(t *testing.T) {
+			p, err := ParseJSONPath(tt.path)
+			require.NoError(t, err)
+
+			v := p.Update(MustJSON(tt.doc).Val, func(interface{}, bool) (interface{}, bool) {
+				return int64(10), true
+			})
+			require.Equal(t, tt.expected, JSONDocument{Val: v}.String())
+		})
+	}
+}
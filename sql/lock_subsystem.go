@@ -6,7 +6,19 @@ import (
 	"time"
 	"unsafe"
 
+	kitmetrics "github.com/go-kit/kit/metrics"
 	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/metrics"
+)
+
+var (
+	// LockWaitCounter describes a metric that accumulates the number of named
+	// locks that could not be acquired right away.
+	LockWaitCounter kitmetrics.Counter = metrics.NewCounter("gms_lock_waits_total", "Number of named lock acquisitions that had to wait.")
+
+	// LockWaitHistogram describes the time spent waiting for named locks.
+	LockWaitHistogram kitmetrics.Histogram = metrics.NewHistogram("gms_lock_wait_seconds", "Time spent waiting for named locks, in seconds.")
 )
 
 // ErrLockTimeout is the kind of error returned when acquiring a lock takes longer than the user specified timeout
@@ -66,7 +78,15 @@ func (ls *LockSubsystem) Lock(ctx *Context, name string, timeout time.Duration)
 	}
 
 	userId := int64(ctx.Session.ID())
-	for i, start := 0, time.Now(); i == 0 || timeout < 0 || time.Since(start) < timeout; i++ {
+	start, waited := time.Now(), false
+	defer func() {
+		if waited {
+			LockWaitCounter.Add(1)
+			LockWaitHistogram.Observe(time.Since(start).Seconds())
+		}
+	}()
+
+	for i := 0; i == 0 || timeout < 0 || time.Since(start) < timeout; i++ {
 		dest := (*unsafe.Pointer)(unsafe.Pointer(nl))
 		curr := atomic.LoadPointer(dest)
 		currLock := *(*ownedLock)(curr)
@@ -83,6 +103,7 @@ func (ls *LockSubsystem) Lock(ctx *Context, name string, timeout time.Duration)
 			}
 		}
 
+		waited = true
 		time.Sleep(100 * time.Microsecond)
 	}
 
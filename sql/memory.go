@@ -185,6 +185,19 @@ func (m *MemoryManager) removeCache(pos uint64) {
 	}
 }
 
+// UsedMemory returns the memory in use in bytes, as given by the reporter of
+// the memory manager.
+func (m *MemoryManager) UsedMemory() uint64 {
+	return m.reporter.UsedMemory()
+}
+
+// NumCaches returns the number of caches currently managed.
+func (m *MemoryManager) NumCaches() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.caches)
+}
+
 // Free the memory of all freeable caches.
 func (m *MemoryManager) Free() {
 	m.mu.RLock()
@@ -24,6 +24,10 @@ const (
 var (
 	ErrOutOfRange = errors.NewKind("%v out of range for %v")
 
+	// ErrDataOutOfRange is returned when the result of an integer operation doesn't fit in the BIGINT or BIGINT
+	// UNSIGNED it's computed as.
+	ErrDataOutOfRange = errors.NewKind("%s value is out of range in '%s'")
+
 	// Boolean is a synonym for TINYINT
 	Boolean = Int8
 	// Int8 is an integer of 8 bits
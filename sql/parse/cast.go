@@ -0,0 +1,51 @@
+package parse
+
+import "strings"
+
+// The parser doesn't support casts to the DOUBLE, REAL, FLOAT and YEAR types,
+// so CAST(expr AS type) and CONVERT(expr, type) with those types are rewritten
+// as `convert`(expr, 'type'), or `convert`(expr, 'float', p) for FLOAT(p),
+// before parsing a query.
+
+// castFunctionName is the name of the function casts are rewritten to.
+const castFunctionName = "convert"
+
+// rewriteCast returns the cast with the given function name and arguments
+// that the parser supports, if it doesn't support the given one.
+func rewriteCast(name token, args []token) ([]token, bool) {
+	var i int
+	switch strings.ToLower(name.text) {
+	case "cast":
+		i = topLevelWord(args, "as")
+	case "convert":
+		i = topLevel(args, func(t token) bool { return t.is(",") })
+	default:
+		return nil, false
+	}
+	if i < 0 {
+		return nil, false
+	}
+
+	castType := nextToken(args, i+1)
+	if castType == len(args) || args[castType].kind != wordToken {
+		return nil, false
+	}
+
+	typeName := strings.ToLower(args[castType].text)
+	rest := trimTokens(args[castType+1:])
+	call := concatTokens(tokensOf("`"+castFunctionName+"`("), args[:i], tokensOf(", '"+typeName+"'"))
+	switch typeName {
+	case "double", "real", "year":
+		if len(rest) == 0 {
+			return concatTokens(call, tokensOf(")")), true
+		}
+	case "float":
+		if len(rest) == 0 {
+			return concatTokens(call, tokensOf(")")), true
+		}
+		if precision, ok := parenthesized(rest); ok {
+			return concatTokens(call, tokensOf(", "), precision, tokensOf(")")), true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,26 @@
+package parse
+
+import "strings"
+
+// The parser doesn't support EXTRACT(unit FROM date), so it's rewritten as
+// extract('unit', date) before parsing a query.
+
+// rewriteExtract returns the call to EXTRACT with the given name and arguments
+// that the parser supports, if it doesn't support the given one.
+func rewriteExtract(name token, args []token) ([]token, bool) {
+	if !strings.EqualFold(name.text, "extract") {
+		return nil, false
+	}
+
+	unit := nextToken(args, 0)
+	if unit == len(args) || args[unit].kind != wordToken {
+		return nil, false
+	}
+
+	from := nextToken(args, unit+1)
+	if from == len(args) || !args[from].isWord("from") {
+		return nil, false
+	}
+
+	return concatTokens([]token{name}, tokensOf("('"+args[unit].text+"',"), args[from+1:], tokensOf(")")), true
+}
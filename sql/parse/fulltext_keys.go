@@ -0,0 +1,65 @@
+package parse
+
+import "github.com/dolthub/vitess/go/vt/sqlparser"
+
+// The parser doesn't support FULLTEXT index definitions inside CREATE TABLE,
+// which SHOW CREATE TABLE emits, so before parsing a CREATE TABLE statement
+// every FULLTEXT [INDEX | KEY] [name] (columns) definition in it is replaced
+// with a KEY [name] (columns) USING definition with an index type that marks
+// the index as a FULLTEXT one. The index type of a CREATE TABLE definition is
+// otherwise ignored.
+
+const fullTextKeyUsing = "gms_fulltext"
+
+// rewriteFullTextKeys replaces the FULLTEXT index definitions of the given
+// CREATE TABLE statement with the KEY definitions that stand for them.
+func rewriteFullTextKeys(tokens []token) []token {
+	if !tokens[nextToken(tokens, 0)].isWord("create") {
+		return tokens
+	}
+
+	var out []token
+	var depth int
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		switch {
+		case t.is("("):
+			depth++
+		case t.is(")"):
+			depth--
+		case depth == 1 && t.isWord("fulltext"):
+			name := nextToken(tokens, i+1)
+			if name < len(tokens) && (tokens[name].isWord("index") || tokens[name].isWord("key")) {
+				name = nextToken(tokens, name+1)
+			}
+
+			open := name
+			for open < len(tokens) && !tokens[open].is("(") {
+				open++
+			}
+			end := closingParen(tokens, open)
+			if end < 0 {
+				break
+			}
+
+			out = append(out, tokensOf("KEY ")...)
+			out = append(out, tokens[name:end+1]...)
+			out = append(out, tokensOf(" USING "+fullTextKeyUsing)...)
+			i = end
+			continue
+		}
+		out = append(out, t)
+	}
+	return out
+}
+
+// isFullTextKey returns whether the given index definition of a CREATE TABLE
+// statement stands for a FULLTEXT index.
+func isFullTextKey(def *sqlparser.IndexDefinition) bool {
+	for _, option := range def.Options {
+		if option.Using == fullTextKeyUsing {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+package parse
+
+import "github.com/dolthub/vitess/go/vt/sqlparser"
+
+// The parser doesn't support generated columns, so before parsing a CREATE
+// TABLE or ALTER TABLE statement the [GENERATED ALWAYS] AS (expr) [VIRTUAL |
+// STORED] clause of every column definition in it is replaced with a
+// DEFAULT (expr) clause, where the expression is wrapped in a call to a
+// function that marks the column as generated. Generated columns can't have a
+// default value, so the two can't be mistaken for each other.
+
+const (
+	generatedStoredFunc  = "gms_generated_stored"
+	generatedVirtualFunc = "gms_generated_virtual"
+)
+
+// isCreateOrAlterTable returns whether the given tokens are a CREATE TABLE or
+// ALTER TABLE statement.
+func isCreateOrAlterTable(tokens []token) bool {
+	i := nextToken(tokens, 0)
+	if i < len(tokens) && tokens[i].isWord("alter") {
+		i = nextToken(tokens, i+1)
+		return i < len(tokens) && tokens[i].isWord("table")
+	}
+
+	if i == len(tokens) || !tokens[i].isWord("create") {
+		return false
+	}
+	if i = nextToken(tokens, i+1); i < len(tokens) && tokens[i].isWord("temporary") {
+		i = nextToken(tokens, i+1)
+	}
+	return i < len(tokens) && tokens[i].isWord("table")
+}
+
+// rewriteGeneratedColumns replaces the generated column clauses of the given
+// CREATE TABLE or ALTER TABLE statement with the DEFAULT clauses that stand for
+// them.
+func rewriteGeneratedColumns(tokens []token) []token {
+	// Column definitions are inside the parentheses of a CREATE TABLE, and
+	// either at the top level or inside parentheses in an ALTER TABLE.
+	minDepth := 1
+	if tokens[nextToken(tokens, 0)].isWord("alter") {
+		minDepth = 0
+	}
+
+	var out []token
+	var depth int
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		switch {
+		case t.is("("):
+			depth++
+		case t.is(")"):
+			depth--
+		case t.kind == wordToken && depth >= minDepth && depth <= 1:
+			open, ok := generatedColumnStart(tokens, i)
+			if !ok {
+				break
+			}
+
+			end := closingParen(tokens, open)
+			if end < 0 {
+				break
+			}
+
+			fn := generatedVirtualFunc
+			i = end
+			if next := nextToken(tokens, end+1); next < len(tokens) {
+				switch {
+				case tokens[next].isWord("stored"):
+					fn = generatedStoredFunc
+					i = next
+				case tokens[next].isWord("virtual"):
+					i = next
+				}
+			}
+
+			out = append(out, tokensOf("DEFAULT ("+fn)...)
+			out = append(out, tokens[open:end+1]...)
+			out = append(out, tokensOf(")")...)
+			continue
+		}
+		out = append(out, t)
+	}
+	return out
+}
+
+// generatedColumnStart returns the position of the parenthesis that opens the
+// expression of a generated column clause starting at the given position.
+func generatedColumnStart(tokens []token, i int) (int, bool) {
+	if tokens[i].isWord("generated") {
+		if i = nextToken(tokens, i+1); i == len(tokens) || !tokens[i].isWord("always") {
+			return 0, false
+		}
+		if i = nextToken(tokens, i+1); i == len(tokens) {
+			return 0, false
+		}
+	}
+
+	if !tokens[i].isWord("as") {
+		return 0, false
+	}
+
+	open := nextToken(tokens, i+1)
+	if open == len(tokens) || !tokens[open].is("(") {
+		return 0, false
+	}
+	return open, true
+}
+
+// generatedColumnExpr returns the expression of a generated column, and
+// whether the column is virtual, if the given default value stands for one.
+func generatedColumnExpr(def sqlparser.Expr) (sqlparser.Expr, bool, bool) {
+	paren, ok := def.(*sqlparser.ParenExpr)
+	if !ok {
+		return nil, false, false
+	}
+
+	fn, ok := paren.Expr.(*sqlparser.FuncExpr)
+	if !ok || len(fn.Exprs) != 1 || !fn.Qualifier.IsEmpty() {
+		return nil, false, false
+	}
+
+	name := fn.Name.Lowered()
+	if name != generatedStoredFunc && name != generatedVirtualFunc {
+		return nil, false, false
+	}
+
+	expr, ok := fn.Exprs[0].(*sqlparser.AliasedExpr)
+	if !ok {
+		return nil, false, false
+	}
+	return expr.Expr, name == generatedVirtualFunc, true
+}
@@ -0,0 +1,444 @@
+package parse
+
+import (
+	"strings"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+)
+
+// ErrInvalidJSONTable is returned when a JSON_TABLE can't be parsed.
+var ErrInvalidJSONTable = errors.NewKind("invalid JSON_TABLE: %s")
+
+// jsonTablePrefix starts the text of every JSON_TABLE in a query.
+const jsonTablePrefix = "json_table"
+
+// The parser doesn't support JSON_TABLE, so before parsing a query every
+// JSON_TABLE(...) that is a table of a FROM clause is replaced with a quoted
+// table name that holds its text. Those tables are then converted to
+// JSONTable nodes, once the tables that precede them in the FROM clause are
+// known.
+
+// fromClauseEnds holds the keywords that end a FROM clause.
+var fromClauseEnds = map[string]bool{
+	"where":     true,
+	"group":     true,
+	"having":    true,
+	"window":    true,
+	"order":     true,
+	"limit":     true,
+	"union":     true,
+	"into":      true,
+	"for":       true,
+	"lock":      true,
+	"set":       true,
+	"partition": true,
+}
+
+// quoteJSONTables replaces every JSON_TABLE(...) that is a table of a FROM
+// clause in the given tokens with a quoted identifier that holds the same
+// text.
+func quoteJSONTables(tokens []token) []token {
+	var out []token
+	// inFrom holds whether there's a FROM clause at every depth of
+	// parentheses, up to the current one.
+	inFrom := []bool{false}
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		depth := len(inFrom) - 1
+		switch {
+		case t.is("("):
+			inFrom = append(inFrom, false)
+		case t.is(")"):
+			if depth > 0 {
+				inFrom = inFrom[:depth]
+			}
+		case t.isWord("from"):
+			inFrom[depth] = true
+		case t.kind == wordToken && fromClauseEnds[strings.ToLower(t.text)]:
+			inFrom[depth] = false
+		case t.isWord(jsonTablePrefix) && inFrom[depth] && startsTableFactor(tokens, i):
+			open := nextToken(tokens, i+1)
+			if open == len(tokens) || !tokens[open].is("(") {
+				break
+			}
+
+			end := closingParen(tokens, open)
+			if end < 0 {
+				break
+			}
+
+			text := joinTokens(tokens[i : end+1])
+			out = append(out, token{quotedToken, "`" + strings.Replace(text, "`", "``", -1) + "`"})
+			i = end
+			continue
+		}
+		out = append(out, t)
+	}
+	return out
+}
+
+// startsTableFactor returns whether the token at the given position of a FROM
+// clause starts a table, because it follows FROM, JOIN or a comma.
+func startsTableFactor(tokens []token, i int) bool {
+	prev := prevToken(tokens, i)
+	return prev >= 0 && (tokens[prev].isWord("from") || tokens[prev].isWord("join") || tokens[prev].is(","))
+}
+
+// isJSONTableName returns whether the given table name is the text of a
+// JSON_TABLE.
+func isJSONTableName(name string) bool {
+	tokens := tokensOf(name)
+	if len(tokens) == 0 || !tokens[0].isWord(jsonTablePrefix) {
+		return false
+	}
+	open := nextToken(tokens, 1)
+	return open < len(tokens) && tokens[open].is("(")
+}
+
+// isJSONTable returns whether the given table expression is a JSON_TABLE.
+func isJSONTable(te sqlparser.TableExpr) bool {
+	t, ok := te.(*sqlparser.AliasedTableExpr)
+	if !ok {
+		return false
+	}
+	name, ok := t.Expr.(sqlparser.TableName)
+	return ok && name.Qualifier.IsEmpty() && isJSONTableName(name.Name.String())
+}
+
+// jsonTableToTable converts a JSON_TABLE to a JSONTable node. The child holds
+// the tables that precede it, and is nil if there are none.
+func jsonTableToTable(ctx *sql.Context, te sqlparser.TableExpr, child sql.Node) (*plan.JSONTable, error) {
+	t := te.(*sqlparser.AliasedTableExpr)
+	if t.As.IsEmpty() {
+		return nil, ErrUnsupportedFeature.New("JSON_TABLE without alias")
+	}
+
+	p := &jsonTableParser{ctx: ctx, tokens: tokensOf(t.Expr.(sqlparser.TableName).Name.String())}
+	if err := p.expectKeyword(jsonTablePrefix); err != nil {
+		return nil, err
+	}
+	if err := p.expectChar('('); err != nil {
+		return nil, err
+	}
+
+	data, err := p.readExpr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectChar(','); err != nil {
+		return nil, err
+	}
+
+	path, err := p.readPath()
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := p.readColumns()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expectChar(')'); err != nil {
+		return nil, err
+	}
+	if p.skipSpaces(); p.pos < len(p.tokens) {
+		return nil, ErrInvalidJSONTable.New("unexpected " + joinTokens(p.tokens[p.pos:]))
+	}
+
+	return plan.NewJSONTable(child, t.As.String(), data, path, columns), nil
+}
+
+// jsonTableJoinToTable converts a join with a JSON_TABLE on its right side,
+// which can refer to the left side.
+func jsonTableJoinToTable(ctx *sql.Context, t *sqlparser.JoinTableExpr, left sql.Node) (sql.Node, error) {
+	join := strings.ToLower(t.Join)
+	switch join {
+	case sqlparser.JoinStr, sqlparser.StraightJoinStr, sqlparser.LeftJoinStr:
+	default:
+		return nil, ErrUnsupportedFeature.New("Join type " + t.Join + " with JSON_TABLE")
+	}
+
+	node, err := jsonTableToTable(ctx, t.RightExpr, left)
+	if err != nil {
+		return nil, err
+	}
+
+	var cond sql.Expression
+	if t.Condition.On != nil {
+		cond, err = exprToExpression(ctx, t.Condition.On)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case join == sqlparser.LeftJoinStr:
+		return node.AsLeftJoin(cond), nil
+	case cond != nil:
+		return plan.NewFilter(cond, node), nil
+	default:
+		return node, nil
+	}
+}
+
+type jsonTableParser struct {
+	ctx    *sql.Context
+	tokens []token
+	pos    int
+}
+
+func (p *jsonTableParser) skipSpaces() {
+	p.pos = nextToken(p.tokens, p.pos)
+}
+
+// keyword consumes the given keyword if it's next.
+func (p *jsonTableParser) keyword(kw string) bool {
+	p.skipSpaces()
+	if p.pos < len(p.tokens) && p.tokens[p.pos].isWord(kw) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *jsonTableParser) expectKeyword(kw string) error {
+	if !p.keyword(kw) {
+		return p.unexpected(strings.ToUpper(kw))
+	}
+	return nil
+}
+
+// char consumes the given character if it's next.
+func (p *jsonTableParser) char(r byte) bool {
+	p.skipSpaces()
+	if p.pos < len(p.tokens) && p.tokens[p.pos].is(string(r)) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *jsonTableParser) expectChar(r byte) error {
+	if !p.char(r) {
+		return p.unexpected(string(r))
+	}
+	return nil
+}
+
+func (p *jsonTableParser) unexpected(expected string) error {
+	if p.pos >= len(p.tokens) {
+		return ErrInvalidJSONTable.New("expecting " + expected + " at the end")
+	}
+	return ErrInvalidJSONTable.New("expecting " + expected + " near '" + joinTokens(p.tokens[p.pos:]) + "'")
+}
+
+// readUntil returns the text up to the first comma or closing parenthesis
+// that is not nested, or up to any of the given keywords.
+func (p *jsonTableParser) readUntil(keywords ...string) (string, error) {
+	p.skipSpaces()
+	start := p.pos
+	for ; p.pos < len(p.tokens); p.pos++ {
+		t := p.tokens[p.pos]
+		switch {
+		case t.is("("):
+			end := closingParen(p.tokens, p.pos)
+			if end < 0 {
+				return "", ErrInvalidJSONTable.New("missing closing parenthesis")
+			}
+			p.pos = end
+		case t.is(",") || t.is(")"):
+			return joinTokens(trimTokens(p.tokens[start:p.pos])), nil
+		case t.kind == wordToken:
+			for _, kw := range keywords {
+				if t.isWord(kw) {
+					return joinTokens(trimTokens(p.tokens[start:p.pos])), nil
+				}
+			}
+		}
+	}
+	return joinTokens(trimTokens(p.tokens[start:])), nil
+}
+
+func (p *jsonTableParser) readExpr() (sql.Expression, error) {
+	text, err := p.readUntil()
+	if err != nil {
+		return nil, err
+	}
+	if text == "" {
+		return nil, p.unexpected("an expression")
+	}
+	return parseExpr(p.ctx, text)
+}
+
+func (p *jsonTableParser) readString() (string, error) {
+	p.skipSpaces()
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != stringToken {
+		return "", p.unexpected("a string")
+	}
+
+	tokenizer := sqlparser.NewStringTokenizer(p.tokens[p.pos].text)
+	_, val := tokenizer.Scan()
+	p.pos++
+	return string(val), nil
+}
+
+func (p *jsonTableParser) readPath() (*sql.JSONPath, error) {
+	path, err := p.readString()
+	if err != nil {
+		return nil, err
+	}
+	return sql.ParseJSONPath(path)
+}
+
+func (p *jsonTableParser) readIdent() (string, error) {
+	p.skipSpaces()
+	if p.pos == len(p.tokens) {
+		return "", p.unexpected("a column name")
+	}
+
+	switch t := p.tokens[p.pos]; t.kind {
+	case quotedToken:
+		p.pos++
+		return strings.Replace(t.text[1:len(t.text)-1], "``", "`", -1), nil
+	case wordToken:
+		p.pos++
+		return t.text, nil
+	default:
+		return "", p.unexpected("a column name")
+	}
+}
+
+// readColumns reads a COLUMNS (...) clause.
+func (p *jsonTableParser) readColumns() ([]plan.JSONTableColumn, error) {
+	if err := p.expectKeyword("columns"); err != nil {
+		return nil, err
+	}
+	if err := p.expectChar('('); err != nil {
+		return nil, err
+	}
+
+	var columns []plan.JSONTableColumn
+	for {
+		col, err := p.readColumn()
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, col)
+
+		if !p.char(',') {
+			break
+		}
+	}
+
+	if err := p.expectChar(')'); err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+func (p *jsonTableParser) readColumn() (plan.JSONTableColumn, error) {
+	var col plan.JSONTableColumn
+	if p.keyword("nested") {
+		p.keyword("path")
+		path, err := p.readPath()
+		if err != nil {
+			return col, err
+		}
+
+		col.Path = path
+		col.Nested, err = p.readColumns()
+		return col, err
+	}
+
+	name, err := p.readIdent()
+	if err != nil {
+		return col, err
+	}
+	col.Name = name
+
+	if p.keyword("for") {
+		col.ForOrdinality = true
+		col.Type = sql.Uint32
+		return col, p.expectKeyword("ordinality")
+	}
+
+	col.Type, err = p.readType()
+	if err != nil {
+		return col, err
+	}
+
+	col.Exists = p.keyword("exists")
+	if err := p.expectKeyword("path"); err != nil {
+		return col, err
+	}
+
+	col.Path, err = p.readPath()
+	if err != nil || col.Exists {
+		return col, err
+	}
+
+	for {
+		var response plan.JSONTableOnResponse
+		switch {
+		case p.keyword("null"):
+		case p.keyword("error"):
+			response.Error = true
+		case p.keyword("default"):
+			text, err := p.readString()
+			if err != nil {
+				return col, err
+			}
+
+			doc, err := sql.JSON.Convert(text)
+			if err != nil {
+				// The default is used as is if it's not valid JSON.
+				doc = sql.JSONDocument{Val: text}
+			}
+			d := doc.(sql.JSONDocument)
+			response.Default = &d
+		default:
+			return col, nil
+		}
+
+		if err := p.expectKeyword("on"); err != nil {
+			return col, err
+		}
+
+		switch {
+		case p.keyword("empty"):
+			col.OnEmpty = response
+		case p.keyword("error"):
+			col.OnError = response
+		default:
+			return col, p.unexpected("EMPTY or ERROR")
+		}
+	}
+}
+
+// readType reads the type of a column, using the parser to convert it.
+func (p *jsonTableParser) readType() (sql.Type, error) {
+	text, err := p.readUntil("exists", "path")
+	if err != nil {
+		return nil, err
+	}
+	if text == "" {
+		return nil, p.unexpected("a column type")
+	}
+
+	stmt, err := sqlparser.Parse("CREATE TABLE t (c " + text + ")")
+	if err != nil {
+		return nil, ErrInvalidJSONTable.New("invalid column type " + text)
+	}
+
+	ddl, ok := stmt.(*sqlparser.DDL)
+	if !ok || ddl.TableSpec == nil || len(ddl.TableSpec.Columns) != 1 {
+		return nil, ErrInvalidJSONTable.New("invalid column type " + text)
+	}
+
+	return sql.ColumnTypeToType(&ddl.TableSpec.Columns[0].Type)
+}
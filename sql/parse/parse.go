@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/dolthub/vitess/go/vt/sqlparser"
@@ -49,6 +50,7 @@ var (
 var (
 	showVariablesRegex   = regexp.MustCompile(`^show\s+(.*)?variables\s*`)
 	showWarningsRegex    = regexp.MustCompile(`^show\s+warnings\s*`)
+	showStatusRegex      = regexp.MustCompile(`^show\s+((global|session)\s+)?status(\s|$)`)
 	fullProcessListRegex = regexp.MustCompile(`^show\s+(full\s+)?processlist$`)
 	unlockTablesRegex    = regexp.MustCompile(`^unlock\s+tables$`)
 	lockTablesRegex      = regexp.MustCompile(`^lock\s+tables\s`)
@@ -108,6 +110,7 @@ func Parse(ctx *sql.Context, query string) (sql.Node, error) {
 		return plan.Nothing, nil
 	}
 
+	s = rewriteQuery(s, sql.LoadSqlMode(ctx))
 	lowerQuery := strings.ToLower(s)
 
 	switch true {
@@ -115,6 +118,8 @@ func Parse(ctx *sql.Context, query string) (sql.Node, error) {
 		return parseShowVariables(ctx, s)
 	case showWarningsRegex.MatchString(lowerQuery):
 		return parseShowWarnings(ctx, s)
+	case showStatusRegex.MatchString(lowerQuery):
+		return parseShowStatus(ctx, s)
 	case fullProcessListRegex.MatchString(lowerQuery):
 		return plan.NewShowProcessList(), nil
 	case unlockTablesRegex.MatchString(lowerQuery):
@@ -789,12 +794,13 @@ func convertCreateTable(ctx *sql.Context, c *sqlparser.DDL) (sql.Node, error) {
 			continue
 		}
 
-		//TODO: add vitess support for FULLTEXT
 		constraint := sql.IndexConstraint_None
 		if idxDef.Info.Unique {
 			constraint = sql.IndexConstraint_Unique
 		} else if idxDef.Info.Spatial {
 			constraint = sql.IndexConstraint_Spatial
+		} else if isFullTextKey(idxDef) {
+			constraint = sql.IndexConstraint_Fulltext
 		}
 
 		columns := make([]sql.IndexColumn, len(idxDef.Columns))
@@ -1125,8 +1131,18 @@ func columnDefinitionToColumn(ctx *sql.Context, cd *sqlparser.ColumnDefinition,
 		comment = string(cd.Type.Comment.Val)
 	}
 
-	var defaultVal *sql.ColumnDefaultValue
-	if cd.Type.Default != nil {
+	var defaultVal, generated *sql.ColumnDefaultValue
+	genExpr, virtual, isGenerated := generatedColumnExpr(cd.Type.Default)
+	if isGenerated {
+		parsedExpr, err := exprToExpression(ctx, genExpr)
+		if err != nil {
+			return nil, err
+		}
+		generated, err = ExpressionToColumnDefaultValue(ctx, parsedExpr, false)
+		if err != nil {
+			return nil, err
+		}
+	} else if cd.Type.Default != nil {
 		parsedExpr, err := exprToExpression(ctx, cd.Type.Default)
 		if err != nil {
 			return nil, err
@@ -1149,6 +1165,8 @@ func columnDefinitionToColumn(ctx *sql.Context, cd *sqlparser.ColumnDefinition,
 		Default:       defaultVal,
 		AutoIncrement: bool(cd.Type.Autoincrement),
 		Comment:       comment,
+		Generated:     generated,
+		Virtual:       virtual,
 	}, nil
 }
 
@@ -1200,26 +1218,33 @@ func tableExprsToTable(
 		return nil, ErrUnsupportedFeature.New("zero tables in FROM")
 	}
 
-	var nodes []sql.Node
+	var node sql.Node
 	for _, t := range te {
+		// A JSON_TABLE can refer to the tables that precede it, so they
+		// become its child.
+		if isJSONTable(t) {
+			n, err := jsonTableToTable(ctx, t, node)
+			if err != nil {
+				return nil, err
+			}
+
+			node = n
+			continue
+		}
+
 		n, err := tableExprToTable(ctx, t)
 		if err != nil {
 			return nil, err
 		}
 
-		nodes = append(nodes, n)
-	}
-
-	if len(nodes) == 1 {
-		return nodes[0], nil
-	}
-
-	join := plan.NewCrossJoin(nodes[0], nodes[1])
-	for i := 2; i < len(nodes); i++ {
-		join = plan.NewCrossJoin(join, nodes[i])
+		if node == nil {
+			node = n
+		} else {
+			node = plan.NewCrossJoin(node, n)
+		}
 	}
 
-	return join, nil
+	return node, nil
 }
 
 func tableExprToTable(
@@ -1230,6 +1255,14 @@ func tableExprToTable(
 	default:
 		return nil, ErrUnsupportedSyntax.New(sqlparser.String(te))
 	case *sqlparser.AliasedTableExpr:
+		if isJSONTable(t) {
+			node, err := jsonTableToTable(ctx, t, nil)
+			if err != nil {
+				return nil, err
+			}
+			return node, nil
+		}
+
 		// TODO: Add support for qualifier.
 		switch e := t.Expr.(type) {
 		case sqlparser.TableName:
@@ -1275,6 +1308,10 @@ func tableExprToTable(
 			return nil, err
 		}
 
+		if isJSONTable(t.RightExpr) {
+			return jsonTableJoinToTable(ctx, t, left)
+		}
+
 		right, err := tableExprToTable(ctx, t.RightExpr)
 		if err != nil {
 			return nil, err
@@ -1340,6 +1377,48 @@ func orderByToSort(ctx *sql.Context, ob sqlparser.OrderBy, child sql.Node) (*pla
 	return plan.NewSort(sortFields, child), nil
 }
 
+func groupConcatToExpression(ctx *sql.Context, gc *sqlparser.GroupConcatExpr) (sql.Expression, error) {
+	exprs, err := selectExprsToExpressions(ctx, gc.Exprs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range exprs {
+		if _, ok := e.(*expression.Star); ok {
+			return nil, ErrUnsupportedSyntax.New("* in GROUP_CONCAT")
+		}
+	}
+
+	var sortFields []aggregation.SortField
+	for _, o := range gc.OrderBy {
+		e, err := exprToExpression(ctx, o.Expr)
+		if err != nil {
+			return nil, err
+		}
+
+		var desc bool
+		switch strings.ToLower(o.Direction) {
+		default:
+			return nil, ErrInvalidSortOrder.New(o.Direction)
+		case sqlparser.AscScr:
+		case sqlparser.DescScr:
+			desc = true
+		}
+
+		sortFields = append(sortFields, aggregation.SortField{Column: e, Descending: desc})
+	}
+
+	// The parser keeps the separator clause as it was written:
+	// " separator 'str'", with the string already unescaped.
+	separator := aggregation.DefaultGroupConcatSeparator
+	if gc.Separator != "" {
+		separator = strings.TrimPrefix(gc.Separator, " separator '")
+		separator = strings.TrimSuffix(separator, "'")
+	}
+
+	return aggregation.NewGroupConcat(gc.Distinct != "", exprs, sortFields, separator), nil
+}
+
 func limitToLimit(
 	ctx *sql.Context,
 	limit sqlparser.Expr,
@@ -1427,7 +1506,7 @@ func isAggregate(e sql.Expression) bool {
 		switch e := e.(type) {
 		case *expression.UnresolvedFunction:
 			isAgg = isAgg || e.IsAggregate
-		case *aggregation.CountDistinct:
+		case *aggregation.CountDistinct, *aggregation.GroupConcat:
 			isAgg = true
 		}
 
@@ -1547,6 +1626,81 @@ func MustStringToColumnDefaultValue(ctx *sql.Context, exprStr string, outType sq
 	return expr
 }
 
+// castFunctionToExpression returns the cast a query was rewritten to by
+// rewriteCasts, which is `convert`(expr, 'type') or `convert`(expr, 'float', p).
+// REAL is cast to FLOAT with REAL_AS_FLOAT.
+func castFunctionToExpression(ctx *sql.Context, exprs []sql.Expression) (sql.Expression, error) {
+	if len(exprs) != 2 && len(exprs) != 3 {
+		return nil, sql.ErrInvalidArgumentNumber.New("CAST", "2 or 3", len(exprs))
+	}
+
+	castType, ok := exprs[1].(*expression.Literal)
+	if !ok {
+		return nil, ErrUnsupportedSyntax.New(exprs[1])
+	}
+	typ, ok := castType.Value().(string)
+	if !ok {
+		return nil, ErrUnsupportedSyntax.New(exprs[1])
+	}
+	if typ == expression.ConvertToReal && sql.LoadSqlMode(ctx).Has("REAL_AS_FLOAT") {
+		typ = expression.ConvertToFloat
+	}
+
+	var precision int64
+	if len(exprs) == 3 {
+		l, ok := exprs[2].(*expression.Literal)
+		if !ok {
+			return nil, ErrUnsupportedSyntax.New(exprs[2])
+		}
+		p, err := sql.Int64.Convert(l.Value())
+		if err != nil {
+			return nil, err
+		}
+		precision = p.(int64)
+		if precision > expression.ConvertMaxFloatPrecision {
+			return nil, expression.ErrConvertPrecisionTooBig.New(precision, "CAST", expression.ConvertMaxFloatPrecision)
+		}
+	}
+
+	return expression.NewConvertWithLengthAndScale(exprs[0], typ, int(precision), 0), nil
+}
+
+// convertTypeLengthAndScale returns the length and scale of the type of a
+// CAST or CONVERT, which are 0 when not given. A DECIMAL precision and scale
+// must be valid for the type.
+func convertTypeLengthAndScale(ct *sqlparser.ConvertType) (int, int, error) {
+	var length, scale int64
+	var err error
+	if ct.Length != nil {
+		length, err = strconv.ParseInt(string(ct.Length.Val), 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	if ct.Scale != nil {
+		scale, err = strconv.ParseInt(string(ct.Scale.Val), 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	switch strings.ToLower(ct.Type) {
+	case expression.ConvertToDecimal:
+		if length > sql.DecimalTypeMaxPrecision || scale > sql.DecimalTypeMaxScale {
+			return 0, 0, sql.ErrInvalidDecimalPrecisionScale.New(length, scale)
+		}
+		if length > 0 && scale > length {
+			return 0, 0, sql.ErrInvalidDecimalPrecisionScale.New(length, scale)
+		}
+	case expression.ConvertToDatetime, expression.ConvertToTime:
+		if length > expression.ConvertMaxFractionalSeconds {
+			return 0, 0, expression.ErrConvertPrecisionTooBig.New(length, "CAST", expression.ConvertMaxFractionalSeconds)
+		}
+	}
+
+	return int(length), int(scale), nil
+}
+
 func exprToExpression(ctx *sql.Context, e sqlparser.Expr) (sql.Expression, error) {
 	switch v := e.(type) {
 	default:
@@ -1611,19 +1765,37 @@ func exprToExpression(ctx *sql.Context, e sqlparser.Expr) (sql.Expression, error
 		}
 
 		if v.Distinct {
-			if v.Name.Lowered() != "count" {
-				return nil, ErrUnsupportedSyntax.New("DISTINCT on non-COUNT aggregations")
+			if v.Name.Lowered() == "count" {
+				if len(exprs) != 1 {
+					return nil, ErrUnsupportedSyntax.New("more than one expression in COUNT")
+				}
+
+				return aggregation.NewCountDistinct(exprs[0]), nil
 			}
 
-			if len(exprs) != 1 {
-				return nil, ErrUnsupportedSyntax.New("more than one expression in COUNT")
+			if !isAggregateFunc(v) {
+				return nil, ErrUnsupportedSyntax.New("DISTINCT on non-aggregate functions")
 			}
 
-			return aggregation.NewCountDistinct(exprs[0]), nil
+			uf := expression.NewUnresolvedFunction(v.Name.Lowered(), true, exprs...)
+			uf.Distinct = true
+			return uf, nil
+		}
+
+		if v.Name.Lowered() == "get_format" {
+			exprs = getFormatTypeToLiteral(v, exprs)
+		}
+
+		if v.Name.Lowered() == castFunctionName {
+			return castFunctionToExpression(ctx, exprs)
 		}
 
 		return expression.NewUnresolvedFunction(v.Name.Lowered(),
 			isAggregateFunc(v), exprs...), nil
+	case *sqlparser.TimestampFuncExpr:
+		return timestampFuncExprToExpression(ctx, v)
+	case *sqlparser.GroupConcatExpr:
+		return groupConcatToExpression(ctx, v)
 	case *sqlparser.ParenExpr:
 		return exprToExpression(ctx, v.Expr)
 	case *sqlparser.AndExpr:
@@ -1656,7 +1828,32 @@ func exprToExpression(ctx *sql.Context, e sqlparser.Expr) (sql.Expression, error
 			return nil, err
 		}
 
-		return expression.NewConvert(expr, v.Type.Type), nil
+		typeLength, typeScale, err := convertTypeLengthAndScale(v.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		if v.Type.Charset != "" {
+			charset, err := parseSupportedCharacterSet(v.Type.Charset)
+			if err != nil {
+				return nil, err
+			}
+			return expression.NewConvertWithCharset(expr, v.Type.Type, typeLength, charset), nil
+		}
+
+		return expression.NewConvertWithLengthAndScale(expr, v.Type.Type, typeLength, typeScale), nil
+	case *sqlparser.ConvertUsingExpr:
+		expr, err := exprToExpression(ctx, v.Expr)
+		if err != nil {
+			return nil, err
+		}
+
+		charset, err := parseSupportedCharacterSet(v.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		return expression.NewConvertUsing(expr, charset), nil
 	case *sqlparser.RangeCond:
 		val, err := exprToExpression(ctx, v.Left)
 		if err != nil {
@@ -1712,12 +1909,61 @@ func exprToExpression(ctx *sql.Context, e sqlparser.Expr) (sql.Expression, error
 	case *sqlparser.CollateExpr:
 		// TODO: handle collation
 		return exprToExpression(ctx, v.Expr)
+	case *sqlparser.MatchExpr:
+		return matchExprToExpression(ctx, v)
 	}
 }
 
+// parseSupportedCharacterSet returns the character set with the given name,
+// whose default collation, used by the strings converted to it, must be
+// supported.
+func parseSupportedCharacterSet(name string) (sql.CharacterSet, error) {
+	charset, err := sql.ParseCharacterSet(strings.ToLower(name))
+	if err != nil {
+		return charset, err
+	}
+	if collation := charset.DefaultCollation(); !collation.IsSupported() {
+		return charset, sql.ErrCollationUnsupported.New(collation)
+	}
+	return charset, nil
+}
+
+func matchExprToExpression(ctx *sql.Context, m *sqlparser.MatchExpr) (sql.Expression, error) {
+	var mode sql.FullTextSearchMode
+	switch m.Option {
+	case "", sqlparser.NaturalLanguageModeStr:
+		mode = sql.FullTextSearchMode_NaturalLanguage
+	case sqlparser.BooleanModeStr:
+		mode = sql.FullTextSearchMode_Boolean
+	default:
+		return nil, ErrUnsupportedFeature.New("MATCH with query expansion")
+	}
+
+	columns := make([]sql.Expression, len(m.Columns))
+	for i, c := range m.Columns {
+		aliased, ok := c.(*sqlparser.AliasedExpr)
+		if !ok {
+			return nil, ErrUnsupportedSyntax.New(sqlparser.String(m))
+		}
+
+		col, err := exprToExpression(ctx, aliased.Expr)
+		if err != nil {
+			return nil, err
+		}
+		columns[i] = col
+	}
+
+	against, err := exprToExpression(ctx, m.Expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return expression.NewMatch(columns, against, mode), nil
+}
+
 func isAggregateFunc(v *sqlparser.FuncExpr) bool {
 	switch v.Name.Lowered() {
-	case "first", "last":
+	case "first", "last", "any_value", "json_arrayagg", "json_objectagg":
 		return true
 	}
 
@@ -1758,6 +2004,37 @@ func convertInt(value string, base int) (sql.Expression, error) {
 	return expression.NewLiteral(uint64(ui64), sql.Uint64), nil
 }
 
+// convertDecimal returns the given number without an exponent as an exact
+// DECIMAL literal, with as many digits as it's written with. It returns false
+// for a number with an exponent, or with too many digits for a DECIMAL, which
+// is a DOUBLE literal instead.
+func convertDecimal(value string) (sql.Expression, bool) {
+	if strings.ContainsAny(value, "eE") {
+		return nil, false
+	}
+
+	intPart, fracPart := value, ""
+	if point := strings.IndexByte(value, '.'); point >= 0 {
+		intPart, fracPart = value[:point], value[point+1:]
+	}
+	intPart = strings.TrimLeft(intPart, "0")
+	if intPart == "" {
+		intPart = "0"
+	}
+
+	precision, scale := len(intPart)+len(fracPart), len(fracPart)
+	if precision > sql.DecimalTypeMaxPrecision || scale > sql.DecimalTypeMaxScale {
+		return nil, false
+	}
+
+	typ := sql.MustCreateDecimalType(uint8(precision), uint8(scale))
+	val, err := typ.Convert(value)
+	if err != nil {
+		return nil, false
+	}
+	return expression.NewLiteral(val, typ), true
+}
+
 func convertVal(v *sqlparser.SQLVal) (sql.Expression, error) {
 	switch v.Type {
 	case sqlparser.StrVal:
@@ -1765,6 +2042,9 @@ func convertVal(v *sqlparser.SQLVal) (sql.Expression, error) {
 	case sqlparser.IntVal:
 		return convertInt(string(v.Val), 10)
 	case sqlparser.FloatVal:
+		if lit, ok := convertDecimal(string(v.Val)); ok {
+			return lit, nil
+		}
 		val, err := strconv.ParseFloat(string(v.Val), 64)
 		if err != nil {
 			return nil, err
@@ -1866,10 +2146,20 @@ func comparisonExprToExpression(ctx *sql.Context, c *sqlparser.ComparisonExpr) (
 		default:
 			return nil, ErrUnsupportedFeature.New(fmt.Sprintf("NOT IN %T", right))
 		}
-	case sqlparser.LikeStr:
-		return expression.NewLike(left, right), nil
-	case sqlparser.NotLikeStr:
-		return expression.NewNot(expression.NewLike(left, right)), nil
+	case sqlparser.LikeStr, sqlparser.NotLikeStr:
+		var escape sql.Expression
+		if c.Escape != nil {
+			escape, err = exprToExpression(ctx, c.Escape)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		like := expression.NewLikeWithEscape(left, right, escape)
+		if strings.ToLower(c.Operator) == sqlparser.NotLikeStr {
+			return expression.NewNot(like), nil
+		}
+		return like, nil
 	default:
 		return nil, ErrUnsupportedFeature.New(c.Operator)
 	}
@@ -1921,6 +2211,13 @@ func unaryExprToExpression(ctx *sql.Context, e *sqlparser.UnaryExpr) (sql.Expres
 		}
 
 		return expression.NewUnaryMinus(expr), nil
+	case sqlparser.TildaStr:
+		expr, err := exprToExpression(ctx, e.Expr)
+		if err != nil {
+			return nil, err
+		}
+
+		return expression.NewBitNot(expr), nil
 	case sqlparser.PlusStr:
 		// Unary plus expressions do nothing (do not turn the expression positive). Just return the underlying expression.
 		return exprToExpression(ctx, e.Expr)
@@ -1967,6 +2264,30 @@ func binaryExprToExpression(ctx *sql.Context, be *sqlparser.BinaryExpr) (sql.Exp
 
 		return expression.NewArithmetic(l, r, be.Operator), nil
 
+	case
+		sqlparser.JSONExtractOp,
+		sqlparser.JSONUnquoteExtractOp:
+
+		l, err := exprToExpression(ctx, be.Left)
+		if err != nil {
+			return nil, err
+		}
+
+		r, err := exprToExpression(ctx, be.Right)
+		if err != nil {
+			return nil, err
+		}
+
+		extract, err := function.NewJSONExtract(l, r)
+		if err != nil {
+			return nil, err
+		}
+
+		if be.Operator == sqlparser.JSONUnquoteExtractOp {
+			return function.NewJSONUnquote(extract), nil
+		}
+		return extract, nil
+
 	default:
 		return nil, ErrUnsupportedFeature.New(be.Operator)
 	}
@@ -2023,6 +2344,37 @@ func intervalExprToExpression(ctx *sql.Context, e *sqlparser.IntervalExpr) (sql.
 	return expression.NewInterval(expr, e.Unit), nil
 }
 
+// getFormatTypeToLiteral returns the arguments of GET_FORMAT with its first
+// one, a type such as DATE that is parsed as a column name, as a string.
+func getFormatTypeToLiteral(v *sqlparser.FuncExpr, exprs []sql.Expression) []sql.Expression {
+	if len(v.Exprs) == 0 {
+		return exprs
+	}
+	if e, ok := v.Exprs[0].(*sqlparser.AliasedExpr); ok {
+		if col, ok := e.Expr.(*sqlparser.ColName); ok && col.Qualifier.IsEmpty() {
+			exprs[0] = expression.NewLiteral(strings.ToUpper(col.Name.String()), sql.LongText)
+		}
+	}
+	return exprs
+}
+
+// timestampFuncExprToExpression returns the TIMESTAMPADD or TIMESTAMPDIFF
+// function with the unit as its first argument.
+func timestampFuncExprToExpression(ctx *sql.Context, e *sqlparser.TimestampFuncExpr) (sql.Expression, error) {
+	expr1, err := exprToExpression(ctx, e.Expr1)
+	if err != nil {
+		return nil, err
+	}
+
+	expr2, err := exprToExpression(ctx, e.Expr2)
+	if err != nil {
+		return nil, err
+	}
+
+	unit := expression.NewLiteral(strings.ToUpper(e.Unit), sql.LongText)
+	return expression.NewUnresolvedFunction(strings.ToLower(e.Name), false, unit, expr1, expr2), nil
+}
+
 func setExprsToExpressions(ctx *sql.Context, e sqlparser.SetExprs) ([]sql.Expression, error) {
 	res := make([]sql.Expression, len(e))
 	for i, updateExpr := range e {
@@ -2204,6 +2556,24 @@ func parseShowTableStatus(ctx *sql.Context, query string) (sql.Node, error) {
 	}
 }
 
+var maxExecutionTimeHintRegex = regexp.MustCompile(`(?is)^\s*select\s*/\*\+(?:[^*]|\*[^/])*?\bmax_execution_time\s*\(\s*(\d+)\s*\)`)
+
+// MaxExecutionTimeHint returns the timeout set by a MAX_EXECUTION_TIME
+// optimizer hint in the top-level SELECT of the given query, if there is one.
+func MaxExecutionTimeHint(query string) (time.Duration, bool) {
+	m := maxExecutionTimeHintRegex.FindStringSubmatch(query)
+	if m == nil {
+		return 0, false
+	}
+
+	ms, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return time.Duration(ms) * time.Millisecond, true
+}
+
 var fixSessionRegex = regexp.MustCompile(`(,\s*|(set|SET)\s+)(SESSION|session)\s+([a-zA-Z0-9_]+)\s*=`)
 var fixGlobalRegex = regexp.MustCompile(`(,\s*|(set|SET)\s+)(GLOBAL|global)\s+([a-zA-Z0-9_]+)\s*=`)
 
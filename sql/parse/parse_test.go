@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/dolthub/vitess/go/sqltypes"
 	"github.com/dolthub/vitess/go/vt/sqlparser"
@@ -89,6 +90,29 @@ var fixtures = map[string]sql.Node{
 		nil,
 		nil,
 	),
+	"CREATE TABLE t1(a INTEGER, b INTEGER AS (a + 1) STORED, `c` TEXT GENERATED ALWAYS AS (concat('as (', b)) NOT NULL)": plan.NewCreateTable(
+		sql.UnresolvedDatabase(""),
+		"t1",
+		sql.Schema{{
+			Name:     "a",
+			Type:     sql.Int32,
+			Nullable: true,
+		}, {
+			Name:      "b",
+			Type:      sql.Int32,
+			Nullable:  true,
+			Generated: MustStringToColumnDefaultValue(sql.NewEmptyContext(), "(a + 1)", nil, true),
+		}, {
+			Name:      "c",
+			Type:      sql.Text,
+			Nullable:  false,
+			Generated: MustStringToColumnDefaultValue(sql.NewEmptyContext(), "(concat('as (', b))", nil, true),
+			Virtual:   true,
+		}},
+		false,
+		nil,
+		nil,
+	),
 	`CREATE TABLE t1(a INTEGER NOT NULL PRIMARY KEY COMMENT "hello", b TEXT COMMENT "goodbye")`: plan.NewCreateTable(
 		sql.UnresolvedDatabase(""),
 		"t1",
@@ -259,6 +283,41 @@ var fixtures = map[string]sql.Node{
 		}},
 		nil,
 	),
+	"CREATE TABLE t1(a INTEGER PRIMARY KEY, b TEXT, c TEXT, FULLTEXT KEY `ft` (`b`,`c`) COMMENT 'x', FULLTEXT (c))": plan.NewCreateTable(
+		sql.UnresolvedDatabase(""),
+		"t1",
+		sql.Schema{{
+			Name:       "a",
+			Type:       sql.Int32,
+			Nullable:   false,
+			PrimaryKey: true,
+		}, {
+			Name:       "b",
+			Type:       sql.Text,
+			Nullable:   true,
+			PrimaryKey: false,
+		}, {
+			Name:       "c",
+			Type:       sql.Text,
+			Nullable:   true,
+			PrimaryKey: false,
+		}},
+		false,
+		[]*plan.IndexDefinition{{
+			IndexName:  "ft",
+			Using:      sql.IndexUsing_Default,
+			Constraint: sql.IndexConstraint_Fulltext,
+			Columns:    []sql.IndexColumn{{Name: "b"}, {Name: "c"}},
+			Comment:    "x",
+		}, {
+			IndexName:  "",
+			Using:      sql.IndexUsing_Default,
+			Constraint: sql.IndexConstraint_Fulltext,
+			Columns:    []sql.IndexColumn{{Name: "c"}},
+			Comment:    "",
+		}},
+		nil,
+	),
 	`CREATE TABLE t1(a INTEGER PRIMARY KEY, b INTEGER, UNIQUE (b))`: plan.NewCreateTable(
 		sql.UnresolvedDatabase(""),
 		"t1",
@@ -580,6 +639,16 @@ var fixtures = map[string]sql.Node{
 			Default:  MustStringToColumnDefaultValue(sql.NewEmptyContext(), "(2+2)/2", nil, true),
 		}, &sql.ColumnOrder{AfterColumn: "baz"},
 	),
+	`ALTER TABLE foo ADD COLUMN bar INT GENERATED ALWAYS AS (baz * 2) VIRTUAL COMMENT 'hello' AFTER baz`: plan.NewAddColumn(
+		sql.UnresolvedDatabase(""), "foo", &sql.Column{
+			Name:      "bar",
+			Type:      sql.Int32,
+			Nullable:  true,
+			Comment:   "hello",
+			Generated: MustStringToColumnDefaultValue(sql.NewEmptyContext(), "(baz * 2)", nil, true),
+			Virtual:   true,
+		}, &sql.ColumnOrder{AfterColumn: "baz"},
+	),
 	`ALTER TABLE foo ADD COLUMN bar VARCHAR(10) NULL DEFAULT 'string' COMMENT 'hello'`: plan.NewAddColumn(
 		sql.UnresolvedDatabase(""), "foo", &sql.Column{
 			Name:     "bar",
@@ -813,6 +882,133 @@ var fixtures = map[string]sql.Node{
 			plan.NewUnresolvedTableAsOf("foo", "",
 				expression.NewLiteral("2019-01-01", sql.LongText))),
 	),
+	`SELECT a, jt.b FROM t, JSON_TABLE(t.js, '$[*]' COLUMNS (id FOR ORDINALITY, b INT PATH '$.b' DEFAULT '0' ON EMPTY ERROR ON ERROR, NESTED '$.c[*]' COLUMNS (c JSON PATH '$'))) AS jt`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewUnresolvedColumn("a"),
+			expression.NewUnresolvedQualifiedColumn("jt", "b"),
+		},
+		plan.NewJSONTable(
+			plan.NewUnresolvedTable("t", ""),
+			"jt",
+			expression.NewUnresolvedQualifiedColumn("t", "js"),
+			mustParseJSONPath("$[*]"),
+			[]plan.JSONTableColumn{
+				{Name: "id", Type: sql.Uint32, ForOrdinality: true},
+				{
+					Name:    "b",
+					Type:    sql.Int32,
+					Path:    mustParseJSONPath("$.b"),
+					OnEmpty: plan.JSONTableOnResponse{Default: &sql.JSONDocument{Val: int64(0)}},
+					OnError: plan.JSONTableOnResponse{Error: true},
+				},
+				{
+					Path: mustParseJSONPath("$.c[*]"),
+					Nested: []plan.JSONTableColumn{
+						{Name: "c", Type: sql.JSON, Path: mustParseJSONPath("$")},
+					},
+				},
+			},
+		),
+	),
+	`SELECT * FROM json_table('[1]', "$[*]" COLUMNS (x VARCHAR(10) EXISTS PATH '$')) jt JOIN t ON jt.x = t.x`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewStar(),
+		},
+		plan.NewInnerJoin(
+			plan.NewJSONTable(
+				nil,
+				"jt",
+				expression.NewLiteral("[1]", sql.LongText),
+				mustParseJSONPath("$[*]"),
+				[]plan.JSONTableColumn{
+					{Name: "x", Type: sql.MustCreateStringWithDefaults(sqltypes.VarChar, 10), Path: mustParseJSONPath("$"), Exists: true},
+				},
+			),
+			plan.NewUnresolvedTable("t", ""),
+			expression.NewEquals(
+				expression.NewUnresolvedQualifiedColumn("jt", "x"),
+				expression.NewUnresolvedQualifiedColumn("t", "x"),
+			),
+		),
+	),
+	`SELECT * FROM t LEFT JOIN JSON_TABLE(t.js, '$[*]' COLUMNS (x INT PATH '$')) jt ON jt.x > t.y`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewStar(),
+		},
+		plan.NewJSONTable(
+			plan.NewUnresolvedTable("t", ""),
+			"jt",
+			expression.NewUnresolvedQualifiedColumn("t", "js"),
+			mustParseJSONPath("$[*]"),
+			[]plan.JSONTableColumn{
+				{Name: "x", Type: sql.Int32, Path: mustParseJSONPath("$")},
+			},
+		).AsLeftJoin(expression.NewGreaterThan(
+			expression.NewUnresolvedQualifiedColumn("jt", "x"),
+			expression.NewUnresolvedQualifiedColumn("t", "y"),
+		)),
+	),
+	"CREATE TABLE json_table (a int)": plan.NewCreateTable(
+		sql.UnresolvedDatabase(""),
+		"json_table",
+		sql.Schema{{
+			Name:     "a",
+			Type:     sql.Int32,
+			Nullable: true,
+		}},
+		false,
+		nil,
+		nil,
+	),
+	"INSERT INTO json_table (a) VALUES (1)": plan.NewInsertInto(
+		plan.NewUnresolvedTable("json_table", ""),
+		plan.NewValues([][]sql.Expression{{
+			expression.NewLiteral(int8(1), sql.Int8),
+		}}),
+		false,
+		[]string{"a"},
+		[]sql.Expression{},
+	),
+	"SELECT a FROM json_table WHERE a = 1": plan.NewProject(
+		[]sql.Expression{
+			expression.NewUnresolvedColumn("a"),
+		},
+		plan.NewFilter(
+			expression.NewEquals(
+				expression.NewUnresolvedColumn("a"),
+				expression.NewLiteral(int8(1), sql.Int8),
+			),
+			plan.NewUnresolvedTable("json_table", ""),
+		),
+	),
+	`SELECT id FROM t WHERE MATCH (a, b) AGAINST ('+foo -bar' IN BOOLEAN MODE)`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewUnresolvedColumn("id"),
+		},
+		plan.NewFilter(
+			expression.NewMatch(
+				[]sql.Expression{
+					expression.NewUnresolvedColumn("a"),
+					expression.NewUnresolvedColumn("b"),
+				},
+				expression.NewLiteral("+foo -bar", sql.LongText),
+				sql.FullTextSearchMode_Boolean,
+			),
+			plan.NewUnresolvedTable("t", ""),
+		),
+	),
+	`SELECT MATCH (t.a) AGAINST ('foo' IN NATURAL LANGUAGE MODE) FROM t`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewMatch(
+				[]sql.Expression{
+					expression.NewUnresolvedQualifiedColumn("t", "a"),
+				},
+				expression.NewLiteral("foo", sql.LongText),
+				sql.FullTextSearchMode_NaturalLanguage,
+			),
+		},
+		plan.NewUnresolvedTable("t", ""),
+	),
 	`SELECT foo, bar FROM foo WHERE foo = bar;`: plan.NewProject(
 		[]sql.Expression{
 			expression.NewUnresolvedColumn("foo"),
@@ -1297,6 +1493,43 @@ var fixtures = map[string]sql.Node{
 		},
 		plan.NewUnresolvedTable("foo", ""),
 	),
+	`SELECT CONVERT(a USING latin1), CONVERT(b USING 'binary') FROM foo`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewConvertUsing(expression.NewUnresolvedColumn("a"), sql.CharacterSet_latin1),
+			expression.NewConvertUsing(expression.NewUnresolvedColumn("b"), sql.CharacterSet_binary),
+		},
+		plan.NewUnresolvedTable("foo", ""),
+	),
+	`SELECT CAST(a AS DECIMAL(5, 2)) FROM foo`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewConvertWithLengthAndScale(expression.NewUnresolvedColumn("a"), expression.ConvertToDecimal, 5, 2),
+		},
+		plan.NewUnresolvedTable("foo", ""),
+	),
+	`SELECT TIMESTAMPDIFF(day, a, b), TIMESTAMPADD(SQL_TSI_MINUTE, 1, a) FROM foo`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewUnresolvedFunction("timestampdiff", false,
+				expression.NewLiteral("DAY", sql.LongText),
+				expression.NewUnresolvedColumn("a"),
+				expression.NewUnresolvedColumn("b"),
+			),
+			expression.NewUnresolvedFunction("timestampadd", false,
+				expression.NewLiteral("SQL_TSI_MINUTE", sql.LongText),
+				expression.NewLiteral(int8(1), sql.Int8),
+				expression.NewUnresolvedColumn("a"),
+			),
+		},
+		plan.NewUnresolvedTable("foo", ""),
+	),
+	`SELECT GET_FORMAT(DATE, 'USA')`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewUnresolvedFunction("get_format", false,
+				expression.NewLiteral("DATE", sql.LongText),
+				expression.NewLiteral("USA", sql.LongText),
+			),
+		},
+		plan.NewUnresolvedTable("dual", ""),
+	),
 	`SELECT 2 = 2 FROM foo`: plan.NewProject(
 		[]sql.Expression{
 			expression.NewEquals(expression.NewLiteral(int8(2), sql.Int8), expression.NewLiteral(int8(2), sql.Int8)),
@@ -1485,12 +1718,20 @@ var fixtures = map[string]sql.Node{
 	`SELECT 1.0 * a + 2.0 * b FROM t;`: plan.NewProject(
 		[]sql.Expression{
 			expression.NewPlus(
-				expression.NewMult(expression.NewLiteral(float64(1.0), sql.Float64), expression.NewUnresolvedColumn("a")),
-				expression.NewMult(expression.NewLiteral(float64(2.0), sql.Float64), expression.NewUnresolvedColumn("b")),
+				expression.NewMult(expression.NewLiteral("1.0", sql.MustCreateDecimalType(2, 1)), expression.NewUnresolvedColumn("a")),
+				expression.NewMult(expression.NewLiteral("2.0", sql.MustCreateDecimalType(2, 1)), expression.NewUnresolvedColumn("b")),
 			),
 		},
 		plan.NewUnresolvedTable("t", ""),
 	),
+	`SELECT 0.50, 012.340, 1.5e2 FROM t;`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewLiteral("0.50", sql.MustCreateDecimalType(3, 2)),
+			expression.NewLiteral("12.340", sql.MustCreateDecimalType(5, 3)),
+			expression.NewLiteral(float64(150), sql.Float64),
+		},
+		plan.NewUnresolvedTable("t", ""),
+	),
 	`SELECT '1.0' + 2;`: plan.NewProject(
 		[]sql.Expression{
 			expression.NewPlus(
@@ -1695,6 +1936,9 @@ var fixtures = map[string]sql.Node{
 	`SHOW SESSION VARIABLES`:                   plan.NewShowVariables(sql.NewEmptyContext().GetAll(), ""),
 	`SHOW VARIABLES LIKE 'gtid_mode'`:          plan.NewShowVariables(sql.NewEmptyContext().GetAll(), "gtid_mode"),
 	`SHOW SESSION VARIABLES LIKE 'autocommit'`: plan.NewShowVariables(sql.NewEmptyContext().GetAll(), "autocommit"),
+	`SHOW STATUS`:                              plan.NewShowStatus(false, ""),
+	`SHOW GLOBAL STATUS`:                       plan.NewShowStatus(true, ""),
+	`SHOW SESSION STATUS LIKE 'com_%'`:         plan.NewShowStatus(false, "com_%"),
 	`UNLOCK TABLES`:                            plan.NewUnlockTables(),
 	`LOCK TABLES foo READ`: plan.NewLockTables([]*plan.TableLock{
 		{Table: plan.NewUnresolvedTable("foo", "")},
@@ -2000,6 +2244,33 @@ var fixtures = map[string]sql.Node{
 		[]sql.Expression{},
 		plan.NewUnresolvedTable("foo", ""),
 	),
+	`SELECT AVG(DISTINCT i) FROM foo`: plan.NewGroupBy(
+		[]sql.Expression{
+			func() sql.Expression {
+				f := expression.NewUnresolvedFunction("avg", true, expression.NewUnresolvedColumn("i"))
+				f.Distinct = true
+				return f
+			}(),
+		},
+		[]sql.Expression{},
+		plan.NewUnresolvedTable("foo", ""),
+	),
+	`SELECT GROUP_CONCAT(i), GROUP_CONCAT(DISTINCT i, s ORDER BY s DESC, i SEPARATOR '\'') FROM foo`: plan.NewGroupBy(
+		[]sql.Expression{
+			aggregation.NewGroupConcat(false, []sql.Expression{expression.NewUnresolvedColumn("i")}, nil, ","),
+			aggregation.NewGroupConcat(
+				true,
+				[]sql.Expression{expression.NewUnresolvedColumn("i"), expression.NewUnresolvedColumn("s")},
+				[]aggregation.SortField{
+					{Column: expression.NewUnresolvedColumn("s"), Descending: true},
+					{Column: expression.NewUnresolvedColumn("i")},
+				},
+				"'",
+			),
+		},
+		[]sql.Expression{},
+		plan.NewUnresolvedTable("foo", ""),
+	),
 	`SELECT -128, 127, 255, -32768, 32767, 65535, -2147483648, 2147483647, 4294967295, -9223372036854775808, 9223372036854775807, 18446744073709551615`: plan.NewProject(
 		[]sql.Expression{
 			expression.NewLiteral(int8(math.MinInt8), sql.Int8),
@@ -2253,21 +2524,38 @@ func assertNodesEqualWithDiff(t *testing.T, expected, actual sql.Node) {
 }
 
 var fixturesErrors = map[string]*errors.Kind{
-	`SHOW METHEMONEY`:                                         ErrUnsupportedFeature,
-	`LOCK TABLES foo AS READ`:                                 errUnexpectedSyntax,
-	`LOCK TABLES foo LOW_PRIORITY READ`:                       errUnexpectedSyntax,
-	`SELECT * FROM mytable LIMIT -100`:                        ErrUnsupportedSyntax,
-	`SELECT * FROM mytable LIMIT 100 OFFSET -1`:               ErrUnsupportedSyntax,
-	`SELECT INTERVAL 1 DAY - '2018-05-01'`:                    ErrUnsupportedSyntax,
-	`SELECT INTERVAL 1 DAY * '2018-05-01'`:                    ErrUnsupportedSyntax,
-	`SELECT '2018-05-01' * INTERVAL 1 DAY`:                    ErrUnsupportedSyntax,
-	`SELECT '2018-05-01' / INTERVAL 1 DAY`:                    ErrUnsupportedSyntax,
-	`SELECT INTERVAL 1 DAY + INTERVAL 1 DAY`:                  ErrUnsupportedSyntax,
-	`SELECT '2018-05-01' + (INTERVAL 1 DAY + INTERVAL 1 DAY)`: ErrUnsupportedSyntax,
-	`SELECT AVG(DISTINCT foo) FROM b`:                         ErrUnsupportedSyntax,
-	`CREATE VIEW myview AS SELECT AVG(DISTINCT foo) FROM b`:   ErrUnsupportedSyntax,
-	"DESCRIBE FORMAT=pretty SELECT * FROM foo":                errInvalidDescribeFormat,
-	`CREATE TABLE test (pk int, primary key(pk, noexist))`:    ErrUnknownIndexColumn,
+	`SHOW METHEMONEY`:                                                                         ErrUnsupportedFeature,
+	`LOCK TABLES foo AS READ`:                                                                 errUnexpectedSyntax,
+	`LOCK TABLES foo LOW_PRIORITY READ`:                                                       errUnexpectedSyntax,
+	`SELECT * FROM mytable LIMIT -100`:                                                        ErrUnsupportedSyntax,
+	`SELECT * FROM mytable LIMIT 100 OFFSET -1`:                                               ErrUnsupportedSyntax,
+	`SELECT INTERVAL 1 DAY - '2018-05-01'`:                                                    ErrUnsupportedSyntax,
+	`SELECT INTERVAL 1 DAY * '2018-05-01'`:                                                    ErrUnsupportedSyntax,
+	`SELECT '2018-05-01' * INTERVAL 1 DAY`:                                                    ErrUnsupportedSyntax,
+	`SELECT '2018-05-01' / INTERVAL 1 DAY`:                                                    ErrUnsupportedSyntax,
+	`SELECT INTERVAL 1 DAY + INTERVAL 1 DAY`:                                                  ErrUnsupportedSyntax,
+	`SELECT '2018-05-01' + (INTERVAL 1 DAY + INTERVAL 1 DAY)`:                                 ErrUnsupportedSyntax,
+	`SELECT UPPER(DISTINCT foo) FROM b`:                                                       ErrUnsupportedSyntax,
+	`CREATE VIEW myview AS SELECT UPPER(DISTINCT foo) FROM b`:                                 ErrUnsupportedSyntax,
+	"DESCRIBE FORMAT=pretty SELECT * FROM foo":                                                errInvalidDescribeFormat,
+	`CREATE TABLE test (pk int, primary key(pk, noexist))`:                                    ErrUnknownIndexColumn,
+	`SELECT * FROM JSON_TABLE('[]', '$[*]' COLUMNS (x INT PATH '$'))`:                         ErrUnsupportedFeature,
+	`SELECT * FROM t RIGHT JOIN JSON_TABLE(t.js, '$[*]' COLUMNS (x INT PATH '$')) jt ON TRUE`: ErrUnsupportedFeature,
+	`SELECT * FROM JSON_TABLE('[]', '$[*]' COLUMNS (x INT)) jt`:                               ErrInvalidJSONTable,
+	`SELECT * FROM JSON_TABLE('[]', '$[*]' COLUMNS (x INT PATH '$' NULL ON NOTHING)) jt`:      ErrInvalidJSONTable,
+	`SELECT * FROM JSON_TABLE('[]', '$[*]' COLUMNS (x INT PATH '$'), 1) jt`:                   ErrInvalidJSONTable,
+	`SELECT * FROM JSON_TABLE('[]', 'x' COLUMNS (x INT PATH '$')) jt`:                         sql.ErrInvalidJSONPath,
+	`SELECT CAST(a AS DECIMAL(66, 2)) FROM foo`:                                               sql.ErrInvalidDecimalPrecisionScale,
+	`SELECT CAST(a AS DECIMAL(5, 6)) FROM foo`:                                                sql.ErrInvalidDecimalPrecisionScale,
+	`SELECT * FROM t WHERE MATCH (a) AGAINST ('foo' WITH QUERY EXPANSION)`:                    ErrUnsupportedFeature,
+}
+
+func mustParseJSONPath(path string) *sql.JSONPath {
+	p, err := sql.ParseJSONPath(path)
+	if err != nil {
+		panic(err)
+	}
+	return p
 }
 
 func TestParseErrors(t *testing.T) {
@@ -2347,6 +2635,170 @@ func TestFixSetQuery(t *testing.T) {
 	}
 }
 
+func TestApplySqlMode(t *testing.T) {
+	testCases := []struct {
+		mode, in, out string
+	}{
+		{"", `select "a" || 'b\''`, `select "a" || 'b\''`},
+		{"ANSI_QUOTES", `select "a""b", 'c"d', "e` + "`" + `f" from "t"`, "select `a\"b`, 'c\"d', `e``f` from `t`"},
+		{"NO_BACKSLASH_ESCAPES", `select 'a\', "\n", ` + "`x\\`", `select 'a\\', "\\n", ` + "`x\\`"},
+		{"ANSI", `select "a" || 'b'`, "select `a` || 'b'"},
+		{"ANSI_QUOTES", `select "a`, `select "a`},
+		{"ANSI_QUOTES", "select \"a\" /* \"b\" */ -- \"c\"\n# \"d\"", "select `a` /* \"b\" */ -- \"c\"\n# \"d\""},
+		{"NO_BACKSLASH_ESCAPES", `select 'a\' /* 'b\' */`, `select 'a\\' /* 'b\' */`},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.mode+" "+tt.in, func(t *testing.T) {
+			require.Equal(t, tt.out, rewriteQuery(tt.in, sql.NewSqlMode(tt.mode)))
+		})
+	}
+}
+
+func TestRewriteGeneratedColumns(t *testing.T) {
+	testCases := []struct {
+		in, out string
+	}{
+		{`create table t (a int, b int as (a + 1) stored, c int generated always as (a) virtual)`, `create table t (a int, b int DEFAULT (gms_generated_stored(a + 1)), c int DEFAULT (gms_generated_virtual(a)))`},
+		{`alter table t add column b int as (a * 2)`, `alter table t add column b int DEFAULT (gms_generated_virtual(a * 2))`},
+		{`create table t (a int /* as (1) */, b varchar(10) default 'as (1)')`, `create table t (a int /* as (1) */, b varchar(10) default 'as (1)')`},
+		{`select a as (b) from t`, `select a as (b) from t`},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.in, func(t *testing.T) {
+			require.Equal(t, tt.out, rewriteQuery(tt.in, sql.SqlMode{}))
+		})
+	}
+}
+
+func TestQuoteJSONTables(t *testing.T) {
+	testCases := []struct {
+		in, out string
+	}{
+		{`select * from json_table('[]', '$' columns (a int path '$')) jt`, "select * from `json_table('[]', '$' columns (a int path '$'))` jt"},
+		{`select * from t left join json_table(t.js, '$' columns (a int path '$')) jt on true, json_table('[]', '$' columns (b int path '$')) jt2`, "select * from t left join `json_table(t.js, '$' columns (a int path '$'))` jt on true, `json_table('[]', '$' columns (b int path '$'))` jt2"},
+		{`select (select a from json_table(x, '$' columns (a int path '$')) jt) from t`, "select (select a from `json_table(x, '$' columns (a int path '$'))` jt) from t"},
+		{`create table json_table (a int)`, `create table json_table (a int)`},
+		{`insert into json_table (a) select a from json_table where a in (1)`, `insert into json_table (a) select a from json_table where a in (1)`},
+		{`select a from t /* from json_table(a) */ # , json_table(b)`, `select a from t /* from json_table(a) */ # , json_table(b)`},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.in, func(t *testing.T) {
+			require.Equal(t, tt.out, rewriteQuery(tt.in, sql.SqlMode{}))
+		})
+	}
+}
+
+func TestRewriteStringFunctions(t *testing.T) {
+	testCases := []struct {
+		in, out string
+	}{
+		{`select insert('abc', 1, 1, 'x'), format(1.5, 2)`, "select `insert`('abc', 1, 1, 'x'), `format`(1.5, 2)"},
+		{`select position('b' in concat('a', 'b'))`, `select locate('b' , concat('a', 'b'))`},
+		{`select char(77, 121 using utf8mb4), char(65)`, `select convert(char(77, 121 ) using utf8mb4), char(65)`},
+		{`select weight_string(a as char(4)), weight_string(b as binary(2)), weight_string(c)`, `select weight_string(a , 'CHAR', 4), weight_string(b , 'BINARY', 2), weight_string(c)`},
+		{`select upper(format(a, 2)), 'format(a)', t.insert(a) from t`, "select upper(`format`(a, 2)), 'format(a)', t.insert(a) from t"},
+		{`insert into t values (1)`, `insert into t values (1)`},
+		{`select format('a`, `select format('a`},
+		{`select format(a, 2) /* insert(b) */ -- position('a' in b)`, "select `format`(a, 2) /* insert(b) */ -- position('a' in b)"},
+		{`select a /* format(`, `select a /* format(`},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.in, func(t *testing.T) {
+			require.Equal(t, tt.out, rewriteQuery(tt.in, sql.SqlMode{}))
+		})
+	}
+}
+
+func TestParseStringFunctions(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	p, err := Parse(ctx, "select insert(a, 1, 2, 'x'), position('b' IN a), a like 'x|%' escape '|' from t")
+	require.NoError(err)
+
+	project, ok := p.(*plan.Project)
+	require.True(ok)
+	require.Len(project.Projections, 3)
+	require.Equal("insert(a, 1, 2, \"x\")", project.Projections[0].String())
+	require.Equal("locate(\"b\", a)", project.Projections[1].String())
+	require.Equal("a LIKE \"x|%\" ESCAPE \"|\"", project.Projections[2].String())
+}
+
+func TestRewriteCasts(t *testing.T) {
+	testCases := []struct {
+		in, out string
+	}{
+		{`select cast(a as double), convert(b, real), cast(c AS YEAR)`, "select `convert`(a , 'double'), `convert`(b, 'real'), `convert`(c , 'year')"},
+		{`select cast(a as float), cast(b as float(30)), convert(c, float(10))`, "select `convert`(a , 'float'), `convert`(b , 'float', 30), `convert`(c, 'float', 10)"},
+		{`select cast(cast(a as double) as signed), convert(upper(b), char)`, "select cast(`convert`(a , 'double') as signed), convert(upper(b), char)"},
+		{`select convert(a using latin1), cast('as double' as char)`, `select convert(a using latin1), cast('as double' as char)`},
+		{`select cast('a`, `select cast('a`},
+		{`select cast(a /* as char */ as double) # cast(b as double)`, "select `convert`(a /* as char */ , 'double') # cast(b as double)"},
+		{`select extract(year_month from cast(a as double)), extract('year', a), t.extract(a from b)`, "select extract('year_month', `convert`(a , 'double')), extract('year', a), t.extract(a from b)"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.in, func(t *testing.T) {
+			require.Equal(t, tt.out, rewriteQuery(tt.in, sql.SqlMode{}))
+		})
+	}
+}
+
+func TestParseCasts(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	p, err := Parse(ctx, "select cast(a as double), cast(a as float(30)), convert(a, year), cast(a as char(3) character set latin1), cast(a as datetime(2)), cast(a as binary(4)) from t")
+	require.NoError(err)
+
+	project, ok := p.(*plan.Project)
+	require.True(ok)
+	require.Len(project.Projections, 6)
+	require.Equal("convert(a, double)", project.Projections[0].String())
+	require.Equal("convert(a, float(30))", project.Projections[1].String())
+	require.Equal("convert(a, year)", project.Projections[2].String())
+	require.Equal("convert(a, char(3) character set latin1)", project.Projections[3].String())
+	require.Equal("convert(a, datetime(2))", project.Projections[4].String())
+	require.Equal("convert(a, binary(4))", project.Projections[5].String())
+
+	require.NoError(ctx.Set(ctx, "sql_mode", sql.LongText, "REAL_AS_FLOAT"))
+	p, err = Parse(ctx, "select cast(a as real) from t")
+	require.NoError(err)
+	require.Equal("convert(a, float)", p.(*plan.Project).Projections[0].String())
+
+	_, err = Parse(ctx, "select cast(a as float(54)) from t")
+	require.True(expression.ErrConvertPrecisionTooBig.Is(err))
+	_, err = Parse(ctx, "select cast(a as time(7)) from t")
+	require.True(expression.ErrConvertPrecisionTooBig.Is(err))
+}
+
+func TestMaxExecutionTimeHint(t *testing.T) {
+	testCases := []struct {
+		query   string
+		timeout time.Duration
+		ok      bool
+	}{
+		{"SELECT /*+ MAX_EXECUTION_TIME(1000) */ * FROM foo", time.Second, true},
+		{"  select /*+ max_execution_time( 50 ) */ 1", 50 * time.Millisecond, true},
+		{"SELECT /*+ BKA(t1) MAX_EXECUTION_TIME(20) */ 1", 20 * time.Millisecond, true},
+		{"SELECT /* MAX_EXECUTION_TIME(1000) */ 1", 0, false},
+		{"SELECT 1 FROM foo WHERE a = '/*+ MAX_EXECUTION_TIME(1000) */'", 0, false},
+		{"INSERT /*+ MAX_EXECUTION_TIME(1000) */ INTO foo VALUES (1)", 0, false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.query, func(t *testing.T) {
+			timeout, ok := MaxExecutionTimeHint(tt.query)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.timeout, timeout)
+		})
+	}
+}
+
 func TestPrintTree(t *testing.T) {
 	require := require.New(t)
 	node, err := Parse(sql.NewEmptyContext(), `
@@ -0,0 +1,267 @@
+package parse
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// Some syntax the parser doesn't support is rewritten, before parsing a query,
+// to syntax it does support. The query is split into tokens once, so that the
+// rewrites never look inside strings, quoted identifiers or comments, and then
+// each rewrite replaces some of those tokens. If the query can't be split into
+// tokens, it's parsed as is, so that the parser reports the error.
+
+type tokenKind byte
+
+const (
+	// spaceToken is whitespace or a comment.
+	spaceToken tokenKind = iota
+	// stringToken is a quoted string.
+	stringToken
+	// quotedToken is a quoted identifier.
+	quotedToken
+	// wordToken is a keyword, an unquoted identifier or a number.
+	wordToken
+	// punctToken is any other character.
+	punctToken
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// is returns whether the token is the given punctuation character.
+func (t token) is(punct string) bool {
+	return t.kind == punctToken && t.text == punct
+}
+
+// isWord returns whether the token is the given word, ignoring case.
+func (t token) isWord(word string) bool {
+	return t.kind == wordToken && strings.EqualFold(t.text, word)
+}
+
+// rewriteQuery rewrites the given query, written for the given SQL mode, to
+// the query the parser supports that means the same.
+func rewriteQuery(query string, mode sql.SqlMode) string {
+	tokens, ok := tokenize(query, mode)
+	if !ok {
+		return query
+	}
+
+	tokens = applySqlMode(tokens, mode)
+	if isCreateOrAlterTable(tokens) {
+		tokens = rewriteGeneratedColumns(tokens)
+		tokens = rewriteFullTextKeys(tokens)
+	}
+	tokens = rewriteFunctionCalls(tokens, func(name token, args []token) []token {
+		if call, ok := rewriteStringFunction(name, args); ok {
+			return call
+		}
+		if call, ok := rewriteCast(name, args); ok {
+			return call
+		}
+		if call, ok := rewriteExtract(name, args); ok {
+			return call
+		}
+		return nil
+	})
+	tokens = quoteJSONTables(tokens)
+	return joinTokens(tokens)
+}
+
+// tokenize splits the given query, written for the given SQL mode, into
+// tokens. It returns false if a string, quoted identifier or comment isn't
+// terminated.
+func tokenize(query string, mode sql.SqlMode) ([]token, bool) {
+	ansiQuotes := mode.Has(sql.SqlMode_AnsiQuotes)
+	backslashEscapes := !mode.Has(sql.SqlMode_NoBackslashEscapes)
+
+	var tokens []token
+	for i := 0; i < len(query); {
+		kind, end := punctToken, i+1
+		switch c := query[i]; {
+		case isSpaceByte(c):
+			kind, end = spaceToken, i
+			for end < len(query) && isSpaceByte(query[end]) {
+				end++
+			}
+		case c == '#' || c == '-' && strings.HasPrefix(query[i:], "--") && (i+2 == len(query) || isSpaceByte(query[i+2])):
+			kind, end = spaceToken, len(query)
+			if eol := strings.IndexByte(query[i:], '\n'); eol >= 0 {
+				end = i + eol + 1
+			}
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			closing := strings.Index(query[i+2:], "*/")
+			if closing < 0 {
+				return nil, false
+			}
+			kind, end = spaceToken, i+2+closing+2
+		case c == '`' || c == '"' && ansiQuotes:
+			kind, end = quotedToken, quotedEnd(query, i, false)
+		case c == '\'' || c == '"':
+			kind, end = stringToken, quotedEnd(query, i, backslashEscapes)
+		case isIdentByte(c):
+			kind, end = wordToken, i
+			for end < len(query) && isIdentByte(query[end]) {
+				end++
+			}
+		}
+		if end < 0 {
+			return nil, false
+		}
+
+		tokens = append(tokens, token{kind, query[i:end]})
+		i = end
+	}
+	return tokens, true
+}
+
+// quotedEnd returns the position after the quote that closes the one at the
+// given position, or -1 if there's none. A quote is escaped by doubling it or,
+// if backslashEscapes is true, by a backslash.
+func quotedEnd(s string, start int, backslashEscapes bool) int {
+	quote := s[start]
+	for i := start + 1; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && backslashEscapes:
+			i++
+		case s[i] == quote && i+1 < len(s) && s[i+1] == quote:
+			i++
+		case s[i] == quote:
+			return i + 1
+		}
+	}
+	return -1
+}
+
+func isSpaceByte(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v'
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c == '$' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c >= 0x80
+}
+
+// tokensOf returns the tokens of the given text, which is written for the
+// default SQL mode.
+func tokensOf(text string) []token {
+	tokens, _ := tokenize(text, sql.SqlMode{})
+	return tokens
+}
+
+func joinTokens(tokens []token) string {
+	var sb strings.Builder
+	for _, t := range tokens {
+		sb.WriteString(t.text)
+	}
+	return sb.String()
+}
+
+// trimTokens returns the given tokens without the leading and trailing
+// whitespace and comments.
+func trimTokens(tokens []token) []token {
+	for len(tokens) > 0 && tokens[0].kind == spaceToken {
+		tokens = tokens[1:]
+	}
+	for len(tokens) > 0 && tokens[len(tokens)-1].kind == spaceToken {
+		tokens = tokens[:len(tokens)-1]
+	}
+	return tokens
+}
+
+// nextToken returns the position of the first token at or after the given
+// one that isn't whitespace nor a comment, or len(tokens) if there's none.
+func nextToken(tokens []token, i int) int {
+	for i < len(tokens) && tokens[i].kind == spaceToken {
+		i++
+	}
+	return i
+}
+
+// prevToken returns the position of the last token before the given one that
+// isn't whitespace nor a comment, or -1 if there's none.
+func prevToken(tokens []token, i int) int {
+	for i--; i >= 0 && tokens[i].kind == spaceToken; i-- {
+	}
+	return i
+}
+
+// closingParen returns the position of the parenthesis that closes the one at
+// the given position, or -1 if there's none.
+func closingParen(tokens []token, start int) int {
+	var depth int
+	for i := start; i < len(tokens); i++ {
+		switch {
+		case tokens[i].is("("):
+			depth++
+		case tokens[i].is(")"):
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// topLevel returns the position of the first of the given tokens that matches
+// and isn't inside parentheses, or -1 if there's none.
+func topLevel(tokens []token, match func(token) bool) int {
+	var depth int
+	for i, t := range tokens {
+		switch {
+		case t.is("("):
+			depth++
+		case t.is(")"):
+			depth--
+		case depth == 0 && match(t):
+			return i
+		}
+	}
+	return -1
+}
+
+// topLevelWord returns the position of the first occurrence of the given word
+// in the given tokens that isn't inside parentheses, or -1 if there's none.
+func topLevelWord(tokens []token, word string) int {
+	return topLevel(tokens, func(t token) bool { return t.isWord(word) })
+}
+
+// rewriteFunctionCalls replaces the function calls in the given tokens, and
+// in their arguments, with the result of the given function for their name
+// and arguments, unless it returns nil.
+func rewriteFunctionCalls(tokens []token, rewrite func(name token, args []token) []token) []token {
+	var out []token
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		qualified := i > 0 && tokens[i-1].is(".")
+		if t.kind != wordToken || qualified || i+1 == len(tokens) || !tokens[i+1].is("(") {
+			out = append(out, t)
+			continue
+		}
+
+		end := closingParen(tokens, i+1)
+		if end < 0 {
+			return append(out, tokens[i:]...)
+		}
+
+		args := rewriteFunctionCalls(tokens[i+2:end], rewrite)
+		call := rewrite(t, args)
+		if call == nil {
+			call = concatTokens([]token{t}, tokensOf("("), args, tokensOf(")"))
+		}
+		out = append(out, call...)
+		i = end
+	}
+	return out
+}
+
+// concatTokens returns the concatenation of the given token slices.
+func concatTokens(parts ...[]token) []token {
+	var tokens []token
+	for _, part := range parts {
+		tokens = append(tokens, part...)
+	}
+	return tokens
+}
@@ -0,0 +1,39 @@
+package parse
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// The parser always parses queries as the default SQL mode does, so queries
+// are rewritten before parsing them to the equivalent query in that mode:
+//   - With ANSI_QUOTES, double quoted text is an identifier rather than a
+//     string, so it's quoted with backticks instead.
+//   - With NO_BACKSLASH_ESCAPES, a backslash in a string is an ordinary
+//     character, so it's escaped.
+// PIPES_AS_CONCAT isn't supported, because || can't be given the precedence
+// of a concatenation without changing the grammar of the parser.
+
+// applySqlMode rewrites the given tokens, written for the given SQL mode, to
+// the tokens that mean the same in the default SQL mode.
+func applySqlMode(tokens []token, mode sql.SqlMode) []token {
+	ansiQuotes := mode.Has(sql.SqlMode_AnsiQuotes)
+	noBackslashEscapes := mode.Has(sql.SqlMode_NoBackslashEscapes)
+	if !ansiQuotes && !noBackslashEscapes {
+		return tokens
+	}
+
+	var out []token
+	for _, t := range tokens {
+		switch {
+		case t.kind == quotedToken && t.text[0] == '"':
+			ident := strings.Replace(t.text[1:len(t.text)-1], `""`, `"`, -1)
+			t.text = "`" + strings.Replace(ident, "`", "``", -1) + "`"
+		case t.kind == stringToken && noBackslashEscapes:
+			t.text = strings.Replace(t.text, `\`, `\\`, -1)
+		}
+		out = append(out, t)
+	}
+	return out
+}
@@ -0,0 +1,60 @@
+package parse
+
+import "strings"
+
+// The parser doesn't support the string functions named after reserved words,
+// nor the special syntax some of them have, so the calls to those functions
+// are rewritten before parsing a query:
+//   - INSERT(...) and FORMAT(...) are quoted as identifiers.
+//   - POSITION(substr IN str) is replaced with LOCATE(substr, str).
+//   - CHAR(... USING charset) is replaced with
+//     CONVERT(CHAR(...) USING charset).
+//   - WEIGHT_STRING(str AS CHAR(n)) and WEIGHT_STRING(str AS BINARY(n)) are
+//     replaced with WEIGHT_STRING(str, 'CHAR', n) and
+//     WEIGHT_STRING(str, 'BINARY', n).
+
+// rewriteStringFunction returns the call to the function with the given name
+// and arguments that the parser supports, if it doesn't support the given one.
+func rewriteStringFunction(name token, args []token) ([]token, bool) {
+	switch strings.ToLower(name.text) {
+	case "insert", "format":
+		quoted := token{quotedToken, "`" + name.text + "`"}
+		return concatTokens([]token{quoted}, tokensOf("("), args, tokensOf(")")), true
+	case "position":
+		if i := topLevelWord(args, "in"); i >= 0 {
+			return concatTokens(tokensOf("locate("), args[:i], tokensOf(","), args[i+1:], tokensOf(")")), true
+		}
+	case "char":
+		if i := topLevelWord(args, "using"); i >= 0 {
+			return concatTokens(tokensOf("convert("), []token{name}, tokensOf("("), args[:i], tokensOf(") using"), args[i+1:], tokensOf(")")), true
+		}
+	case "weight_string":
+		i := topLevelWord(args, "as")
+		if i < 0 {
+			break
+		}
+
+		castType := nextToken(args, i+1)
+		if castType == len(args) || !args[castType].isWord("char") && !args[castType].isWord("binary") {
+			break
+		}
+
+		length, ok := parenthesized(args[castType+1:])
+		if !ok {
+			break
+		}
+		typeName := tokensOf(", '" + strings.ToUpper(args[castType].text) + "', ")
+		return concatTokens([]token{name}, tokensOf("("), args[:i], typeName, length, tokensOf(")")), true
+	}
+	return nil, false
+}
+
+// parenthesized returns the tokens inside the parentheses the given tokens
+// consist of, leaving aside whitespace and comments.
+func parenthesized(tokens []token) ([]token, bool) {
+	tokens = trimTokens(tokens)
+	if len(tokens) < 2 || !tokens[0].is("(") || closingParen(tokens, 0) != len(tokens)-1 {
+		return nil, false
+	}
+	return trimTokens(tokens[1 : len(tokens)-1]), true
+}
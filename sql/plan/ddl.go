@@ -118,7 +118,7 @@ func (c *CreateTable) Schema() sql.Schema {
 func (c *CreateTable) Resolved() bool {
 	resolved := c.ddlNode.Resolved()
 	for _, col := range c.schema {
-		resolved = resolved && col.Default.Resolved()
+		resolved = resolved && col.Default.Resolved() && col.Generated.Resolved()
 	}
 	return resolved
 }
@@ -205,10 +205,13 @@ func (c *CreateTable) String() string {
 	return fmt.Sprintf("Create table %s%s", ifNotExists, c.name)
 }
 
+// Expressions implements the sql.Expressioner interface. The default values of the columns come first, followed by
+// their generated expressions.
 func (c *CreateTable) Expressions() []sql.Expression {
-	exprs := make([]sql.Expression, len(c.schema))
+	exprs := make([]sql.Expression, 2*len(c.schema))
 	for i, col := range c.schema {
 		exprs[i] = expression.WrapExpression(col.Default)
+		exprs[len(c.schema)+i] = expression.WrapExpression(col.Generated)
 	}
 	return exprs
 }
@@ -226,17 +229,13 @@ func (c *CreateTable) IfNotExists() bool {
 }
 
 func (c *CreateTable) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
-	if len(exprs) != len(c.schema) {
-		return nil, sql.ErrInvalidChildrenNumber.New(c, len(exprs), len(c.schema))
+	if len(exprs) != 2*len(c.schema) {
+		return nil, sql.ErrInvalidChildrenNumber.New(c, len(exprs), 2*len(c.schema))
 	}
 	nc := *c
-	for i, expr := range exprs {
-		unwrappedColDefVal, ok := expr.(*expression.Wrapper).Unwrap().(*sql.ColumnDefaultValue)
-		if ok {
-			nc.schema[i].Default = unwrappedColDefVal
-		} else { // nil fails type check
-			nc.schema[i].Default = nil
-		}
+	for i := range c.schema {
+		nc.schema[i].Default = unwrapColumnDefaultValue(exprs[i])
+		nc.schema[i].Generated = unwrapColumnDefaultValue(exprs[len(c.schema)+i])
 	}
 	return &nc, nil
 }
@@ -474,7 +473,7 @@ func (a *AddColumn) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error)
 		}
 	}
 
-	if !a.column.Nullable && a.column.Default == nil {
+	if !a.column.Nullable && a.column.Default == nil && a.column.Generated == nil {
 		return nil, ErrNullDefault.New()
 	}
 
@@ -486,26 +485,22 @@ func (a *AddColumn) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error)
 }
 
 func (a *AddColumn) Expressions() []sql.Expression {
-	return expression.WrapExpressions(a.column.Default)
+	return expression.WrapExpressions(a.column.Default, a.column.Generated)
 }
 
 func (a *AddColumn) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
-	if len(exprs) != 1 {
-		return nil, sql.ErrInvalidChildrenNumber.New(a, len(exprs), 1)
+	if len(exprs) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(a, len(exprs), 2)
 	}
 	na := *a
-	unwrappedColDefVal, ok := exprs[0].(*expression.Wrapper).Unwrap().(*sql.ColumnDefaultValue)
-	if ok {
-		na.column.Default = unwrappedColDefVal
-	} else { // nil fails type check
-		na.column.Default = nil
-	}
+	na.column.Default = unwrapColumnDefaultValue(exprs[0])
+	na.column.Generated = unwrapColumnDefaultValue(exprs[1])
 	return &na, nil
 }
 
 // Resolved implements the Resolvable interface.
 func (a *AddColumn) Resolved() bool {
-	return a.ddlNode.Resolved() && a.column.Default.Resolved()
+	return a.ddlNode.Resolved() && a.column.Default.Resolved() && a.column.Generated.Resolved()
 }
 
 func (a *AddColumn) validateDefaultPosition(tblSch sql.Schema) error {
@@ -588,22 +583,24 @@ func (d *DropColumn) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error)
 	}
 
 	for _, col := range tbl.Schema() {
-		if col.Default == nil {
+		if col.Default == nil && col.Generated == nil {
 			continue
 		}
 		var err error
-		sql.Inspect(col.Default, func(expr sql.Expression) bool {
-			switch expr := expr.(type) {
-			case *expression.GetField:
-				if expr.Name() == d.column {
-					err = sql.ErrDropColumnReferencedInDefault.New(d.column, expr.Name())
-					return false
+		for _, e := range []*sql.ColumnDefaultValue{col.Default, col.Generated} {
+			sql.Inspect(e, func(expr sql.Expression) bool {
+				switch expr := expr.(type) {
+				case *expression.GetField:
+					if expr.Name() == d.column {
+						err = sql.ErrDropColumnReferencedInDefault.New(d.column, col.Name)
+						return false
+					}
 				}
+				return true
+			})
+			if err != nil {
+				return nil, err
 			}
-			return true
-		})
-		if err != nil {
-			return nil, err
 		}
 	}
 
@@ -754,26 +751,22 @@ func (m *ModifyColumn) WithChildren(children ...sql.Node) (sql.Node, error) {
 }
 
 func (m *ModifyColumn) Expressions() []sql.Expression {
-	return expression.WrapExpressions(m.column.Default)
+	return expression.WrapExpressions(m.column.Default, m.column.Generated)
 }
 
 func (m *ModifyColumn) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
-	if len(exprs) != 1 {
-		return nil, sql.ErrInvalidChildrenNumber.New(m, len(exprs), 1)
+	if len(exprs) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(m, len(exprs), 2)
 	}
 	nm := *m
-	unwrappedColDefVal, ok := exprs[0].(*expression.Wrapper).Unwrap().(*sql.ColumnDefaultValue)
-	if ok {
-		nm.column.Default = unwrappedColDefVal
-	} else { // nil fails type check
-		nm.column.Default = nil
-	}
+	nm.column.Default = unwrapColumnDefaultValue(exprs[0])
+	nm.column.Generated = unwrapColumnDefaultValue(exprs[1])
 	return &nm, nil
 }
 
 // Resolved implements the Resolvable interface.
 func (m *ModifyColumn) Resolved() bool {
-	return m.ddlNode.Resolved() && m.column.Default.Resolved()
+	return m.ddlNode.Resolved() && m.column.Default.Resolved() && m.column.Generated.Resolved()
 }
 
 func (m *ModifyColumn) validateDefaultPosition(tblSch sql.Schema) error {
@@ -845,24 +838,39 @@ func getAlterableTable(db sql.Database, ctx *sql.Context, tableName string) (sql
 }
 
 func inspectDefaultForInvalidColumns(col *sql.Column, columnsAfterThis map[string]*sql.Column) error {
-	if col.Default == nil {
+	if col.Default == nil && col.Generated == nil {
 		return nil
 	}
 	var err error
-	sql.Inspect(col.Default, func(expr sql.Expression) bool {
-		switch expr := expr.(type) {
-		case *expression.GetField:
-			if col, ok := columnsAfterThis[expr.Name()]; ok && col.Default != nil && !col.Default.IsLiteral() {
-				err = sql.ErrInvalidDefaultValueOrder.New(col.Name)
-				return false
+	for _, e := range []*sql.ColumnDefaultValue{col.Default, col.Generated} {
+		sql.Inspect(e, func(expr sql.Expression) bool {
+			switch expr := expr.(type) {
+			case *expression.GetField:
+				if col, ok := columnsAfterThis[expr.Name()]; ok && (col.Generated != nil || col.Default != nil && !col.Default.IsLiteral()) {
+					err = sql.ErrInvalidDefaultValueOrder.New(col.Name)
+					return false
+				}
 			}
+			return true
+		})
+		if err != nil {
+			return err
 		}
-		return true
-	})
-	return err
+	}
+	return nil
+}
+
+// unwrapColumnDefaultValue returns the default value or generated expression of a column held by the given wrapper.
+func unwrapColumnDefaultValue(expr sql.Expression) *sql.ColumnDefaultValue {
+	colDefVal, ok := expr.(*expression.Wrapper).Unwrap().(*sql.ColumnDefaultValue)
+	if !ok { // nil fails type check
+		return nil
+	}
+	return colDefVal
 }
 
-// updateDefaultsOnColumnRename updates each column that references the old column name within its default value.
+// updateDefaultsOnColumnRename updates each column that references the old column name within its default value or
+// generated expression.
 func updateDefaultsOnColumnRename(ctx *sql.Context, tbl sql.AlterableTable, oldName, newName string) error {
 	if oldName == newName {
 		return nil
@@ -870,21 +878,23 @@ func updateDefaultsOnColumnRename(ctx *sql.Context, tbl sql.AlterableTable, oldN
 	var err error
 	colsToModify := make(map[*sql.Column]struct{})
 	for _, col := range tbl.Schema() {
-		if col.Default == nil {
-			continue
-		}
 		newCol := *col
-		newCol.Default.Expression, err = expression.TransformUp(col.Default.Expression, func(e sql.Expression) (sql.Expression, error) {
-			if expr, ok := e.(*expression.GetField); ok {
-				if strings.ToLower(expr.Name()) == oldName {
-					colsToModify[&newCol] = struct{}{}
-					return expr.WithName(newName), nil
+		for _, colDefVal := range []*sql.ColumnDefaultValue{newCol.Default, newCol.Generated} {
+			if colDefVal == nil {
+				continue
+			}
+			colDefVal.Expression, err = expression.TransformUp(colDefVal.Expression, func(e sql.Expression) (sql.Expression, error) {
+				if expr, ok := e.(*expression.GetField); ok {
+					if strings.ToLower(expr.Name()) == oldName {
+						colsToModify[&newCol] = struct{}{}
+						return expr.WithName(newName), nil
+					}
 				}
+				return e, nil
+			})
+			if err != nil {
+				return err
 			}
-			return e, nil
-		})
-		if err != nil {
-			return err
 		}
 	}
 	for col := range colsToModify {
@@ -85,7 +85,8 @@ func (d *deleteIter) Next() (sql.Row, error) {
 		row = row[len(row)-len(d.schema):]
 	}
 
-	return row, d.deleter.Delete(d.ctx, row)
+	// TIMESTAMP values are stored in UTC
+	return row, d.deleter.Delete(d.ctx, sql.TimestampsFromSession(d.ctx, d.schema, row))
 }
 
 func (d *deleteIter) Close() error {
@@ -33,7 +33,7 @@ func (d *Distinct) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
 		return nil, err
 	}
 
-	return sql.NewSpanIter(span, newDistinctIter(ctx, it)), nil
+	return sql.NewSpanIter(span, newDistinctIter(ctx, it, d.Child.Schema())), nil
 }
 
 // WithChildren implements the Node interface.
@@ -59,14 +59,20 @@ func (d Distinct) String() string {
 // result sets.
 type distinctIter struct {
 	childIter sql.RowIter
+	types     []sql.Type
 	seen      sql.KeyValueCache
 	dispose   sql.DisposeFunc
 }
 
-func newDistinctIter(ctx *sql.Context, child sql.RowIter) *distinctIter {
+func newDistinctIter(ctx *sql.Context, child sql.RowIter, schema sql.Schema) *distinctIter {
 	cache, dispose := ctx.Memory.NewHistoryCache()
+	types := make([]sql.Type, len(schema))
+	for i, col := range schema {
+		types[i] = col.Type
+	}
 	return &distinctIter{
 		childIter: child,
+		types:     types,
 		seen:      cache,
 		dispose:   dispose,
 	}
@@ -82,7 +88,7 @@ func (di *distinctIter) Next() (sql.Row, error) {
 			return nil, err
 		}
 
-		hash, err := sql.HashOf(row)
+		hash, err := sql.HashOfTyped(row, di.types)
 		if err != nil {
 			return nil, err
 		}
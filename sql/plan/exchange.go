@@ -319,7 +319,11 @@ func (exchangePartition) Children() []sql.Node { return nil }
 func (exchangePartition) Resolved() bool { return true }
 
 func (p *exchangePartition) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
-	return p.table.PartitionRows(ctx, p.Partition)
+	iter, err := p.table.PartitionRows(ctx, p.Partition)
+	if err != nil {
+		return nil, err
+	}
+	return sql.NewTimestampsToSessionIter(ctx, p.table.Schema(), iter), nil
 }
 
 func (p *exchangePartition) Schema() sql.Schema {
@@ -29,14 +29,22 @@ func (i *IndexedTableAccess) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter
 		return nil, ErrNoIndexableTable.New(i.ResolvedTable)
 	}
 
-	// evaluate the key expressions against the row given to obtain the key for an index lookup
+	// evaluate the key expressions against the row given to obtain the key for an index lookup, whose TIMESTAMP values
+	// are in UTC as they're stored
+	indexExprs := i.index.Expressions()
 	key := make([]interface{}, len(i.keyExprs))
-	for i, keyExpr := range i.keyExprs {
+	for j, keyExpr := range i.keyExprs {
 		var err error
-		key[i], err = keyExpr.Eval(ctx, row)
+		key[j], err = keyExpr.Eval(ctx, row)
 		if err != nil {
 			return nil, err
 		}
+
+		if j < len(indexExprs) {
+			if col := GetColumnFromIndexExpr(indexExprs[j], i.ResolvedTable); col != nil {
+				key[j] = sql.TimestampFromSession(ctx, col.Type, key[j])
+			}
+		}
 	}
 
 	lookup, err := i.index.Get(key...)
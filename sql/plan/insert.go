@@ -61,6 +61,8 @@ type insertIter struct {
 	updateExprs []sql.Expression
 	tableNode   sql.Node
 	closed      bool
+	rowNumber   int
+	warnings    uint16
 }
 
 func GetInsertable(node sql.Node) (sql.InsertableTable, error) {
@@ -118,6 +120,8 @@ func newInsertIter(
 		}
 	}
 
+	// The row source can compute its rows as soon as it's created, so the warnings added for them are counted from here
+	warnings := ctx.WarningCount()
 	rowIter, err := values.RowIter(ctx, row)
 	if err != nil {
 		return nil, err
@@ -132,10 +136,11 @@ func newInsertIter(
 		rowSource:   rowIter,
 		updateExprs: onDupUpdateExpr,
 		ctx:         ctx,
+		warnings:    warnings,
 	}, nil
 }
 
-func (i insertIter) Next() (returnRow sql.Row, returnErr error) {
+func (i *insertIter) Next() (returnRow sql.Row, returnErr error) {
 	row, err := i.rowSource.Next()
 	if err == io.EOF {
 		return nil, err
@@ -146,6 +151,13 @@ func (i insertIter) Next() (returnRow sql.Row, returnErr error) {
 		return nil, err
 	}
 
+	i.rowNumber++
+	if err = checkDivisionByZero(i.ctx, i.warnings); err != nil {
+		_ = i.rowSource.Close()
+		return nil, err
+	}
+	i.warnings = i.ctx.WarningCount()
+
 	// Prune the row down to the size of the schema. It can be larger in the case of running with an outer scope, in which
 	// case the additional scope variables are prepended to the row.
 	if len(row) > len(i.schema) {
@@ -159,18 +171,19 @@ func (i insertIter) Next() (returnRow sql.Row, returnErr error) {
 	}
 
 	// Do any necessary type conversions to the target schema
-	for i, col := range i.schema {
-		if row[i] != nil {
-			row[i], err = col.Type.Convert(row[i])
-			if err != nil {
-				return nil, err
-			}
+	for idx, col := range i.schema {
+		row[idx], err = sql.ConvertToColumn(i.ctx, col, i.rowNumber, row[idx])
+		if err != nil {
+			return nil, err
 		}
 	}
 
+	// TIMESTAMP values are stored in UTC
+	stored := sql.TimestampsFromSession(i.ctx, i.schema, row)
+
 	if i.replacer != nil {
 		toReturn := row.Append(row)
-		if err = i.replacer.Delete(i.ctx, row); err != nil {
+		if err = i.replacer.Delete(i.ctx, stored); err != nil {
 			if !sql.ErrDeleteRowNotFound.Is(err) {
 				_ = i.rowSource.Close()
 				return nil, err
@@ -181,13 +194,13 @@ func (i insertIter) Next() (returnRow sql.Row, returnErr error) {
 			}
 		}
 
-		if err = i.replacer.Insert(i.ctx, row); err != nil {
+		if err = i.replacer.Insert(i.ctx, stored); err != nil {
 			_ = i.rowSource.Close()
 			return nil, err
 		}
 		return toReturn, nil
 	} else {
-		if err := i.inserter.Insert(i.ctx, row); err != nil {
+		if err := i.inserter.Insert(i.ctx, stored); err != nil {
 			if !sql.ErrUniqueKeyViolation.Is(err) || len(i.updateExprs) == 0 {
 				_ = i.rowSource.Close()
 				return nil, err
@@ -228,12 +241,29 @@ func (i insertIter) Next() (returnRow sql.Row, returnErr error) {
 				return nil, err
 			}
 
+			warnings := i.ctx.WarningCount()
 			newRow, err := applyUpdateExpressions(i.ctx, i.updateExprs, rowToUpdate)
 			if err != nil {
 				return nil, err
 			}
 
-			err = i.updater.Update(i.ctx, rowToUpdate, newRow)
+			if err = checkDivisionByZero(i.ctx, warnings); err != nil {
+				return nil, err
+			}
+
+			newRow, err = applyGeneratedColumns(i.ctx, i.schema, newRow)
+			if err != nil {
+				return nil, err
+			}
+
+			newRow, err = convertUpdatedColumns(i.ctx, i.schema, i.rowNumber, i.updateExprs, 0, newRow)
+			if err != nil {
+				return nil, err
+			}
+
+			err = i.updater.Update(i.ctx,
+				sql.TimestampsFromSession(i.ctx, i.schema, rowToUpdate),
+				sql.TimestampsFromSession(i.ctx, i.schema, newRow))
 			if err != nil {
 				return nil, err
 			}
@@ -246,7 +276,7 @@ func (i insertIter) Next() (returnRow sql.Row, returnErr error) {
 	return row, nil
 }
 
-func (i insertIter) Close() error {
+func (i *insertIter) Close() error {
 	if !i.closed {
 		i.closed = true
 		if i.inserter != nil {
@@ -314,7 +344,7 @@ func (p InsertInto) DebugString() string {
 
 func validateNullability(dstSchema sql.Schema, row sql.Row) error {
 	for i, col := range dstSchema {
-		if !col.Nullable && row[i] == nil {
+		if !col.Nullable && (row[i] == nil || row[i] == sql.Null) {
 			return ErrInsertIntoNonNullableProvidedNull.New(col.Name)
 		}
 	}
@@ -0,0 +1,491 @@
+package plan
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+var (
+	// ErrJSONTableMissingValue is returned when a JSON_TABLE column with
+	// ERROR ON EMPTY finds no value.
+	ErrJSONTableMissingValue = errors.NewKind("Missing value for JSON_TABLE column '%s'")
+	// ErrJSONTableInvalidValue is returned when a JSON_TABLE column with
+	// ERROR ON ERROR finds a value that can't be stored in it.
+	ErrJSONTableInvalidValue = errors.NewKind("Invalid value for JSON_TABLE column '%s': %s")
+)
+
+// JSONTableOnResponse is what a JSON_TABLE column produces when its path
+// finds no value (ON EMPTY) or a value that can't be stored in the column
+// (ON ERROR): NULL, the given default or an error.
+type JSONTableOnResponse struct {
+	Error   bool
+	Default *sql.JSONDocument
+}
+
+// JSONTableColumn is a column of a JSON_TABLE. It's either a column whose
+// value is found with a path, a FOR ORDINALITY column, an EXISTS PATH column
+// or a NESTED PATH with its own columns.
+type JSONTableColumn struct {
+	Name          string
+	Type          sql.Type
+	Path          *sql.JSONPath
+	ForOrdinality bool
+	Exists        bool
+	OnEmpty       JSONTableOnResponse
+	OnError       JSONTableOnResponse
+	// Nested holds the columns of a NESTED PATH, which is Path.
+	Nested []JSONTableColumn
+}
+
+// IsNested returns whether the column is a NESTED PATH.
+func (c JSONTableColumn) IsNested() bool {
+	return c.Nested != nil
+}
+
+func (c JSONTableColumn) String() string {
+	switch {
+	case c.IsNested():
+		var cols = make([]string, len(c.Nested))
+		for i, col := range c.Nested {
+			cols[i] = col.String()
+		}
+		return fmt.Sprintf("NESTED PATH '%s' COLUMNS (%s)", c.Path, strings.Join(cols, ", "))
+	case c.ForOrdinality:
+		return fmt.Sprintf("%s FOR ORDINALITY", c.Name)
+	case c.Exists:
+		return fmt.Sprintf("%s %s EXISTS PATH '%s'", c.Name, c.Type, c.Path)
+	default:
+		return fmt.Sprintf("%s %s PATH '%s'", c.Name, c.Type, c.Path)
+	}
+}
+
+// JSONTable is a table whose rows are generated from a JSON document, as
+// JSON_TABLE does. Every value found by the row path generates a row, whose
+// columns are found with their own paths relative to that value. The table
+// can be preceded by other tables in the FROM clause, which are its child, so
+// the document can refer to their columns. Then, the rows of the child are
+// returned along with each of the rows generated for them. If the table is
+// the right side of a LEFT JOIN, only the generated rows that satisfy the
+// join condition are returned, and the rows of the child that have none are
+// returned along with NULL columns.
+type JSONTable struct {
+	Child   sql.Node
+	Data    sql.Expression
+	Path    *sql.JSONPath
+	Columns []JSONTableColumn
+	// Outer is whether the table is the right side of a LEFT JOIN with its
+	// child, on Cond, which is nil if there's no condition.
+	Outer bool
+	Cond  sql.Expression
+	name  string
+}
+
+var _ sql.Node = (*JSONTable)(nil)
+var _ sql.Expressioner = (*JSONTable)(nil)
+var _ sql.Nameable = (*JSONTable)(nil)
+
+// NewJSONTable creates a new JSONTable node with the given name. The child is
+// nil if no tables precede it.
+func NewJSONTable(child sql.Node, name string, data sql.Expression, path *sql.JSONPath, columns []JSONTableColumn) *JSONTable {
+	return &JSONTable{
+		Child:   child,
+		Data:    data,
+		Path:    path,
+		Columns: columns,
+		name:    name,
+	}
+}
+
+// AsLeftJoin returns a copy of the table that is the right side of a LEFT
+// JOIN with its child, on the given condition.
+func (t *JSONTable) AsLeftJoin(cond sql.Expression) *JSONTable {
+	nt := *t
+	nt.Outer = true
+	nt.Cond = cond
+	return &nt
+}
+
+// Name implements the sql.Nameable interface.
+func (t *JSONTable) Name() string {
+	return t.name
+}
+
+// Resolved implements the sql.Node interface.
+func (t *JSONTable) Resolved() bool {
+	return (t.Child == nil || t.Child.Resolved()) && t.Data.Resolved() && (t.Cond == nil || t.Cond.Resolved())
+}
+
+// Children implements the sql.Node interface.
+func (t *JSONTable) Children() []sql.Node {
+	if t.Child == nil {
+		return nil
+	}
+	return []sql.Node{t.Child}
+}
+
+// WithChildren implements the sql.Node interface.
+func (t *JSONTable) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != len(t.Children()) {
+		return nil, sql.ErrInvalidChildrenNumber.New(t, len(children), len(t.Children()))
+	}
+
+	nt := *t
+	if len(children) > 0 {
+		nt.Child = children[0]
+	}
+	return &nt, nil
+}
+
+// Expressions implements the sql.Expressioner interface.
+func (t *JSONTable) Expressions() []sql.Expression {
+	if t.Cond == nil {
+		return []sql.Expression{t.Data}
+	}
+	return []sql.Expression{t.Data, t.Cond}
+}
+
+// WithExpressions implements the sql.Expressioner interface.
+func (t *JSONTable) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	if len(exprs) != len(t.Expressions()) {
+		return nil, sql.ErrInvalidChildrenNumber.New(t, len(exprs), len(t.Expressions()))
+	}
+
+	nt := *t
+	nt.Data = exprs[0]
+	if t.Cond != nil {
+		nt.Cond = exprs[1]
+	}
+	return &nt, nil
+}
+
+// Schema implements the sql.Node interface. It's the schema of the child
+// followed by the columns of the table.
+func (t *JSONTable) Schema() sql.Schema {
+	var schema sql.Schema
+	if t.Child != nil {
+		schema = append(schema, t.Child.Schema()...)
+	}
+	return t.appendColumns(schema, t.Columns)
+}
+
+func (t *JSONTable) appendColumns(schema sql.Schema, columns []JSONTableColumn) sql.Schema {
+	for _, col := range columns {
+		if col.IsNested() {
+			schema = t.appendColumns(schema, col.Nested)
+			continue
+		}
+
+		schema = append(schema, &sql.Column{
+			Name:     col.Name,
+			Type:     col.Type,
+			Source:   t.name,
+			Nullable: t.Outer || !col.ForOrdinality && !col.Exists,
+		})
+	}
+	return schema
+}
+
+// RowIter implements the sql.Node interface.
+func (t *JSONTable) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	span, ctx := ctx.Span("plan.JSONTable")
+
+	var child sql.RowIter
+	if t.Child != nil {
+		var err error
+		child, err = t.Child.RowIter(ctx, row)
+		if err != nil {
+			span.Finish()
+			return nil, err
+		}
+	} else {
+		child = sql.RowsToRowIter(row)
+	}
+
+	return sql.NewSpanIter(span, &jsonTableIter{
+		ctx:         ctx,
+		table:       t,
+		child:       child,
+		appendToRow: t.Child != nil,
+	}), nil
+}
+
+func (t *JSONTable) String() string {
+	var cols = make([]string, len(t.Columns))
+	for i, col := range t.Columns {
+		cols[i] = col.String()
+	}
+
+	tp := sql.NewTreePrinter()
+	switch {
+	case t.Cond != nil:
+		_ = tp.WriteNode("LeftJSONTable(%s, %s, '%s' COLUMNS (%s)) ON %s", t.name, t.Data, t.Path, strings.Join(cols, ", "), t.Cond)
+	case t.Outer:
+		_ = tp.WriteNode("LeftJSONTable(%s, %s, '%s' COLUMNS (%s))", t.name, t.Data, t.Path, strings.Join(cols, ", "))
+	default:
+		_ = tp.WriteNode("JSONTable(%s, %s, '%s' COLUMNS (%s))", t.name, t.Data, t.Path, strings.Join(cols, ", "))
+	}
+	if t.Child != nil {
+		_ = tp.WriteChildren(t.Child.String())
+	}
+	return tp.String()
+}
+
+// generateRows returns the rows of the table for the given document.
+func (t *JSONTable) generateRows(doc sql.JSONDocument) ([]interface{}, error) {
+	var rows []interface{}
+	for i, v := range t.Path.Extract(doc.Val) {
+		generated, err := jsonTableRows(v, t.Columns, int64(i+1))
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range generated {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+// jsonTableWidth returns the number of values the given columns generate.
+func jsonTableWidth(columns []JSONTableColumn) int {
+	var n int
+	for _, col := range columns {
+		if col.IsNested() {
+			n += jsonTableWidth(col.Nested)
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+// jsonTableRows returns the rows the given columns generate for a value found
+// by the path of their parent, which is at the given position among the values
+// found. Every value found by a nested path generates a row, with the columns
+// of the sibling nested paths set to NULL. If nested paths find no values, a
+// single row is generated with their columns set to NULL.
+func jsonTableRows(v interface{}, columns []JSONTableColumn, ordinality int64) ([]sql.Row, error) {
+	width := jsonTableWidth(columns)
+	base := make(sql.Row, width)
+
+	type nestedRows struct {
+		offset int
+		rows   []sql.Row
+	}
+	var nested []nestedRows
+
+	var pos int
+	for _, col := range columns {
+		if col.IsNested() {
+			var rows []sql.Row
+			for i, nv := range col.Path.Extract(v) {
+				generated, err := jsonTableRows(nv, col.Nested, int64(i+1))
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, generated...)
+			}
+
+			nested = append(nested, nestedRows{pos, rows})
+			pos += jsonTableWidth(col.Nested)
+			continue
+		}
+
+		val, err := jsonTableValue(v, col, ordinality)
+		if err != nil {
+			return nil, err
+		}
+		base[pos] = val
+		pos++
+	}
+
+	var result []sql.Row
+	for _, n := range nested {
+		for _, nrow := range n.rows {
+			row := make(sql.Row, width)
+			copy(row, base)
+			copy(row[n.offset:], nrow)
+			result = append(result, row)
+		}
+	}
+
+	if len(result) == 0 {
+		result = append(result, base)
+	}
+
+	return result, nil
+}
+
+// jsonTableValue returns the value of a column for the given value found by
+// the path of its parent.
+func jsonTableValue(v interface{}, col JSONTableColumn, ordinality int64) (interface{}, error) {
+	if col.ForOrdinality {
+		return col.Type.Convert(ordinality)
+	}
+
+	values := col.Path.Extract(v)
+	if col.Exists {
+		var exists int64
+		if len(values) > 0 {
+			exists = 1
+		}
+		return col.Type.Convert(exists)
+	}
+
+	switch {
+	case len(values) == 0:
+		if col.OnEmpty.Error {
+			return nil, ErrJSONTableMissingValue.New(col.Name)
+		}
+		if col.OnEmpty.Default == nil {
+			return nil, nil
+		}
+		return jsonTableConvert(col, col.OnEmpty.Default.Val)
+	case len(values) > 1:
+		return jsonTableOnError(col, fmt.Errorf("more than one value found"))
+	}
+
+	val, err := jsonTableConvert(col, values[0])
+	if err != nil {
+		return jsonTableOnError(col, err)
+	}
+	return val, nil
+}
+
+func jsonTableOnError(col JSONTableColumn, err error) (interface{}, error) {
+	if col.OnError.Error {
+		return nil, ErrJSONTableInvalidValue.New(col.Name, err)
+	}
+	if col.OnError.Default == nil {
+		return nil, nil
+	}
+	return jsonTableConvert(col, col.OnError.Default.Val)
+}
+
+// jsonTableConvert converts a JSON value to the type of a column. Objects and
+// arrays can only be stored in JSON columns, and JSON strings are stored
+// unquoted in the other ones.
+func jsonTableConvert(col JSONTableColumn, v interface{}) (interface{}, error) {
+	if col.Type == sql.JSON {
+		return sql.JSONDocument{Val: v}, nil
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}, []interface{}:
+		return nil, fmt.Errorf("can't store an array or an object in a scalar column")
+	case bool:
+		if sql.IsText(col.Type) {
+			return col.Type.Convert(sql.JSONDocument{Val: v}.String())
+		}
+		if v {
+			return col.Type.Convert(1)
+		}
+		return col.Type.Convert(0)
+	default:
+		return col.Type.Convert(v)
+	}
+}
+
+type jsonTableIter struct {
+	ctx         *sql.Context
+	table       *JSONTable
+	child       sql.RowIter
+	appendToRow bool
+
+	gen sql.Generator
+	row sql.Row
+}
+
+func (i *jsonTableIter) Next() (sql.Row, error) {
+	for {
+		if i.gen == nil {
+			var err error
+			i.row, err = i.child.Next()
+			if err != nil {
+				return nil, err
+			}
+
+			rows, err := i.generate(i.row)
+			if err != nil {
+				return nil, err
+			}
+			i.gen = sql.NewArrayGenerator(rows)
+		}
+
+		generated, err := i.gen.Next()
+		if err != nil {
+			if err == io.EOF {
+				if err := i.gen.Close(); err != nil {
+					return nil, err
+				}
+
+				i.gen = nil
+				continue
+			}
+			return nil, err
+		}
+
+		if !i.appendToRow {
+			return generated.(sql.Row), nil
+		}
+		return i.row.Append(generated.(sql.Row)), nil
+	}
+}
+
+// generate returns the rows generated for the given row of the child.
+func (i *jsonTableIter) generate(row sql.Row) ([]interface{}, error) {
+	rows, err := i.generateRows(row)
+	if err != nil || !i.table.Outer {
+		return rows, err
+	}
+
+	if i.table.Cond != nil {
+		var matched []interface{}
+		for _, generated := range rows {
+			ok, err := sql.EvaluateCondition(i.ctx, i.table.Cond, row.Append(generated.(sql.Row)))
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				matched = append(matched, generated)
+			}
+		}
+		rows = matched
+	}
+
+	if len(rows) == 0 {
+		rows = append(rows, make(sql.Row, jsonTableWidth(i.table.Columns)))
+	}
+	return rows, nil
+}
+
+func (i *jsonTableIter) generateRows(row sql.Row) ([]interface{}, error) {
+	v, err := i.table.Data.Eval(i.ctx, row)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	doc, err := sql.JSON.Convert(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.table.generateRows(doc.(sql.JSONDocument))
+}
+
+func (i *jsonTableIter) Close() error {
+	if i.gen != nil {
+		if err := i.gen.Close(); err != nil {
+			_ = i.child.Close()
+			return err
+		}
+	}
+
+	return i.child.Close()
+}
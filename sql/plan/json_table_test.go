@@ -0,0 +1,258 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+func mustJSONPath(path string) *sql.JSONPath {
+	p, err := sql.ParseJSONPath(path)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func TestJSONTableRowIter(t *testing.T) {
+	require := require.New(t)
+
+	zero := sql.MustJSON(`0`)
+	table := NewJSONTable(
+		nil,
+		"jt",
+		expression.NewLiteral(`[{"a": 1, "b": [11, 111]}, {"a": "x", "c": {"d": true}}, {"a": 3, "b": 33}]`, sql.LongText),
+		mustJSONPath("$[*]"),
+		[]JSONTableColumn{
+			{Name: "id", Type: sql.Uint32, ForOrdinality: true},
+			{Name: "a", Type: sql.Int64, Path: mustJSONPath("$.a"), OnError: JSONTableOnResponse{Default: &zero}},
+			{Name: "has_c", Type: sql.Int8, Path: mustJSONPath("$.c"), Exists: true},
+			{Name: "c", Type: sql.JSON, Path: mustJSONPath("$.c")},
+			{Name: "d", Type: sql.LongText, Path: mustJSONPath("$.c.d")},
+			{
+				Path: mustJSONPath("$.b[*]"),
+				Nested: []JSONTableColumn{
+					{Name: "bid", Type: sql.Uint32, ForOrdinality: true},
+					{Name: "b", Type: sql.Int64, Path: mustJSONPath("$")},
+				},
+			},
+		},
+	)
+
+	require.Equal(sql.Schema{
+		{Name: "id", Type: sql.Uint32, Source: "jt"},
+		{Name: "a", Type: sql.Int64, Source: "jt", Nullable: true},
+		{Name: "has_c", Type: sql.Int8, Source: "jt"},
+		{Name: "c", Type: sql.JSON, Source: "jt", Nullable: true},
+		{Name: "d", Type: sql.LongText, Source: "jt", Nullable: true},
+		{Name: "bid", Type: sql.Uint32, Source: "jt"},
+		{Name: "b", Type: sql.Int64, Source: "jt", Nullable: true},
+	}, table.Schema())
+
+	iter, err := table.RowIter(sql.NewEmptyContext(), nil)
+	require.NoError(err)
+
+	rows, err := sql.RowIterToRows(iter)
+	require.NoError(err)
+
+	expected := []sql.Row{
+		{uint32(1), int64(1), int8(0), nil, nil, uint32(1), int64(11)},
+		{uint32(1), int64(1), int8(0), nil, nil, uint32(2), int64(111)},
+		{uint32(2), int64(0), int8(1), sql.MustJSON(`{"d": true}`), "true", nil, nil},
+		{uint32(3), int64(3), int8(0), nil, nil, nil, nil},
+	}
+
+	require.Equal(expected, rows)
+}
+
+func TestJSONTableSiblingNestedPaths(t *testing.T) {
+	require := require.New(t)
+
+	table := NewJSONTable(
+		nil,
+		"jt",
+		expression.NewLiteral(`{"a": 1, "b": [2, 3], "c": [4]}`, sql.LongText),
+		mustJSONPath("$"),
+		[]JSONTableColumn{
+			{Name: "a", Type: sql.Int64, Path: mustJSONPath("$.a")},
+			{Path: mustJSONPath("$.b[*]"), Nested: []JSONTableColumn{{Name: "b", Type: sql.Int64, Path: mustJSONPath("$")}}},
+			{Path: mustJSONPath("$.c[*]"), Nested: []JSONTableColumn{{Name: "c", Type: sql.Int64, Path: mustJSONPath("$")}}},
+		},
+	)
+
+	iter, err := table.RowIter(sql.NewEmptyContext(), nil)
+	require.NoError(err)
+
+	rows, err := sql.RowIterToRows(iter)
+	require.NoError(err)
+
+	expected := []sql.Row{
+		{int64(1), int64(2), nil},
+		{int64(1), int64(3), nil},
+		{int64(1), nil, int64(4)},
+	}
+
+	require.Equal(expected, rows)
+}
+
+func TestJSONTableLateral(t *testing.T) {
+	require := require.New(t)
+
+	child := newFakeNode(
+		sql.Schema{
+			{Name: "id", Type: sql.Int64, Source: "t"},
+			{Name: "js", Type: sql.JSON, Source: "t"},
+		},
+		sql.RowsToRowIter(
+			sql.Row{int64(1), sql.MustJSON(`[1, 2]`)},
+			sql.Row{int64(2), nil},
+			sql.Row{int64(3), sql.MustJSON(`[]`)},
+			sql.Row{int64(4), sql.MustJSON(`[3]`)},
+		),
+	)
+
+	table := NewJSONTable(
+		child,
+		"jt",
+		expression.NewGetFieldWithTable(1, sql.JSON, "t", "js", true),
+		mustJSONPath("$[*]"),
+		[]JSONTableColumn{
+			{Name: "x", Type: sql.Int64, Path: mustJSONPath("$")},
+		},
+	)
+
+	require.Len(table.Schema(), 3)
+
+	iter, err := table.RowIter(sql.NewEmptyContext(), nil)
+	require.NoError(err)
+
+	rows, err := sql.RowIterToRows(iter)
+	require.NoError(err)
+
+	expected := []sql.Row{
+		{int64(1), sql.MustJSON(`[1, 2]`), int64(1)},
+		{int64(1), sql.MustJSON(`[1, 2]`), int64(2)},
+		{int64(4), sql.MustJSON(`[3]`), int64(3)},
+	}
+
+	require.Equal(expected, rows)
+}
+
+func TestJSONTableLeftJoin(t *testing.T) {
+	require := require.New(t)
+
+	child := newFakeNode(
+		sql.Schema{
+			{Name: "id", Type: sql.Int64, Source: "t"},
+			{Name: "js", Type: sql.JSON, Source: "t"},
+		},
+		sql.RowsToRowIter(
+			sql.Row{int64(1), sql.MustJSON(`[1, 2]`)},
+			sql.Row{int64(2), nil},
+			sql.Row{int64(3), sql.MustJSON(`[4]`)},
+		),
+	)
+
+	table := NewJSONTable(
+		child,
+		"jt",
+		expression.NewGetFieldWithTable(1, sql.JSON, "t", "js", true),
+		mustJSONPath("$[*]"),
+		[]JSONTableColumn{
+			{Name: "x", Type: sql.Int64, Path: mustJSONPath("$")},
+		},
+	).AsLeftJoin(expression.NewGreaterThan(
+		expression.NewGetFieldWithTable(2, sql.Int64, "jt", "x", true),
+		expression.NewGetFieldWithTable(0, sql.Int64, "t", "id", false),
+	))
+
+	require.True(table.Schema()[2].Nullable)
+
+	iter, err := table.RowIter(sql.NewEmptyContext(), nil)
+	require.NoError(err)
+
+	rows, err := sql.RowIterToRows(iter)
+	require.NoError(err)
+
+	expected := []sql.Row{
+		{int64(1), sql.MustJSON(`[1, 2]`), int64(2)},
+		{int64(2), nil, nil},
+		{int64(3), sql.MustJSON(`[4]`), int64(4)},
+	}
+
+	require.Equal(expected, rows)
+}
+
+func TestJSONTableErrors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		data   string
+		column JSONTableColumn
+		err    bool
+	}{
+		{
+			"missing value",
+			`[{}]`,
+			JSONTableColumn{Name: "x", Type: sql.Int64, Path: mustJSONPath("$.x"), OnEmpty: JSONTableOnResponse{Error: true}},
+			true,
+		},
+		{
+			"missing value with default",
+			`[{}]`,
+			JSONTableColumn{Name: "x", Type: sql.Int64, Path: mustJSONPath("$.x"), OnError: JSONTableOnResponse{Error: true}},
+			false,
+		},
+		{
+			"object in scalar column",
+			`[{"x": {}}]`,
+			JSONTableColumn{Name: "x", Type: sql.Int64, Path: mustJSONPath("$.x"), OnError: JSONTableOnResponse{Error: true}},
+			true,
+		},
+		{
+			"invalid number",
+			`[{"x": "a"}]`,
+			JSONTableColumn{Name: "x", Type: sql.Int64, Path: mustJSONPath("$.x"), OnError: JSONTableOnResponse{Error: true}},
+			true,
+		},
+		{
+			"invalid number with default",
+			`[{"x": "a"}]`,
+			JSONTableColumn{Name: "x", Type: sql.Int64, Path: mustJSONPath("$.x")},
+			false,
+		},
+		{
+			"several values",
+			`[{"x": [1, 2]}]`,
+			JSONTableColumn{Name: "x", Type: sql.Int64, Path: mustJSONPath("$.x[*]"), OnError: JSONTableOnResponse{Error: true}},
+			true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			table := NewJSONTable(
+				nil,
+				"jt",
+				expression.NewLiteral(tt.data, sql.LongText),
+				mustJSONPath("$[*]"),
+				[]JSONTableColumn{tt.column},
+			)
+
+			iter, err := table.RowIter(sql.NewEmptyContext(), nil)
+			require.NoError(err)
+
+			rows, err := sql.RowIterToRows(iter)
+			if tt.err {
+				require.Error(err)
+			} else {
+				require.NoError(err)
+				require.Equal([]sql.Row{{nil}}, rows)
+			}
+		})
+	}
+}
@@ -135,6 +135,19 @@ func setSystemVar(ctx *sql.Context, sysVar *expression.SystemVar, right sql.Expr
 	}
 	typ = sysVar.Type()
 
+	if strings.EqualFold(varName, "time_zone") {
+		if err = validateTimeZone(ctx, value); err != nil {
+			return nil, err
+		}
+	}
+
+	if sysVar.Global {
+		if err = sql.SetGlobal(varName, value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	}
+
 	// TODO: differentiate between system and user vars here
 	err = ctx.Set(ctx, varName, typ, value)
 	if err != nil {
@@ -144,6 +157,17 @@ func setSystemVar(ctx *sql.Context, sysVar *expression.SystemVar, right sql.Expr
 	return value, nil
 }
 
+// validateTimeZone returns an error if the given value is not a valid time
+// zone for the time_zone variable.
+func validateTimeZone(ctx *sql.Context, value interface{}) error {
+	tz, err := sql.LongText.Convert(value)
+	if err != nil || tz == nil {
+		return sql.ErrUnknownTimeZone.New(value)
+	}
+	_, err = sql.ResolveTimeZone(ctx, tz.(string))
+	return err
+}
+
 // Schema implements the sql.Node interface.
 func (s *Set) Schema() sql.Schema {
 	return nil
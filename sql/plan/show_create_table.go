@@ -151,6 +151,14 @@ func (i *showCreateTablesIter) produceCreateTableStatement(table sql.Table) (str
 	for i, col := range schema {
 		stmt := fmt.Sprintf("  `%s` %s", col.Name, strings.ToLower(col.Type.String()))
 
+		if col.Generated != nil {
+			kind := "STORED"
+			if col.Virtual {
+				kind = "VIRTUAL"
+			}
+			stmt = fmt.Sprintf("%s GENERATED ALWAYS AS (%s) %s", stmt, col.Generated.Expression.String(), kind)
+		}
+
 		if !col.Nullable {
 			stmt = fmt.Sprintf("%s NOT NULL", stmt)
 		}
@@ -195,12 +203,14 @@ func (i *showCreateTablesIter) produceCreateTableStatement(table sql.Table) (str
 			}
 		}
 
-		unique := ""
+		kind := ""
 		if index.IsUnique() {
-			unique = "UNIQUE "
+			kind = "UNIQUE "
+		} else if _, ok := index.(sql.FullTextIndex); ok {
+			kind = "FULLTEXT "
 		}
 
-		key := fmt.Sprintf("  %sKEY `%s` (%s)", unique, index.ID(), strings.Join(indexCols, ","))
+		key := fmt.Sprintf("  %sKEY `%s` (%s)", kind, index.ID(), strings.Join(indexCols, ","))
 		if index.Comment() != "" {
 			key = fmt.Sprintf("%s COMMENT '%s'", key, index.Comment())
 		}
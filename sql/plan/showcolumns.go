@@ -95,6 +95,14 @@ func (s *ShowColumns) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error
 			defaultVal = col.Default.String()
 		}
 
+		var extra string
+		if col.Generated != nil {
+			extra = "STORED GENERATED"
+			if col.Virtual {
+				extra = "VIRTUAL GENERATED"
+			}
+		}
+
 		// TODO: rather than lower-casing here, we should lower-case the String() method of types
 		if s.Full {
 			row = sql.Row{
@@ -104,7 +112,7 @@ func (s *ShowColumns) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error
 				null,
 				key, // Key
 				defaultVal,
-				"",          // Extra
+				extra,       // Extra
 				"",          // Privileges
 				col.Comment, // Comment
 			}
@@ -115,7 +123,7 @@ func (s *ShowColumns) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error
 				null,
 				key,
 				defaultVal,
-				"", // Extra
+				extra,
 			}
 		}
 
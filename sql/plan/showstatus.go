@@ -0,0 +1,108 @@
+package plan
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+)
+
+// ShowStatus is a node that shows the global or session status variables.
+type ShowStatus struct {
+	*sql.StatusVariables
+	Global  bool
+	pattern string
+}
+
+// NewShowStatus returns a new ShowStatus reference.
+// global is whether the global values should be shown instead of the ones of
+// the current session.
+// like is a "like pattern". If like is an empty string it will return all variables.
+func NewShowStatus(global bool, like string) *ShowStatus {
+	return &ShowStatus{
+		Global:  global,
+		pattern: like,
+	}
+}
+
+// Resolved implements sql.Node interface. The function always returns true.
+func (s *ShowStatus) Resolved() bool {
+	return true
+}
+
+// WithChildren implements the Node interface.
+func (s *ShowStatus) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, sql.ErrInvalidChildrenNumber.New(s, len(children), 0)
+	}
+
+	return s, nil
+}
+
+// String implements the fmt.Stringer interface.
+func (s *ShowStatus) String() string {
+	var scope, like string
+	if s.Global {
+		scope = "GLOBAL "
+	}
+	if s.pattern != "" {
+		like = fmt.Sprintf(" LIKE '%s'", s.pattern)
+	}
+	return fmt.Sprintf("SHOW %sSTATUS%s", scope, like)
+}
+
+// Schema returns a new Schema reference for "SHOW STATUS" query.
+func (*ShowStatus) Schema() sql.Schema {
+	return sql.Schema{
+		&sql.Column{Name: "Variable_name", Type: sql.LongText, Nullable: false},
+		&sql.Column{Name: "Value", Type: sql.LongText, Nullable: true},
+	}
+}
+
+// Children implements sql.Node interface. The function always returns nil.
+func (*ShowStatus) Children() []sql.Node { return nil }
+
+// RowIter implements the sql.Node interface.
+// The function returns an iterator for filtered status variables (based on
+// like pattern, matched case-insensitively), sorted by name.
+func (s *ShowStatus) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	var (
+		rows []sql.Row
+		like sql.Expression
+	)
+	if s.pattern != "" {
+		like = expression.NewLike(
+			expression.NewGetField(0, sql.LongText, "", false),
+			expression.NewGetField(1, sql.LongText, "", false),
+		)
+	}
+
+	var status map[string]int64
+	if s.Global {
+		status = s.GlobalStatus()
+	} else {
+		status = s.SessionStatus(ctx.ID())
+	}
+
+	for k, v := range status {
+		if like != nil {
+			b, err := like.Eval(ctx, sql.NewRow(strings.ToLower(k), strings.ToLower(s.pattern)))
+			if err != nil {
+				return nil, err
+			}
+			if !b.(bool) {
+				continue
+			}
+		}
+
+		rows = append(rows, sql.NewRow(k, fmt.Sprint(v)))
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i][0].(string) < rows[j][0].(string)
+	})
+
+	return sql.RowsToRowIter(rows...), nil
+}
@@ -0,0 +1,37 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+func TestShowStatus(t *testing.T) {
+	require := require.New(t)
+
+	ctx := sql.NewEmptyContext()
+	status := sql.NewStatusVariables()
+	status.IncrementStatus(ctx.ID(), "Com_select", 2)
+	status.IncrementStatus(ctx.ID()+1, "Com_select", 3)
+	status.IncrementStatus(ctx.ID(), "Com_insert", 1)
+
+	node := NewShowStatus(false, "com_%e%t")
+	node.StatusVariables = status
+	require.True(node.Resolved())
+
+	rows, err := sql.NodeToRows(ctx, node)
+	require.NoError(err)
+	require.Equal([]sql.Row{
+		{"Com_insert", "1"},
+		{"Com_select", "2"},
+	}, rows)
+
+	node = NewShowStatus(true, "Com_select")
+	node.StatusVariables = status
+
+	rows, err = sql.NodeToRows(ctx, node)
+	require.NoError(err)
+	require.Equal([]sql.Row{{"Com_select", "5"}}, rows)
+}
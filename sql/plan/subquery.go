@@ -159,6 +159,16 @@ func prependRowInPlan(row sql.Row) func(n sql.Node) (sql.Node, error) {
 				UnaryNode: UnaryNode{Child: n},
 				row:       row,
 			}, nil
+		case *JSONTable:
+			// A JSON_TABLE with a child appends its rows to the ones of the
+			// child, which are already prepended.
+			if n.Child != nil {
+				return n, nil
+			}
+			return &prependNode{
+				UnaryNode: UnaryNode{Child: n},
+				row:       row,
+			}, nil
 		default:
 			return n, nil
 		}
@@ -227,7 +237,7 @@ func (s *Subquery) evalMultiple(ctx *sql.Context, row sql.Row) ([]interface{}, e
 }
 
 // HashMultiple returns all rows returned by a subquery, backed by a sql.KeyValueCache. Keys are constructed using the
-// 64-bit hash of the values stored.
+// 64-bit hash of the values stored, where strings are hashed by their sort key in the collation of the subquery type.
 func (s *Subquery) HashMultiple(ctx *sql.Context, row sql.Row) (sql.KeyValueCache, error) {
 	s.cacheMu.Lock()
 	cached := s.resultsCached && s.hashCache != nil
@@ -246,7 +256,7 @@ func (s *Subquery) HashMultiple(ctx *sql.Context, row sql.Row) (sql.KeyValueCach
 		defer s.cacheMu.Unlock()
 		if !s.resultsCached || s.hashCache == nil {
 			hashCache, disposeFn := ctx.Memory.NewHistoryCache()
-			err = putAllRows(hashCache, result)
+			err = putAllRows(hashCache, result, s.Type())
 			if err != nil {
 				return nil, err
 			}
@@ -256,12 +266,12 @@ func (s *Subquery) HashMultiple(ctx *sql.Context, row sql.Row) (sql.KeyValueCach
 	}
 
 	cache := sql.NewMapCache()
-	return cache, putAllRows(cache, result)
+	return cache, putAllRows(cache, result, s.Type())
 }
 
-func putAllRows(cache sql.KeyValueCache, vals []interface{}) error {
+func putAllRows(cache sql.KeyValueCache, vals []interface{}, typ sql.Type) error {
 	for _, val := range vals {
-		rowKey, err := sql.HashOf(sql.NewRow(val))
+		rowKey, err := sql.HashOfTyped(sql.NewRow(val), []sql.Type{typ})
 		if err != nil {
 			return err
 		}
@@ -6,6 +6,7 @@ import (
 	"gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
 )
 
 var ErrUpdateNotSupported = errors.NewKind("table doesn't support UPDATE")
@@ -77,7 +78,10 @@ func (u *updateIter) Next() (sql.Row, error) {
 	oldRow, newRow := oldAndNewRow[:len(oldAndNewRow)/2], oldAndNewRow[len(oldAndNewRow)/2:]
 	if equals, err := oldRow.Equals(newRow, u.schema); err == nil {
 		if !equals {
-			err = u.updater.Update(u.ctx, oldRow, newRow)
+			// TIMESTAMP values are stored in UTC
+			err = u.updater.Update(u.ctx,
+				sql.TimestampsFromSession(u.ctx, u.schema, oldRow),
+				sql.TimestampsFromSession(u.ctx, u.schema, newRow))
 			if err != nil {
 				return nil, err
 			}
@@ -107,6 +111,83 @@ func applyUpdateExpressions(ctx *sql.Context, updateExprs []sql.Expression, row
 	return prev, nil
 }
 
+// applyGeneratedColumns computes again the values of the generated columns of the given row, which has the schema of
+// its table.
+func applyGeneratedColumns(ctx *sql.Context, schema sql.Schema, row sql.Row) (sql.Row, error) {
+	var newRow sql.Row
+	for i, col := range schema {
+		if col.Generated == nil {
+			continue
+		}
+		if newRow == nil {
+			newRow = row.Copy()
+		}
+
+		val, err := col.Generated.Eval(ctx, newRow)
+		if err != nil {
+			return nil, err
+		}
+		newRow[i] = val
+	}
+
+	if newRow == nil {
+		return row, nil
+	}
+	return newRow, nil
+}
+
+// convertUpdatedColumns converts the values assigned by the given update expressions, and those of the generated
+// columns, to the types of their columns, in order to write the row with the given number. The row has the schema of
+// the table, while the fields of the expressions are offset by the given number of values from an outer scope. See
+// sql.ConvertToColumn.
+func convertUpdatedColumns(ctx *sql.Context, schema sql.Schema, rowNum int, updateExprs []sql.Expression, offset int, row sql.Row) (sql.Row, error) {
+	updated := make([]bool, len(schema))
+	for i, col := range schema {
+		updated[i] = col.Generated != nil
+	}
+	for _, e := range updateExprs {
+		if sf, ok := e.(*expression.SetField); ok {
+			if gf, ok := sf.Left.(*expression.GetField); ok && gf.Index() >= offset && gf.Index()-offset < len(schema) {
+				updated[gf.Index()-offset] = true
+			}
+		}
+	}
+
+	newRow := row.Copy()
+	for i, col := range schema {
+		if !updated[i] {
+			continue
+		}
+		val, err := sql.ConvertToColumn(ctx, col, rowNum, row[i])
+		if err != nil {
+			return nil, err
+		}
+		newRow[i] = val
+	}
+	return newRow, nil
+}
+
+// checkDivisionByZero returns an error if a division by zero was reported since the session had the given number of
+// warnings, when the SQL mode makes it an error to write the result of one to a table.
+func checkDivisionByZero(ctx *sql.Context, warnings uint16) error {
+	n := int(ctx.WarningCount()) - int(warnings)
+	if n <= 0 {
+		return nil
+	}
+
+	mode := sql.LoadSqlMode(ctx)
+	if !mode.Strict() || !mode.Has(sql.SqlMode_ErrorForDivisionByZero) {
+		return nil
+	}
+
+	for _, w := range ctx.Warnings()[:n] {
+		if w.Code == sql.ERDivisionByZero {
+			return sql.ErrDivisionByZero.New()
+		}
+	}
+	return nil
+}
+
 func (u *updateIter) Close() error {
 	if !u.closed {
 		u.closed = true
@@ -93,6 +93,7 @@ type updateSourceIter struct {
 	updateExprs []sql.Expression
 	tableSchema sql.Schema
 	ctx         *sql.Context
+	rowNumber   int
 }
 
 func (u *updateSourceIter) Next() (sql.Row, error) {
@@ -101,20 +102,37 @@ func (u *updateSourceIter) Next() (sql.Row, error) {
 		return nil, err
 	}
 
+	u.rowNumber++
+	warnings := u.ctx.WarningCount()
 	newRow, err := applyUpdateExpressions(u.ctx, u.updateExprs, oldRow)
 	if err != nil {
 		return nil, err
 	}
 
+	if err = checkDivisionByZero(u.ctx, warnings); err != nil {
+		return nil, err
+	}
+
 	// Reduce the row to the length of the schema. The length can differ when some update values come from an outer
 	// scope, which will be the first N values in the row.
 	// TODO: handle this in the analyzer instead?
 	expectedSchemaLen := len(u.tableSchema)
+	offset := len(oldRow) - expectedSchemaLen
 	if expectedSchemaLen < len(oldRow) {
 		oldRow = oldRow[len(oldRow)-expectedSchemaLen:]
 		newRow = newRow[len(newRow)-expectedSchemaLen:]
 	}
 
+	newRow, err = applyGeneratedColumns(u.ctx, u.tableSchema, newRow)
+	if err != nil {
+		return nil, err
+	}
+
+	newRow, err = convertUpdatedColumns(u.ctx, u.tableSchema, u.rowNumber, u.updateExprs, offset, newRow)
+	if err != nil {
+		return nil, err
+	}
+
 	return oldRow.Append(newRow), nil
 }
 
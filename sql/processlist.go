@@ -85,10 +85,19 @@ type Process struct {
 	Progress   map[string]TableProgress
 	StartedAt  time.Time
 	Kill       context.CancelFunc
+	// Killed is set when the process was cancelled for running longer than
+	// its maximum execution time.
+	Killed bool
+	timer  *time.Timer
 }
 
 // Done needs to be called when this process has finished.
-func (p *Process) Done() { p.Kill() }
+func (p *Process) Done() {
+	if p.timer != nil {
+		p.timer.Stop()
+	}
+	p.Kill()
+}
 
 // Seconds returns the number of seconds this process has been running.
 func (p *Process) Seconds() uint64 {
@@ -112,6 +121,10 @@ func NewProcessList() *ProcessList {
 // ErrPidAlreadyUsed is returned when the pid is already registered.
 var ErrPidAlreadyUsed = errors.NewKind("pid %d is already in use")
 
+// ErrQueryTimeout is returned when a query is killed for running longer than
+// its maximum execution time.
+var ErrQueryTimeout = errors.NewKind("Query execution was interrupted, maximum statement execution time exceeded")
+
 // AddProcess adds a new process to the list given a process type and a query.
 // Steps is a map between the name of the items that need to be completed and
 // the total amount in these items. -1 means unknown.
@@ -268,6 +281,45 @@ func (pl *ProcessList) RemovePartitionProgress(pid uint64, tableName, partitionN
 	delete(tablePg.PartitionsProgress, partitionName)
 }
 
+// SetTimeout arms a timer that kills the process with the given pid once the
+// given duration has elapsed. A process killed this way stays in the list,
+// marked as killed, until it is done. If the pid does not exist, it will do
+// nothing.
+func (pl *ProcessList) SetTimeout(pid uint64, timeout time.Duration) {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	p, ok := pl.procs[pid]
+	if !ok {
+		return
+	}
+
+	if p.timer != nil {
+		p.timer.Stop()
+	}
+
+	p.timer = time.AfterFunc(timeout, func() {
+		pl.mu.Lock()
+		defer pl.mu.Unlock()
+
+		if p, ok := pl.procs[pid]; ok {
+			logrus.Infof("kill query: pid %d exceeded its execution time of %s", pid, timeout)
+			p.Killed = true
+			p.Kill()
+		}
+	})
+}
+
+// Killed returns whether the process with the given pid was killed for
+// exceeding its maximum execution time.
+func (pl *ProcessList) Killed(pid uint64) bool {
+	pl.mu.RLock()
+	defer pl.mu.RUnlock()
+
+	p, ok := pl.procs[pid]
+	return ok && p.Killed
+}
+
 // Kill terminates all queries for a given connection id.
 func (pl *ProcessList) Kill(connID uint32) {
 	pl.mu.Lock()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -138,3 +139,32 @@ func TestKillConnection(t *testing.T) {
 	require.False(t, killed[2])
 	require.True(t, killed[3])
 }
+
+func TestProcessListTimeout(t *testing.T) {
+	require := require.New(t)
+
+	pl := NewProcessList()
+	sess := NewSession("", "", "", 1)
+
+	ctx, err := pl.AddProcess(
+		NewContext(context.Background(), WithPid(1), WithSession(sess)),
+		QueryProcess,
+		"SELECT SLEEP(10)",
+	)
+	require.NoError(err)
+
+	pl.SetTimeout(ctx.Pid(), 10*time.Millisecond)
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		require.FailNow("process was not killed after its timeout")
+	}
+
+	require.True(pl.Killed(ctx.Pid()))
+	require.Len(pl.procs, 1)
+
+	pl.Done(ctx.Pid())
+	require.False(pl.Killed(ctx.Pid()))
+	require.Len(pl.procs, 0)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -233,19 +234,95 @@ type (
 	}
 )
 
-// DefaultSessionConfig returns default values for session variables
+// DefaultSessionConfig returns default values for session variables, which
+// are the global values of the variables that have one.
 // TODO: allow integrators to specify defaults for their system variables
 func DefaultSessionConfig() map[string]TypedValue {
+	config := defaultSystemVariables()
+
+	globals.RLock()
+	defer globals.RUnlock()
+	for name, value := range globals.values {
+		config[name] = value
+	}
+
+	return config
+}
+
+// globalSystemVariables are the system variables that also have a global
+// value, which can be changed with SET GLOBAL.
+var globalSystemVariables = map[string]bool{
+	"max_execution_time": true,
+}
+
+// globals holds the global values set for the system variables, which are
+// shared by all the sessions of the process.
+var globals = struct {
+	sync.RWMutex
+	values map[string]TypedValue
+}{values: make(map[string]TypedValue)}
+
+// IsGlobalVariable returns whether the system variable with the given name has
+// a global value.
+func IsGlobalVariable(name string) bool {
+	return globalSystemVariables[strings.ToLower(name)]
+}
+
+// GetGlobal returns the type and the global value of the system variable with
+// the given name.
+func GetGlobal(name string) (Type, interface{}, error) {
+	name = strings.ToLower(name)
+	if !globalSystemVariables[name] {
+		return nil, nil, ErrSessionOnlyVariable.New(name)
+	}
+
+	globals.RLock()
+	defer globals.RUnlock()
+	if v, ok := globals.values[name]; ok {
+		return v.Typ, v.Value, nil
+	}
+
+	v := defaultSystemVariables()[name]
+	return v.Typ, v.Value, nil
+}
+
+// SetGlobal sets the global value of the system variable with the given name.
+// Sessions created from then on start with that value.
+func SetGlobal(name string, value interface{}) error {
+	name = strings.ToLower(name)
+	if !globalSystemVariables[name] {
+		return ErrSessionOnlyVariable.New(name)
+	}
+
+	typ := defaultSystemVariables()[name].Typ
+	value, err := typ.Convert(value)
+	if err != nil {
+		return err
+	}
+
+	globals.Lock()
+	defer globals.Unlock()
+	globals.values[name] = TypedValue{typ, value}
+
+	return nil
+}
+
+// defaultSystemVariables returns the values the system variables have when
+// no global value was set for them.
+func defaultSystemVariables() map[string]TypedValue {
 	return map[string]TypedValue{
 		"auto_increment_increment": TypedValue{Int64, int64(1)},
 		"time_zone":                TypedValue{LongText, "SYSTEM"},
 		"system_time_zone":         TypedValue{LongText, time.Now().UTC().Location().String()},
 		"max_allowed_packet":       TypedValue{Int32, math.MaxInt32},
-		"sql_mode":                 TypedValue{LongText, ""},
+		"max_execution_time":       TypedValue{Int64, int64(0)},
+		"long_query_time":          TypedValue{Float64, float64(10)},
+		"sql_mode":                 TypedValue{LongText, DefaultSqlMode},
 		"gtid_mode":                TypedValue{Int32, int32(0)},
 		"collation_database":       TypedValue{LongText, Collation_Default.String()},
 		"ndbinfo_version":          TypedValue{LongText, ""},
 		"sql_select_limit":         TypedValue{Int32, math.MaxInt32},
+		"group_concat_max_len":     TypedValue{Int64, int64(1024)},
 		"transaction_isolation":    TypedValue{LongText, "READ UNCOMMITTED"},
 		"version":                  TypedValue{LongText, ""},
 		"version_comment":          TypedValue{LongText, ""},
@@ -301,6 +378,8 @@ type Context struct {
 	queryTime time.Time
 	tracer    opentracing.Tracer
 	rootSpan  opentracing.Span
+	// sqlMode holds the last SQL mode parsed for the query, see LoadSqlMode.
+	sqlMode *atomic.Value
 }
 
 // ContextOption is a function to configure the context.
@@ -385,7 +464,7 @@ func NewContext(
 	ctx context.Context,
 	opts ...ContextOption,
 ) *Context {
-	c := &Context{ctx, NewBaseSession(), nil, nil, nil, 0, "", ctxNowFunc(), opentracing.NoopTracer{}, nil}
+	c := &Context{ctx, NewBaseSession(), nil, nil, nil, 0, "", ctxNowFunc(), opentracing.NoopTracer{}, nil, new(atomic.Value)}
 	for _, opt := range opts {
 		opt(c)
 	}
@@ -450,6 +529,7 @@ func (c *Context) Span(
 		queryTime:     c.queryTime,
 		tracer:        c.tracer,
 		rootSpan:      c.rootSpan,
+		sqlMode:       c.sqlMode,
 	}
 }
 
@@ -468,6 +548,7 @@ func (c *Context) NewSubContext() (*Context, context.CancelFunc) {
 		queryTime:     c.queryTime,
 		tracer:        c.tracer,
 		rootSpan:      c.rootSpan,
+		sqlMode:       c.sqlMode,
 	}, cancelFunc
 }
 
@@ -489,6 +570,7 @@ func (c *Context) WithContext(ctx context.Context) *Context {
 		queryTime:     c.queryTime,
 		tracer:        c.tracer,
 		rootSpan:      c.rootSpan,
+		sqlMode:       c.sqlMode,
 	}
 }
 
@@ -36,6 +36,34 @@ func TestSessionConfig(t *testing.T) {
 	require.Equal(1, sess.Warnings()[2].Code)
 }
 
+func TestGlobalVariables(t *testing.T) {
+	require := require.New(t)
+
+	require.True(IsGlobalVariable("MAX_EXECUTION_TIME"))
+	require.False(IsGlobalVariable("autocommit"))
+
+	typ, v, err := GetGlobal("max_execution_time")
+	require.NoError(err)
+	require.Equal(Int64, typ)
+	require.Equal(int64(0), v)
+
+	require.NoError(SetGlobal("max_execution_time", "1000"))
+	defer func() {
+		require.NoError(SetGlobal("max_execution_time", 0))
+	}()
+
+	_, v, err = GetGlobal("max_execution_time")
+	require.NoError(err)
+	require.Equal(int64(1000), v)
+
+	sess := NewSession("foo", "baz", "bar", 1)
+	_, v = sess.Get("max_execution_time")
+	require.Equal(int64(1000), v)
+
+	err = SetGlobal("autocommit", 0)
+	require.True(ErrSessionOnlyVariable.Is(err))
+}
+
 func TestHasDefaultValue(t *testing.T) {
 	require := require.New(t)
 	sess := NewSession("foo", "baz", "bar", 1)
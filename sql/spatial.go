@@ -0,0 +1,648 @@
+package sql
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/proto/query"
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+var (
+	// ErrInvalidGeometry is returned when a value can't be converted to a
+	// geometry of the type of a column.
+	ErrInvalidGeometry = errors.NewKind("Cannot get geometry object from data you send to the GEOMETRY field")
+
+	// ErrInvalidWKT is returned when a text is not a valid well-known text
+	// representation of a geometry.
+	ErrInvalidWKT = errors.NewKind("invalid WKT: %s")
+
+	// ErrInvalidWKB is returned when a value is not a valid well-known
+	// binary representation of a geometry.
+	ErrInvalidWKB = errors.NewKind("invalid WKB")
+)
+
+// GeometryKind identifies the type of a geometry. Its values are the type
+// codes of the geometries in their well-known binary representation.
+type GeometryKind uint32
+
+const (
+	// GeometryKindAny is the kind of the GEOMETRY type, which holds
+	// geometries of any kind.
+	GeometryKindAny GeometryKind = iota
+	GeometryKindPoint
+	GeometryKindLineString
+	GeometryKindPolygon
+)
+
+// String returns the SQL name of the geometry kind.
+func (k GeometryKind) String() string {
+	switch k {
+	case GeometryKindPoint:
+		return "POINT"
+	case GeometryKindLineString:
+		return "LINESTRING"
+	case GeometryKindPolygon:
+		return "POLYGON"
+	default:
+		return "GEOMETRY"
+	}
+}
+
+var (
+	// Geometry is the type of geometries of any kind.
+	Geometry SpatialType = spatialType{GeometryKindAny}
+	// Point is the type of points. Its values are PointValue.
+	Point SpatialType = spatialType{GeometryKindPoint}
+	// LineString is the type of line strings. Its values are LineStringValue.
+	LineString SpatialType = spatialType{GeometryKindLineString}
+	// Polygon is the type of polygons. Its values are PolygonValue.
+	Polygon SpatialType = spatialType{GeometryKindPolygon}
+)
+
+// Represents the spatial types GEOMETRY, POINT, LINESTRING and POLYGON.
+// https://dev.mysql.com/doc/refman/8.0/en/spatial-type-overview.html
+type SpatialType interface {
+	Type
+	// Kind returns the kind of the geometries of the type.
+	Kind() GeometryKind
+}
+
+type spatialType struct {
+	kind GeometryKind
+}
+
+// GeometryValue is a value of a spatial type: a PointValue, a
+// LineStringValue or a PolygonValue. Every value has the identifier of the
+// spatial reference system its coordinates refer to, its SRID.
+type GeometryValue interface {
+	// Kind returns the kind of the geometry.
+	Kind() GeometryKind
+	// GetSRID returns the SRID of the geometry.
+	GetSRID() uint32
+	// WithSRID returns a copy of the geometry with the given SRID.
+	WithSRID(srid uint32) GeometryValue
+
+	appendWKB(b []byte) []byte
+	appendWKT(b []byte) []byte
+}
+
+// PointValue is a point with coordinates X and Y.
+type PointValue struct {
+	SRID uint32
+	X, Y float64
+}
+
+// LineStringValue is a curve made of the segments between consecutive
+// points. It has at least two points. The SRID of its points is ignored.
+type LineStringValue struct {
+	SRID   uint32
+	Points []PointValue
+}
+
+// PolygonValue is a planar surface delimited by rings, which are closed line
+// strings with at least four points. The first ring is the exterior of the
+// polygon and the others are its holes. The SRID of its rings is ignored.
+type PolygonValue struct {
+	SRID  uint32
+	Rings []LineStringValue
+}
+
+var _ GeometryValue = PointValue{}
+var _ GeometryValue = LineStringValue{}
+var _ GeometryValue = PolygonValue{}
+
+// Kind implements the GeometryValue interface.
+func (p PointValue) Kind() GeometryKind { return GeometryKindPoint }
+
+// GetSRID implements the GeometryValue interface.
+func (p PointValue) GetSRID() uint32 { return p.SRID }
+
+// WithSRID implements the GeometryValue interface.
+func (p PointValue) WithSRID(srid uint32) GeometryValue {
+	p.SRID = srid
+	return p
+}
+
+func (p PointValue) appendWKB(b []byte) []byte {
+	b = appendWKBHeader(b, GeometryKindPoint)
+	return appendWKBPoint(b, p)
+}
+
+func (p PointValue) appendWKT(b []byte) []byte {
+	b = append(b, "POINT("...)
+	b = appendWKTPoint(b, p)
+	return append(b, ')')
+}
+
+// Kind implements the GeometryValue interface.
+func (l LineStringValue) Kind() GeometryKind { return GeometryKindLineString }
+
+// GetSRID implements the GeometryValue interface.
+func (l LineStringValue) GetSRID() uint32 { return l.SRID }
+
+// WithSRID implements the GeometryValue interface.
+func (l LineStringValue) WithSRID(srid uint32) GeometryValue {
+	l.SRID = srid
+	return l
+}
+
+func (l LineStringValue) appendWKB(b []byte) []byte {
+	b = appendWKBHeader(b, GeometryKindLineString)
+	return appendWKBPoints(b, l.Points)
+}
+
+func (l LineStringValue) appendWKT(b []byte) []byte {
+	b = append(b, "LINESTRING"...)
+	return appendWKTPoints(b, l.Points)
+}
+
+// Kind implements the GeometryValue interface.
+func (p PolygonValue) Kind() GeometryKind { return GeometryKindPolygon }
+
+// GetSRID implements the GeometryValue interface.
+func (p PolygonValue) GetSRID() uint32 { return p.SRID }
+
+// WithSRID implements the GeometryValue interface.
+func (p PolygonValue) WithSRID(srid uint32) GeometryValue {
+	p.SRID = srid
+	return p
+}
+
+func (p PolygonValue) appendWKB(b []byte) []byte {
+	b = appendWKBHeader(b, GeometryKindPolygon)
+	b = appendUint32(b, uint32(len(p.Rings)))
+	for _, ring := range p.Rings {
+		b = appendWKBPoints(b, ring.Points)
+	}
+	return b
+}
+
+func (p PolygonValue) appendWKT(b []byte) []byte {
+	b = append(b, "POLYGON("...)
+	for i, ring := range p.Rings {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = appendWKTPoints(b, ring.Points)
+	}
+	return append(b, ')')
+}
+
+func appendUint32(b []byte, n uint32) []byte {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], n)
+	return append(b, buf[:]...)
+}
+
+func appendUint64(b []byte, n uint64) []byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], n)
+	return append(b, buf[:]...)
+}
+
+func appendWKBHeader(b []byte, kind GeometryKind) []byte {
+	// All geometries are encoded in little-endian byte order, as MySQL does.
+	b = append(b, 1)
+	return appendUint32(b, uint32(kind))
+}
+
+func appendWKBPoint(b []byte, p PointValue) []byte {
+	b = appendUint64(b, math.Float64bits(p.X))
+	return appendUint64(b, math.Float64bits(p.Y))
+}
+
+func appendWKBPoints(b []byte, points []PointValue) []byte {
+	b = appendUint32(b, uint32(len(points)))
+	for _, p := range points {
+		b = appendWKBPoint(b, p)
+	}
+	return b
+}
+
+func appendWKTPoint(b []byte, p PointValue) []byte {
+	b = strconv.AppendFloat(b, p.X, 'f', -1, 64)
+	b = append(b, ' ')
+	return strconv.AppendFloat(b, p.Y, 'f', -1, 64)
+}
+
+func appendWKTPoints(b []byte, points []PointValue) []byte {
+	b = append(b, '(')
+	for i, p := range points {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = appendWKTPoint(b, p)
+	}
+	return append(b, ')')
+}
+
+// WKB returns the well-known binary representation of a geometry, which
+// doesn't include its SRID.
+func WKB(g GeometryValue) []byte {
+	return g.appendWKB(nil)
+}
+
+// WKT returns the well-known text representation of a geometry, which
+// doesn't include its SRID.
+func WKT(g GeometryValue) string {
+	return string(g.appendWKT(nil))
+}
+
+// SerializeGeometry returns the geometry in the format MySQL uses to store
+// geometries and send them to clients: its SRID as a 4-byte little-endian
+// integer followed by its well-known binary representation.
+func SerializeGeometry(g GeometryValue) []byte {
+	b := appendUint32(nil, g.GetSRID())
+	return g.appendWKB(b)
+}
+
+// DeserializeGeometry parses a geometry in the format returned by
+// SerializeGeometry.
+func DeserializeGeometry(b []byte) (GeometryValue, error) {
+	if len(b) < 4 {
+		return nil, ErrInvalidWKB.New()
+	}
+	return ParseWKB(b[4:], binary.LittleEndian.Uint32(b))
+}
+
+// ParseWKB parses the well-known binary representation of a geometry and
+// returns it with the given SRID.
+func ParseWKB(b []byte, srid uint32) (GeometryValue, error) {
+	r := wkbReader{b: b}
+	g, err := r.readGeometry()
+	if err != nil {
+		return nil, err
+	}
+	if len(r.b) != 0 {
+		return nil, ErrInvalidWKB.New()
+	}
+	return g.WithSRID(srid), nil
+}
+
+type wkbReader struct {
+	b     []byte
+	order binary.ByteOrder
+}
+
+func (r *wkbReader) readUint32() (uint32, error) {
+	if len(r.b) < 4 {
+		return 0, ErrInvalidWKB.New()
+	}
+	n := r.order.Uint32(r.b)
+	r.b = r.b[4:]
+	return n, nil
+}
+
+func (r *wkbReader) readPoint() (PointValue, error) {
+	if len(r.b) < 16 {
+		return PointValue{}, ErrInvalidWKB.New()
+	}
+	p := PointValue{
+		X: math.Float64frombits(r.order.Uint64(r.b)),
+		Y: math.Float64frombits(r.order.Uint64(r.b[8:])),
+	}
+	r.b = r.b[16:]
+	return p, nil
+}
+
+func (r *wkbReader) readPoints(min int) ([]PointValue, error) {
+	n, err := r.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	if int(n) < min || uint64(n)*16 > uint64(len(r.b)) {
+		return nil, ErrInvalidWKB.New()
+	}
+
+	points := make([]PointValue, n)
+	for i := range points {
+		if points[i], err = r.readPoint(); err != nil {
+			return nil, err
+		}
+	}
+	return points, nil
+}
+
+func (r *wkbReader) readGeometry() (GeometryValue, error) {
+	if len(r.b) == 0 {
+		return nil, ErrInvalidWKB.New()
+	}
+	switch r.b[0] {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		return nil, ErrInvalidWKB.New()
+	}
+	r.b = r.b[1:]
+
+	kind, err := r.readUint32()
+	if err != nil {
+		return nil, err
+	}
+
+	switch GeometryKind(kind) {
+	case GeometryKindPoint:
+		return r.readPoint()
+	case GeometryKindLineString:
+		points, err := r.readPoints(2)
+		if err != nil {
+			return nil, err
+		}
+		return LineStringValue{Points: points}, nil
+	case GeometryKindPolygon:
+		n, err := r.readUint32()
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 || uint64(n)*4 > uint64(len(r.b)) {
+			return nil, ErrInvalidWKB.New()
+		}
+		rings := make([]LineStringValue, n)
+		for i := range rings {
+			points, err := r.readPoints(4)
+			if err != nil {
+				return nil, err
+			}
+			if !isClosed(points) {
+				return nil, ErrInvalidWKB.New()
+			}
+			rings[i] = LineStringValue{Points: points}
+		}
+		return PolygonValue{Rings: rings}, nil
+	default:
+		return nil, ErrInvalidWKB.New()
+	}
+}
+
+func isClosed(points []PointValue) bool {
+	first, last := points[0], points[len(points)-1]
+	return first.X == last.X && first.Y == last.Y
+}
+
+// ParseWKT parses the well-known text representation of a geometry and
+// returns it with the given SRID. Keywords are case insensitive and
+// whitespace may appear between any tokens.
+func ParseWKT(text string, srid uint32) (GeometryValue, error) {
+	p := wktParser{text: text}
+	g, err := p.parseGeometry()
+	if err == nil && !p.atEnd() {
+		err = p.error()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return g.WithSRID(srid), nil
+}
+
+type wktParser struct {
+	text string
+	pos  int
+}
+
+func (p *wktParser) error() error {
+	return ErrInvalidWKT.New(p.text)
+}
+
+func (p *wktParser) skipSpaces() {
+	for p.pos < len(p.text) && strings.IndexByte(" \t\r\n", p.text[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+func (p *wktParser) atEnd() bool {
+	p.skipSpaces()
+	return p.pos == len(p.text)
+}
+
+// accept consumes the given character if it's the next token.
+func (p *wktParser) accept(c byte) bool {
+	p.skipSpaces()
+	if p.pos < len(p.text) && p.text[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *wktParser) expect(c byte) error {
+	if !p.accept(c) {
+		return p.error()
+	}
+	return nil
+}
+
+func (p *wktParser) parseWord() string {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.text) {
+		c := p.text[p.pos] | 0x20
+		if c < 'a' || c > 'z' {
+			break
+		}
+		p.pos++
+	}
+	return strings.ToUpper(p.text[start:p.pos])
+}
+
+func (p *wktParser) parseNumber() (float64, error) {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.text) && strings.IndexByte("0123456789+-.eE", p.text[p.pos]) >= 0 {
+		p.pos++
+	}
+	f, err := strconv.ParseFloat(p.text[start:p.pos], 64)
+	if err != nil || math.IsInf(f, 0) {
+		return 0, p.error()
+	}
+	return f, nil
+}
+
+func (p *wktParser) parsePoint() (PointValue, error) {
+	x, err := p.parseNumber()
+	if err != nil {
+		return PointValue{}, err
+	}
+	y, err := p.parseNumber()
+	if err != nil {
+		return PointValue{}, err
+	}
+	return PointValue{X: x, Y: y}, nil
+}
+
+// parsePoints parses a parenthesized list of at least min points.
+func (p *wktParser) parsePoints(min int) ([]PointValue, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	var points []PointValue
+	for {
+		point, err := p.parsePoint()
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+		if !p.accept(',') {
+			break
+		}
+	}
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+	if len(points) < min {
+		return nil, p.error()
+	}
+	return points, nil
+}
+
+func (p *wktParser) parseGeometry() (GeometryValue, error) {
+	switch p.parseWord() {
+	case "POINT":
+		if err := p.expect('('); err != nil {
+			return nil, err
+		}
+		point, err := p.parsePoint()
+		if err != nil {
+			return nil, err
+		}
+		return point, p.expect(')')
+	case "LINESTRING":
+		points, err := p.parsePoints(2)
+		if err != nil {
+			return nil, err
+		}
+		return LineStringValue{Points: points}, nil
+	case "POLYGON":
+		if err := p.expect('('); err != nil {
+			return nil, err
+		}
+		var rings []LineStringValue
+		for {
+			points, err := p.parsePoints(4)
+			if err != nil {
+				return nil, err
+			}
+			if !isClosed(points) {
+				return nil, p.error()
+			}
+			rings = append(rings, LineStringValue{Points: points})
+			if !p.accept(',') {
+				break
+			}
+		}
+		return PolygonValue{Rings: rings}, p.expect(')')
+	default:
+		return nil, p.error()
+	}
+}
+
+// Compare implements Type interface. Geometries are compared by their
+// serialized form, as MySQL does.
+func (t spatialType) Compare(a interface{}, b interface{}) (int, error) {
+	if hasNulls, res := compareNulls(a, b); hasNulls {
+		return res, nil
+	}
+
+	ag, err := t.Convert(a)
+	if err != nil {
+		return 0, err
+	}
+	bg, err := t.Convert(b)
+	if err != nil {
+		return 0, err
+	}
+
+	return bytes.Compare(SerializeGeometry(ag.(GeometryValue)), SerializeGeometry(bg.(GeometryValue))), nil
+}
+
+// Convert implements Type interface. Strings and byte slices are parsed in
+// the format returned by SerializeGeometry. Only geometries of the kind of
+// the type are accepted.
+func (t spatialType) Convert(v interface{}) (interface{}, error) {
+	var g GeometryValue
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case GeometryValue:
+		g = v
+	case string:
+		var err error
+		if g, err = DeserializeGeometry([]byte(v)); err != nil {
+			return nil, ErrInvalidGeometry.New()
+		}
+	case []byte:
+		var err error
+		if g, err = DeserializeGeometry(v); err != nil {
+			return nil, ErrInvalidGeometry.New()
+		}
+	default:
+		return nil, ErrInvalidGeometry.New()
+	}
+
+	if t.kind != GeometryKindAny && g.Kind() != t.kind {
+		return nil, ErrInvalidGeometry.New()
+	}
+	return g, nil
+}
+
+// MustConvert implements the Type interface.
+func (t spatialType) MustConvert(v interface{}) interface{} {
+	value, err := t.Convert(v)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// Promote implements the Type interface.
+func (t spatialType) Promote() Type {
+	return Geometry
+}
+
+// SQL implements Type interface.
+func (t spatialType) SQL(v interface{}) (sqltypes.Value, error) {
+	if v == nil {
+		return sqltypes.NULL, nil
+	}
+
+	v, err := t.Convert(v)
+	if err != nil {
+		return sqltypes.Value{}, err
+	}
+
+	return sqltypes.MakeTrusted(sqltypes.Geometry, SerializeGeometry(v.(GeometryValue))), nil
+}
+
+// String implements Type interface.
+func (t spatialType) String() string {
+	return t.kind.String()
+}
+
+// Type implements Type interface.
+func (t spatialType) Type() query.Type {
+	return sqltypes.Geometry
+}
+
+// Zero implements Type interface. The zero value of the GEOMETRY type is
+// a point.
+func (t spatialType) Zero() interface{} {
+	switch t.kind {
+	case GeometryKindLineString:
+		return LineStringValue{Points: []PointValue{{}, {}}}
+	case GeometryKindPolygon:
+		return PolygonValue{Rings: []LineStringValue{{Points: []PointValue{{}, {}, {}, {}}}}}
+	default:
+		return PointValue{}
+	}
+}
+
+// Kind implements SpatialType interface.
+func (t spatialType) Kind() GeometryKind {
+	return t.kind
+}
@@ -0,0 +1,201 @@
+package sql
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWKT(t *testing.T) {
+	tests := []struct {
+		text        string
+		expected    GeometryValue
+		expectedWKT string
+	}{
+		{"POINT(1 2)", PointValue{X: 1, Y: 2}, "POINT(1 2)"},
+		{" point ( -1.5  2e3 ) ", PointValue{X: -1.5, Y: 2000}, "POINT(-1.5 2000)"},
+		{"LINESTRING(0 0, 1 1,2 0)", LineStringValue{Points: []PointValue{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 0}}}, "LINESTRING(0 0,1 1,2 0)"},
+		{
+			"Polygon((0 0,4 0,4 4,0 4,0 0),(1 1,2 1,2 2,1 1))",
+			PolygonValue{Rings: []LineStringValue{
+				{Points: []PointValue{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 0, Y: 4}, {X: 0, Y: 0}}},
+				{Points: []PointValue{{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 2}, {X: 1, Y: 1}}},
+			}},
+			"POLYGON((0 0,4 0,4 4,0 4,0 0),(1 1,2 1,2 2,1 1))",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			g, err := ParseWKT(test.text, 0)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, g)
+			assert.Equal(t, test.expectedWKT, WKT(g))
+		})
+	}
+
+	invalid := []string{
+		"",
+		"POINT",
+		"POINT()",
+		"POINT(1)",
+		"POINT(1 2",
+		"POINT(1 2) x",
+		"POINT(1,2)",
+		"CIRCLE(1 2)",
+		"LINESTRING(1 2)",
+		"POLYGON((0 0,1 0,1 1))",
+		"POLYGON((0 0,1 0,1 1,0 1))",
+	}
+	for _, text := range invalid {
+		t.Run(text, func(t *testing.T) {
+			_, err := ParseWKT(text, 0)
+			require.True(t, ErrInvalidWKT.Is(err), "unexpected error %v", err)
+		})
+	}
+}
+
+func TestSerializeGeometry(t *testing.T) {
+	tests := []struct {
+		wkt      string
+		srid     uint32
+		expected string
+	}{
+		{"POINT(1 2)", 0, "000000000101000000000000000000f03f0000000000000040"},
+		{"POINT(1 2)", 4326, "e61000000101000000000000000000f03f0000000000000040"},
+		{"LINESTRING(0 0,1 1)", 0, "00000000010200000002000000" + strings.Repeat("0", 32) + "000000000000f03f000000000000f03f"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.wkt, func(t *testing.T) {
+			require := require.New(t)
+			g, err := ParseWKT(test.wkt, test.srid)
+			require.NoError(err)
+
+			b := SerializeGeometry(g)
+			require.Equal(test.expected, hex.EncodeToString(b))
+			require.Equal(b[4:], WKB(g))
+
+			g2, err := DeserializeGeometry(b)
+			require.NoError(err)
+			require.Equal(g, g2)
+			require.Equal(test.srid, g2.GetSRID())
+		})
+	}
+
+	polygon, err := ParseWKT("POLYGON((0 0,1 0,1 1,0 0))", 3857)
+	require.NoError(t, err)
+	g, err := DeserializeGeometry(SerializeGeometry(polygon))
+	require.NoError(t, err)
+	require.Equal(t, polygon, g)
+
+	bigEndian, err := hex.DecodeString("00000000" + "00000000013ff00000000000004000000000000000")
+	require.NoError(t, err)
+	g, err = DeserializeGeometry(bigEndian)
+	require.NoError(t, err)
+	require.Equal(t, PointValue{X: 1, Y: 2}, g)
+
+	for _, invalid := range []string{"", "000000", "0000000001", "000000000101000000000000000000f03f", "000000000109000000"} {
+		b, err := hex.DecodeString(invalid)
+		require.NoError(t, err)
+		_, err = DeserializeGeometry(b)
+		require.True(t, ErrInvalidWKB.Is(err), "unexpected error %v for %s", err, invalid)
+	}
+}
+
+func TestSpatialConvert(t *testing.T) {
+	point := PointValue{SRID: 4326, X: 1, Y: 2}
+	line := LineStringValue{Points: []PointValue{{X: 0, Y: 0}, {X: 1, Y: 1}}}
+
+	tests := []struct {
+		typ         Type
+		val         interface{}
+		expectedVal interface{}
+		expectedErr bool
+	}{
+		{Geometry, nil, nil, false},
+		{Geometry, point, point, false},
+		{Geometry, line, line, false},
+		{Point, point, point, false},
+		{Point, string(SerializeGeometry(point)), point, false},
+		{Point, SerializeGeometry(point), point, false},
+		{Point, line, nil, true},
+		{LineString, line, line, false},
+		{Polygon, line, nil, true},
+		{Geometry, "POINT(1 2)", nil, true},
+		{Geometry, int64(1), nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.typ.String(), func(t *testing.T) {
+			val, err := test.typ.Convert(test.val)
+			if test.expectedErr {
+				require.True(t, ErrInvalidGeometry.Is(err), "unexpected error %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedVal, val)
+		})
+	}
+}
+
+func TestSpatialCompare(t *testing.T) {
+	a := PointValue{X: 1, Y: 2}
+	b := PointValue{X: 1, Y: 3}
+
+	tests := []struct {
+		val1        interface{}
+		val2        interface{}
+		expectedCmp int
+	}{
+		{nil, a, -1},
+		{a, nil, 1},
+		{a, a, 0},
+		{a, PointValue{X: 1, Y: 2}, 0},
+		{a, a.WithSRID(1), -1},
+		{a, b, -1},
+		{b, a, 1},
+	}
+
+	for _, test := range tests {
+		cmp, err := Geometry.Compare(test.val1, test.val2)
+		require.NoError(t, err)
+		assert.Equal(t, test.expectedCmp, cmp)
+	}
+}
+
+func TestSpatialSQL(t *testing.T) {
+	require := require.New(t)
+	point := PointValue{X: 1, Y: 2}
+
+	v, err := Point.SQL(point)
+	require.NoError(err)
+	require.Equal(sqltypes.Geometry, v.Type())
+	require.Equal(SerializeGeometry(point), v.Raw())
+
+	v, err = Geometry.SQL(nil)
+	require.NoError(err)
+	require.True(v.IsNull())
+
+	_, err = LineString.SQL(point)
+	require.Error(err)
+
+	s, err := LongBlob.Convert(point)
+	require.NoError(err)
+	require.Equal(string(SerializeGeometry(point)), s)
+}
+
+func TestSpatialColumnTypes(t *testing.T) {
+	for _, typ := range []Type{Geometry, Point, LineString, Polygon} {
+		parsed, err := ColumnTypeToType(&sqlparser.ColumnType{Type: strings.ToLower(typ.String())})
+		require.NoError(t, err)
+		require.Equal(t, typ, parsed)
+		require.True(t, IsSpatial(parsed))
+	}
+	require.False(t, IsSpatial(LongBlob))
+}
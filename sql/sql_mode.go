@@ -0,0 +1,433 @@
+package sql
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/proto/query"
+	"github.com/spf13/cast"
+)
+
+// The SQL modes that change the behavior of the engine. Any other mode in sql_mode is accepted and ignored.
+const (
+	SqlMode_StrictTransTables      = "STRICT_TRANS_TABLES"
+	SqlMode_StrictAllTables        = "STRICT_ALL_TABLES"
+	SqlMode_NoZeroDate             = "NO_ZERO_DATE"
+	SqlMode_NoZeroInDate           = "NO_ZERO_IN_DATE"
+	SqlMode_ErrorForDivisionByZero = "ERROR_FOR_DIVISION_BY_ZERO"
+	SqlMode_OnlyFullGroupBy        = "ONLY_FULL_GROUP_BY"
+	SqlMode_AnsiQuotes             = "ANSI_QUOTES"
+	SqlMode_NoBackslashEscapes     = "NO_BACKSLASH_ESCAPES"
+)
+
+// DefaultSqlMode is the value of sql_mode in a new session, which is the default of MySQL 8.0.
+const DefaultSqlMode = "ONLY_FULL_GROUP_BY,STRICT_TRANS_TABLES,NO_ZERO_IN_DATE,NO_ZERO_DATE,ERROR_FOR_DIVISION_BY_ZERO,NO_ENGINE_SUBSTITUTION"
+
+// combinationSqlModes are the modes that stand for a list of other modes.
+var combinationSqlModes = map[string][]string{
+	"ANSI":        {"REAL_AS_FLOAT", "PIPES_AS_CONCAT", SqlMode_AnsiQuotes, "IGNORE_SPACE", SqlMode_OnlyFullGroupBy},
+	"TRADITIONAL": {SqlMode_StrictTransTables, SqlMode_StrictAllTables, SqlMode_NoZeroInDate, SqlMode_NoZeroDate, SqlMode_ErrorForDivisionByZero, "NO_ENGINE_SUBSTITUTION"},
+}
+
+// MySQL error codes of the warnings and errors caused by writing a value that doesn't fit its column.
+const (
+	ERWarnDataOutOfRange          = 1264
+	ERWarnDataTruncated           = 1265
+	ERDivisionByZero              = 1365
+	ERTruncatedWrongValueForField = 1366
+)
+
+var (
+	// ErrDivisionByZero is returned when a value written to a table divides by zero, in strict mode with
+	// ERROR_FOR_DIVISION_BY_ZERO.
+	ErrDivisionByZero = errors.NewKind("Division by 0")
+
+	// ErrIncorrectValue is returned when a value can't be written to a column because of the SQL mode.
+	ErrIncorrectValue = errors.NewKind("Incorrect %s value: '%v' for column '%s' at row %d")
+)
+
+// SqlMode is the set of modes in the sql_mode system variable.
+type SqlMode struct {
+	modes []string
+}
+
+// NewSqlMode returns the SqlMode for the given value of sql_mode, which is a comma separated list of modes. Combination
+// modes, such as ANSI or TRADITIONAL, are expanded to the modes they stand for.
+func NewSqlMode(s string) SqlMode {
+	var modes []string
+	for _, mode := range strings.Split(s, ",") {
+		mode = strings.ToUpper(strings.TrimSpace(mode))
+		if mode == "" {
+			continue
+		}
+		modes = append(modes, combinationSqlModes[mode]...)
+		modes = append(modes, mode)
+	}
+	return SqlMode{modes}
+}
+
+// parsedSqlMode is a value of sql_mode along with the SqlMode parsed from it.
+type parsedSqlMode struct {
+	value string
+	mode  SqlMode
+}
+
+// LoadSqlMode returns the SqlMode of the session of the given context. The mode is parsed once per query, and again
+// only if the query changes sql_mode, since it's loaded for every row written.
+func LoadSqlMode(ctx *Context) SqlMode {
+	if ctx == nil || ctx.Session == nil {
+		return NewSqlMode(DefaultSqlMode)
+	}
+	_, val := ctx.Get("sql_mode")
+	s, ok := val.(string)
+	if !ok {
+		return SqlMode{}
+	}
+
+	if ctx.sqlMode == nil {
+		return NewSqlMode(s)
+	}
+	if parsed, ok := ctx.sqlMode.Load().(parsedSqlMode); ok && parsed.value == s {
+		return parsed.mode
+	}
+
+	mode := NewSqlMode(s)
+	ctx.sqlMode.Store(parsedSqlMode{s, mode})
+	return mode
+}
+
+// Has returns whether the given mode is set.
+func (m SqlMode) Has(mode string) bool {
+	for _, mm := range m.modes {
+		if mm == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// Strict returns whether values that don't fit their column are rejected, rather than adjusted with a warning.
+func (m SqlMode) Strict() bool {
+	return m.Has(SqlMode_StrictTransTables) || m.Has(SqlMode_StrictAllTables)
+}
+
+// String implements fmt.Stringer.
+func (m SqlMode) String() string {
+	return strings.Join(m.modes, ",")
+}
+
+// ConvertToColumn converts the given value to the type of the given column, in order to write it in the row with the
+// given number, starting at 1. In strict mode, a value that doesn't fit the column is an error. Otherwise, it's
+// adjusted to the closest value that does, as MySQL does, and a warning is added to the session.
+func ConvertToColumn(ctx *Context, col *Column, rowNum int, v interface{}) (interface{}, error) {
+	// Some expressions, such as a division by zero, evaluate to the NULL type rather than nil
+	if v == nil || v == Null {
+		return nil, nil
+	}
+
+	res, err := col.Type.Convert(v)
+	if err == nil {
+		switch r := res.(type) {
+		case time.Time:
+			if r.Equal(zeroTime) {
+				return res, checkZeroDate(ctx, col, rowNum, v)
+			}
+		case string:
+			if st, ok := col.Type.(StringType); ok && !st.CharacterSet().CanEncode(r) {
+				return checkStringEncoding(ctx, col, rowNum, st.CharacterSet(), r)
+			}
+		}
+		return res, nil
+	}
+
+	res, code, ok := coerceToType(col.Type, v)
+	if !ok {
+		return nil, err
+	}
+
+	// A number that only has to be rounded to fit the column, such as a decimal written to an integer column, is
+	// written without a warning, even in strict mode.
+	if code == 0 {
+		return res, nil
+	}
+
+	if LoadSqlMode(ctx).Strict() {
+		return nil, err
+	}
+
+	switch code {
+	case ERWarnDataOutOfRange:
+		ctx.Warn(code, "Out of range value for column '%s' at row %d", col.Name, rowNum)
+	case ERTruncatedWrongValueForField:
+		ctx.Warn(code, "Incorrect %s value: '%v' for column '%s' at row %d", typeWord(col.Type), v, col.Name, rowNum)
+	default:
+		ctx.Warn(code, "Data truncated for column '%s' at row %d", col.Name, rowNum)
+	}
+	return res, nil
+}
+
+// checkZeroDate returns an error for a zero date written to a column in strict mode with NO_ZERO_DATE, and adds a
+// warning for it without strict mode.
+func checkZeroDate(ctx *Context, col *Column, rowNum int, v interface{}) error {
+	mode := LoadSqlMode(ctx)
+	if !mode.Has(SqlMode_NoZeroDate) {
+		return nil
+	}
+	if mode.Strict() {
+		if t, ok := v.(time.Time); ok {
+			v = t.Format(TimestampDatetimeLayout)
+		}
+		return ErrIncorrectValue.New(typeWord(col.Type), v, col.Name, rowNum)
+	}
+	ctx.Warn(ERWarnDataOutOfRange, "Out of range value for column '%s' at row %d", col.Name, rowNum)
+	return nil
+}
+
+// checkStringEncoding returns an error for a string with characters that the character set of the column can't
+// represent in strict mode. Without strict mode, those characters are replaced with '?' and a warning is added.
+func checkStringEncoding(ctx *Context, col *Column, rowNum int, cs CharacterSet, s string) (interface{}, error) {
+	// MySQL shows the bytes of the string from the first character it can't represent
+	var invalid string
+	for i, r := range s {
+		if !cs.CanEncode(string(r)) {
+			invalid = s[i:]
+			break
+		}
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(invalid) && i < 6; i++ {
+		fmt.Fprintf(&sb, "\\x%02X", invalid[i])
+	}
+	if len(invalid) > 6 {
+		sb.WriteString("...")
+	}
+
+	if LoadSqlMode(ctx).Strict() {
+		return nil, ErrIncorrectStringValue.New(sb.String(), col.Name, rowNum)
+	}
+
+	ctx.Warn(ERTruncatedWrongValueForField, "Incorrect string value: '%s' for column '%s' at row %d", sb.String(), col.Name, rowNum)
+	b, _ := cs.Encode(s)
+	res, _ := cs.Decode(b)
+	return res, nil
+}
+
+// coerceToType returns the value of the given type that is closest to the given value, which can't be converted to
+// it, along with the code of the warning for the adjustment. It returns false if the type doesn't allow adjusting
+// values.
+func coerceToType(typ Type, v interface{}) (interface{}, int, bool) {
+	switch t := typ.(type) {
+	case numberTypeImpl:
+		d, code, ok := toDecimalPrefix(v)
+		if !ok {
+			return nil, 0, false
+		}
+		if t.baseType == sqltypes.Float32 || t.baseType == sqltypes.Float64 {
+			if code == ERTruncatedWrongValueForField {
+				code = ERWarnDataTruncated
+			}
+		}
+		min, max := numberTypeRange(t.baseType)
+		if d.LessThan(min) {
+			d, code = min, ERWarnDataOutOfRange
+		} else if d.GreaterThan(max) {
+			d, code = max, ERWarnDataOutOfRange
+		}
+		var res interface{}
+		var err error
+		switch {
+		case IsFloat(t):
+			f, _ := d.Float64()
+			res, err = t.Convert(f)
+		case d.Sign() < 0:
+			res, err = t.Convert(d.Round(0).IntPart())
+		default:
+			u, _ := strconv.ParseUint(d.Round(0).String(), 10, 64)
+			res, err = t.Convert(u)
+		}
+		return res, code, err == nil
+	case decimalType:
+		d, code, ok := toDecimalPrefix(v)
+		if !ok {
+			return nil, 0, false
+		}
+		max := t.exclusiveUpperBound.Sub(decimal.New(1, -int32(t.scale)))
+		if d.Round(int32(t.scale)).Abs().GreaterThan(max) {
+			if d.Sign() < 0 {
+				d = max.Neg()
+			} else {
+				d = max
+			}
+			code = ERWarnDataOutOfRange
+		}
+		res, err := t.Convert(d)
+		return res, code, err == nil
+	case stringType:
+		s, err := cast.ToStringE(v)
+		if err != nil {
+			return nil, 0, false
+		}
+		res, err := t.Convert(truncateString(t, s))
+		return res, ERWarnDataTruncated, err == nil
+	case datetimeType:
+		return zeroTime, ERWarnDataTruncated, true
+	default:
+		return nil, 0, false
+	}
+}
+
+// truncateString returns the longest prefix of the given string that fits in the string type, which limits the
+// number of bytes of binary strings and TEXT, and the number of characters of the rest.
+func truncateString(t stringType, s string) string {
+	cs := t.CharacterSet()
+	if cs == CharacterSet_binary {
+		if int64(len(s)) > t.charLength {
+			return s[:t.charLength]
+		}
+		return s
+	}
+
+	var chars, bytes int64
+	for i, r := range s {
+		if t.baseType == sqltypes.Text {
+			bytes += int64(cs.ByteLength(string(r)))
+			if bytes > t.MaxByteLength() {
+				return s[:i]
+			}
+		} else if chars++; chars > t.charLength {
+			return s[:i]
+		}
+	}
+	return s
+}
+
+// toDecimalPrefix converts a number, or the longest prefix of a string that is a number, to a decimal. The code of the
+// warning for a string that isn't entirely a number is returned too.
+func toDecimalPrefix(v interface{}) (decimal.Decimal, int, bool) {
+	s, ok := v.(string)
+	if !ok {
+		switch v := v.(type) {
+		case float32:
+			return decimal.NewFromFloat32(v), 0, true
+		case float64:
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return decimal.Decimal{}, 0, false
+			}
+			return decimal.NewFromFloat(v), 0, true
+		}
+		d, err := ToDecimal(v)
+		if err != nil || !d.Valid {
+			return decimal.Decimal{}, 0, false
+		}
+		return d.Decimal, 0, true
+	}
+
+	trimmed := strings.TrimSpace(s)
+	prefix := NumberPrefix(trimmed)
+	if prefix == "" {
+		return decimal.Zero, ERTruncatedWrongValueForField, true
+	}
+
+	d, err := decimal.NewFromString(prefix)
+	if err != nil {
+		return decimal.Zero, ERTruncatedWrongValueForField, true
+	}
+	if prefix != trimmed {
+		return d, ERWarnDataTruncated, true
+	}
+	return d, 0, true
+}
+
+// NumberPrefix returns the longest prefix of the given string that is a number, which is how MySQL reads strings
+// as numbers.
+func NumberPrefix(s string) string {
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+
+	digits := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+		digits++
+	}
+	if i < len(s) && s[i] == '.' {
+		i++
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+			digits++
+		}
+	}
+	if digits == 0 {
+		return ""
+	}
+
+	end := i
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			i++
+		}
+		start := i
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		if i > start {
+			end = i
+		}
+	}
+
+	return strings.TrimSuffix(s[:end], ".")
+}
+
+// numberTypeRange returns the minimum and maximum values of the given number type.
+func numberTypeRange(baseType query.Type) (decimal.Decimal, decimal.Decimal) {
+	switch baseType {
+	case sqltypes.Int8:
+		return decimal.NewFromInt(math.MinInt8), decimal.NewFromInt(math.MaxInt8)
+	case sqltypes.Uint8:
+		return decimal.Zero, decimal.NewFromInt(math.MaxUint8)
+	case sqltypes.Int16:
+		return decimal.NewFromInt(math.MinInt16), decimal.NewFromInt(math.MaxInt16)
+	case sqltypes.Uint16:
+		return decimal.Zero, decimal.NewFromInt(math.MaxUint16)
+	case sqltypes.Int24:
+		return decimal.NewFromInt(-1 << 23), decimal.NewFromInt(1<<23 - 1)
+	case sqltypes.Uint24:
+		return decimal.Zero, decimal.NewFromInt(1<<24 - 1)
+	case sqltypes.Int32:
+		return decimal.NewFromInt(math.MinInt32), decimal.NewFromInt(math.MaxInt32)
+	case sqltypes.Uint32:
+		return decimal.Zero, decimal.NewFromInt(math.MaxUint32)
+	case sqltypes.Int64:
+		return decimal.NewFromInt(math.MinInt64), decimal.NewFromInt(math.MaxInt64)
+	case sqltypes.Uint64:
+		return decimal.Zero, decimal.RequireFromString(fmt.Sprint(uint64(math.MaxUint64)))
+	case sqltypes.Float32:
+		return decimal.NewFromFloat(-math.MaxFloat32), decimal.NewFromFloat(math.MaxFloat32)
+	default:
+		return decimal.NewFromFloat(-math.MaxFloat64), decimal.NewFromFloat(math.MaxFloat64)
+	}
+}
+
+// typeWord returns the name of the given type used in the messages of warnings, such as "integer" or "datetime".
+func typeWord(typ Type) string {
+	switch {
+	case IsInteger(typ):
+		return "integer"
+	case IsDecimal(typ):
+		return "decimal"
+	case IsFloat(typ):
+		return "double"
+	default:
+		return strings.ToLower(typ.String())
+	}
+}
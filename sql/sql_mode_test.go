@@ -0,0 +1,117 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSqlMode(t *testing.T) {
+	require := require.New(t)
+
+	mode := NewSqlMode(" ansi_quotes, Traditional ,")
+	require.True(mode.Has(SqlMode_AnsiQuotes))
+	require.True(mode.Has(SqlMode_NoZeroDate))
+	require.True(mode.Has("TRADITIONAL"))
+	require.False(mode.Has(SqlMode_OnlyFullGroupBy))
+	require.True(mode.Strict())
+
+	require.False(NewSqlMode("").Strict())
+	require.True(NewSqlMode(DefaultSqlMode).Strict())
+
+	ctx := NewEmptyContext()
+	require.True(LoadSqlMode(ctx).Has(SqlMode_ErrorForDivisionByZero))
+	require.True(LoadSqlMode(ctx).Has(SqlMode_NoZeroInDate))
+	require.NoError(ctx.Set(ctx, "sql_mode", LongText, "ANSI_QUOTES"))
+	require.False(LoadSqlMode(ctx).Strict())
+	require.True(LoadSqlMode(ctx).Has(SqlMode_AnsiQuotes))
+	require.NoError(ctx.Set(ctx, "sql_mode", LongText, "STRICT_ALL_TABLES"))
+	require.True(LoadSqlMode(ctx).Strict())
+	require.NoError(ctx.Set(ctx, "sql_mode", Int64, int64(0)))
+	require.False(LoadSqlMode(ctx).Strict())
+}
+
+func TestConvertToColumn(t *testing.T) {
+	testCases := []struct {
+		typ      Type
+		val      interface{}
+		expected interface{}
+		code     int
+	}{
+		{Int8, int64(1), int8(1), 0},
+		{Int8, int64(200), int8(127), ERWarnDataOutOfRange},
+		{Int8, "-1000", int8(-128), ERWarnDataOutOfRange},
+		{Int32, "12abc", int32(12), ERWarnDataTruncated},
+		{Int32, "abc", int32(0), ERTruncatedWrongValueForField},
+		{Int32, "12.50000", int32(13), 0},
+		{Int64, "-2.5", int64(-3), 0},
+		{Uint8, int64(-5), uint8(0), ERWarnDataOutOfRange},
+		{Uint64, "1e30", uint64(18446744073709551615), ERWarnDataOutOfRange},
+		{Float32, 1e300, float32(3.4028234663852886e+38), ERWarnDataOutOfRange},
+		{Float64, "1.5x", 1.5, ERWarnDataTruncated},
+		{MustCreateDecimalType(4, 2), "123.456", "99.99", ERWarnDataOutOfRange},
+		{MustCreateDecimalType(4, 2), -1000, "-99.99", ERWarnDataOutOfRange},
+		{MustCreateStringWithDefaults(sqltypes.VarChar, 3), "abcdef", "abc", ERWarnDataTruncated},
+		{MustCreateStringWithDefaults(sqltypes.VarChar, 3), "añbc", "añb", ERWarnDataTruncated},
+		{MustCreateString(sqltypes.VarChar, 10, Collation_latin1_swedish_ci), "a€b中", "a€b?", ERTruncatedWrongValueForField},
+		{MustCreateString(sqltypes.Text, 10, Collation_ascii_general_ci), "añb", "a?b", ERTruncatedWrongValueForField},
+		{Datetime, "not a date", zeroTime, ERWarnDataTruncated},
+		{Datetime, "0000-00-00", zeroTime, 0},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.typ.String(), func(t *testing.T) {
+			require := require.New(t)
+			ctx := NewEmptyContext()
+			require.NoError(ctx.Set(ctx, "sql_mode", LongText, ""))
+
+			col := &Column{Name: "c", Type: tt.typ}
+			res, err := ConvertToColumn(ctx, col, 2, tt.val)
+			require.NoError(err)
+			require.Equal(tt.expected, res)
+
+			if tt.code == 0 {
+				require.Zero(ctx.WarningCount())
+			} else {
+				require.Equal(uint16(1), ctx.WarningCount())
+				require.Equal(tt.code, ctx.Warnings()[0].Code)
+			}
+
+			require.NoError(ctx.Set(ctx, "sql_mode", LongText, "STRICT_ALL_TABLES"))
+			_, err = ConvertToColumn(ctx, col, 2, tt.val)
+			require.Equal(tt.code != 0, err != nil)
+		})
+	}
+}
+
+func TestConvertToColumnStrict(t *testing.T) {
+	require := require.New(t)
+	ctx := NewEmptyContext()
+	col := &Column{Name: "c", Type: Int32}
+
+	res, err := ConvertToColumn(ctx, col, 1, "12.10000")
+	require.NoError(err)
+	require.Equal(int32(12), res)
+	require.Zero(ctx.WarningCount())
+
+	_, err = ConvertToColumn(ctx, col, 1, "12abc")
+	require.Error(err)
+}
+
+func TestConvertToColumnZeroDate(t *testing.T) {
+	require := require.New(t)
+	ctx := NewEmptyContext()
+	col := &Column{Name: "d", Type: Date}
+
+	require.NoError(ctx.Set(ctx, "sql_mode", LongText, "NO_ZERO_DATE"))
+	res, err := ConvertToColumn(ctx, col, 1, "0000-00-00")
+	require.NoError(err)
+	require.Equal(zeroTime, res)
+	require.Equal(ERWarnDataOutOfRange, ctx.Warnings()[0].Code)
+
+	require.NoError(ctx.Set(ctx, "sql_mode", LongText, "NO_ZERO_DATE,STRICT_TRANS_TABLES"))
+	_, err = ConvertToColumn(ctx, col, 1, "0000-00-00")
+	require.True(ErrIncorrectValue.Is(err))
+	require.Equal("Incorrect date value: '0000-00-00' for column 'd' at row 1", err.Error())
+}
@@ -0,0 +1,132 @@
+package sql
+
+import (
+	"sync"
+	"time"
+)
+
+// Names of the status variables maintained by the server.
+const (
+	StatusUptime           = "Uptime"
+	StatusConnections      = "Connections"
+	StatusThreadsConnected = "Threads_connected"
+	StatusQuestions        = "Questions"
+	StatusQueries          = "Queries"
+	StatusSlowQueries      = "Slow_queries"
+	StatusBytesReceived    = "Bytes_received"
+	StatusBytesSent        = "Bytes_sent"
+)
+
+// globalOnlyStatus are the status variables that have no session value, so
+// the global value is reported for every session.
+var globalOnlyStatus = map[string]bool{
+	StatusUptime:           true,
+	StatusConnections:      true,
+	StatusThreadsConnected: true,
+}
+
+// defaultStatus are the status variables that are always reported, even
+// before they are updated for the first time.
+var defaultStatus = []string{
+	StatusUptime,
+	StatusConnections,
+	StatusThreadsConnected,
+	StatusQuestions,
+	StatusQueries,
+	StatusSlowQueries,
+	StatusBytesReceived,
+	StatusBytesSent,
+	"Com_select",
+	"Com_insert",
+	"Com_replace",
+	"Com_update",
+	"Com_delete",
+	"Com_set_option",
+}
+
+// StatusVariables keeps track of the server status counters, both globally
+// and for each session, as shown by SHOW STATUS.
+type StatusVariables struct {
+	mu       sync.RWMutex
+	start    time.Time
+	global   map[string]int64
+	sessions map[uint32]map[string]int64
+}
+
+// NewStatusVariables creates a new set of status variables with all its
+// counters set to zero.
+func NewStatusVariables() *StatusVariables {
+	global := make(map[string]int64, len(defaultStatus))
+	for _, name := range defaultStatus {
+		global[name] = 0
+	}
+
+	return &StatusVariables{
+		start:    time.Now(),
+		global:   global,
+		sessions: make(map[uint32]map[string]int64),
+	}
+}
+
+// IncrementStatus adds delta to the status variable with the given name, both
+// globally and for the session with the given id. Variables that only have a
+// global value, or a zero session id, only update the global value.
+func (s *StatusVariables) IncrementStatus(sessionID uint32, name string, delta int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.global[name] += delta
+
+	if sessionID == 0 || globalOnlyStatus[name] {
+		return
+	}
+
+	session, ok := s.sessions[sessionID]
+	if !ok {
+		session = make(map[string]int64)
+		s.sessions[sessionID] = session
+	}
+	session[name] += delta
+}
+
+// GlobalStatus returns the global value of all status variables.
+func (s *StatusVariables) GlobalStatus() map[string]int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make(map[string]int64, len(s.global))
+	for name, value := range s.global {
+		result[name] = value
+	}
+	result[StatusUptime] = int64(time.Since(s.start) / time.Second)
+
+	return result
+}
+
+// SessionStatus returns the value of all status variables for the session
+// with the given id. Variables without a session value report the global one.
+func (s *StatusVariables) SessionStatus(sessionID uint32) map[string]int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	session := s.sessions[sessionID]
+	result := make(map[string]int64, len(s.global))
+	for name, value := range s.global {
+		if globalOnlyStatus[name] {
+			result[name] = value
+		} else {
+			result[name] = session[name]
+		}
+	}
+	result[StatusUptime] = int64(time.Since(s.start) / time.Second)
+
+	return result
+}
+
+// CloseSessionStatus discards the session values of the status variables for
+// the session with the given id. The global values are kept.
+func (s *StatusVariables) CloseSessionStatus(sessionID uint32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, sessionID)
+}
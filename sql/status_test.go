@@ -0,0 +1,41 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusVariables(t *testing.T) {
+	require := require.New(t)
+
+	s := NewStatusVariables()
+	global := s.GlobalStatus()
+	require.Equal(int64(0), global[StatusQuestions])
+	require.Contains(global, "Com_select")
+
+	s.IncrementStatus(0, StatusConnections, 1)
+	s.IncrementStatus(0, StatusConnections, 1)
+	s.IncrementStatus(1, StatusQuestions, 1)
+	s.IncrementStatus(1, "Com_select", 1)
+	s.IncrementStatus(2, StatusQuestions, 1)
+	s.IncrementStatus(2, StatusBytesReceived, 10)
+
+	global = s.GlobalStatus()
+	require.Equal(int64(2), global[StatusConnections])
+	require.Equal(int64(2), global[StatusQuestions])
+	require.Equal(int64(1), global["Com_select"])
+	require.Equal(int64(10), global[StatusBytesReceived])
+
+	session := s.SessionStatus(1)
+	require.Equal(int64(2), session[StatusConnections])
+	require.Equal(int64(1), session[StatusQuestions])
+	require.Equal(int64(1), session["Com_select"])
+	require.Equal(int64(0), session[StatusBytesReceived])
+
+	s.CloseSessionStatus(1)
+	session = s.SessionStatus(1)
+	require.Equal(int64(0), session[StatusQuestions])
+	require.Equal(int64(0), session["Com_select"])
+	require.Equal(int64(2), s.GlobalStatus()[StatusQuestions])
+}
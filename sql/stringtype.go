@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/dolthub/vitess/go/sqltypes"
 	"github.com/dolthub/vitess/go/vt/proto/query"
@@ -195,7 +196,7 @@ func (t stringType) Compare(a interface{}, b interface{}) (int, error) {
 		bs = bi.(string)
 	}
 
-	return strings.Compare(as, bs), nil
+	return t.collation.Compare(as, bs), nil
 }
 
 // Convert implements Type interface.
@@ -207,6 +208,9 @@ func (t stringType) Convert(v interface{}) (interface{}, error) {
 	if ti, ok := v.(time.Time); ok {
 		v = ti.Format(TimestampDatetimeLayout)
 	}
+	if g, ok := v.(GeometryValue); ok {
+		v = string(SerializeGeometry(g))
+	}
 
 	val, err := cast.ToStringE(v)
 	if err != nil {
@@ -215,21 +219,16 @@ func (t stringType) Convert(v interface{}) (interface{}, error) {
 
 	if t.baseType == sqltypes.Text {
 		// for TEXT types, we use the byte length instead of the character length
-		if int64(len(val)) > t.MaxByteLength() {
+		if int64(t.CharacterSet().ByteLength(val)) > t.MaxByteLength() {
 			return nil, ErrLengthBeyondLimit.New()
 		}
-	} else {
-		if t.CharacterSet().MaxLength() == 1 {
-			// if the character set only has a max size of 1, we can just count the bytes
-			if int64(len(val)) > t.charLength {
-				return nil, ErrLengthBeyondLimit.New()
-			}
-		} else {
-			//TODO: this should count the string's length properly according to the character set
-			if int64(len(val)) > t.charLength {
-				return nil, ErrLengthBeyondLimit.New()
-			}
+	} else if t.CharacterSet() == CharacterSet_binary {
+		// binary strings are kept as their bytes, so we can just count them
+		if int64(len(val)) > t.charLength {
+			return nil, ErrLengthBeyondLimit.New()
 		}
+	} else if int64(utf8.RuneCountInString(val)) > t.charLength {
+		return nil, ErrLengthBeyondLimit.New()
 	}
 
 	if t.baseType == sqltypes.Binary {
@@ -44,7 +44,7 @@ func (i *TableRowIter) Next() (Row, error) {
 			return nil, err
 		}
 
-		i.rows = rows
+		i.rows = NewTimestampsToSessionIter(i.ctx, i.table.Schema(), rows)
 	}
 
 	row, err := i.rows.Next()
@@ -37,7 +37,22 @@ type TimeType interface {
 	Unmarshal(v int64) string
 }
 
-type timespanType struct{}
+type timespanType struct {
+	// precision is the number of fractional seconds digits of the values of
+	// the type. A precision of 0 keeps the microseconds of values, and
+	// renders them only when they aren't 0.
+	precision int
+}
+
+// CreateTimeTypeWithPrecision creates a TIME type whose values are rounded
+// to, and rendered with, the given number of fractional seconds digits.
+func CreateTimeTypeWithPrecision(precision int) (TimeType, error) {
+	if precision < 0 || precision > DatetimeTypeMaxPrecision {
+		return nil, ErrInvalidTimePrecision.New(precision, DatetimeTypeMaxPrecision)
+	}
+	return timespanType{precision: precision}, nil
+}
+
 type timespanImpl struct {
 	negative     bool
 	hours        int16
@@ -80,8 +95,27 @@ func (t timespanType) Convert(v interface{}) (interface{}, error) {
 	if ti, err := t.ConvertToTimespanImpl(v); err != nil {
 		return nil, err
 	} else {
-		return ti.String(), nil
+		return t.format(ti), nil
+	}
+}
+
+// format returns the given time as a string with the fractional seconds of
+// the precision of the type, rounded half away from zero.
+func (t timespanType) format(ti timespanImpl) string {
+	if t.precision == 0 {
+		return ti.String()
 	}
+
+	unit := int64(math.Pow10(DatetimeTypeMaxPrecision - t.precision))
+	micros := int64Abs(ti.AsMicroseconds())
+	rounded := microsecondsToTimespan((micros + unit/2) / unit * unit)
+
+	sign := ""
+	if ti.negative && micros != 0 {
+		sign = "-"
+	}
+	return fmt.Sprintf("%v%02d:%02d:%02d.%0*d", sign, rounded.hours, rounded.minutes, rounded.seconds,
+		t.precision, int64(rounded.microseconds)/unit)
 }
 
 // MustConvert implements the Type interface.
@@ -205,11 +239,14 @@ func (t timespanType) SQL(v interface{}) (sqltypes.Value, error) {
 	if err != nil {
 		return sqltypes.Value{}, err
 	}
-	return sqltypes.MakeTrusted(sqltypes.Time, []byte(ti.String())), nil
+	return sqltypes.MakeTrusted(sqltypes.Time, []byte(t.format(ti))), nil
 }
 
 // String implements Type interface.
 func (t timespanType) String() string {
+	if t.precision > 0 {
+		return fmt.Sprintf("TIME(%d)", t.precision)
+	}
 	return "TIME"
 }
 
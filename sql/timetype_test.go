@@ -190,3 +190,33 @@ func TestTimeConvertToTimeDuration(t *testing.T) {
 func TestTimeString(t *testing.T) {
 	require.Equal(t, "TIME", Time.String())
 }
+
+func TestTimeWithPrecision(t *testing.T) {
+	typ, err := CreateTimeTypeWithPrecision(1)
+	require.NoError(t, err)
+	require.Equal(t, "TIME(1)", typ.String())
+
+	tests := []struct {
+		val         interface{}
+		expectedVal string
+	}{
+		{"03:04:05.678", "03:04:05.7"},
+		{"03:04:05", "03:04:05.0"},
+		{"-03:04:05.95", "-03:04:06.0"},
+		{"-00:00:00.4", "-00:00:00.4"},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprint(test.val), func(t *testing.T) {
+			val, err := typ.Convert(test.val)
+			require.NoError(t, err)
+			require.Equal(t, test.expectedVal, val)
+
+			sqlVal, err := typ.SQL(test.val)
+			require.NoError(t, err)
+			require.Equal(t, test.expectedVal, sqlVal.ToString())
+		})
+	}
+
+	_, err = CreateTimeTypeWithPrecision(7)
+	require.Error(t, err)
+}
@@ -0,0 +1,216 @@
+package sql
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	// Named time zones are resolved with the tz database embedded in the
+	// binary, so they work on hosts without one.
+	_ "time/tzdata"
+
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+// ErrUnknownTimeZone is returned when a time zone is not a valid offset or
+// a known named zone.
+var ErrUnknownTimeZone = errors.NewKind("Unknown or incorrect time zone: '%s'")
+
+// SystemTimeZone is the value of the time_zone variable that makes sessions
+// use the zone given by the system_time_zone variable.
+const SystemTimeZone = "SYSTEM"
+
+var timeZoneOffsetRegex = regexp.MustCompile(`^([+-])(\d{1,2}):(\d{2})$`)
+
+// namedTimeZones caches the locations of the named zones already loaded.
+var namedTimeZones sync.Map
+
+// ParseTimeZone returns the location for the given time zone, which can be an
+// offset from UTC such as "+01:00" or a named zone such as "Europe/Madrid".
+// The system zone is resolved by SessionTimeZone, as it depends on the
+// session.
+func ParseTimeZone(tz string) (*time.Location, error) {
+	if m := timeZoneOffsetRegex.FindStringSubmatch(tz); m != nil {
+		hours, _ := strconv.Atoi(m[2])
+		minutes, _ := strconv.Atoi(m[3])
+		if minutes > 59 {
+			return nil, ErrUnknownTimeZone.New(tz)
+		}
+
+		offset := hours*60 + minutes
+		if m[1] == "-" {
+			offset = -offset
+		}
+		// MySQL accepts offsets from -13:59 to +14:00.
+		if offset < -(13*60+59) || offset > 14*60 {
+			return nil, ErrUnknownTimeZone.New(tz)
+		}
+		if offset == 0 {
+			return time.UTC, nil
+		}
+		return time.FixedZone(tz, offset*60), nil
+	}
+
+	if tz == "" || strings.EqualFold(tz, SystemTimeZone) || strings.EqualFold(tz, "local") {
+		return nil, ErrUnknownTimeZone.New(tz)
+	}
+
+	if loc, ok := namedTimeZones.Load(tz); ok {
+		return loc.(*time.Location), nil
+	}
+
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, ErrUnknownTimeZone.New(tz)
+	}
+	namedTimeZones.Store(tz, loc)
+	return loc, nil
+}
+
+// ResolveTimeZone returns the location for the given time zone as
+// ParseTimeZone, also resolving the system zone of the session.
+func ResolveTimeZone(ctx *Context, tz string) (*time.Location, error) {
+	if strings.EqualFold(tz, SystemTimeZone) {
+		tz = timeZoneVariable(ctx, "system_time_zone")
+	}
+	return ParseTimeZone(tz)
+}
+
+// SessionTimeZone returns the location of the time zone of the session, as
+// given by its time_zone variable. When the zone cannot be resolved, UTC is
+// used.
+func SessionTimeZone(ctx *Context) *time.Location {
+	loc, err := ResolveTimeZone(ctx, timeZoneVariable(ctx, "time_zone"))
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func timeZoneVariable(ctx *Context, name string) string {
+	if ctx == nil || ctx.Session == nil {
+		return "UTC"
+	}
+	_, v := ctx.Get(name)
+	if v == nil {
+		return "UTC"
+	}
+	return fmt.Sprint(v)
+}
+
+// InTimeZone returns the wall clock time of the given instant in the given
+// location. As all the date and time values, the result is in UTC.
+func InTimeZone(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// FromTimeZone returns the instant of the given wall clock time in the given
+// location. Only the wall clock of the given time is used.
+func FromTimeZone(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+// ConvertTimeZone converts the given wall clock time from a location to
+// another one.
+func ConvertTimeZone(t time.Time, from, to *time.Location) time.Time {
+	return InTimeZone(FromTimeZone(t, from), to)
+}
+
+// TimestampsToSession returns the given row read from a table with the given
+// schema with its TIMESTAMP values, which are stored in UTC, converted to the
+// time zone of the session. The row is returned as is when there is nothing
+// to convert, and copied otherwise.
+func TimestampsToSession(ctx *Context, sch Schema, row Row) Row {
+	return convertTimestamps(SessionTimeZone(ctx), sch, row, true)
+}
+
+// TimestampsFromSession returns the given row to be written to a table with
+// the given schema with its TIMESTAMP values converted from the time zone of
+// the session to UTC. The row is returned as is when there is nothing to
+// convert, and copied otherwise.
+func TimestampsFromSession(ctx *Context, sch Schema, row Row) Row {
+	return convertTimestamps(SessionTimeZone(ctx), sch, row, false)
+}
+
+// TimestampFromSession returns the given value, compared with values of the
+// given type, converted from the time zone of the session to UTC if the type
+// is TIMESTAMP, as it's stored. Other values are returned as is, and so are
+// the ones that can't be converted to a TIMESTAMP.
+func TimestampFromSession(ctx *Context, typ Type, v interface{}) interface{} {
+	if typ != Timestamp || v == nil {
+		return v
+	}
+	t, err := Timestamp.Convert(v)
+	if err != nil {
+		return v
+	}
+	return convertTimestamps(SessionTimeZone(ctx), Schema{{Type: typ}}, Row{t}, false)[0]
+}
+
+func convertTimestamps(loc *time.Location, sch Schema, row Row, toSession bool) Row {
+	if loc == time.UTC {
+		return row
+	}
+
+	var res Row
+	for i, col := range sch {
+		if i >= len(row) || col.Type != Timestamp {
+			continue
+		}
+		t, ok := row[i].(time.Time)
+		if !ok {
+			continue
+		}
+
+		if res == nil {
+			res = row.Copy()
+		}
+		if toSession {
+			res[i] = InTimeZone(t, loc)
+		} else {
+			res[i] = FromTimeZone(t, loc).UTC()
+		}
+	}
+
+	if res == nil {
+		return row
+	}
+	return res
+}
+
+// timestampsToSessionIter converts the TIMESTAMP values of the rows of a table
+// to the time zone of the session.
+type timestampsToSessionIter struct {
+	RowIter
+	loc *time.Location
+	sch Schema
+}
+
+// NewTimestampsToSessionIter returns an iterator over the rows of the given
+// iterator, which are read from a table with the given schema, that converts
+// their TIMESTAMP values to the time zone of the session. The iterator is
+// returned as is when there is nothing to convert.
+func NewTimestampsToSessionIter(ctx *Context, sch Schema, iter RowIter) RowIter {
+	loc := SessionTimeZone(ctx)
+	if loc == time.UTC {
+		return iter
+	}
+	for _, col := range sch {
+		if col.Type == Timestamp {
+			return &timestampsToSessionIter{iter, loc, sch}
+		}
+	}
+	return iter
+}
+
+// Next implements the RowIter interface.
+func (i *timestampsToSessionIter) Next() (Row, error) {
+	row, err := i.RowIter.Next()
+	if err != nil {
+		return nil, err
+	}
+	return convertTimestamps(i.loc, i.sch, row, true), nil
+}
@@ -0,0 +1,120 @@
+package sql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimeZone(t *testing.T) {
+	testCases := []struct {
+		tz     string
+		offset int
+		err    bool
+	}{
+		{"+00:00", 0, false},
+		{"-00:00", 0, false},
+		{"+01:00", 3600, false},
+		{"-05:30", -(5*3600 + 30*60), false},
+		{"+14:00", 14 * 3600, false},
+		{"+14:01", 0, true},
+		{"-14:00", 0, true},
+		{"+01:60", 0, true},
+		{"UTC", 0, false},
+		{"Asia/Kolkata", 5*3600 + 30*60, false},
+		{"Mars/Olympus_Mons", 0, true},
+		{"SYSTEM", 0, true},
+		{"", 0, true},
+	}
+
+	ref := time.Date(2021, time.January, 15, 12, 0, 0, 0, time.UTC)
+	for _, tt := range testCases {
+		t.Run(tt.tz, func(t *testing.T) {
+			require := require.New(t)
+			loc, err := ParseTimeZone(tt.tz)
+			if tt.err {
+				require.Error(err)
+				require.True(ErrUnknownTimeZone.Is(err))
+				return
+			}
+			require.NoError(err)
+			_, offset := ref.In(loc).Zone()
+			require.Equal(tt.offset, offset)
+		})
+	}
+}
+
+func TestSessionTimeZone(t *testing.T) {
+	require := require.New(t)
+	ctx := NewEmptyContext()
+
+	require.Equal(time.UTC, SessionTimeZone(ctx))
+
+	require.NoError(ctx.Set(ctx, "time_zone", LongText, "Europe/Madrid"))
+	require.Equal("Europe/Madrid", SessionTimeZone(ctx).String())
+
+	require.NoError(ctx.Set(ctx, "time_zone", LongText, "SYSTEM"))
+	require.NoError(ctx.Set(ctx, "system_time_zone", LongText, "+02:00"))
+	loc, err := ResolveTimeZone(ctx, "system")
+	require.NoError(err)
+	require.Equal(loc.String(), SessionTimeZone(ctx).String())
+}
+
+func TestConvertTimeZone(t *testing.T) {
+	require := require.New(t)
+
+	madrid, err := ParseTimeZone("Europe/Madrid")
+	require.NoError(err)
+	offset, err := ParseTimeZone("-03:00")
+	require.NoError(err)
+
+	winter := time.Date(2021, time.January, 15, 12, 0, 0, 0, time.UTC)
+	require.Equal(time.Date(2021, time.January, 15, 13, 0, 0, 0, time.UTC), InTimeZone(winter, madrid))
+	require.Equal(time.Date(2021, time.January, 15, 11, 0, 0, 0, time.UTC), FromTimeZone(winter, madrid).UTC())
+
+	summer := time.Date(2021, time.July, 15, 12, 0, 0, 0, time.UTC)
+	require.Equal(time.Date(2021, time.July, 15, 7, 0, 0, 0, time.UTC), ConvertTimeZone(summer, madrid, offset))
+}
+
+func TestTimestampsSession(t *testing.T) {
+	require := require.New(t)
+	ctx := NewEmptyContext()
+
+	sch := Schema{
+		{Name: "ts", Type: Timestamp},
+		{Name: "dt", Type: Datetime},
+		{Name: "ts2", Type: Timestamp, Nullable: true},
+	}
+	stored := NewRow(
+		time.Date(2021, time.January, 15, 12, 0, 0, 0, time.UTC),
+		time.Date(2021, time.January, 15, 12, 0, 0, 0, time.UTC),
+		nil,
+	)
+
+	require.Equal(stored, TimestampsToSession(ctx, sch, stored))
+
+	require.NoError(ctx.Set(ctx, "time_zone", LongText, "+01:00"))
+	session := TimestampsToSession(ctx, sch, stored)
+	require.Equal(NewRow(
+		time.Date(2021, time.January, 15, 13, 0, 0, 0, time.UTC),
+		time.Date(2021, time.January, 15, 12, 0, 0, 0, time.UTC),
+		nil,
+	), session)
+	require.Equal(time.Date(2021, time.January, 15, 12, 0, 0, 0, time.UTC), stored[0])
+
+	require.Equal(stored, TimestampsFromSession(ctx, sch, session))
+}
+
+func TestTimestampFromSession(t *testing.T) {
+	require := require.New(t)
+	ctx := NewEmptyContext()
+	require.NoError(ctx.Set(ctx, "time_zone", LongText, "+02:00"))
+
+	utc := time.Date(2020, time.January, 1, 12, 0, 0, 0, time.UTC)
+	require.Equal(utc, TimestampFromSession(ctx, Timestamp, "2020-01-01 14:00:00"))
+	require.Equal(utc, TimestampFromSession(ctx, Timestamp, time.Date(2020, time.January, 1, 14, 0, 0, 0, time.UTC)))
+	require.Equal("2020-01-01 14:00:00", TimestampFromSession(ctx, Datetime, "2020-01-01 14:00:00"))
+	require.Equal("not a date", TimestampFromSession(ctx, Timestamp, "not a date"))
+	require.Nil(TimestampFromSession(ctx, Timestamp, nil))
+}
@@ -1,7 +1,6 @@
 package sql
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"strconv"
@@ -174,13 +173,13 @@ func ColumnTypeToType(ct *sqlparser.ColumnType) (Type, error) {
 	case "longblob":
 		return LongBlob, nil
 	case "tinytext":
-		collation, err := ParseCollation(&ct.Charset, &ct.Collate, false)
+		collation, err := parseColumnCollation(ct)
 		if err != nil {
 			return nil, err
 		}
 		return CreateString(sqltypes.Text, tinyTextBlobMax/collation.CharacterSet().MaxLength(), collation)
 	case "text":
-		collation, err := ParseCollation(&ct.Charset, &ct.Collate, false)
+		collation, err := parseColumnCollation(ct)
 		if err != nil {
 			return nil, err
 		}
@@ -193,19 +192,19 @@ func ColumnTypeToType(ct *sqlparser.ColumnType) (Type, error) {
 		}
 		return CreateString(sqltypes.Text, length, collation)
 	case "mediumtext", "long", "long varchar":
-		collation, err := ParseCollation(&ct.Charset, &ct.Collate, false)
+		collation, err := parseColumnCollation(ct)
 		if err != nil {
 			return nil, err
 		}
 		return CreateString(sqltypes.Text, mediumTextBlobMax/collation.CharacterSet().MaxLength(), collation)
 	case "longtext":
-		collation, err := ParseCollation(&ct.Charset, &ct.Collate, false)
+		collation, err := parseColumnCollation(ct)
 		if err != nil {
 			return nil, err
 		}
 		return CreateString(sqltypes.Text, longTextBlobMax/collation.CharacterSet().MaxLength(), collation)
 	case "char", "character":
-		collation, err := ParseCollation(&ct.Charset, &ct.Collate, false)
+		collation, err := parseColumnCollation(ct)
 		if err != nil {
 			return nil, err
 		}
@@ -229,7 +228,7 @@ func ColumnTypeToType(ct *sqlparser.ColumnType) (Type, error) {
 		}
 		return CreateString(sqltypes.Char, length, Collation_utf8mb3_general_ci)
 	case "varchar", "character varying":
-		collation, err := ParseCollation(&ct.Charset, &ct.Collate, false)
+		collation, err := parseColumnCollation(ct)
 		if err != nil {
 			return nil, err
 		}
@@ -280,13 +279,13 @@ func ColumnTypeToType(ct *sqlparser.ColumnType) (Type, error) {
 	case "datetime":
 		return Datetime, nil
 	case "enum":
-		collation, err := ParseCollation(&ct.Charset, &ct.Collate, false)
+		collation, err := parseColumnCollation(ct)
 		if err != nil {
 			return nil, err
 		}
 		return CreateEnumType(ct.EnumValues, collation)
 	case "set":
-		collation, err := ParseCollation(&ct.Charset, &ct.Collate, false)
+		collation, err := parseColumnCollation(ct)
 		if err != nil {
 			return nil, err
 		}
@@ -294,12 +293,16 @@ func ColumnTypeToType(ct *sqlparser.ColumnType) (Type, error) {
 	case "json":
 		return JSON, nil
 	case "geometry":
+		return Geometry, nil
 	case "geometrycollection":
 	case "linestring":
+		return LineString, nil
 	case "multilinestring":
 	case "point":
+		return Point, nil
 	case "multipoint":
 	case "polygon":
+		return Polygon, nil
 	case "multipolygon":
 	default:
 		return nil, fmt.Errorf("unknown type: %v", ct.Type)
@@ -307,6 +310,19 @@ func ColumnTypeToType(ct *sqlparser.ColumnType) (Type, error) {
 	return nil, fmt.Errorf("type not yet implemented: %v", ct.Type)
 }
 
+// parseColumnCollation returns the collation of a column of the given type,
+// which must be supported.
+func parseColumnCollation(ct *sqlparser.ColumnType) (Collation, error) {
+	collation, err := ParseCollation(&ct.Charset, &ct.Collate, false)
+	if err != nil {
+		return Collation_Default, err
+	}
+	if !collation.IsSupported() {
+		return Collation_Default, ErrCollationUnsupported.New(collation)
+	}
+	return collation, nil
+}
+
 func ConvertToBool(v interface{}) (bool, error) {
 	switch b := v.(type) {
 	case bool:
@@ -419,7 +435,13 @@ func IsNumber(t Type) bool {
 
 // IsSigned checks if t is a signed type.
 func IsSigned(t Type) bool {
-	return t == Int8 || t == Int16 || t == Int32 || t == Int64
+	return t == Int8 || t == Int16 || t == Int24 || t == Int32 || t == Int64
+}
+
+// IsSpatial checks if t is one of the spatial types.
+func IsSpatial(t Type) bool {
+	_, ok := t.(spatialType)
+	return ok
 }
 
 // IsText checks if t is a text type.
@@ -464,7 +486,7 @@ func IsTuple(t Type) bool {
 
 // IsUnsigned checks if t is an unsigned type.
 func IsUnsigned(t Type) bool {
-	return t == Uint8 || t == Uint16 || t == Uint32 || t == Uint64
+	return t == Uint8 || t == Uint16 || t == Uint24 || t == Uint32 || t == Uint64
 }
 
 // NumColumns returns the number of columns in a type. This is one for all
@@ -492,17 +514,11 @@ func convertForJSON(t Type, v interface{}) (interface{}, error) {
 	switch t := t.(type) {
 	case jsonType:
 		val, err := t.Convert(v)
-		if err != nil {
-			return nil, err
-		}
-
-		var doc interface{}
-		err = json.Unmarshal(val.([]byte), &doc)
-		if err != nil {
+		if err != nil || val == nil {
 			return nil, err
 		}
 
-		return doc, nil
+		return val.(JSONDocument).Val, nil
 	case arrayType:
 		return convertArrayForJSON(t, v)
 	default: